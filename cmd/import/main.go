@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ebookstore/internal/container"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/config"
+)
+
+// Import the books of a CSV or ONIX catalog file and print the report of the import.
+// The command exits with an error when the file cannot be imported or any of its rows failed.
+func main() {
+	path := flag.String("file", "", "path of the catalog file")
+	format := flag.String("format", catalog.ImportFormatCSV, "format of the catalog file: csv or onix")
+	dryRun := flag.Bool("dry-run", false, "validate the file without importing it")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	config.LoadConfiguration()
+
+	file, err := os.Open(*path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed opening file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	// The command is run by the store operators, so it has the same access as an admin
+	ctx := context.WithValue(context.Background(), "admin", true) //nolint:staticcheck
+	c := container.New()
+
+	report, err := c.Catalog().ImportBooks(ctx, catalog.ImportBooks{Format: *format, DryRun: *dryRun, File: file})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed importing books: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
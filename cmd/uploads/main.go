@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ebookstore/internal/container"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/config"
+)
+
+// Delete the uploads that no book or author references once their grace period is over, and print the report.
+// A dry run only lists the uploads that would be deleted.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "list the orphan uploads without deleting them")
+	gracePeriod := flag.Duration("grace-period", 0, "minimum age of the deleted uploads, defaults to ORPHAN_UPLOADS_GRACE_PERIOD")
+	flag.Parse()
+
+	config.LoadConfiguration()
+
+	if *gracePeriod <= 0 {
+		*gracePeriod = container.OrphanUploadsGracePeriod()
+	}
+
+	c := container.New()
+
+	report, err := c.Catalog().CollectOrphanUploads(context.Background(), catalog.CollectOrphanUploads{GracePeriod: *gracePeriod, DryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed collecting orphan uploads: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(report)
+}
@@ -13,24 +13,34 @@ import (
 
 	"github.com/ebookstore/internal/core/auth"
 	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/reviews"
 	"github.com/ebookstore/internal/core/shop"
 	"github.com/ebookstore/internal/log"
 	"github.com/ebookstore/internal/platform/config"
 	"github.com/ebookstore/internal/platform/email"
+	"github.com/ebookstore/internal/platform/exporter"
 	"github.com/ebookstore/internal/platform/generator"
 	"github.com/ebookstore/internal/platform/hash"
+	"github.com/ebookstore/internal/platform/imaging"
+	"github.com/ebookstore/internal/platform/importer"
+	"github.com/ebookstore/internal/platform/lcp"
 	"github.com/ebookstore/internal/platform/migrator"
 	"github.com/ebookstore/internal/platform/payment"
 	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/ebookstore/internal/platform/sampler"
+	"github.com/ebookstore/internal/platform/scheduler"
 	"github.com/ebookstore/internal/platform/server"
 	"github.com/ebookstore/internal/platform/storage"
 	"github.com/ebookstore/internal/platform/token"
 	"github.com/ebookstore/internal/platform/validator"
+	"github.com/ebookstore/internal/platform/watermarker"
 	"gorm.io/gorm"
 )
 
 type Container struct {
 	server     *server.Server
+	catalog    *catalog.Catalog
+	scheduler  *scheduler.Scheduler
 	dbMigrator *migrator.Migrator
 	db         *gorm.DB
 	cache      *redisclient.Client
@@ -65,17 +75,22 @@ func New() *Container {
 	passwordGenerator := generator.NewPasswordGenerator()
 	uuidGenerator := generator.NewUUIDGenerator()
 	validatorValidator := validator.New()
+	appTokenRepository := persistence.NewAppTokenRepository(db)
+	secretGenerator := generator.NewSecretGenerator()
 	authConfig := auth.Config{
-		Repository:        userRepository,
-		Tokener:           jwtWrapper,
-		Hasher:            bcryptWrapper,
-		EmailClient:       emailEmail,
-		PasswordGenerator: passwordGenerator,
-		IDGenerator:       uuidGenerator,
-		Validator:         validatorValidator,
+		Repository:         userRepository,
+		Tokener:            jwtWrapper,
+		Hasher:             bcryptWrapper,
+		EmailClient:        emailEmail,
+		PasswordGenerator:  passwordGenerator,
+		AppTokenRepository: appTokenRepository,
+		SecretGenerator:    secretGenerator,
+		IDGenerator:        uuidGenerator,
+		Validator:          validatorValidator,
 	}
 	authenticator := auth.New(authConfig)
 	authenticationHandler := server.NewAuthenticatorHandler(authenticator)
+	basicAuthenticationMiddleware := server.NewBasicAuthenticationMiddleware(authenticator)
 	bookRepository := persistence.NewBookRepository(db)
 	s3Client := config.NewS3Client(awsConfig)
 	presignClient := config.NewPresignClient(s3Client)
@@ -86,27 +101,112 @@ func New() *Container {
 		Bucket:        bucket,
 	}
 	storageStorage := storage.NewStorage(storageConfig)
+	linkCacheTTL := time.Minute * time.Duration(viper.GetInt("IMAGE_LINK_CACHE_TTL"))
+	var storageClient catalog.StorageClient = storageStorage
+	var localStorageHandler *server.LocalStorageHandler
+	if localStorageDir := config.NewLocalStorageDir(); localStorageDir != "" {
+		localConfig := storage.LocalConfig{
+			Dir:    localStorageDir,
+			URL:    config.NewLocalStorageURL(),
+			Secret: config.NewLocalStorageSecret(),
+		}
+		if err := localConfig.Validate(linkCacheTTL); err != nil {
+			log.Fatalf(context.TODO(), "invalid local storage configuration: %v", err)
+		}
+		localStorage := storage.NewLocalStorage(localConfig)
+		storageClient = localStorage
+		localStorageHandler = server.NewLocalStorageHandler(localStorage)
+	} else if err := storageConfig.Validate(linkCacheTTL); err != nil {
+		log.Fatalf(context.TODO(), "invalid storage configuration: %v", err)
+	} else if cdnDomain := config.NewCDNDomain(); cdnDomain != "" {
+		cdnConfig := storage.CDNConfig{
+			Storage:    storageStorage,
+			Signer:     config.NewCDNSigner(),
+			Domain:     cdnDomain,
+			Expiration: config.NewCDNLinkExpiration(),
+			SourceIP:   config.NewCDNSourceIP(),
+			Links:      config.NewCDNLinks(),
+		}
+		if err := cdnConfig.Validate(linkCacheTTL); err != nil {
+			log.Fatalf(context.TODO(), "invalid CDN configuration: %v", err)
+		}
+		storageClient = storage.NewCDN(cdnConfig)
+	}
+	categoryRepository := persistence.NewCategoryRepository(db)
+	authorRepository := persistence.NewAuthorRepository(db)
+	imageRepository := persistence.NewImageRepository(db)
+	uploadRepository := persistence.NewUploadRepository(db)
+	importerParser := importer.NewParser()
+	storeURL := config.NewStoreURL()
+	exporterConfig := exporter.Config{
+		StoreURL: storeURL,
+	}
+	exporterEncoder := exporter.NewEncoder(exporterConfig)
+	samplerGenerator := sampler.NewGenerator()
+	bookWatermarker := watermarker.NewWatermarker()
+	lcpSecret := config.NewLCPSecret()
+	lcpProtector := lcp.NewProtector(lcpSecret)
+	protectedContentRepository := persistence.NewProtectedContentRepository(db)
+	imagingResizer := imaging.NewResizer()
+	feedCache := persistence.NewFeedCache(cache, time.Minute*time.Duration(viper.GetInt("CATALOG_FEED_TTL")))
+	linkCache := persistence.NewLinkCache(cache, linkCacheTTL)
 	catalogConfig := catalog.Config{
-		Repository:    bookRepository,
-		StorageClient: storageStorage,
-		IDGenerator:   uuidGenerator,
-		Validator:     validatorValidator,
+		Repository:         bookRepository,
+		CategoryRepository: categoryRepository,
+		AuthorRepository:   authorRepository,
+		ImageRepository:    imageRepository,
+		UploadRepository:   uploadRepository,
+		StorageClient:      storageClient,
+		ImageResizer:       imagingResizer,
+		ImportParser:       importerParser,
+		ExportEncoder:      exporterEncoder,
+		SampleGenerator:    samplerGenerator,
+		Watermarker:        bookWatermarker,
+		FeedCache:          feedCache,
+		LinkCache:          linkCache,
+		IDGenerator:        uuidGenerator,
+		Validator:          validatorValidator,
+		MaxProcessedSize:   viper.GetInt64("CONTENT_PROCESSING_MAX_SIZE") << 20,
+
+		ProtectedContentRepository: protectedContentRepository,
+		ContentProtector:           lcpProtector,
 	}
 	catalogCatalog := catalog.New(catalogConfig)
 	catalogHandler := server.NewCatalogHandler(catalogCatalog)
 	orderRepository := persistence.NewOrderRepository(db)
 	cartRepository := persistence.NewCartRepository(cache, time.Minute*time.Duration(viper.GetInt("REDIS_CART_TTL")))
 	stripePaymentService := payment.NewStripePaymentService()
+	wishlistRepository := persistence.NewWishlistRepository(db)
+	downloadRepository := persistence.NewDownloadRepository(db)
+	licenseRepository := persistence.NewLicenseRepository(db)
+	lcpIssuer := lcp.NewIssuer(lcpSecret, config.NewLCPProvider())
 	shopConfig := shop.Config{
-		OrderRepository: orderRepository,
-		CartRepository:  cartRepository,
-		PaymentClient:   stripePaymentService,
-		CatalogService:  catalogCatalog,
-		IDGenerator:     uuidGenerator,
-		Validator:       validatorValidator,
+		OrderRepository:    orderRepository,
+		CartRepository:     cartRepository,
+		WishlistRepository: wishlistRepository,
+		DownloadRepository: downloadRepository,
+		LicenseRepository:  licenseRepository,
+		EmailClient:        emailEmail,
+		PaymentClient:      stripePaymentService,
+		CatalogService:     catalogCatalog,
+		LicenseIssuer:      lcpIssuer,
+		LicenseRights:      config.NewLicenseRights(),
+		IDGenerator:        uuidGenerator,
+		Validator:          validatorValidator,
 	}
 	shopShop := shop.New(shopConfig)
-	shopHandler := server.NewShopHandler(shopShop)
+	downloadStreaming := config.NewDownloadStreaming()
+	shopHandler := server.NewShopHandler(shopShop, downloadStreaming)
+	reviewRepository := persistence.NewReviewRepository(db)
+	reviewsConfig := reviews.Config{
+		Repository:  reviewRepository,
+		ShopService: shopShop,
+		IDGenerator: uuidGenerator,
+		Validator:   validatorValidator,
+	}
+	reviewsReviews := reviews.New(reviewsConfig)
+	reviewHandler := server.NewReviewHandler(reviewsReviews)
+	opdsHandler := server.NewOPDSHandler(catalogCatalog, shopShop, basicAuthenticationMiddleware, server.StoreURL(storeURL), downloadStreaming)
 	addr := config.NewServerAddr()
 	timeout := config.NewServerTimeout()
 	serverConfig := server.Config{
@@ -120,6 +220,9 @@ func New() *Container {
 		AuthenticationHandler:    authenticationHandler,
 		CatalogHandler:           catalogHandler,
 		ShopHandler:              shopHandler,
+		ReviewHandler:            reviewHandler,
+		OPDSHandler:              opdsHandler,
+		LocalStorageHandler:      localStorageHandler,
 		Addr:                     addr,
 		Timeout:                  timeout,
 	}
@@ -128,6 +231,43 @@ func New() *Container {
 	container.cache = cache
 	container.dbMigrator = dbMigrator
 	container.server = server.New(serverConfig)
+	container.catalog = catalogCatalog
+	orphanUploadsGracePeriod := OrphanUploadsGracePeriod()
+	container.scheduler = scheduler.New(
+		scheduler.Job{
+			Name:     "wishlist-price-drops",
+			Interval: time.Minute * time.Duration(viper.GetInt("WISHLIST_PRICE_CHECK_INTERVAL")),
+			Run:      shopShop.NotifyPriceDrops,
+		},
+		scheduler.Job{
+			Name:     "pre-order-releases",
+			Interval: time.Minute * time.Duration(viper.GetInt("RELEASE_NOTIFICATION_INTERVAL")),
+			Run:      shopShop.NotifyReleases,
+		},
+		scheduler.Job{
+			Name:     "catalog-feed",
+			Interval: time.Minute * time.Duration(viper.GetInt("CATALOG_FEED_REFRESH_INTERVAL")),
+			Run:      catalogCatalog.RefreshPublicFeed,
+		},
+		scheduler.Job{
+			Name:     "image-variants",
+			Interval: time.Minute * time.Duration(viper.GetInt("IMAGE_VARIANTS_INTERVAL")),
+			Run:      catalogCatalog.GenerateImageVariants,
+		},
+		scheduler.Job{
+			Name:     "scheduled-publishing",
+			Interval: time.Minute * time.Duration(viper.GetInt("SCHEDULED_PUBLISHING_INTERVAL")),
+			Run:      catalogCatalog.PublishScheduledBooks,
+		},
+		scheduler.Job{
+			Name:     "orphan-uploads",
+			Interval: time.Minute * time.Duration(viper.GetInt("ORPHAN_UPLOADS_INTERVAL")),
+			Run: func(ctx context.Context) error {
+				_, err := catalogCatalog.CollectOrphanUploads(ctx, catalog.CollectOrphanUploads{GracePeriod: orphanUploadsGracePeriod})
+				return err
+			},
+		},
+	)
 
 	return container
 }
@@ -145,6 +285,9 @@ func (c *Container) Start(ctx context.Context) {
 	}()
 	log.Infof(ctx, "starting HTTP server")
 
+	c.scheduler.Start(ctx)
+	log.Infof(ctx, "starting background jobs")
+
 	<-done
 	log.Infof(ctx, "shutting down HTTP server")
 
@@ -155,6 +298,9 @@ func (c *Container) Start(ctx context.Context) {
 		log.Infof(ctx, "failed to shutdown HTTP server")
 	}
 
+	log.Infof(ctx, "shutting down background jobs")
+	c.scheduler.Stop()
+
 	log.Infof(ctx, "shutting down database")
 	db, err := c.db.DB()
 	if err != nil {
@@ -175,3 +321,18 @@ func (c *Container) DB() *gorm.DB {
 func (c *Container) Cache() *redisclient.Client {
 	return c.cache
 }
+
+// OrphanUploadsGracePeriod is the time uploads are kept before they are collected when nothing references them.
+// It must be positive, otherwise an upload could be deleted before the book or author referencing it is saved.
+func OrphanUploadsGracePeriod() time.Duration {
+	gracePeriod := time.Minute * time.Duration(viper.GetInt("ORPHAN_UPLOADS_GRACE_PERIOD"))
+	if gracePeriod <= 0 {
+		log.Fatalf(context.TODO(), "ORPHAN_UPLOADS_GRACE_PERIOD must be a positive number of minutes")
+	}
+
+	return gracePeriod
+}
+
+func (c *Container) Catalog() *catalog.Catalog {
+	return c.catalog
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// AppToken is a long-lived secret that reading apps use in place of the user password.
+// Only the hash of the secret is stored, so it can be shown to the user only once.
+type AppToken struct {
+	ID        string
+	UserID    string
+	Name      string
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// LicenseKey returns the key derived from a license passphrase, the SHA-256 of the passphrase as the reading apps
+// compute it to open the licenses.
+func LicenseKey(passphrase string) string {
+	sum := sha256.Sum256([]byte(passphrase))
+	return hex.EncodeToString(sum[:])
+}
+
+// HashAppToken returns the hash an app token secret is stored and looked up by.
+func HashAppToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashAppToken(t *testing.T) {
+	hash := HashAppToken("some-token")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashAppToken("some-token"))
+	assert.NotEqual(t, hash, HashAppToken("other-token"))
+}
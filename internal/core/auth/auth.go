@@ -11,6 +11,14 @@ type Repository interface {
 	Save(ctx context.Context, user *User) error
 	Update(ctx context.Context, user *User) error
 	FindByEmail(ctx context.Context, email string) (User, error)
+	FindByID(ctx context.Context, id string) (User, error)
+}
+
+type AppTokenRepository interface {
+	Create(ctx context.Context, appToken *AppToken) error
+	FindByUserID(ctx context.Context, userID string) ([]AppToken, error)
+	FindByHash(ctx context.Context, tokenHash string) (AppToken, error)
+	Delete(ctx context.Context, userID, id string) error
 }
 
 type TokenHandler interface {
@@ -31,6 +39,11 @@ type PasswordGenerator interface {
 	NewPassword() string
 }
 
+// SecretGenerator generates the unguessable secrets of the app tokens.
+type SecretGenerator interface {
+	NewSecret() string
+}
+
 type IDGenerator interface {
 	NewID() string
 }
@@ -40,13 +53,15 @@ type Validator interface {
 }
 
 type Config struct {
-	Repository        Repository
-	Tokener           TokenHandler
-	Hasher            HashHandler
-	EmailClient       EmailClient
-	PasswordGenerator PasswordGenerator
-	IDGenerator       IDGenerator
-	Validator         Validator
+	Repository         Repository
+	Tokener            TokenHandler
+	Hasher             HashHandler
+	EmailClient        EmailClient
+	PasswordGenerator  PasswordGenerator
+	AppTokenRepository AppTokenRepository
+	SecretGenerator    SecretGenerator
+	IDGenerator        IDGenerator
+	Validator          Validator
 }
 
 type Authenticator struct {
@@ -148,3 +163,106 @@ func (a *Authenticator) ResetPassword(ctx context.Context, request PasswordReset
 
 	return nil
 }
+
+// AuthenticateBasic finds the user of HTTP Basic credentials. The secret can be the password of the user or one of their app tokens.
+func (a *Authenticator) AuthenticateBasic(ctx context.Context, email, secret string) (User, error) {
+	user, err := a.Repository.FindByEmail(ctx, email)
+	if err != nil {
+		return User{}, fmt.Errorf("(AuthenticateBasic) failed finding user: %w", err)
+	}
+
+	if err = a.Hasher.CompareHashAndPassword(user.Password, secret); err == nil {
+		return user, nil
+	}
+
+	appToken, err := a.AppTokenRepository.FindByHash(ctx, HashAppToken(secret))
+	if err != nil || appToken.UserID != user.ID {
+		return User{}, fmt.Errorf("(AuthenticateBasic) failed comparing hash and password: %w", ErrWrongPassword)
+	}
+
+	return user, nil
+}
+
+// AuthenticateAppToken finds the user an app token was created for.
+func (a *Authenticator) AuthenticateAppToken(ctx context.Context, token string) (User, error) {
+	appToken, err := a.AppTokenRepository.FindByHash(ctx, HashAppToken(token))
+	if err != nil {
+		return User{}, fmt.Errorf("(AuthenticateAppToken) failed finding app token: %w", ErrInvalidAppToken)
+	}
+
+	user, err := a.Repository.FindByID(ctx, appToken.UserID)
+	if err != nil {
+		return User{}, fmt.Errorf("(AuthenticateAppToken) failed finding user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (a *Authenticator) CreateAppToken(ctx context.Context, request CreateAppTokenRequest) (AppTokenResponse, error) {
+	if err := a.Validator.Validate(request); err != nil {
+		return AppTokenResponse{}, fmt.Errorf("(CreateAppToken) failed validating request: %w", err)
+	}
+
+	token := a.SecretGenerator.NewSecret()
+	appToken := request.AppToken(a.IDGenerator.NewID(), userId(ctx), token)
+
+	log.Infof(ctx, "creating app token %s", appToken.ID)
+
+	if err := a.AppTokenRepository.Create(ctx, &appToken); err != nil {
+		return AppTokenResponse{}, fmt.Errorf("(CreateAppToken) failed creating app token: %w", err)
+	}
+
+	return NewAppTokenResponse(appToken, token), nil
+}
+
+func (a *Authenticator) FindAppTokens(ctx context.Context) ([]AppTokenResponse, error) {
+	appTokens, err := a.AppTokenRepository.FindByUserID(ctx, userId(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("(FindAppTokens) failed finding app tokens: %w", err)
+	}
+
+	responses := make([]AppTokenResponse, 0, len(appTokens))
+	for _, appToken := range appTokens {
+		responses = append(responses, NewAppTokenResponse(appToken, ""))
+	}
+
+	return responses, nil
+}
+
+func (a *Authenticator) DeleteAppToken(ctx context.Context, id string) error {
+	log.Infof(ctx, "deleting app token %s", id)
+
+	if err := a.AppTokenRepository.Delete(ctx, userId(ctx), id); err != nil {
+		return fmt.Errorf("(DeleteAppToken) failed deleting app token: %w", err)
+	}
+
+	return nil
+}
+
+// SetLicensePassphrase replaces the license passphrase of the current user. The licenses already downloaded keep the
+// previous passphrase, the next downloads get the new one.
+func (a *Authenticator) SetLicensePassphrase(ctx context.Context, request SetLicensePassphraseRequest) error {
+	if err := a.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(SetLicensePassphrase) failed validating request: %w", err)
+	}
+
+	user, err := a.Repository.FindByID(ctx, userId(ctx))
+	if err != nil {
+		return fmt.Errorf("(SetLicensePassphrase) failed finding user: %w", err)
+	}
+
+	log.Infof(ctx, "setting license passphrase for user with id %s", user.ID)
+
+	user.LicenseKey = LicenseKey(request.Passphrase)
+	user.LicenseHint = request.Hint
+	if err = a.Repository.Update(ctx, &user); err != nil {
+		return fmt.Errorf("(SetLicensePassphrase) failed updating user: %w", err)
+	}
+
+	return nil
+}
+
+func userId(ctx context.Context) string {
+	id, _ := ctx.Value("userId").(string)
+	return id
+}
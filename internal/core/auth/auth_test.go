@@ -22,6 +22,12 @@ const (
 	hashPasswordMethod           = "HashPassword"
 	compareHashAndPasswordMethod = "CompareHashAndPassword"
 	validateMethod               = "Validate"
+	findByIDMethod               = "FindByID"
+	createAppTokenMethod         = "Create"
+	findAppTokensMethod          = "FindByUserID"
+	findAppTokenByHashMethod     = "FindByHash"
+	deleteAppTokenMethod         = "Delete"
+	newSecretMethod              = "NewSecret"
 )
 
 type AuthenticatorTestSuite struct {
@@ -33,6 +39,8 @@ type AuthenticatorTestSuite struct {
 	hash              *auth.MockHashHandler
 	idGenerator       *auth.MockIDGenerator
 	validator         *auth.MockValidator
+	appTokenRepo      *auth.MockAppTokenRepository
+	secretGenerator   *auth.MockSecretGenerator
 	authenticator     *auth.Authenticator
 }
 
@@ -44,15 +52,19 @@ func (s *AuthenticatorTestSuite) SetupTest() {
 	s.hash = new(auth.MockHashHandler)
 	s.idGenerator = new(auth.MockIDGenerator)
 	s.validator = new(auth.MockValidator)
+	s.appTokenRepo = new(auth.MockAppTokenRepository)
+	s.secretGenerator = new(auth.MockSecretGenerator)
 
 	config := auth.Config{
-		Repository:        s.repo,
-		Tokener:           s.token,
-		Hasher:            s.hash,
-		EmailClient:       s.emailClient,
-		PasswordGenerator: s.passwordGenerator,
-		IDGenerator:       s.idGenerator,
-		Validator:         s.validator,
+		Repository:         s.repo,
+		Tokener:            s.token,
+		Hasher:             s.hash,
+		EmailClient:        s.emailClient,
+		PasswordGenerator:  s.passwordGenerator,
+		AppTokenRepository: s.appTokenRepo,
+		SecretGenerator:    s.secretGenerator,
+		IDGenerator:        s.idGenerator,
+		Validator:          s.validator,
 	}
 
 	s.authenticator = auth.New(config)
@@ -409,3 +421,157 @@ func (s *AuthenticatorTestSuite) TestResetPassword_Successfully() {
 	s.passwordGenerator.AssertNumberOfCalls(s.T(), newPasswordMethod, 1)
 	s.emailClient.AssertNumberOfCalls(s.T(), sendEmailMethod, 1)
 }
+
+func (s *AuthenticatorTestSuite) TestAuthenticateBasic_WithPassword() {
+	user := auth.User{ID: "some-id", Email: "email@test.com", Password: "some-password"}
+
+	s.repo.On(findByEmail, context.TODO(), user.Email).Return(user, nil)
+	s.hash.On(compareHashAndPasswordMethod, user.Password, "12345678").Return(nil)
+
+	actual, err := s.authenticator.AuthenticateBasic(context.TODO(), user.Email, "12345678")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), user, actual)
+
+	s.appTokenRepo.AssertNotCalled(s.T(), findAppTokenByHashMethod)
+}
+
+func (s *AuthenticatorTestSuite) TestAuthenticateBasic_WithAppToken() {
+	user := auth.User{ID: "some-id", Email: "email@test.com", Password: "some-password"}
+
+	s.repo.On(findByEmail, context.TODO(), user.Email).Return(user, nil)
+	s.hash.On(compareHashAndPasswordMethod, user.Password, "app-token").Return(auth.ErrWrongPassword)
+	s.appTokenRepo.On(findAppTokenByHashMethod, context.TODO(), auth.HashAppToken("app-token")).Return(auth.AppToken{ID: "token-id", UserID: user.ID}, nil)
+
+	actual, err := s.authenticator.AuthenticateBasic(context.TODO(), user.Email, "app-token")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), user, actual)
+}
+
+func (s *AuthenticatorTestSuite) TestAuthenticateBasic_WithAppTokenOfAnotherUser() {
+	user := auth.User{ID: "some-id", Email: "email@test.com", Password: "some-password"}
+
+	s.repo.On(findByEmail, context.TODO(), user.Email).Return(user, nil)
+	s.hash.On(compareHashAndPasswordMethod, user.Password, "app-token").Return(auth.ErrWrongPassword)
+	s.appTokenRepo.On(findAppTokenByHashMethod, context.TODO(), auth.HashAppToken("app-token")).Return(auth.AppToken{ID: "token-id", UserID: "other-id"}, nil)
+
+	_, err := s.authenticator.AuthenticateBasic(context.TODO(), user.Email, "app-token")
+
+	assert.ErrorIs(s.T(), err, auth.ErrWrongPassword)
+}
+
+func (s *AuthenticatorTestSuite) TestAuthenticateBasic_WhenUserWasNotFound() {
+	s.repo.On(findByEmail, context.TODO(), "email@test.com").Return(auth.User{}, fmt.Errorf("some error"))
+
+	_, err := s.authenticator.AuthenticateBasic(context.TODO(), "email@test.com", "12345678")
+
+	assert.Error(s.T(), err)
+	s.hash.AssertNotCalled(s.T(), compareHashAndPasswordMethod)
+}
+
+func (s *AuthenticatorTestSuite) TestAuthenticateAppToken_Successfully() {
+	user := auth.User{ID: "some-id", Email: "email@test.com"}
+
+	s.appTokenRepo.On(findAppTokenByHashMethod, context.TODO(), auth.HashAppToken("app-token")).Return(auth.AppToken{ID: "token-id", UserID: user.ID}, nil)
+	s.repo.On(findByIDMethod, context.TODO(), user.ID).Return(user, nil)
+
+	actual, err := s.authenticator.AuthenticateAppToken(context.TODO(), "app-token")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), user, actual)
+}
+
+func (s *AuthenticatorTestSuite) TestAuthenticateAppToken_WhenTokenWasNotFound() {
+	s.appTokenRepo.On(findAppTokenByHashMethod, context.TODO(), auth.HashAppToken("app-token")).Return(auth.AppToken{}, fmt.Errorf("some error"))
+
+	_, err := s.authenticator.AuthenticateAppToken(context.TODO(), "app-token")
+
+	assert.ErrorIs(s.T(), err, auth.ErrInvalidAppToken)
+	s.repo.AssertNotCalled(s.T(), findByIDMethod)
+}
+
+func (s *AuthenticatorTestSuite) TestCreateAppToken_WhenValidationFails() {
+	request := auth.CreateAppTokenRequest{}
+	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+
+	_, err := s.authenticator.CreateAppToken(context.TODO(), request)
+
+	assert.Error(s.T(), err)
+	s.appTokenRepo.AssertNotCalled(s.T(), createAppTokenMethod)
+}
+
+func (s *AuthenticatorTestSuite) TestCreateAppToken_Successfully() {
+	ctx := context.WithValue(context.TODO(), "userId", "user-id")
+	request := auth.CreateAppTokenRequest{Name: "My e-reader"}
+	appToken := request.AppToken("token-id", "user-id", "secret")
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.secretGenerator.On(newSecretMethod).Return("secret")
+	s.idGenerator.On(newIdMethod).Return("token-id")
+	s.appTokenRepo.On(createAppTokenMethod, ctx, &appToken).Return(nil)
+
+	response, err := s.authenticator.CreateAppToken(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), auth.NewAppTokenResponse(appToken, "secret"), response)
+	s.appTokenRepo.AssertNumberOfCalls(s.T(), createAppTokenMethod, 1)
+}
+
+func (s *AuthenticatorTestSuite) TestFindAppTokens_Successfully() {
+	ctx := context.WithValue(context.TODO(), "userId", "user-id")
+	appTokens := []auth.AppToken{{ID: "token-id", UserID: "user-id", Name: "My e-reader", TokenHash: "hash"}}
+
+	s.appTokenRepo.On(findAppTokensMethod, ctx, "user-id").Return(appTokens, nil)
+
+	response, err := s.authenticator.FindAppTokens(ctx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []auth.AppTokenResponse{{ID: "token-id", Name: "My e-reader"}}, response)
+}
+
+func (s *AuthenticatorTestSuite) TestDeleteAppToken_WithError() {
+	ctx := context.WithValue(context.TODO(), "userId", "user-id")
+	s.appTokenRepo.On(deleteAppTokenMethod, ctx, "user-id", "token-id").Return(fmt.Errorf("some error"))
+
+	err := s.authenticator.DeleteAppToken(ctx, "token-id")
+
+	assert.Error(s.T(), err)
+}
+
+func (s *AuthenticatorTestSuite) TestDeleteAppToken_Successfully() {
+	ctx := context.WithValue(context.TODO(), "userId", "user-id")
+	s.appTokenRepo.On(deleteAppTokenMethod, ctx, "user-id", "token-id").Return(nil)
+
+	err := s.authenticator.DeleteAppToken(ctx, "token-id")
+
+	assert.Nil(s.T(), err)
+}
+
+func (s *AuthenticatorTestSuite) TestSetLicensePassphrase_WhenValidationFails() {
+	request := auth.SetLicensePassphraseRequest{Passphrase: "short"}
+	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+
+	err := s.authenticator.SetLicensePassphrase(context.TODO(), request)
+
+	assert.Error(s.T(), err)
+	s.repo.AssertNotCalled(s.T(), updateMethod)
+}
+
+func (s *AuthenticatorTestSuite) TestSetLicensePassphrase_Successfully() {
+	ctx := context.WithValue(context.TODO(), "userId", "user-id")
+	request := auth.SetLicensePassphraseRequest{Passphrase: "my license passphrase", Hint: "the usual one"}
+	user := auth.User{ID: "user-id", Email: "user@test.com"}
+	updated := user
+	updated.LicenseKey = "c7ef083fbafa5fb1c0efb46146ee6534f1dfbb347b5e4748a91570d424b71d56"
+	updated.LicenseHint = "the usual one"
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIDMethod, ctx, "user-id").Return(user, nil)
+	s.repo.On(updateMethod, ctx, &updated).Return(nil)
+
+	err := s.authenticator.SetLicensePassphrase(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), updateMethod, ctx, &updated)
+}
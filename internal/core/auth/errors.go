@@ -3,3 +3,4 @@ package auth
 import "fmt"
 
 var ErrWrongPassword = fmt.Errorf("the provided password is incorrect")
+var ErrInvalidAppToken = fmt.Errorf("the provided app token is not valid")
@@ -41,6 +41,34 @@ func (_m *MockRepository) FindByEmail(ctx context.Context, email string) (User,
 	return r0, r1
 }
 
+// FindByID provides a mock function with given fields: ctx, id
+func (_m *MockRepository) FindByID(ctx context.Context, id string) (User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByID")
+	}
+
+	var r0 User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, user
 func (_m *MockRepository) Save(ctx context.Context, user *User) error {
 	ret := _m.Called(ctx, user)
@@ -27,3 +27,22 @@ type LoginRequest struct {
 type PasswordResetRequest struct {
 	Email string `json:"email" validate:"required,email"`
 }
+
+// SetLicensePassphraseRequest sets the passphrase the user types in the reading apps to open the protected books.
+type SetLicensePassphraseRequest struct {
+	Passphrase string `json:"passphrase" validate:"required,min=8,max=100"`
+	Hint       string `json:"hint" validate:"required,max=255"`
+}
+
+type CreateAppTokenRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+}
+
+func (r CreateAppTokenRequest) AppToken(id, userID, token string) AppToken {
+	return AppToken{
+		ID:        id,
+		UserID:    userID,
+		Name:      r.Name,
+		TokenHash: HashAppToken(token),
+	}
+}
@@ -35,3 +35,17 @@ func TestRegisterRequest_User(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestCreateAppTokenRequest_AppToken(t *testing.T) {
+	request := CreateAppTokenRequest{Name: "My e-reader"}
+
+	expected := AppToken{
+		ID:        "token-id",
+		UserID:    "user-id",
+		Name:      "My e-reader",
+		TokenHash: HashAppToken("secret"),
+	}
+	actual := request.AppToken("token-id", "user-id", "secret")
+
+	assert.Equal(t, expected, actual)
+}
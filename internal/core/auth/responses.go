@@ -1,5 +1,7 @@
 package auth
 
+import "time"
+
 type CredentialsResponse struct {
 	Token string `json:"token"`
 }
@@ -7,3 +9,20 @@ type CredentialsResponse struct {
 func NewCredentialsResponse(credentials Credentials) CredentialsResponse {
 	return CredentialsResponse(credentials)
 }
+
+type AppTokenResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Token is only returned when the app token is created
+	Token     string    `json:"token,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func NewAppTokenResponse(appToken AppToken, token string) AppTokenResponse {
+	return AppTokenResponse{
+		ID:        appToken.ID,
+		Name:      appToken.Name,
+		Token:     token,
+		CreatedAt: appToken.CreatedAt,
+	}
+}
@@ -15,6 +15,10 @@ type User struct {
 	Role      UserRole
 	Password  string
 	CreatedAt int64
+	// LicenseKey is the key the licenses of the protected books are encrypted for, derived from the license
+	// passphrase of the user. The passphrase itself is never stored, LicenseHint reminds the user of it.
+	LicenseKey  string
+	LicenseHint string
 }
 
 func (u User) IsAdmin() bool {
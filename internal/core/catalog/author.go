@@ -0,0 +1,36 @@
+package catalog
+
+import "time"
+
+type Author struct {
+	ID        string
+	Name      string
+	Slug      string
+	Bio       string
+	PhotoID   *string
+	Books     []BookAuthor
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type AuthorRole string
+
+const (
+	RoleAuthor      AuthorRole = "author"
+	RoleTranslator  AuthorRole = "translator"
+	RoleIllustrator AuthorRole = "illustrator"
+)
+
+// BookAuthor links a book with one of its contributors. Position keeps the order in which they are credited.
+type BookAuthor struct {
+	BookID   string
+	AuthorID string
+	Role     AuthorRole
+	Position int
+	Book     Book
+	Author   Author
+}
+
+func (BookAuthor) TableName() string {
+	return "books_authors"
+}
@@ -5,26 +5,134 @@ import "time"
 type Book struct {
 	ID          string
 	Title       string
+	Subtitle    string
 	Description string
-	AuthorName  string
-	Images      []Image
-	ContentID   string
-	Price       int
-	ReleaseDate time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// ISBN is kept in the normalized ISBN-13 form. Books without one have a nil ISBN.
+	ISBN *string
+	// ExternalID is the id the publisher uses for the book in its catalog files.
+	ExternalID            *string
+	Publisher             string
+	Language              string
+	PageCount             int
+	Edition               string
+	AccessibilityFeatures []AccessibilityFeature
+	Authors               []BookAuthor
+	Images                []Image
+	Categories            []Category `gorm:"many2many:books_categories"`
+	Files                 []BookFile
+	Sample                *BookSample
+	Price                 int
+	// Watermarked books are delivered to their buyers as copies stamped with who bought them. Only PDFs are stamped.
+	Watermarked bool
+	// Protected books are only delivered as encrypted EPUBs, which their buyers open with a license.
+	Protected bool
+	// RatingAverage and RatingCount summarize the approved reviews of the book. They are only written when reviews change.
+	RatingAverage float64
+	RatingCount   int
+	ReleaseDate   time.Time
+	// Status is the publication status of the book. PublishAt is when a scheduled book goes live, or when the book
+	// was published.
+	Status    PublicationStatus `gorm:"default:draft"`
+	PublishAt *time.Time
+	// ArchivedAt is set when the book is withdrawn from sale. Archived books are still downloadable by their owners.
+	ArchivedAt *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
+// PublicationStatus tells whether the book is listed in the catalog. Only published books are listed and sold.
+type PublicationStatus string
+
+const (
+	StatusDraft     PublicationStatus = "draft"
+	StatusScheduled PublicationStatus = "scheduled"
+	StatusPublished PublicationStatus = "published"
+	// StatusUnpublished is a book withdrawn by the editors. Unlike drafts, it was sold before.
+	StatusUnpublished PublicationStatus = "unpublished"
+)
+
+// Released reports whether the book was ever published. Drafts and scheduled books are only visible to admins,
+// while unpublished books can still be opened by the customers who bought them.
+func (b Book) Released() bool {
+	return b.Status != StatusDraft && b.Status != StatusScheduled
+}
+
+// AccessibilityFeature is one of the schema.org accessibilityFeature values supported by a book.
+type AccessibilityFeature struct {
+	BookID  string `gorm:"primaryKey"`
+	Feature string `gorm:"primaryKey"`
+}
+
+func (AccessibilityFeature) TableName() string {
+	return "books_accessibility_features"
+}
+
+// accessibilityFeatures is the subset of the schema.org accessibilityFeature vocabulary that applies to ebooks.
+var accessibilityFeatures = map[string]bool{
+	"alternativeText":         true,
+	"annotations":             true,
+	"describedMath":           true,
+	"displayTransformability": true,
+	"highContrastDisplay":     true,
+	"index":                   true,
+	"largePrint":              true,
+	"longDescription":         true,
+	"MathML":                  true,
+	"pageNavigation":          true,
+	"printPageNumbers":        true,
+	"readingOrder":            true,
+	"structuralNavigation":    true,
+	"tableOfContents":         true,
+	"taggedPDF":               true,
+	"ttsMarkup":               true,
+}
+
+func IsAccessibilityFeature(feature string) bool {
+	return accessibilityFeatures[feature]
+}
+
+// Image is an uploaded image of a book. Its ID is the storage key of the original upload.
+// Images are listed by Position and the one marked IsMain is the cover of the book.
+// VariantsGenerated is set once the variants were generated, or it was found the image has none.
 type Image struct {
-	ID          string
-	Description string
-	BookID      string
+	ID                string
+	Description       string
+	BookID            string
+	Position          int
+	IsMain            bool
+	Variants          []ImageVariant
+	VariantsGenerated bool
 }
 
+// MainImageID returns the cover of the book, the first image unless another one was chosen.
 func (b Book) MainImageID() string {
-	// TODO: implement position to be able to choose main image
+	for _, image := range b.Images {
+		if image.IsMain {
+			return image.ID
+		}
+	}
 	if len(b.Images) > 0 {
 		return b.Images[0].ID
 	}
 	return ""
 }
+
+// Features returns the names of the accessibility features of the book.
+func (b Book) Features() []string {
+	features := make([]string, 0, len(b.AccessibilityFeatures))
+	for _, f := range b.AccessibilityFeatures {
+		features = append(features, f.Feature)
+	}
+	return features
+}
+
+// AuthorNames returns the names of the contributors with the given role in the order they are credited.
+func (b Book) AuthorNames(role AuthorRole) []string {
+	names := make([]string, 0, len(b.Authors))
+	for _, a := range b.Authors {
+		if a.Role == role {
+			names = append(names, a.Author.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,177 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ebookstore/internal/log"
+)
+
+var errInvalidSeek = fmt.Errorf("the content cannot be read before its start")
+
+// BookContent is a book file read from the storage on demand, so it can be served in ranges without being
+// downloaded first. It must be closed once it's read.
+type BookContent struct {
+	io.ReadSeekCloser
+	Format BookFormat
+	// Filename is built from the title of the book and the extension of the format
+	Filename    string
+	ContentType string
+	Size        int64
+	// ETag identifies the content of the file. Each upload gets its own key, so it changes when the file is replaced
+	ETag      string
+	UpdatedAt time.Time
+}
+
+// OpenBookContent opens the book content in the given format. An empty format opens the preferred one.
+func (c *Catalog) OpenBookContent(ctx context.Context, id string, format BookFormat) (BookContent, error) {
+	log.Infof(ctx, "new request for opening book content %s", id)
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return BookContent{}, fmt.Errorf("(OpenBookContent) failed finding book %s: %w", id, err)
+	}
+
+	file, ok := book.File(format)
+	if !ok {
+		return BookContent{}, fmt.Errorf("(OpenBookContent) failed finding %q file of book %s: %w", format, id, ErrBookFormatNotAvailable)
+	}
+
+	content, err := c.bookContent(ctx, book, file)
+	if err != nil {
+		return BookContent{}, fmt.Errorf("(OpenBookContent) failed opening %q file of book %s: %w", file.Format, id, err)
+	}
+
+	return content, nil
+}
+
+// bookContent opens the file of the book without reading it yet. The files migrated before their size was recorded
+// have none, so it's taken from the stored object.
+func (c *Catalog) bookContent(ctx context.Context, book Book, file BookFile) (BookContent, error) {
+	if file.Size == 0 {
+		object, err := c.StorageClient.HeadObject(ctx, file.ContentID)
+		if err != nil {
+			return BookContent{}, fmt.Errorf("(bookContent) failed finding size of content %s: %w", file.ContentID, err)
+		}
+		file.Size = object.Size
+	}
+
+	etag := file.Checksum
+	if etag == "" {
+		etag = file.ContentID
+	}
+
+	return BookContent{
+		ReadSeekCloser: &objectReader{ctx: ctx, storage: c.StorageClient, key: file.ContentID, size: file.Size},
+		Format:         file.Format,
+		Filename:       contentFilename(book.Title, file.Format),
+		ContentType:    file.MediaType,
+		Size:           file.Size,
+		ETag:           fmt.Sprintf("%q", etag),
+		UpdatedAt:      file.UpdatedAt,
+	}, nil
+}
+
+// openProcessedContent opens the file to be read whole by a sampler, a watermarker or a protector, unless it's larger
+// than MaxProcessedSize.
+func (c *Catalog) openProcessedContent(ctx context.Context, file BookFile) (io.ReadCloser, error) {
+	if c.MaxProcessedSize > 0 {
+		size := file.Size
+		if size == 0 {
+			object, err := c.StorageClient.HeadObject(ctx, file.ContentID)
+			if err != nil {
+				return nil, fmt.Errorf("(openProcessedContent) failed finding size of content %s: %w", file.ContentID, err)
+			}
+			size = object.Size
+		}
+		if size > c.MaxProcessedSize {
+			return nil, fmt.Errorf("(openProcessedContent) failed checking size %d of content %s: %w", size, file.ContentID, ErrContentTooLarge)
+		}
+	}
+
+	content, err := c.StorageClient.GetObject(ctx, file.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("(openProcessedContent) failed reading content %s: %w", file.ContentID, err)
+	}
+	return content, nil
+}
+
+// contentFilename is the title of the book without the characters that are not allowed in filenames.
+func contentFilename(title string, format BookFormat) string {
+	name := strings.Join(strings.FieldsFunc(title, func(r rune) bool {
+		return unicode.IsControl(r) || unicode.IsSpace(r) || strings.ContainsRune(`/\:*?"<>|`, r)
+	}), " ")
+	if name == "" {
+		name = "book"
+	}
+
+	return name + "." + string(format)
+}
+
+// objectReader reads an object of the storage from the current offset. Seeking only moves the offset, and the object
+// is requested again from there on the next read.
+type objectReader struct {
+	ctx     context.Context
+	storage StorageClient
+	key     string
+	size    int64
+	offset  int64
+	body    io.ReadCloser
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.storage.GetObjectRange(r.ctx, r.key, r.offset)
+		if err != nil {
+			return 0, fmt.Errorf("(Read) failed reading content from %d: %w", r.offset, err)
+		}
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err == io.EOF && r.offset < r.size {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += r.offset
+	case io.SeekEnd:
+		offset += r.size
+	}
+
+	if offset < 0 {
+		return r.offset, errInvalidSeek
+	}
+
+	if offset != r.offset && r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+	r.offset = offset
+
+	return offset, nil
+}
+
+func (r *objectReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
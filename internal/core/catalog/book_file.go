@@ -0,0 +1,58 @@
+package catalog
+
+import "time"
+
+// BookFormat is the file format a book content is distributed in.
+type BookFormat string
+
+const (
+	FormatPDF  BookFormat = "pdf"
+	FormatEPUB BookFormat = "epub"
+	FormatMOBI BookFormat = "mobi"
+)
+
+// bookFormats are the supported formats in the order they are preferred when the reader doesn't choose one.
+var bookFormats = []BookFormat{FormatPDF, FormatEPUB, FormatMOBI}
+
+var bookFormatMediaTypes = map[BookFormat]string{
+	FormatPDF:  "application/pdf",
+	FormatEPUB: "application/epub+zip",
+	FormatMOBI: "application/x-mobipocket-ebook",
+}
+
+// MediaType returns the MIME type of the files in the format.
+func (f BookFormat) MediaType() string {
+	return bookFormatMediaTypes[f]
+}
+
+// BookFile is the content of a book in one of the formats. ContentID is the storage key of the uploaded file
+// and Checksum is its hex encoded SHA-256.
+type BookFile struct {
+	BookID    string     `gorm:"primaryKey"`
+	Format    BookFormat `gorm:"primaryKey"`
+	ContentID string
+	MediaType string
+	Size      int64
+	Checksum  string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// File returns the file of the book in the given format. An empty format returns the file in the preferred format.
+func (b Book) File(format BookFormat) (BookFile, bool) {
+	if format == "" {
+		for _, f := range bookFormats {
+			if file, ok := b.File(f); ok {
+				return file, true
+			}
+		}
+		return BookFile{}, false
+	}
+
+	for _, file := range b.Files {
+		if file.Format == format {
+			return file, true
+		}
+	}
+	return BookFile{}, false
+}
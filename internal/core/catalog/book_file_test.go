@@ -0,0 +1,35 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBookFormat_MediaType(t *testing.T) {
+	assert.Equal(t, "application/pdf", FormatPDF.MediaType())
+	assert.Equal(t, "application/epub+zip", FormatEPUB.MediaType())
+	assert.Equal(t, "application/x-mobipocket-ebook", FormatMOBI.MediaType())
+	assert.Empty(t, BookFormat("docx").MediaType())
+}
+
+func TestBook_File(t *testing.T) {
+	book := Book{Files: []BookFile{
+		{Format: FormatMOBI, ContentID: "mobi-content"},
+		{Format: FormatEPUB, ContentID: "epub-content"},
+	}}
+
+	file, ok := book.File(FormatMOBI)
+	assert.True(t, ok)
+	assert.Equal(t, "mobi-content", file.ContentID)
+
+	file, ok = book.File("")
+	assert.True(t, ok)
+	assert.Equal(t, "epub-content", file.ContentID)
+
+	_, ok = book.File(FormatPDF)
+	assert.False(t, ok)
+
+	_, ok = Book{}.File("")
+	assert.False(t, ok)
+}
@@ -36,6 +36,16 @@ func TestBook_MainImageID(t *testing.T) {
 			},
 			Expected: "image-id",
 		},
+		{
+			Name: "chosen main image",
+			Book: Book{
+				Images: []Image{
+					{ID: "image-id", Position: 0},
+					{ID: "image-id2", Position: 1, IsMain: true},
+				},
+			},
+			Expected: "image-id2",
+		},
 	}
 
 	for _, tc := range tests {
@@ -45,3 +55,17 @@ func TestBook_MainImageID(t *testing.T) {
 		})
 	}
 }
+
+func TestBook_AuthorNames(t *testing.T) {
+	book := Book{
+		Authors: []BookAuthor{
+			{Role: RoleAuthor, Author: Author{Name: "Terry Pratchett"}},
+			{Role: RoleIllustrator, Author: Author{Name: "Josh Kirby"}},
+			{Role: RoleAuthor, Author: Author{Name: "Neil Gaiman"}},
+		},
+	}
+
+	assert.Equal(t, []string{"Terry Pratchett", "Neil Gaiman"}, book.AuthorNames(RoleAuthor))
+	assert.Equal(t, []string{"Josh Kirby"}, book.AuthorNames(RoleIllustrator))
+	assert.Empty(t, book.AuthorNames(RoleTranslator))
+}
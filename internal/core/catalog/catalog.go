@@ -3,6 +3,8 @@ package catalog
 import (
 	"context"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/ebookstore/internal/core/query"
 	"github.com/ebookstore/internal/log"
@@ -13,12 +15,80 @@ type Repository interface {
 	FindByID(ctx context.Context, id string) (book Book, err error)
 	Create(ctx context.Context, book *Book) error
 	Update(ctx context.Context, book *Book) error
+	// Archive withdraws the book from sale, keeping the time it was first archived.
+	Archive(ctx context.Context, id string) error
+	Restore(ctx context.Context, id string) error
+	// UpdateStatus sets the publication status of the book along with the time it goes live.
+	UpdateStatus(ctx context.Context, id string, status PublicationStatus, publishAt *time.Time) error
+	// PublishScheduled publishes the scheduled books due at the given time and returns how many were published.
+	PublishScheduled(ctx context.Context, now time.Time) (int64, error)
+	// Delete removes the book permanently.
 	Delete(ctx context.Context, id string) error
+	// SaveFile creates the file of the book in its format or replaces the existing one.
+	SaveFile(ctx context.Context, file *BookFile) error
+	DeleteFile(ctx context.Context, bookID string, format BookFormat) error
+	// SaveSample sets the sample of the book, replacing the existing one.
+	SaveSample(ctx context.Context, sample *BookSample) error
+	// SaveImages replaces the images of the book with the given ones, keeping their positions and main image.
+	SaveImages(ctx context.Context, bookID string, images []Image) error
 }
 
+type CategoryRepository interface {
+	FindAll(ctx context.Context) ([]Category, error)
+	FindByID(ctx context.Context, id string) (Category, error)
+	FindBySlug(ctx context.Context, slug string) (Category, error)
+	FindByIDs(ctx context.Context, ids []string) ([]Category, error)
+	// FindSubtreeIDs returns the id of the given category and the ids of all its descendants.
+	FindSubtreeIDs(ctx context.Context, id string) ([]string, error)
+	// CountBooks returns the number of distinct books of each category including its descendants.
+	CountBooks(ctx context.Context) (map[string]int64, error)
+	Create(ctx context.Context, category *Category) error
+	Update(ctx context.Context, category *Category) error
+	Delete(ctx context.Context, id string) error
+}
+
+type AuthorRepository interface {
+	// FindByID returns the author with the books it is credited in.
+	FindByID(ctx context.Context, id string) (Author, error)
+	FindByIDs(ctx context.Context, ids []string) ([]Author, error)
+	FindBySlugs(ctx context.Context, slugs []string) ([]Author, error)
+	Create(ctx context.Context, author *Author) error
+	Update(ctx context.Context, author *Author) error
+	Delete(ctx context.Context, id string) error
+}
+
+// Asset is the kind of content stored in an object, so storages can serve each kind differently.
+type Asset string
+
+const (
+	// AssetImage is an image of a book, one of its variants or the photo of an author.
+	AssetImage    Asset = "image"
+	AssetBookFile Asset = "file"
+	AssetSample   Asset = "sample"
+)
+
 type StorageClient interface {
-	GenerateGetPreSignedUrl(ctx context.Context, key string) (string, error)
-	GeneratePutPreSignedUrl(ctx context.Context, key string) (string, error)
+	// GenerateGetPreSignedUrl generates a temporary link to the object, which is an asset of the given kind.
+	GenerateGetPreSignedUrl(ctx context.Context, key string, asset Asset) (string, error)
+	// GeneratePutPreSignedUrl generates a URL that only accepts an upload matching the content type and size of the
+	// object, and its checksum when it has one.
+	GeneratePutPreSignedUrl(ctx context.Context, key string, object ObjectInfo) (string, error)
+	// HeadObject describes the object stored with the key, or fails with ErrUploadNotFound when there is none.
+	HeadObject(ctx context.Context, key string) (ObjectInfo, error)
+	// GetObject returns the content stored with the key. The caller must close it.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetObjectRange returns the content stored with the key from the offset to the end. The caller must close it.
+	GetObjectRange(ctx context.Context, key string, offset int64) (io.ReadCloser, error)
+	PutObject(ctx context.Context, key string, contentType string, body io.Reader) error
+	// ListObjects returns the keys of the objects stored with keys starting with the prefix.
+	ListObjects(ctx context.Context, prefix string) ([]string, error)
+	// DeleteObjects deletes the objects stored with the keys. Keys without an object are ignored.
+	DeleteObjects(ctx context.Context, keys []string) error
+}
+
+// ImportParser reads the books of a catalog file in one of the import formats.
+type ImportParser interface {
+	Parse(format string, r io.Reader) ([]ImportRow, error)
 }
 
 type IDGenerator interface {
@@ -30,10 +100,28 @@ type Validator interface {
 }
 
 type Config struct {
-	Repository    Repository
-	StorageClient StorageClient
-	IDGenerator   IDGenerator
-	Validator     Validator
+	Repository         Repository
+	CategoryRepository CategoryRepository
+	AuthorRepository   AuthorRepository
+	ImageRepository    ImageRepository
+	UploadRepository   UploadRepository
+	StorageClient      StorageClient
+	ImageResizer       ImageResizer
+	ImportParser       ImportParser
+	ExportEncoder      ExportEncoder
+	SampleGenerator    SampleGenerator
+	Watermarker        Watermarker
+	FeedCache          FeedCache
+	LinkCache          LinkCache
+	IDGenerator        IDGenerator
+	Validator          Validator
+
+	// MaxProcessedSize bounds the size of the files read whole to be sampled, watermarked or encrypted. Zero means no limit
+	MaxProcessedSize int64
+
+	// ProtectedContentRepository and ContentProtector are only used to deliver the protected books
+	ProtectedContentRepository ProtectedContentRepository
+	ContentProtector           ContentProtector
 }
 
 type Catalog struct {
@@ -47,21 +135,36 @@ func New(c Config) *Catalog {
 func (c *Catalog) FindBooks(ctx context.Context, request SearchBooks) (PaginatedBooksResponse, error) {
 	log.Infof(ctx, "new request for fetching books")
 
-	paginatedBooks, err := c.Repository.FindByQuery(ctx, request.CreateQuery(), request.CreatePage())
+	// Archived books are hidden from the listings, only admins can list them to restore them
+	q := request.CreateQuery()
+	q.And(query.Condition{Field: "archived", Operator: query.Equal, Value: request.Archived && isAdmin(ctx)})
+	// Books not published yet are only listed to admins, who see every status unless they filter by one
+	if !isAdmin(ctx) {
+		q.And(query.Condition{Field: "status", Operator: query.Equal, Value: StatusPublished})
+	} else if request.Status != "" {
+		q.And(query.Condition{Field: "status", Operator: query.Equal, Value: request.Status})
+	}
+	if request.Category != "" {
+		categoryIDs, err := c.findCategorySubtreeIDs(ctx, request.Category)
+		if err != nil {
+			return PaginatedBooksResponse{}, fmt.Errorf("(FindBooks) failed finding categories: %w", err)
+		}
+		q.And(query.Condition{Field: "category_id", Operator: query.In, Value: categoryIDs})
+	}
+
+	paginatedBooks, err := c.Repository.FindByQuery(ctx, q, request.CreatePage())
 	if err != nil {
 		return PaginatedBooksResponse{}, fmt.Errorf("(FindBooks) failed finding books: %w", err)
 	}
 
-	fmt.Println("paginatedBooks", paginatedBooks.Books[0].Images)
-
-	imageLinksByBookId := make(map[string][]string)
+	variant := request.ImageVariant
+	if variant == "" {
+		variant = VariantThumbnail
+	}
 
-	for _, book := range paginatedBooks.Books {
-		imageLinks, err := c.getPresignedUrlsForBook(ctx, book)
-		if err != nil {
-			return PaginatedBooksResponse{}, fmt.Errorf("(FindBooks) failed getting urls: %w", err)
-		}
-		imageLinksByBookId[book.ID] = imageLinks
+	imageLinksByBookId, err := c.getPresignedUrlsForBooks(ctx, paginatedBooks.Books, variant, false)
+	if err != nil {
+		return PaginatedBooksResponse{}, fmt.Errorf("(FindBooks) failed getting urls: %w", err)
 	}
 
 	return NewPaginatedBooksResponse(paginatedBooks, imageLinksByBookId), nil
@@ -75,7 +178,11 @@ func (c *Catalog) FindBookByID(ctx context.Context, id string) (BookResponse, er
 		return BookResponse{}, fmt.Errorf("(FindBookByID) failed finding book %s: %w", id, err)
 	}
 
-	imageLinks, err := c.getPresignedUrlsForBook(ctx, book)
+	if !book.Released() && !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(FindBookByID) failed validating status of book %s: %w", id, ErrBookNotPublished)
+	}
+
+	imageLinks, err := c.getPresignedUrlsForBook(ctx, book, VariantOriginal, true)
 	if err != nil {
 		return BookResponse{}, fmt.Errorf("(FindBookByID) failed getting urls: %w", err)
 	}
@@ -83,20 +190,91 @@ func (c *Catalog) FindBookByID(ctx context.Context, id string) (BookResponse, er
 	return NewBookResponse(book, imageLinks), nil
 }
 
-func (c *Catalog) getPresignedUrlsForBook(ctx context.Context, book Book) ([]string, error) {
-	imageLinks := make([]string, 0, len(book.Images))
-	for _, img := range book.Images {
-		url, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, img.ID)
-		if err != nil {
-			return nil, fmt.Errorf("(getPresignedUrlsForBook) failed generating presigned url: %w", err)
+// FindBooksByIDs returns the books with the given ids whatever their status, as they are listed to the users who
+// bought them. Unknown ids are skipped. The books are fetched in a single query and their links presigned together.
+func (c *Catalog) FindBooksByIDs(ctx context.Context, ids []string) ([]BookResponse, error) {
+	if len(ids) == 0 {
+		return []BookResponse{}, nil
+	}
+
+	q := query.New().And(query.Condition{Field: "id", Operator: query.In, Value: ids})
+	paginatedBooks, err := c.Repository.FindByQuery(ctx, *q, query.Page{Size: len(ids), Keyset: true})
+	if err != nil {
+		return nil, fmt.Errorf("(FindBooksByIDs) failed finding books: %w", err)
+	}
+
+	imageLinksByBookId, err := c.getPresignedUrlsForBooks(ctx, paginatedBooks.Books, VariantOriginal, true)
+	if err != nil {
+		return nil, fmt.Errorf("(FindBooksByIDs) failed getting urls: %w", err)
+	}
+
+	books := make([]BookResponse, 0, len(paginatedBooks.Books))
+	for _, book := range paginatedBooks.Books {
+		books = append(books, NewBookResponse(book, imageLinksByBookId[book.ID]))
+	}
+
+	return books, nil
+}
+
+// getPresignedUrlsForBooks links the images of the books in the given variant, falling back to the original when
+// the variant was not generated. The links to every variant are only included when requested. The links of all the
+// books are presigned at once, so they are read from the cache in a single call and the missing ones signed together.
+func (c *Catalog) getPresignedUrlsForBooks(ctx context.Context, books []Book, variant VariantName, withVariants bool) (map[string][]ImageLinks, error) {
+	var keys []string
+	for _, book := range books {
+		for _, img := range book.Images {
+			keys = append(keys, imageKey(img, variant))
+			if withVariants {
+				for _, v := range img.Variants {
+					keys = append(keys, v.ContentID)
+				}
+			}
 		}
-		imageLinks = append(imageLinks, url)
 	}
 
-	return imageLinks, nil
+	links, err := c.presignImageLinks(ctx, keys)
+	if err != nil {
+		return nil, fmt.Errorf("(getPresignedUrlsForBooks) failed generating presigned urls: %w", err)
+	}
+
+	imageLinksByBookID := make(map[string][]ImageLinks, len(books))
+	for _, book := range books {
+		imageLinks := make([]ImageLinks, 0, len(book.Images))
+		for _, img := range book.Images {
+			imageLink := ImageLinks{Link: links[imageKey(img, variant)]}
+			if withVariants && len(img.Variants) > 0 {
+				imageLink.Variants = make(map[VariantName]string, len(img.Variants))
+				for _, v := range img.Variants {
+					imageLink.Variants[v.Name] = links[v.ContentID]
+				}
+			}
+			imageLinks = append(imageLinks, imageLink)
+		}
+		imageLinksByBookID[book.ID] = imageLinks
+	}
+
+	return imageLinksByBookID, nil
+}
+
+func (c *Catalog) getPresignedUrlsForBook(ctx context.Context, book Book, variant VariantName, withVariants bool) ([]ImageLinks, error) {
+	imageLinks, err := c.getPresignedUrlsForBooks(ctx, []Book{book}, variant, withVariants)
+	if err != nil {
+		return nil, err
+	}
+
+	return imageLinks[book.ID], nil
 }
 
-func (c *Catalog) GetBookContentURL(ctx context.Context, id string) (string, error) {
+// imageKey is the key of the image in the given variant, or of the original when the variant was not generated.
+func imageKey(img Image, variant VariantName) string {
+	if v, ok := img.Variant(variant); ok {
+		return v.ContentID
+	}
+	return img.ID
+}
+
+// GetBookContentURL returns a link to the book content in the given format. An empty format links to the preferred one.
+func (c *Catalog) GetBookContentURL(ctx context.Context, id string, format BookFormat) (string, error) {
 	log.Infof(ctx, "new request for generating book content url %s", id)
 
 	book, err := c.Repository.FindByID(ctx, id)
@@ -104,7 +282,12 @@ func (c *Catalog) GetBookContentURL(ctx context.Context, id string) (string, err
 		return "", fmt.Errorf("(GetBookContent) failed finding book %s: %w", id, err)
 	}
 
-	contentUrl, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, book.ContentID)
+	file, ok := book.File(format)
+	if !ok {
+		return "", fmt.Errorf("(GetBookContent) failed finding %q file of book %s: %w", format, id, ErrBookFormatNotAvailable)
+	}
+
+	contentUrl, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, file.ContentID, AssetBookFile)
 	if err != nil {
 		return "", fmt.Errorf("(GetBookContent) failed generating presigned url: %w", err)
 	}
@@ -123,10 +306,43 @@ func (c *Catalog) CreateBook(ctx context.Context, request CreateBook) (BookRespo
 		return BookResponse{}, fmt.Errorf("(CreateBook) failed validating request: %w", err)
 	}
 
+	if err := c.verifyBookFiles(ctx, request.Files); err != nil {
+		return BookResponse{}, fmt.Errorf("(CreateBook) failed verifying files: %w", err)
+	}
+
+	if err := c.verifyImages(ctx, Book{}, request.Images); err != nil {
+		return BookResponse{}, fmt.Errorf("(CreateBook) failed verifying images: %w", err)
+	}
+
 	book := request.Book(c.IDGenerator.NewID())
+	if err := validateProtection(book); err != nil {
+		return BookResponse{}, fmt.Errorf("(CreateBook) failed validating protection: %w", err)
+	}
+
+	for _, file := range book.Files {
+		if err := c.verifyWatermarkSource(ctx, book, file); err != nil {
+			return BookResponse{}, fmt.Errorf("(CreateBook) failed verifying %s file: %w", file.Format, err)
+		}
+	}
+
+	authors, err := c.findBookAuthors(ctx, book.ID, request.Authors)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(CreateBook) failed finding authors: %w", err)
+	}
+	book.Authors = authors
+
+	if len(request.CategoryIDs) > 0 {
+		categories, err := c.findCategoriesByIDs(ctx, request.CategoryIDs)
+		if err != nil {
+			return BookResponse{}, fmt.Errorf("(CreateBook) failed finding categories: %w", err)
+		}
+		book.Categories = categories
+	}
+
 	if err := c.Repository.Create(ctx, &book); err != nil {
 		return BookResponse{}, fmt.Errorf("(CreateBook) failed creating book: %w", err)
 	}
+	c.invalidatePublicFeed(ctx)
 
 	return c.FindBookByID(ctx, book.ID)
 }
@@ -147,35 +363,469 @@ func (c *Catalog) UpdateBook(ctx context.Context, request UpdateBook) error {
 		return fmt.Errorf("(UpdateBook) failed finding book %s: %w", request.ID, err)
 	}
 
+	if err = c.verifyImages(ctx, existing, request.Images); err != nil {
+		return fmt.Errorf("(UpdateBook) failed verifying images: %w", err)
+	}
+
 	updated := request.Update(existing)
+	if err = validateProtection(updated); err != nil {
+		return fmt.Errorf("(UpdateBook) failed validating protection: %w", err)
+	}
+
+	if updated.Watermarked && !existing.Watermarked {
+		for _, file := range updated.Files {
+			if err = c.verifyWatermarkSource(ctx, updated, file); err != nil {
+				return fmt.Errorf("(UpdateBook) failed verifying %s file: %w", file.Format, err)
+			}
+		}
+	}
+
+	if err = c.trackRemovedUploads(ctx, removedImageKeys(existing, updated.Images)); err != nil {
+		return fmt.Errorf("(UpdateBook) failed tracking removed images: %w", err)
+	}
+
+	if request.Authors != nil {
+		if updated.Authors, err = c.findBookAuthors(ctx, updated.ID, request.Authors); err != nil {
+			return fmt.Errorf("(UpdateBook) failed finding authors: %w", err)
+		}
+	}
+
+	if request.CategoryIDs != nil {
+		if updated.Categories, err = c.findCategoriesByIDs(ctx, request.CategoryIDs); err != nil {
+			return fmt.Errorf("(UpdateBook) failed finding categories: %w", err)
+		}
+	}
+
 	if err = c.Repository.Update(ctx, &updated); err != nil {
 		return fmt.Errorf("(UpdateBook) failed updating book %s: %w", request.ID, err)
 	}
+	c.invalidatePublicFeed(ctx)
+
 	return nil
 }
 
+// DeleteBook archives the book. It can no longer be found or bought, but the customers who bought it keep downloading it.
 func (c *Catalog) DeleteBook(ctx context.Context, id string) error {
-	log.Infof(ctx, "new request for deleting book with id %s", id)
+	log.Infof(ctx, "new request for archiving book with id %s", id)
 
 	if !isAdmin(ctx) {
 		return fmt.Errorf("(DeleteBook) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
 	}
 
-	if err := c.Repository.Delete(ctx, id); err != nil {
-		return fmt.Errorf("(DeleteBook) failed deleting book: %w", err)
+	if err := c.Repository.Archive(ctx, id); err != nil {
+		return fmt.Errorf("(DeleteBook) failed archiving book: %w", err)
 	}
+	c.invalidatePublicFeed(ctx)
 
 	return nil
 }
 
-func (c *Catalog) GeneratePutPreSignedUrl(ctx context.Context) (PresignURLResponse, error) {
-	idGenerator := c.IDGenerator.NewID()
-	url, err := c.StorageClient.GeneratePutPreSignedUrl(ctx, idGenerator)
+// RestoreBook puts an archived book back on sale.
+func (c *Catalog) RestoreBook(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for restoring book with id %s", id)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(RestoreBook) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Repository.Restore(ctx, id); err != nil {
+		return fmt.Errorf("(RestoreBook) failed restoring book: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return nil
+}
+
+// PurgeBook deletes an archived book permanently, along with its images and files. The customers who bought it
+// can no longer download it, so books must be archived first.
+func (c *Catalog) PurgeBook(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for purging book with id %s", id)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(PurgeBook) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	book, err := c.Repository.FindByID(ctx, id)
 	if err != nil {
-		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) failed generating presigned url: %w", err)
+		return fmt.Errorf("(PurgeBook) failed finding book %s: %w", id, err)
+	}
+
+	if book.ArchivedAt == nil {
+		return fmt.Errorf("(PurgeBook) failed validating book %s: %w", id, ErrBookNotArchived)
+	}
+
+	if err = c.trackRemovedUploads(ctx, bookUploadKeys(book)); err != nil {
+		return fmt.Errorf("(PurgeBook) failed tracking removed content: %w", err)
+	}
+
+	if err = c.Repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("(PurgeBook) failed deleting book: %w", err)
 	}
 
-	return PresignURLResponse{ID: idGenerator, URL: url}, nil
+	return nil
+}
+
+// SaveBookFile attaches the content uploaded with a presigned URL to the book as the file of its format.
+func (c *Catalog) SaveBookFile(ctx context.Context, request SaveBookFile) (BookResponse, error) {
+	log.Infof(ctx, "new request for saving the %s file of book %s", request.Format, request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed validating request: %w", err)
+	}
+
+	book, err := c.Repository.FindByID(ctx, request.BookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed finding book %s: %w", request.BookID, err)
+	}
+
+	if err = c.verifyUpload(ctx, request.ContentID, request.ObjectInfo()); err != nil {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed verifying file: %w", err)
+	}
+
+	file := request.BookFile(request.BookID)
+	if err = c.verifyWatermarkSource(ctx, book, file); err != nil {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed verifying file: %w", err)
+	}
+
+	if previous, ok := book.File(file.Format); ok {
+		if err = c.trackRemovedUploads(ctx, replacedKey(previous.ContentID, file.ContentID)); err != nil {
+			return BookResponse{}, fmt.Errorf("(SaveBookFile) failed tracking replaced file: %w", err)
+		}
+	}
+
+	if err = c.Repository.SaveFile(ctx, &file); err != nil {
+		return BookResponse{}, fmt.Errorf("(SaveBookFile) failed saving file: %w", err)
+	}
+
+	return c.FindBookByID(ctx, request.BookID)
+}
+
+// DeleteBookFile removes a format of the book. Books keep at least one file, so they can always be downloaded.
+func (c *Catalog) DeleteBookFile(ctx context.Context, bookID string, format BookFormat) error {
+	log.Infof(ctx, "new request for deleting the %s file of book %s", format, bookID)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(DeleteBookFile) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	book, err := c.Repository.FindByID(ctx, bookID)
+	if err != nil {
+		return fmt.Errorf("(DeleteBookFile) failed finding book %s: %w", bookID, err)
+	}
+
+	file, ok := book.File(format)
+	if !ok || format == "" {
+		return fmt.Errorf("(DeleteBookFile) failed finding %q file: %w", format, ErrBookFormatNotAvailable)
+	}
+
+	if len(book.Files) == 1 {
+		return fmt.Errorf("(DeleteBookFile) failed validating files of book %s: %w", bookID, ErrLastBookFile)
+	}
+
+	if book.Protected && format == FormatEPUB {
+		return fmt.Errorf("(DeleteBookFile) failed validating files of book %s: %w", bookID, ErrProtectedBookWithoutEPUB)
+	}
+
+	if err = c.trackRemovedUploads(ctx, []string{file.ContentID}); err != nil {
+		return fmt.Errorf("(DeleteBookFile) failed tracking removed file: %w", err)
+	}
+
+	if err = c.Repository.DeleteFile(ctx, bookID, format); err != nil {
+		return fmt.Errorf("(DeleteBookFile) failed deleting file: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) FindCategories(ctx context.Context) ([]CategoryResponse, error) {
+	log.Infof(ctx, "new request for fetching categories")
+
+	categories, err := c.CategoryRepository.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("(FindCategories) failed finding categories: %w", err)
+	}
+
+	bookCounts, err := c.CategoryRepository.CountBooks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("(FindCategories) failed counting books: %w", err)
+	}
+
+	return NewCategoryTreeResponse(categories, bookCounts), nil
+}
+
+func (c *Catalog) FindCategoryByID(ctx context.Context, id string) (CategoryResponse, error) {
+	log.Infof(ctx, "new request for fetching category %s", id)
+
+	category, err := c.CategoryRepository.FindByID(ctx, id)
+	if err != nil {
+		return CategoryResponse{}, fmt.Errorf("(FindCategoryByID) failed finding category %s: %w", id, err)
+	}
+
+	bookCounts, err := c.CategoryRepository.CountBooks(ctx)
+	if err != nil {
+		return CategoryResponse{}, fmt.Errorf("(FindCategoryByID) failed counting books: %w", err)
+	}
+
+	return NewCategoryResponse(category, bookCounts[category.ID]), nil
+}
+
+func (c *Catalog) CreateCategory(ctx context.Context, request CreateCategory) (CategoryResponse, error) {
+	log.Infof(ctx, "new request for creating category")
+
+	if !isAdmin(ctx) {
+		return CategoryResponse{}, fmt.Errorf("(CreateCategory) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return CategoryResponse{}, fmt.Errorf("(CreateCategory) failed validating request: %w", err)
+	}
+
+	if request.ParentID != nil {
+		if _, err := c.CategoryRepository.FindByID(ctx, *request.ParentID); err != nil {
+			return CategoryResponse{}, fmt.Errorf("(CreateCategory) failed finding parent category %s: %w", *request.ParentID, err)
+		}
+	}
+
+	category := request.Category(c.IDGenerator.NewID())
+	if err := c.CategoryRepository.Create(ctx, &category); err != nil {
+		return CategoryResponse{}, fmt.Errorf("(CreateCategory) failed creating category: %w", err)
+	}
+
+	return NewCategoryResponse(category, 0), nil
+}
+
+func (c *Catalog) UpdateCategory(ctx context.Context, request UpdateCategory) error {
+	log.Infof(ctx, "new request for updating category with id %s", request.ID)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(UpdateCategory) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(UpdateCategory) failed validating request: %w", err)
+	}
+
+	existing, err := c.CategoryRepository.FindByID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("(UpdateCategory) failed finding category %s: %w", request.ID, err)
+	}
+
+	updated := request.Update(existing)
+	if updated.ParentID != nil && (existing.ParentID == nil || *existing.ParentID != *updated.ParentID) {
+		if err = c.validateCategoryParent(ctx, updated); err != nil {
+			return fmt.Errorf("(UpdateCategory) failed validating parent category: %w", err)
+		}
+	}
+
+	if err = c.CategoryRepository.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("(UpdateCategory) failed updating category %s: %w", request.ID, err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) DeleteCategory(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for deleting category with id %s", id)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(DeleteCategory) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.CategoryRepository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("(DeleteCategory) failed deleting category: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) FindAuthorByID(ctx context.Context, id string) (AuthorResponse, error) {
+	log.Infof(ctx, "new request for fetching author %s", id)
+
+	author, err := c.AuthorRepository.FindByID(ctx, id)
+	if err != nil {
+		return AuthorResponse{}, fmt.Errorf("(FindAuthorByID) failed finding author %s: %w", id, err)
+	}
+
+	photoURL, err := c.getPresignedUrlForAuthor(ctx, author)
+	if err != nil {
+		return AuthorResponse{}, fmt.Errorf("(FindAuthorByID) failed getting photo url: %w", err)
+	}
+
+	return NewAuthorResponse(author, photoURL), nil
+}
+
+func (c *Catalog) CreateAuthor(ctx context.Context, request CreateAuthor) (AuthorResponse, error) {
+	log.Infof(ctx, "new request for creating author")
+
+	if !isAdmin(ctx) {
+		return AuthorResponse{}, fmt.Errorf("(CreateAuthor) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return AuthorResponse{}, fmt.Errorf("(CreateAuthor) failed validating request: %w", err)
+	}
+
+	author := request.Author(c.IDGenerator.NewID())
+	if err := c.AuthorRepository.Create(ctx, &author); err != nil {
+		return AuthorResponse{}, fmt.Errorf("(CreateAuthor) failed creating author: %w", err)
+	}
+
+	return c.FindAuthorByID(ctx, author.ID)
+}
+
+func (c *Catalog) UpdateAuthor(ctx context.Context, request UpdateAuthor) error {
+	log.Infof(ctx, "new request for updating author with id %s", request.ID)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(UpdateAuthor) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(UpdateAuthor) failed validating request: %w", err)
+	}
+
+	existing, err := c.AuthorRepository.FindByID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("(UpdateAuthor) failed finding author %s: %w", request.ID, err)
+	}
+
+	updated := request.Update(existing)
+	if err = c.AuthorRepository.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("(UpdateAuthor) failed updating author %s: %w", request.ID, err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) DeleteAuthor(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for deleting author with id %s", id)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(DeleteAuthor) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.AuthorRepository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("(DeleteAuthor) failed deleting author: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) getPresignedUrlForAuthor(ctx context.Context, author Author) (string, error) {
+	if author.PhotoID == nil {
+		return "", nil
+	}
+
+	links, err := c.presignImageLinks(ctx, []string{*author.PhotoID})
+	if err != nil {
+		return "", fmt.Errorf("(getPresignedUrlForAuthor) failed generating presigned url: %w", err)
+	}
+
+	return links[*author.PhotoID], nil
+}
+
+// findBookAuthors links the requested authors to the book, loading them so they can be part of the response.
+func (c *Catalog) findBookAuthors(ctx context.Context, bookID string, requests []BookAuthorRequest) ([]BookAuthor, error) {
+	if len(requests) == 0 {
+		return nil, nil
+	}
+
+	bookAuthors := BookAuthors(bookID, requests)
+
+	credited := make(map[string]bool, len(bookAuthors))
+	ids := make([]string, 0, len(bookAuthors))
+	for _, ba := range bookAuthors {
+		key := ba.AuthorID + "/" + string(ba.Role)
+		if credited[key] {
+			return nil, ErrDuplicateBookAuthor
+		}
+		credited[key] = true
+		ids = append(ids, ba.AuthorID)
+	}
+
+	ids = uniqueStrings(ids)
+	authors, err := c.AuthorRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("(findBookAuthors) failed finding authors: %w", err)
+	}
+
+	if len(authors) != len(ids) {
+		return nil, ErrUnknownAuthor
+	}
+
+	authorsByID := make(map[string]Author, len(authors))
+	for _, a := range authors {
+		authorsByID[a.ID] = a
+	}
+
+	for i := range bookAuthors {
+		bookAuthors[i].Author = authorsByID[bookAuthors[i].AuthorID]
+	}
+
+	return bookAuthors, nil
+}
+
+// validateCategoryParent checks that the new parent exists and is not part of the category subtree.
+func (c *Catalog) validateCategoryParent(ctx context.Context, category Category) error {
+	if _, err := c.CategoryRepository.FindByID(ctx, *category.ParentID); err != nil {
+		return fmt.Errorf("(validateCategoryParent) failed finding parent category %s: %w", *category.ParentID, err)
+	}
+
+	subtreeIDs, err := c.CategoryRepository.FindSubtreeIDs(ctx, category.ID)
+	if err != nil {
+		return fmt.Errorf("(validateCategoryParent) failed finding subtree of category %s: %w", category.ID, err)
+	}
+
+	for _, id := range subtreeIDs {
+		if id == *category.ParentID {
+			return ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
+func (c *Catalog) findCategorySubtreeIDs(ctx context.Context, slug string) ([]string, error) {
+	category, err := c.CategoryRepository.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, fmt.Errorf("(findCategorySubtreeIDs) failed finding category %s: %w", slug, err)
+	}
+
+	ids, err := c.CategoryRepository.FindSubtreeIDs(ctx, category.ID)
+	if err != nil {
+		return nil, fmt.Errorf("(findCategorySubtreeIDs) failed finding subtree of category %s: %w", slug, err)
+	}
+
+	return ids, nil
+}
+
+func (c *Catalog) findCategoriesByIDs(ctx context.Context, ids []string) ([]Category, error) {
+	categories, err := c.CategoryRepository.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("(findCategoriesByIDs) failed finding categories: %w", err)
+	}
+
+	if len(categories) != len(uniqueStrings(ids)) {
+		return nil, ErrUnknownCategory
+	}
+
+	return categories, nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
 }
 
 func isAdmin(ctx context.Context) bool {
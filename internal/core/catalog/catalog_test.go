@@ -2,14 +2,20 @@
 package catalog_test
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/query"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -25,12 +31,68 @@ const (
 	updateBookMethod              = "Update"
 	deleteBookMethod              = "Delete"
 	validateMethod                = "Validate"
+	findAllMethod                 = "FindAll"
+	findBySlugMethod              = "FindBySlug"
+	findByIDsMethod               = "FindByIDs"
+	findSubtreeIDsMethod          = "FindSubtreeIDs"
+	countBooksMethod              = "CountBooks"
+	createMethod                  = "Create"
+	updateMethod                  = "Update"
+	deleteMethod                  = "Delete"
+	findBySlugsMethod             = "FindBySlugs"
+	parseMethod                   = "Parse"
+	newWriterMethod               = "NewWriter"
+	writeMethod                   = "Write"
+	closeMethod                   = "Close"
+	getMethod                     = "Get"
+	saveMethod                    = "Save"
+	deleteFileMethod              = "DeleteFile"
+	saveSampleMethod              = "SaveSample"
+	getObjectMethod               = "GetObject"
+	listObjectsMethod             = "ListObjects"
+	putObjectMethod               = "PutObject"
+	generateMethod                = "Generate"
+	findWithoutVariantsMethod     = "FindWithoutVariants"
+	saveVariantsMethod            = "SaveVariants"
+	markVariantsFailedMethod      = "MarkVariantsFailed"
+	resizeMethod                  = "Resize"
+	saveImagesMethod              = "SaveImages"
+	archiveMethod                 = "Archive"
+	restoreMethod                 = "Restore"
+	updateStatusMethod            = "UpdateStatus"
+	publishScheduledMethod        = "PublishScheduled"
+	headObjectMethod              = "HeadObject"
+	generatePutPreSignedUrlMethod = "GeneratePutPreSignedUrl"
+	trackMethod                   = "Track"
+	findOrphansMethod             = "FindOrphans"
+	untrackReferencedMethod       = "UntrackReferenced"
+	untrackMethod                 = "Untrack"
+	deleteObjectsMethod           = "DeleteObjects"
+	getLinksMethod                = "GetLinks"
+	saveLinksMethod               = "SaveLinks"
+	getObjectRangeMethod          = "GetObjectRange"
+	watermarkMethod               = "Watermark"
+	findByContentIDMethod         = "FindByContentID"
+	protectMethod                 = "Protect"
 )
 
 type CatalogTestSuite struct {
 	suite.Suite
 	repo          *catalog.MockRepository
+	categoryRepo  *catalog.MockCategoryRepository
+	authorRepo    *catalog.MockAuthorRepository
+	imageRepo     *catalog.MockImageRepository
+	uploadRepo    *catalog.MockUploadRepository
 	storageClient *catalog.MockStorageClient
+	resizer       *catalog.MockImageResizer
+	importParser  *catalog.MockImportParser
+	exportEncoder *catalog.MockExportEncoder
+	sampler       *catalog.MockSampleGenerator
+	watermarker   *catalog.MockWatermarker
+	protector     *catalog.MockContentProtector
+	protectedRepo *catalog.MockProtectedContentRepository
+	feedCache     *catalog.MockFeedCache
+	linkCache     *catalog.MockLinkCache
 	idGenerator   *catalog.MockIDGenerator
 	validator     *catalog.MockValidator
 	catalog       *catalog.Catalog
@@ -38,27 +100,68 @@ type CatalogTestSuite struct {
 
 func (s *CatalogTestSuite) SetupTest() {
 	s.repo = new(catalog.MockRepository)
+	s.categoryRepo = new(catalog.MockCategoryRepository)
+	s.authorRepo = new(catalog.MockAuthorRepository)
+	s.imageRepo = new(catalog.MockImageRepository)
+	s.uploadRepo = new(catalog.MockUploadRepository)
 	s.storageClient = new(catalog.MockStorageClient)
+	s.resizer = new(catalog.MockImageResizer)
+	s.importParser = new(catalog.MockImportParser)
+	s.exportEncoder = new(catalog.MockExportEncoder)
+	s.sampler = new(catalog.MockSampleGenerator)
+	s.watermarker = new(catalog.MockWatermarker)
+	s.protector = new(catalog.MockContentProtector)
+	s.protectedRepo = new(catalog.MockProtectedContentRepository)
+	s.feedCache = new(catalog.MockFeedCache)
+	s.linkCache = new(catalog.MockLinkCache)
 	s.idGenerator = new(catalog.MockIDGenerator)
 	s.validator = new(catalog.MockValidator)
 
 	config := catalog.Config{
-		Repository:    s.repo,
-		StorageClient: s.storageClient,
-		IDGenerator:   s.idGenerator,
-		Validator:     s.validator,
+		Repository:         s.repo,
+		CategoryRepository: s.categoryRepo,
+		AuthorRepository:   s.authorRepo,
+		ImageRepository:    s.imageRepo,
+		UploadRepository:   s.uploadRepo,
+		StorageClient:      s.storageClient,
+		ImageResizer:       s.resizer,
+		ImportParser:       s.importParser,
+		ExportEncoder:      s.exportEncoder,
+		SampleGenerator:    s.sampler,
+		Watermarker:        s.watermarker,
+		FeedCache:          s.feedCache,
+		LinkCache:          s.linkCache,
+		IDGenerator:        s.idGenerator,
+		Validator:          s.validator,
+
+		ProtectedContentRepository: s.protectedRepo,
+		ContentProtector:           s.protector,
 	}
 
 	s.catalog = catalog.New(config)
+
+	// Every change of a book invalidates the public feed
+	s.feedCache.On(deleteMethod, mock.Anything).Return(nil).Maybe()
+	// Image links are not cached unless a test says otherwise, so they are always signed
+	s.linkCache.On(getLinksMethod, mock.Anything, mock.Anything).Return(map[string]string(nil), nil).Maybe()
+	s.linkCache.On(saveLinksMethod, mock.Anything, mock.Anything).Return(nil).Maybe()
 }
 
 func TestCatalog(t *testing.T) {
 	suite.Run(t, new(CatalogTestSuite))
 }
 
+// searchQuery is the query FindBooks runs for a request of a customer, which only lists the published books on sale.
+func searchQuery(request catalog.SearchBooks) query.Query {
+	q := request.CreateQuery()
+	q.And(query.Condition{Field: "archived", Operator: query.Equal, Value: false})
+	q.And(query.Condition{Field: "status", Operator: query.Equal, Value: catalog.StatusPublished})
+	return q
+}
+
 func (s *CatalogTestSuite) TestFindByQuery_WhenRepositoryFails() {
 	request := catalog.SearchBooks{}
-	query := request.CreateQuery()
+	query := searchQuery(request)
 	page := request.CreatePage()
 
 	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(catalog.PaginatedBooks{}, fmt.Errorf("some error"))
@@ -73,7 +176,7 @@ func (s *CatalogTestSuite) TestFindByQuery_WhenRepositoryFails() {
 
 func (s *CatalogTestSuite) TestFindByQuery_WhenStorageClientFails() {
 	request := catalog.SearchBooks{}
-	query := request.CreateQuery()
+	query := searchQuery(request)
 	page := request.CreatePage()
 
 	paginatedBooks := catalog.PaginatedBooks{
@@ -83,7 +186,7 @@ func (s *CatalogTestSuite) TestFindByQuery_WhenStorageClientFails() {
 	}
 
 	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key").Return("", fmt.Errorf("some error"))
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("", fmt.Errorf("some error"))
 
 	_, err := s.catalog.FindBooks(context.TODO(), request)
 
@@ -95,7 +198,7 @@ func (s *CatalogTestSuite) TestFindByQuery_WhenStorageClientFails() {
 
 func (s *CatalogTestSuite) TestFindByQuery_Successfully() {
 	request := catalog.SearchBooks{}
-	query := request.CreateQuery()
+	query := searchQuery(request)
 	page := request.CreatePage()
 
 	paginatedBooks := catalog.PaginatedBooks{
@@ -107,13 +210,13 @@ func (s *CatalogTestSuite) TestFindByQuery_Successfully() {
 	}
 
 	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key").Return("some-link-1", nil).Once()
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key2").Return("some-link-2", nil).Once()
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key3").Return("some-link-3", nil).Once()
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("some-link-1", nil).Once()
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key2", catalog.AssetImage).Return("some-link-2", nil).Once()
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key3", catalog.AssetImage).Return("some-link-3", nil).Once()
 
 	expected := catalog.NewPaginatedBooksResponse(
 		paginatedBooks,
-		map[string][]string{"book-id-1": {"some-link-1", "some-link-2"}, "book-id-2": {"some-link-3"}},
+		map[string][]catalog.ImageLinks{"book-id-1": {{Link: "some-link-1"}, {Link: "some-link-2"}}, "book-id-2": {{Link: "some-link-3"}}},
 	)
 
 	actual, err := s.catalog.FindBooks(context.TODO(), request)
@@ -125,6 +228,104 @@ func (s *CatalogTestSuite) TestFindByQuery_Successfully() {
 	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 3)
 }
 
+func (s *CatalogTestSuite) TestFindByQuery_LinksThumbnails() {
+	request := catalog.SearchBooks{}
+	query := searchQuery(request)
+	page := request.CreatePage()
+
+	paginatedBooks := catalog.PaginatedBooks{
+		Books: []catalog.Book{
+			{ID: "book-id", Images: []catalog.Image{
+				{ID: "some-key", Variants: []catalog.ImageVariant{
+					{Name: catalog.VariantThumbnail, ContentID: "thumbnail-key"},
+					{Name: catalog.VariantLarge, ContentID: "large-key"},
+				}},
+				{ID: "some-key2"},
+			}},
+		},
+		Limit: 10,
+	}
+
+	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "thumbnail-key", catalog.AssetImage).Return("thumbnail-link", nil).Once()
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key2", catalog.AssetImage).Return("some-link-2", nil).Once()
+
+	actual, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "thumbnail-link", actual.Results[0].Images[0].Link)
+	assert.Nil(s.T(), actual.Results[0].Images[0].Variants)
+	assert.Equal(s.T(), "some-link-2", actual.Results[0].Images[1].Link)
+	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 2)
+}
+
+func (s *CatalogTestSuite) TestFindByQuery_ReusesCachedLinks() {
+	request := catalog.SearchBooks{}
+	query := searchQuery(request)
+	page := request.CreatePage()
+
+	paginatedBooks := catalog.PaginatedBooks{
+		Books: []catalog.Book{
+			{ID: "book-id-1", Images: []catalog.Image{{ID: "some-key"}, {ID: "some-key2"}}},
+			{ID: "book-id-2", Images: []catalog.Image{{ID: "some-key3"}}},
+		},
+		Limit: 10,
+	}
+	keys := []string{"some-key", "some-key2", "some-key3"}
+
+	s.linkCache.ExpectedCalls = nil
+	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
+	s.linkCache.On(getLinksMethod, context.TODO(), keys).Return(map[string]string{"some-key": "cached-link-1", "some-key3": "cached-link-3"}, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key2", catalog.AssetImage).Return("some-link-2", nil).Once()
+	s.linkCache.On(saveLinksMethod, context.TODO(), map[string]string{"some-key2": "some-link-2"}).Return(nil)
+
+	actual, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "cached-link-1", actual.Results[0].Images[0].Link)
+	assert.Equal(s.T(), "some-link-2", actual.Results[0].Images[1].Link)
+	assert.Equal(s.T(), "cached-link-3", actual.Results[1].Images[0].Link)
+	s.linkCache.AssertNumberOfCalls(s.T(), getLinksMethod, 1)
+	s.linkCache.AssertCalled(s.T(), saveLinksMethod, context.TODO(), map[string]string{"some-key2": "some-link-2"})
+	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestFindByQuery_WhenLinkCacheFails() {
+	request := catalog.SearchBooks{}
+	query := searchQuery(request)
+	page := request.CreatePage()
+
+	paginatedBooks := catalog.PaginatedBooks{Books: []catalog.Book{{ID: "book-id", Images: []catalog.Image{{ID: "some-key"}}}}, Limit: 10}
+
+	s.linkCache.ExpectedCalls = nil
+	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
+	s.linkCache.On(getLinksMethod, context.TODO(), mock.Anything).Return(map[string]string(nil), fmt.Errorf("some error"))
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("some-link", nil)
+	s.linkCache.On(saveLinksMethod, context.TODO(), mock.Anything).Return(fmt.Errorf("some error"))
+
+	actual, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "some-link", actual.Results[0].Images[0].Link)
+}
+
+func (s *CatalogTestSuite) TestFindByQuery_WhenSigningFails() {
+	request := catalog.SearchBooks{}
+	query := searchQuery(request)
+	page := request.CreatePage()
+
+	paginatedBooks := catalog.PaginatedBooks{Books: []catalog.Book{{ID: "book-id", Images: []catalog.Image{{ID: "some-key"}, {ID: "some-key2"}}}}, Limit: 10}
+
+	s.repo.On(findByQueryMethod, context.TODO(), query, page).Return(paginatedBooks, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("some-link", nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key2", catalog.AssetImage).Return("", fmt.Errorf("some error"))
+
+	_, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Error(s.T(), err)
+	s.linkCache.AssertNotCalled(s.T(), saveLinksMethod, mock.Anything, mock.Anything)
+}
+
 func (s *CatalogTestSuite) TestFindBookByID_WhenRepositoryFails() {
 	s.repo.On(findByIdMethod, context.TODO(), "some-id").Return(catalog.Book{}, fmt.Errorf("some error"))
 
@@ -143,14 +344,14 @@ func (s *CatalogTestSuite) TestFindBookByID_WhenStorageClientFails() {
 	}
 
 	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key").Return("", fmt.Errorf("some error"))
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("", fmt.Errorf("some error"))
 
 	_, err := s.catalog.FindBookByID(context.TODO(), book.ID)
 
 	assert.Error(s.T(), err)
 
 	s.repo.AssertCalled(s.T(), findByIdMethod, context.TODO(), book.ID)
-	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "some-key")
+	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage)
 }
 
 func (s *CatalogTestSuite) TestFindBookByID_Successfully() {
@@ -160,22 +361,74 @@ func (s *CatalogTestSuite) TestFindBookByID_Successfully() {
 	}
 
 	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key").Return("some-link", nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("some-link", nil)
 
 	actual, err := s.catalog.FindBookByID(context.TODO(), book.ID)
 
 	assert.Nil(s.T(), err)
-	assert.Equal(s.T(), catalog.NewBookResponse(book, []string{"some-link"}), actual)
+	assert.Equal(s.T(), catalog.NewBookResponse(book, []catalog.ImageLinks{{Link: "some-link"}}), actual)
 
 	s.repo.AssertCalled(s.T(), findByIdMethod, context.TODO(), book.ID)
-	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "some-key")
+	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage)
+}
+
+func (s *CatalogTestSuite) TestFindBookByID_LinksVariants() {
+	book := catalog.Book{
+		ID: "some-id",
+		Images: []catalog.Image{{ID: "some-key", Variants: []catalog.ImageVariant{
+			{Name: catalog.VariantThumbnail, ContentID: "thumbnail-key"},
+			{Name: catalog.VariantMedium, ContentID: "medium-key"},
+		}}},
+	}
+
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetImage).Return("some-link", nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "thumbnail-key", catalog.AssetImage).Return("thumbnail-link", nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "medium-key", catalog.AssetImage).Return("medium-link", nil)
+
+	actual, err := s.catalog.FindBookByID(context.TODO(), book.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "some-link", actual.Images[0].Link)
+	assert.Equal(s.T(), map[catalog.VariantName]string{
+		catalog.VariantThumbnail: "thumbnail-link",
+		catalog.VariantMedium:    "medium-link",
+	}, actual.Images[0].Variants)
+}
+
+func (s *CatalogTestSuite) TestFindBooksByIDs_Successfully() {
+	ctx := context.TODO()
+	book1 := catalog.Book{ID: "book-1", Status: catalog.StatusScheduled, Images: []catalog.Image{{ID: "some-key"}}}
+	book2 := catalog.Book{ID: "book-2", Status: catalog.StatusPublished}
+	q := *query.New().And(query.Condition{Field: "id", Operator: query.In, Value: []string{"book-1", "book-2"}})
+
+	s.repo.On(findByQueryMethod, ctx, q, query.Page{Size: 2, Keyset: true}).Return(catalog.PaginatedBooks{Books: []catalog.Book{book1, book2}}, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "some-key", catalog.AssetImage).Return("some-link", nil)
+
+	actual, err := s.catalog.FindBooksByIDs(ctx, []string{"book-1", "book-2"})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []catalog.BookResponse{
+		catalog.NewBookResponse(book1, []catalog.ImageLinks{{Link: "some-link"}}),
+		catalog.NewBookResponse(book2, nil),
+	}, actual)
+	s.repo.AssertNotCalled(s.T(), findByIdMethod)
+}
+
+func (s *CatalogTestSuite) TestFindBooksByIDs_WhenRepositoryFails() {
+	ctx := context.TODO()
+	s.repo.On(findByQueryMethod, ctx, mock.Anything, mock.Anything).Return(catalog.PaginatedBooks{}, fmt.Errorf("some error"))
+
+	_, err := s.catalog.FindBooksByIDs(ctx, []string{"book-1"})
+
+	assert.Error(s.T(), err)
 }
 
 func (s *CatalogTestSuite) TestGetBookContentURL_WhenBookCouldNotBeFound() {
 	id := "some-id"
 	s.repo.On(findByIdMethod, context.TODO(), id).Return(catalog.Book{}, fmt.Errorf("some error"))
 
-	_, err := s.catalog.GetBookContentURL(context.TODO(), id)
+	_, err := s.catalog.GetBookContentURL(context.TODO(), id, "")
 
 	assert.Error(s.T(), err)
 
@@ -185,310 +438,2281 @@ func (s *CatalogTestSuite) TestGetBookContentURL_WhenBookCouldNotBeFound() {
 
 func (s *CatalogTestSuite) TestGetBookContentURL_WithError() {
 	book := catalog.Book{
-		ID:        "some-id",
-		ContentID: "some-key",
+		ID:    "some-id",
+		Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "some-key"}},
 	}
 	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), book.ContentID).Return("", fmt.Errorf("some error"))
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetBookFile).Return("", fmt.Errorf("some error"))
 
-	_, err := s.catalog.GetBookContentURL(context.TODO(), book.ID)
+	_, err := s.catalog.GetBookContentURL(context.TODO(), book.ID, "")
 
 	assert.Error(s.T(), err)
 
 	s.repo.AssertCalled(s.T(), findByIdMethod, context.TODO(), book.ID)
-	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), book.ContentID)
+	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "some-key", catalog.AssetBookFile)
+}
+
+func (s *CatalogTestSuite) TestGetBookContentURL_WhenFormatIsNotAvailable() {
+	book := catalog.Book{
+		ID:    "some-id",
+		Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "some-key"}},
+	}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+
+	_, err := s.catalog.GetBookContentURL(context.TODO(), book.ID, catalog.FormatEPUB)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookFormatNotAvailable)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, mock.Anything, mock.Anything, mock.Anything)
 }
 
 func (s *CatalogTestSuite) TestGetBookContentURL_Successfully() {
 	book := catalog.Book{
-		ID:        "some-id",
-		ContentID: "some-key",
+		ID: "some-id",
+		Files: []catalog.BookFile{
+			{Format: catalog.FormatPDF, ContentID: "pdf-key"},
+			{Format: catalog.FormatEPUB, ContentID: "epub-key"},
+		},
 	}
 	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), book.ContentID).Return("url", nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "epub-key", catalog.AssetBookFile).Return("url", nil)
 
-	url, err := s.catalog.GetBookContentURL(context.TODO(), book.ID)
+	url, err := s.catalog.GetBookContentURL(context.TODO(), book.ID, catalog.FormatEPUB)
 
 	assert.Nil(s.T(), err)
 	assert.Equal(s.T(), "url", url)
 
 	s.repo.AssertCalled(s.T(), findByIdMethod, context.TODO(), book.ID)
-	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), book.ContentID)
+	s.storageClient.AssertCalled(s.T(), generateGetPreSignedUrlMethod, context.TODO(), "epub-key", catalog.AssetBookFile)
 }
 
-func (s *CatalogTestSuite) TestCreateBook_WithNonAdminUser() {
-	request := catalog.CreateBook{
-		Title:       "Clean Code",
-		Description: "A Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		Price:       4000,
-		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+func (s *CatalogTestSuite) TestOpenBookContent_WhenFormatIsNotAvailable() {
+	book := catalog.Book{
+		ID:    "some-id",
+		Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "some-key"}},
 	}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
 
-	ctx := context.WithValue(context.Background(), "admin", false)
-	_, err := s.catalog.CreateBook(ctx, request)
-
-	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+	_, err := s.catalog.OpenBookContent(context.TODO(), book.ID, catalog.FormatEPUB)
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 0)
-	s.idGenerator.AssertNumberOfCalls(s.T(), newIdMethod, 0)
-	s.storageClient.AssertNumberOfCalls(s.T(), saveFileMethod, 0)
-	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, "poster_name")
-	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+	assert.ErrorIs(s.T(), err, catalog.ErrBookFormatNotAvailable)
 }
 
-func (s *CatalogTestSuite) TestCreateBook_ValidationFails() {
-	request := catalog.CreateBook{
-		Title:       "Clean Code",
-		Description: "A Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		Price:       4000,
-		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+func (s *CatalogTestSuite) TestOpenBookContent_Successfully() {
+	updatedAt := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "Clean Code: A Handbook / 2nd",
+		Files: []catalog.BookFile{
+			{Format: catalog.FormatEPUB, ContentID: "epub-key", MediaType: "application/epub+zip", Size: 10, Checksum: "abc", UpdatedAt: updatedAt},
+		},
 	}
-	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(getObjectRangeMethod, context.TODO(), "epub-key", int64(6)).Return(io.NopCloser(strings.NewReader("6789")), nil)
 
-	ctx := context.WithValue(context.Background(), "admin", true)
-	_, err := s.catalog.CreateBook(ctx, request)
+	content, err := s.catalog.OpenBookContent(context.TODO(), book.ID, catalog.FormatEPUB)
+	assert.NoError(s.T(), err)
+	defer content.Close()
 
-	assert.Error(s.T(), err)
+	assert.Equal(s.T(), "Clean Code A Handbook 2nd.epub", content.Filename)
+	assert.Equal(s.T(), "application/epub+zip", content.ContentType)
+	assert.Equal(s.T(), int64(10), content.Size)
+	assert.Equal(s.T(), `"abc"`, content.ETag)
+	assert.Equal(s.T(), updatedAt, content.UpdatedAt)
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.idGenerator.AssertNumberOfCalls(s.T(), newIdMethod, 0)
-	s.storageClient.AssertNumberOfCalls(s.T(), saveFileMethod, 0)
-	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, "poster_name")
-	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+	size, err := content.Seek(0, io.SeekEnd)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(10), size)
+	s.storageClient.AssertNotCalled(s.T(), getObjectRangeMethod, mock.Anything, mock.Anything, mock.Anything)
+
+	_, err = content.Seek(6, io.SeekStart)
+	assert.NoError(s.T(), err)
+	read, err := io.ReadAll(content)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "6789", string(read))
 }
 
-func (s *CatalogTestSuite) TestCreateBook_WhenRepositoryFails() {
-	request := catalog.CreateBook{
-		Title:       "Clean Code",
-		Description: "A Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		Price:       4000,
-		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+func (s *CatalogTestSuite) TestOpenBookContent_WhenSizeIsUnknown() {
+	book := catalog.Book{
+		ID:    "some-id",
+		Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}},
 	}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "pdf-key").Return(catalog.ObjectInfo{Size: 5}, nil)
+	s.storageClient.On(getObjectRangeMethod, context.TODO(), "pdf-key", int64(0)).Return(io.NopCloser(strings.NewReader("01234")), nil)
 
-	book := request.Book("some-id")
-	ctx := context.WithValue(context.Background(), "admin", true)
+	content, err := s.catalog.OpenBookContent(context.TODO(), book.ID, "")
+	assert.NoError(s.T(), err)
+	defer content.Close()
 
-	s.validator.On(validateMethod, request).Return(nil)
-	s.idGenerator.On(newIdMethod).Return("some-id")
+	data, err := io.ReadAll(content)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "01234", string(data))
+	assert.Equal(s.T(), int64(5), content.Size)
+}
 
-	s.repo.On(createBookMethod, ctx, &book).Return(fmt.Errorf("some error"))
+func (s *CatalogTestSuite) TestOpenBookContent_WhenObjectIsShorter() {
+	book := catalog.Book{
+		ID:    "some-id",
+		Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", Size: 10}},
+	}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(getObjectRangeMethod, context.TODO(), "pdf-key", int64(0)).Return(io.NopCloser(strings.NewReader("01234")), nil)
 
-	_, err := s.catalog.CreateBook(ctx, request)
+	content, err := s.catalog.OpenBookContent(context.TODO(), book.ID, "")
+	assert.NoError(s.T(), err)
+	defer content.Close()
 
-	assert.Error(s.T(), err)
+	_, err = io.ReadAll(content)
+	assert.ErrorIs(s.T(), err, io.ErrUnexpectedEOF)
+	assert.Equal(s.T(), "book.pdf", content.Filename)
+}
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+// watermarkOf returns a WatermarkFunc of the mark.
+func watermarkOf(mark catalog.Watermark) catalog.WatermarkFunc {
+	return func(context.Context) (catalog.Watermark, error) { return mark, nil }
 }
 
-func (s *CatalogTestSuite) TestCreateBook_WhenPreSigningFails() {
-	request := catalog.CreateBook{
-		Title:       "Clean Code",
-		Description: "A Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		Price:       4000,
-		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
-		Images: []catalog.ImageRequest{
-			{
-				ID: "some-key",
-			},
-		},
-	}
+// noWatermark fails the test when the watermark is looked up.
+func (s *CatalogTestSuite) noWatermark(context.Context) (catalog.Watermark, error) {
+	s.T().Error("the watermark should not be looked up")
+	return catalog.Watermark{}, nil
+}
 
-	book := request.Book("some-id")
-	ctx := context.WithValue(context.Background(), "admin", true)
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenBookIsNotWatermarked() {
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "pdf-key", catalog.AssetBookFile).Return("https://some-url", nil)
 
-	s.validator.On(validateMethod, request).Return(nil)
-	s.idGenerator.On(newIdMethod).Return("some-id")
-	s.repo.On(createBookMethod, ctx, &book).Return(nil)
-	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "some-key").Return("", fmt.Errorf("some error"))
+	url, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatPDF, "order-id", s.noWatermark)
 
-	_, err := s.catalog.CreateBook(ctx, request)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://some-url", url)
+	s.storageClient.AssertNotCalled(s.T(), headObjectMethod, mock.Anything, mock.Anything)
+	s.watermarker.AssertNotCalled(s.T(), watermarkMethod, mock.Anything, mock.Anything, mock.Anything)
+}
 
-	assert.Error(s.T(), err)
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenFormatIsNotPDF() {
+	book := catalog.Book{ID: "some-id", Watermarked: true, Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "epub-key", catalog.AssetBookFile).Return("https://some-url", nil)
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 1)
-	s.repo.AssertNumberOfCalls(s.T(), findByIdMethod, 1)
-	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+	url, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatEPUB, "order-id", s.noWatermark)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://some-url", url)
+	s.watermarker.AssertNotCalled(s.T(), watermarkMethod, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (s *CatalogTestSuite) TestCreateBook_Successfully() {
-	request := catalog.CreateBook{
-		Title:       "Clean Code",
-		Description: "A Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		Price:       4000,
-		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
-		Images: []catalog.ImageRequest{
-			{
-				ID: "some-key",
-			},
-		},
-	}
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenCopyIsStored() {
+	book := catalog.Book{ID: "some-id", Watermarked: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "watermarks/pdf-key/order-id").Return(catalog.ObjectInfo{Size: 10}, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "watermarks/pdf-key/order-id", catalog.AssetBookFile).Return("https://some-url", nil)
 
-	book := request.Book("some-id")
-	ctx := context.WithValue(context.Background(), "admin", true)
+	url, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatPDF, "order-id", s.noWatermark)
 
-	s.validator.On(validateMethod, request).Return(nil)
-	s.idGenerator.On(newIdMethod).Return("some-id")
-	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
-	s.repo.On(createBookMethod, ctx, &book).Return(nil)
-	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "some-key").Return("link", nil)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://some-url", url)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod, mock.Anything, mock.Anything)
+	s.watermarker.AssertNotCalled(s.T(), watermarkMethod, mock.Anything, mock.Anything, mock.Anything)
+}
 
-	_, err := s.catalog.CreateBook(ctx, request)
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenContentCannotBeWatermarked() {
+	book := catalog.Book{ID: "some-id", Watermarked: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	mark := catalog.Watermark{Name: "Jane Doe", Email: "jane@ebookstore.com", OrderID: "order-id"}
+	content := io.NopCloser(strings.NewReader("not a pdf"))
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "watermarks/pdf-key/order-id").Return(catalog.ObjectInfo{}, catalog.ErrUploadNotFound)
+	s.storageClient.On(getObjectMethod, context.TODO(), "pdf-key").Return(content, nil)
+	s.watermarker.On(watermarkMethod, catalog.FormatPDF, content, mark).Return(nil, catalog.ErrInvalidWatermarkSource)
 
-	assert.NoError(s.T(), err)
+	_, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatPDF, "order-id", watermarkOf(mark))
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 1)
-	s.repo.AssertNumberOfCalls(s.T(), findByIdMethod, 1)
-	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidWatermarkSource)
+	s.storageClient.AssertNotCalled(s.T(), putObjectMethod, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func (s *CatalogTestSuite) TestUpdateBook_WithNonAdminUser() {
-	newTitle := "new title"
-	request := catalog.UpdateBook{
-		ID:    "some-id",
-		Title: &newTitle,
-	}
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenWatermarkCannotBeFound() {
+	book := catalog.Book{ID: "some-id", Watermarked: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "watermarks/pdf-key/order-id").Return(catalog.ObjectInfo{}, catalog.ErrUploadNotFound)
+	mark := func(context.Context) (catalog.Watermark, error) { return catalog.Watermark{}, fmt.Errorf("some error") }
 
-	ctx := context.Background()
-	err := s.catalog.UpdateBook(ctx, request)
+	_, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatPDF, "order-id", mark)
 
-	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+	assert.Error(s.T(), err)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod, mock.Anything, mock.Anything)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, mock.Anything, mock.Anything, mock.Anything)
+}
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 0)
-	s.repo.AssertNotCalled(s.T(), findByIdMethod, ctx, request.ID)
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_Successfully() {
+	book := catalog.Book{ID: "some-id", Watermarked: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", MediaType: "application/pdf"}}}
+	mark := catalog.Watermark{Name: "Jane Doe", Email: "jane@ebookstore.com", OrderID: "order-id"}
+	content := io.NopCloser(strings.NewReader("pdf content"))
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "watermarks/pdf-key/order-id").Return(catalog.ObjectInfo{}, catalog.ErrUploadNotFound)
+	s.storageClient.On(getObjectMethod, context.TODO(), "pdf-key").Return(content, nil)
+	s.watermarker.On(watermarkMethod, catalog.FormatPDF, content, mark).Return([]byte("stamped"), nil)
+	s.storageClient.On(putObjectMethod, context.TODO(), "watermarks/pdf-key/order-id", "application/pdf", bytes.NewReader([]byte("stamped"))).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "watermarks/pdf-key/order-id", catalog.AssetBookFile).Return("https://some-url", nil)
+
+	url, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatPDF, "order-id", watermarkOf(mark))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://some-url", url)
+	s.storageClient.AssertCalled(s.T(), putObjectMethod, context.TODO(), "watermarks/pdf-key/order-id", "application/pdf", bytes.NewReader([]byte("stamped")))
 }
 
-func (s *CatalogTestSuite) TestUpdateBook_ValidationFails() {
-	newTitle := "new title"
-	request := catalog.UpdateBook{
-		ID:    "some-id",
-		Title: &newTitle,
+func (s *CatalogTestSuite) TestOpenWatermarkedBookContent_Successfully() {
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Clean Code",
+		Watermarked: true,
+		Files:       []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", MediaType: "application/pdf", Size: 5, Checksum: "abc"}},
 	}
-	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, context.TODO(), "watermarks/pdf-key/order-id").Return(catalog.ObjectInfo{Size: 7, ContentType: "application/pdf"}, nil)
+	s.storageClient.On(getObjectRangeMethod, context.TODO(), "watermarks/pdf-key/order-id", int64(0)).Return(io.NopCloser(strings.NewReader("stamped")), nil)
 
-	ctx := context.WithValue(context.Background(), "admin", true)
-	err := s.catalog.UpdateBook(ctx, request)
+	content, err := s.catalog.OpenWatermarkedBookContent(context.TODO(), book.ID, catalog.FormatPDF, "order-id", s.noWatermark)
+	assert.NoError(s.T(), err)
+	defer content.Close()
 
-	assert.Error(s.T(), err)
+	assert.Equal(s.T(), "Clean Code.pdf", content.Filename)
+	assert.Equal(s.T(), int64(7), content.Size)
+	assert.Equal(s.T(), `"watermarks/pdf-key/order-id"`, content.ETag)
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.repo.AssertNotCalled(s.T(), findByIdMethod, ctx, request.ID)
+	read, err := io.ReadAll(content)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "stamped", string(read))
 }
 
-func (s *CatalogTestSuite) TestUpdateBook_WhenBookIsNotFound() {
-	newTitle := "new title"
-	request := catalog.UpdateBook{
+func (s *CatalogTestSuite) TestGetWatermarkedBookContentURL_WhenBookIsProtected() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+
+	_, err := s.catalog.GetWatermarkedBookContentURL(context.TODO(), book.ID, catalog.FormatEPUB, "order-id", s.noWatermark)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookProtected)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestOpenWatermarkedBookContent_WhenBookIsProtected() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+
+	_, err := s.catalog.OpenWatermarkedBookContent(context.TODO(), book.ID, catalog.FormatEPUB, "order-id", s.noWatermark)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookProtected)
+	s.storageClient.AssertNotCalled(s.T(), getObjectRangeMethod, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_WhenBookIsNotProtected() {
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+
+	_, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookNotProtected)
+	s.protectedRepo.AssertNotCalled(s.T(), findByContentIDMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_WithoutEPUB() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+
+	_, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookFormatNotAvailable)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_WhenContentIsProtected() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	protected := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/package-id", Key: []byte("sealed"), Size: 10, Checksum: "abc"}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(&protected, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "protected/epub-key/package-id", catalog.AssetBookFile).Return("https://some-url", nil)
+
+	content, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), catalog.ProtectedBookContent{ProtectedContent: protected, URL: "https://some-url"}, content)
+	s.protector.AssertNotCalled(s.T(), protectMethod, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_WhenContentCannotBeProtected() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	epub := io.NopCloser(strings.NewReader("not an epub"))
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(nil, nil)
+	s.storageClient.On(getObjectMethod, context.TODO(), "epub-key").Return(epub, nil)
+	s.protector.On(protectMethod, epub, mock.Anything).Return(nil, catalog.ErrInvalidProtectionSource)
+
+	_, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidProtectionSource)
+	s.storageClient.AssertNotCalled(s.T(), putObjectMethod, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	s.protectedRepo.AssertNotCalled(s.T(), createMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_Successfully() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{BookID: "some-id", Format: catalog.FormatEPUB, ContentID: "epub-key", MediaType: "application/epub+zip"}}}
+	epub := io.NopCloser(strings.NewReader("epub content"))
+	saved := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/package-id", Key: []byte("sealed")}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(nil, nil).Once()
+	s.storageClient.On(getObjectMethod, context.TODO(), "epub-key").Return(epub, nil)
+	s.protector.On(protectMethod, epub, mock.Anything).Return([]byte("sealed"), nil).Run(func(args mock.Arguments) {
+		_, _ = args.Get(1).(io.Writer).Write([]byte("encrypted"))
+	})
+	s.idGenerator.On(newIdMethod).Return("package-id")
+	var stored []byte
+	s.storageClient.On(putObjectMethod, context.TODO(), "protected/epub-key/package-id", "application/epub+zip", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		stored, _ = ioutil.ReadAll(args.Get(3).(io.Reader))
+	})
+	isProtected := mock.MatchedBy(func(content *catalog.ProtectedContent) bool {
+		return content.ContentID == "epub-key" && content.BookID == book.ID && content.PackageKey == "protected/epub-key/package-id" &&
+			string(content.Key) == "sealed" && content.Size == 9 && content.Checksum == "954d1bb83d80bb6f6e746b28f0de3ec4c4ed980cfe67ed23a9159cd464ff339a"
+	})
+	s.protectedRepo.On(createMethod, context.TODO(), isProtected).Return(nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(&saved, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "protected/epub-key/package-id", catalog.AssetBookFile).Return("https://some-url", nil)
+
+	content, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://some-url", content.URL)
+	assert.Equal(s.T(), []byte("sealed"), content.Key)
+	assert.Equal(s.T(), "encrypted", string(stored))
+	s.protectedRepo.AssertCalled(s.T(), createMethod, context.TODO(), isProtected)
+	s.storageClient.AssertNotCalled(s.T(), deleteObjectsMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGetProtectedBookContent_WhenProtectedConcurrently() {
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{BookID: "some-id", Format: catalog.FormatEPUB, ContentID: "epub-key", MediaType: "application/epub+zip"}}}
+	epub := io.NopCloser(strings.NewReader("epub content"))
+	first := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/first-id", Key: []byte("first")}
+	s.repo.On(findByIdMethod, context.TODO(), book.ID).Return(book, nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(nil, nil).Once()
+	s.storageClient.On(getObjectMethod, context.TODO(), "epub-key").Return(epub, nil)
+	s.protector.On(protectMethod, epub, mock.Anything).Return([]byte("sealed"), nil).Run(func(args mock.Arguments) {
+		_, _ = args.Get(1).(io.Writer).Write([]byte("encrypted"))
+	})
+	s.idGenerator.On(newIdMethod).Return("package-id")
+	s.storageClient.On(putObjectMethod, context.TODO(), "protected/epub-key/package-id", "application/epub+zip", mock.Anything).Return(nil)
+	s.protectedRepo.On(createMethod, context.TODO(), mock.Anything).Return(nil)
+	s.protectedRepo.On(findByContentIDMethod, context.TODO(), "epub-key").Return(&first, nil)
+	s.storageClient.On(deleteObjectsMethod, context.TODO(), []string{"protected/epub-key/package-id"}).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "protected/epub-key/first-id", catalog.AssetBookFile).Return("https://some-url", nil)
+
+	content, err := s.catalog.GetProtectedBookContent(context.TODO(), book.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []byte("first"), content.Key)
+	s.storageClient.AssertCalled(s.T(), deleteObjectsMethod, context.TODO(), []string{"protected/epub-key/package-id"})
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_WithNonAdminUser() {
+	_, err := s.catalog.SaveBookFile(context.TODO(), catalog.SaveBookFile{BookID: "some-id"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), saveFileMethod)
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_WhenBookCouldNotBeFound() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{BookID: "some-id", BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatEPUB, ContentID: "epub-key"}}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{}, fmt.Errorf("some error"))
+
+	_, err := s.catalog.SaveBookFile(ctx, request)
+
+	assert.Error(s.T(), err)
+	s.repo.AssertNotCalled(s.T(), saveFileMethod)
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{
+		BookID:          "some-id",
+		BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatEPUB, ContentID: "epub-key", Size: 1024, Checksum: strings.Repeat("a", 64)},
+	}
+	file := request.BookFile("some-id")
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{file}}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(headObjectMethod, ctx, "epub-key").Return(catalog.ObjectInfo{Size: 1024, ContentType: "application/epub+zip", Checksum: strings.Repeat("a", 64)}, nil)
+	s.repo.On(saveFileMethod, ctx, &file).Return(nil)
+
+	response, err := s.catalog.SaveBookFile(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []catalog.BookFileResponse{{Format: catalog.FormatEPUB, MediaType: "application/epub+zip", Size: 1024}}, response.Formats)
+	s.repo.AssertNumberOfCalls(s.T(), saveFileMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_WhenWatermarkedPDFCannotBeStamped() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{
+		BookID:          "some-id",
+		BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatPDF, ContentID: "pdf-key", Size: 1024, Checksum: strings.Repeat("a", 64)},
+	}
+	content := io.NopCloser(strings.NewReader("encrypted pdf"))
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Watermarked: true}, nil)
+	s.storageClient.On(headObjectMethod, ctx, "pdf-key").Return(catalog.ObjectInfo{Size: 1024, ContentType: "application/pdf", Checksum: strings.Repeat("a", 64)}, nil)
+	s.storageClient.On(getObjectMethod, ctx, "pdf-key").Return(content, nil)
+	s.watermarker.On(watermarkMethod, catalog.FormatPDF, content, catalog.Watermark{}).Return(nil, catalog.ErrInvalidWatermarkSource)
+
+	_, err := s.catalog.SaveBookFile(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidWatermarkSource)
+	s.repo.AssertNotCalled(s.T(), saveFileMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_TracksReplacedFile() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{
+		BookID:          "some-id",
+		BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatEPUB, ContentID: "epub-key", Size: 1024, Checksum: strings.Repeat("a", 64)},
+	}
+	file := request.BookFile("some-id")
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{BookID: "some-id", Format: catalog.FormatEPUB, ContentID: "old-epub-key"}}}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil).Once()
+	s.storageClient.On(headObjectMethod, ctx, "epub-key").Return(catalog.ObjectInfo{Size: 1024, ContentType: "application/epub+zip", Checksum: strings.Repeat("a", 64)}, nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"old-epub-key"}).Return(nil)
+	s.repo.On(saveFileMethod, ctx, &file).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Files: []catalog.BookFile{file}}, nil)
+
+	_, err := s.catalog.SaveBookFile(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"old-epub-key"})
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_WhenUploadIsMissing() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{
+		BookID:          "some-id",
+		BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatEPUB, ContentID: "epub-key", Size: 1024, Checksum: strings.Repeat("a", 64)},
+	}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+	s.storageClient.On(headObjectMethod, ctx, "epub-key").Return(catalog.ObjectInfo{}, catalog.ErrUploadNotFound)
+
+	_, err := s.catalog.SaveBookFile(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrUploadNotFound)
+	s.repo.AssertNotCalled(s.T(), saveFileMethod)
+}
+
+func (s *CatalogTestSuite) TestSaveBookFile_WhenUploadDoesNotMatch() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SaveBookFile{
+		BookID:          "some-id",
+		BookFileRequest: catalog.BookFileRequest{Format: catalog.FormatEPUB, ContentID: "epub-key", Size: 1024, Checksum: strings.Repeat("a", 64)},
+	}
+	uploads := []catalog.ObjectInfo{
+		{Size: 1024, ContentType: "application/pdf"},
+		{Size: 2048, ContentType: "application/epub+zip"},
+		{Size: 1024, ContentType: "application/epub+zip", Checksum: strings.Repeat("b", 64)},
+	}
+
+	for _, upload := range uploads {
+		s.SetupTest()
+		s.validator.On(validateMethod, request).Return(nil)
+		s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+		s.storageClient.On(headObjectMethod, ctx, "epub-key").Return(upload, nil)
+
+		_, err := s.catalog.SaveBookFile(ctx, request)
+
+		assert.ErrorIs(s.T(), err, catalog.ErrInvalidUpload)
+		s.repo.AssertNotCalled(s.T(), saveFileMethod)
+	}
+}
+
+func (s *CatalogTestSuite) TestDeleteBookFile_WithNonAdminUser() {
+	err := s.catalog.DeleteBookFile(context.TODO(), "some-id", catalog.FormatPDF)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), deleteFileMethod)
+}
+
+func (s *CatalogTestSuite) TestDeleteBookFile_WhenFormatIsNotAvailable() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF}, {Format: catalog.FormatMOBI}}}
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+
+	err := s.catalog.DeleteBookFile(ctx, "some-id", catalog.FormatEPUB)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookFormatNotAvailable)
+	s.repo.AssertNotCalled(s.T(), deleteFileMethod)
+}
+
+func (s *CatalogTestSuite) TestDeleteBookFile_WhenItIsTheLastFile() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF}}}
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+
+	err := s.catalog.DeleteBookFile(ctx, "some-id", catalog.FormatPDF)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrLastBookFile)
+	s.repo.AssertNotCalled(s.T(), deleteFileMethod)
+}
+
+func (s *CatalogTestSuite) TestDeleteBookFile_WhenItIsTheEPUBOfAProtectedBook() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	book := catalog.Book{ID: "some-id", Protected: true, Files: []catalog.BookFile{{Format: catalog.FormatPDF}, {Format: catalog.FormatEPUB}}}
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+
+	err := s.catalog.DeleteBookFile(ctx, "some-id", catalog.FormatEPUB)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrProtectedBookWithoutEPUB)
+	s.repo.AssertNotCalled(s.T(), deleteFileMethod)
+}
+
+func (s *CatalogTestSuite) TestDeleteBookFile_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}, {Format: catalog.FormatEPUB, ContentID: "epub-key"}}}
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"epub-key"}).Return(nil)
+	s.repo.On(deleteFileMethod, ctx, "some-id", catalog.FormatEPUB).Return(nil)
+
+	err := s.catalog.DeleteBookFile(ctx, "some-id", catalog.FormatEPUB)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertNumberOfCalls(s.T(), deleteFileMethod, 1)
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"epub-key"})
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithNonAdminUser() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", false)
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 0)
+	s.idGenerator.AssertNumberOfCalls(s.T(), newIdMethod, 0)
+	s.storageClient.AssertNumberOfCalls(s.T(), saveFileMethod, 0)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, "poster_name")
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_ValidationFails() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+	}
+	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.idGenerator.AssertNumberOfCalls(s.T(), newIdMethod, 0)
+	s.storageClient.AssertNumberOfCalls(s.T(), saveFileMethod, 0)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod, "poster_name")
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WhenRepositoryFails() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+	}
+
+	book := request.Book("some-id")
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+
+	s.repo.On(createBookMethod, ctx, &book).Return(fmt.Errorf("some error"))
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WhenPreSigningFails() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		Images: []catalog.ImageRequest{
+			{
+				ID: "some-key",
+			},
+		},
+	}
+
+	book := request.Book("some-id")
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.storageClient.On(headObjectMethod, ctx, "some-key").Return(catalog.ObjectInfo{Size: 2048, ContentType: "image/jpeg"}, nil)
+	s.repo.On(createBookMethod, ctx, &book).Return(nil)
+	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "some-key", catalog.AssetImage).Return("", fmt.Errorf("some error"))
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), findByIdMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_Successfully() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		Images: []catalog.ImageRequest{
+			{
+				ID: "some-key",
+			},
+		},
+	}
+
+	book := request.Book("some-id")
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.storageClient.On(headObjectMethod, ctx, "some-key").Return(catalog.ObjectInfo{Size: 2048, ContentType: "image/jpeg"}, nil)
+	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
+	s.repo.On(createBookMethod, ctx, &book).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "some-key", catalog.AssetImage).Return("link", nil)
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.NoError(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), findByIdMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WhenFileIsMissing() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		Files:       []catalog.BookFileRequest{{Format: catalog.FormatPDF, ContentID: "pdf-key", Size: 1024, Checksum: strings.Repeat("a", 64)}},
+	}
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.storageClient.On(headObjectMethod, ctx, "pdf-key").Return(catalog.ObjectInfo{}, catalog.ErrUploadNotFound)
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrUploadNotFound)
+	s.repo.AssertNotCalled(s.T(), createBookMethod)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WhenProtectedBookHasNoEPUB() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		Protected:   true,
+		Files:       []catalog.BookFileRequest{{Format: catalog.FormatPDF, ContentID: "pdf-key", Size: 1024, Checksum: strings.Repeat("a", 64)}},
+	}
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.storageClient.On(headObjectMethod, ctx, "pdf-key").Return(catalog.ObjectInfo{Size: 1024, ContentType: "application/pdf", Checksum: strings.Repeat("a", 64)}, nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrProtectedBookWithoutEPUB)
+	s.repo.AssertNotCalled(s.T(), createBookMethod)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WhenProtectedBookHasNoEPUB() {
+	protected := true
+	request := catalog.UpdateBook{ID: "some-id", Protected: &protected}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrProtectedBookWithoutEPUB)
+	s.repo.AssertNotCalled(s.T(), updateBookMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGeneratePutPreSignedUrl_Successfully() {
+	request := catalog.PresignUpload{ContentType: "application/pdf", Size: 1024, Checksum: strings.Repeat("A", 64)}
+	object := catalog.ObjectInfo{ContentType: "application/pdf", Size: 1024, Checksum: strings.Repeat("a", 64)}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.uploadRepo.On(trackMethod, context.TODO(), []string{"some-id"}).Return(nil)
+	s.storageClient.On(generatePutPreSignedUrlMethod, context.TODO(), "some-id", object).Return("some-url", nil)
+
+	response, err := s.catalog.GeneratePutPreSignedUrl(context.TODO(), request)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), catalog.PresignURLResponse{ID: "some-id", URL: "some-url"}, response)
+}
+
+func (s *CatalogTestSuite) TestGeneratePutPreSignedUrl_WhenUploadIsNotAllowed() {
+	requests := []catalog.PresignUpload{
+		{ContentType: "application/zip", Size: 1024},
+		{ContentType: "image/png", Size: 20 << 20},
+	}
+
+	for _, request := range requests {
+		s.validator.On(validateMethod, request).Return(nil)
+
+		_, err := s.catalog.GeneratePutPreSignedUrl(context.TODO(), request)
+
+		assert.ErrorIs(s.T(), err, catalog.ErrInvalidUpload)
+	}
+	s.storageClient.AssertNotCalled(s.T(), generatePutPreSignedUrlMethod)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WithNonAdminUser() {
+	newTitle := "new title"
+	request := catalog.UpdateBook{
+		ID:    "some-id",
+		Title: &newTitle,
+	}
+
+	ctx := context.Background()
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 0)
+	s.repo.AssertNotCalled(s.T(), findByIdMethod, ctx, request.ID)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_ValidationFails() {
+	newTitle := "new title"
+	request := catalog.UpdateBook{
+		ID:    "some-id",
+		Title: &newTitle,
+	}
+	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.repo.AssertNotCalled(s.T(), findByIdMethod, ctx, request.ID)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WhenBookIsNotFound() {
+	newTitle := "new title"
+	request := catalog.UpdateBook{
+		ID:    "some-id",
+		Title: &newTitle,
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(catalog.Book{}, fmt.Errorf("some error"))
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WhenUpdateFails() {
+	newTitle := "new title"
+	request := catalog.UpdateBook{
+		ID:    "some-id",
+		Title: &newTitle,
+	}
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "old-title",
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+
+	updated := request.Update(book)
+	s.repo.On(updateBookMethod, ctx, &updated).Return(fmt.Errorf("some error"))
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
+	s.repo.AssertCalled(s.T(), updateBookMethod, ctx, &updated)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_Successfully() {
+	newTitle := "new title"
+	request := catalog.UpdateBook{
 		ID:    "some-id",
 		Title: &newTitle,
 	}
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "old-title",
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+
+	updated := request.Update(book)
+	s.repo.On(updateBookMethod, ctx, &updated).Return(nil)
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Nil(s.T(), err)
+
+	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
+	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
+	s.repo.AssertCalled(s.T(), updateBookMethod, ctx, &updated)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WhenWatermarkedPDFCannotBeStamped() {
+	watermarked := true
+	request := catalog.UpdateBook{ID: "some-id", Watermarked: &watermarked}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	content := io.NopCloser(strings.NewReader("encrypted pdf"))
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+	s.storageClient.On(getObjectMethod, ctx, "pdf-key").Return(content, nil)
+	s.watermarker.On(watermarkMethod, catalog.FormatPDF, content, catalog.Watermark{}).Return(nil, catalog.ErrInvalidWatermarkSource)
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidWatermarkSource)
+	s.repo.AssertNotCalled(s.T(), updateBookMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_TracksRemovedImages() {
+	request := catalog.UpdateBook{
+		ID:     "some-id",
+		Images: []catalog.ImageRequest{{ID: "kept-image"}, {ID: "new-image"}},
+	}
+	book := catalog.Book{
+		ID:     "some-id",
+		Images: []catalog.Image{{ID: "kept-image", BookID: "some-id"}, {ID: "removed-image", BookID: "some-id"}},
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+	s.storageClient.On(headObjectMethod, ctx, "new-image").Return(catalog.ObjectInfo{Size: 2048, ContentType: "image/webp"}, nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"removed-image"}).Return(nil)
+
+	updated := request.Update(book)
+	s.repo.On(updateBookMethod, ctx, &updated).Return(nil)
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.storageClient.AssertNotCalled(s.T(), headObjectMethod, ctx, "kept-image")
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"removed-image"})
+}
+
+func (s *CatalogTestSuite) TestCollectOrphanUploads_DryRun() {
+	ctx := context.TODO()
+	trackedAt := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	s.uploadRepo.On(findOrphansMethod, ctx, mock.Anything, mock.Anything).Return([]catalog.Upload{{Key: "orphan-key", CreatedAt: trackedAt}}, nil)
+
+	report, err := s.catalog.CollectOrphanUploads(ctx, catalog.CollectOrphanUploads{GracePeriod: time.Hour, DryRun: true})
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.OrphanUploadsReportResponse{DryRun: true, Orphans: []catalog.OrphanUploadResponse{{Key: "orphan-key", TrackedAt: trackedAt}}}, report)
+	s.storageClient.AssertNotCalled(s.T(), deleteObjectsMethod)
+	s.uploadRepo.AssertNotCalled(s.T(), untrackMethod)
+	s.uploadRepo.AssertNotCalled(s.T(), untrackReferencedMethod)
+}
+
+func (s *CatalogTestSuite) TestCollectOrphanUploads_DeletesOrphansAndVariants() {
+	ctx := context.TODO()
+	objects := []string{"orphan-key", "variants/orphan-key/thumbnail", "variants/orphan-key/medium", "variants/orphan-key/large",
+		"watermarks/orphan-key/order-id", "protected/orphan-key", "protected/orphan-key/package-id"}
+
+	s.uploadRepo.On(findOrphansMethod, ctx, mock.Anything, mock.Anything).Return([]catalog.Upload{{Key: "orphan-key"}}, nil)
+	s.storageClient.On(listObjectsMethod, ctx, "watermarks/orphan-key/").Return([]string{"watermarks/orphan-key/order-id"}, nil)
+	s.storageClient.On(listObjectsMethod, ctx, "protected/orphan-key").
+		Return([]string{"protected/orphan-key", "protected/orphan-key/package-id", "protected/orphan-key-2/package-id"}, nil)
+	s.storageClient.On(deleteObjectsMethod, ctx, objects).Return(nil)
+	s.protectedRepo.On(deleteMethod, ctx, []string{"orphan-key"}).Return(nil)
+	s.uploadRepo.On(untrackMethod, ctx, []string{"orphan-key"}).Return(nil)
+	s.uploadRepo.On(untrackReferencedMethod, ctx, mock.Anything).Return(int64(3), nil)
+
+	report, err := s.catalog.CollectOrphanUploads(ctx, catalog.CollectOrphanUploads{GracePeriod: time.Hour})
+
+	assert.Nil(s.T(), err)
+	assert.Len(s.T(), report.Orphans, 1)
+	assert.Equal(s.T(), int64(3), report.Referenced)
+	s.storageClient.AssertCalled(s.T(), deleteObjectsMethod, ctx, objects)
+	s.protectedRepo.AssertCalled(s.T(), deleteMethod, ctx, []string{"orphan-key"})
+	s.uploadRepo.AssertCalled(s.T(), untrackMethod, ctx, []string{"orphan-key"})
+}
+
+func (s *CatalogTestSuite) TestCollectOrphanUploads_WhenDeletingFails() {
+	ctx := context.TODO()
+
+	s.uploadRepo.On(findOrphansMethod, ctx, mock.Anything, mock.Anything).Return([]catalog.Upload{{Key: "orphan-key"}}, nil)
+	s.storageClient.On(listObjectsMethod, ctx, mock.Anything).Return(nil, nil)
+	s.storageClient.On(deleteObjectsMethod, ctx, mock.Anything).Return(fmt.Errorf("some error"))
+
+	_, err := s.catalog.CollectOrphanUploads(ctx, catalog.CollectOrphanUploads{GracePeriod: time.Hour})
+
+	assert.Error(s.T(), err)
+	s.uploadRepo.AssertNotCalled(s.T(), untrackMethod)
+}
+
+func (s *CatalogTestSuite) TestDeleteBook_WithNonAdminUser() {
+	id := "some-id"
+
+	ctx := context.Background()
+	err := s.catalog.DeleteBook(ctx, id)
+
+	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+
+	s.repo.AssertNotCalled(s.T(), archiveMethod, ctx, id)
+}
+
+func (s *CatalogTestSuite) TestDeleteBook_WhenRepositoryFails() {
+	id := "some-id"
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(archiveMethod, ctx, id).Return(fmt.Errorf("some error"))
+
+	err := s.catalog.DeleteBook(ctx, id)
+
+	assert.Error(s.T(), err)
+
+	s.repo.AssertCalled(s.T(), archiveMethod, ctx, id)
+}
+
+func (s *CatalogTestSuite) TestDeleteBook_Successfully() {
+	id := "some-id"
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(archiveMethod, ctx, id).Return(nil)
+
+	err := s.catalog.DeleteBook(ctx, id)
+	assert.Nil(s.T(), err)
+
+	s.repo.AssertCalled(s.T(), archiveMethod, ctx, id)
+	s.repo.AssertNotCalled(s.T(), deleteBookMethod, ctx, id)
+}
+
+func (s *CatalogTestSuite) TestRestoreBook_WithNonAdminUser() {
+	err := s.catalog.RestoreBook(context.TODO(), "some-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), restoreMethod)
+}
+
+func (s *CatalogTestSuite) TestRestoreBook_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(restoreMethod, ctx, "some-id").Return(nil)
+
+	err := s.catalog.RestoreBook(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), restoreMethod, ctx, "some-id")
+	s.feedCache.AssertCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestPurgeBook_WhenBookIsNotArchived() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+
+	err := s.catalog.PurgeBook(ctx, "some-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookNotArchived)
+	s.repo.AssertNotCalled(s.T(), deleteBookMethod)
+}
+
+func (s *CatalogTestSuite) TestPurgeBook_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	archivedAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	book := catalog.Book{
+		ID:         "some-id",
+		ArchivedAt: &archivedAt,
+		Images:     []catalog.Image{{ID: "image-key"}},
+		Files:      []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}},
+		Sample:     &catalog.BookSample{ContentID: "sample-key"},
+	}
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"image-key", "pdf-key", "sample-key"}).Return(nil)
+	s.repo.On(deleteBookMethod, ctx, "some-id").Return(nil)
+
+	err := s.catalog.PurgeBook(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), deleteBookMethod, ctx, "some-id")
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"image-key", "pdf-key", "sample-key"})
+}
+
+func (s *CatalogTestSuite) TestFindBooks_ArchivedForAdmins() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SearchBooks{Archived: true}
+	q := request.CreateQuery()
+	q.And(query.Condition{Field: "archived", Operator: query.Equal, Value: true})
+
+	s.repo.On(findByQueryMethod, ctx, q, request.CreatePage()).Return(catalog.PaginatedBooks{Limit: 15}, nil)
+
+	_, err := s.catalog.FindBooks(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), findByQueryMethod, ctx, q, request.CreatePage())
+}
+
+func (s *CatalogTestSuite) TestFindBooks_ArchivedForCustomers() {
+	request := catalog.SearchBooks{Archived: true}
+
+	s.repo.On(findByQueryMethod, context.TODO(), searchQuery(request), request.CreatePage()).Return(catalog.PaginatedBooks{Limit: 15}, nil)
+
+	_, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), findByQueryMethod, context.TODO(), searchQuery(request), request.CreatePage())
+}
+
+func (s *CatalogTestSuite) TestFindBooks_ByStatusForAdmins() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.SearchBooks{Status: catalog.StatusDraft}
+	q := request.CreateQuery()
+	q.And(query.Condition{Field: "archived", Operator: query.Equal, Value: false})
+	q.And(query.Condition{Field: "status", Operator: query.Equal, Value: catalog.StatusDraft})
+
+	s.repo.On(findByQueryMethod, ctx, q, request.CreatePage()).Return(catalog.PaginatedBooks{Limit: 15}, nil)
+
+	_, err := s.catalog.FindBooks(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), findByQueryMethod, ctx, q, request.CreatePage())
+}
+
+func (s *CatalogTestSuite) TestFindBooks_StatusIgnoredForCustomers() {
+	request := catalog.SearchBooks{Status: catalog.StatusDraft}
+
+	s.repo.On(findByQueryMethod, context.TODO(), searchQuery(request), request.CreatePage()).Return(catalog.PaginatedBooks{Limit: 15}, nil)
+
+	_, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), findByQueryMethod, context.TODO(), searchQuery(request), request.CreatePage())
+}
+
+func (s *CatalogTestSuite) TestFindBookByID_WhenBookIsDraft() {
+	s.repo.On(findByIdMethod, context.TODO(), "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusDraft}, nil)
+
+	_, err := s.catalog.FindBookByID(context.TODO(), "some-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookNotPublished)
+}
+
+func (s *CatalogTestSuite) TestFindBookByID_DraftForAdmins() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusDraft}, nil)
+
+	response, err := s.catalog.FindBookByID(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.StatusDraft, response.Status)
+}
+
+func (s *CatalogTestSuite) TestFindBookByID_UnpublishedForOwners() {
+	s.repo.On(findByIdMethod, context.TODO(), "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusUnpublished}, nil)
+
+	response, err := s.catalog.FindBookByID(context.TODO(), "some-id")
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), response.OnSale())
+}
+
+func (s *CatalogTestSuite) TestPublishBook_WithNonAdminUser() {
+	_, err := s.catalog.PublishBook(context.TODO(), catalog.PublishBook{BookID: "some-id"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), updateStatusMethod)
+}
+
+func (s *CatalogTestSuite) TestPublishBook_Now() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.PublishBook{BookID: "some-id"}
+	justNow := mock.MatchedBy(func(t *time.Time) bool { return time.Since(*t) < time.Minute })
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(updateStatusMethod, ctx, "some-id", catalog.StatusPublished, justNow).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusPublished}, nil)
+
+	response, err := s.catalog.PublishBook(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.StatusPublished, response.Status)
+	s.repo.AssertCalled(s.T(), updateStatusMethod, ctx, "some-id", catalog.StatusPublished, justNow)
+	s.feedCache.AssertCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestPublishBook_Scheduled() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	publishAt := time.Now().Add(24 * time.Hour)
+	request := catalog.PublishBook{BookID: "some-id", PublishAt: &publishAt}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(updateStatusMethod, ctx, "some-id", catalog.StatusScheduled, &publishAt).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusScheduled, PublishAt: &publishAt}, nil)
+
+	response, err := s.catalog.PublishBook(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.StatusScheduled, response.Status)
+	assert.Equal(s.T(), &publishAt, response.PublishAt)
+}
+
+func (s *CatalogTestSuite) TestPublishBook_WhenBookDoesNotExist() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.PublishBook{BookID: "some-id"}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(updateStatusMethod, ctx, "some-id", catalog.StatusPublished, mock.Anything).Return(fmt.Errorf("some error"))
+
+	_, err := s.catalog.PublishBook(ctx, request)
+
+	assert.Error(s.T(), err)
+	s.repo.AssertNotCalled(s.T(), findByIdMethod)
+}
+
+func (s *CatalogTestSuite) TestUnpublishBook_WhenPublished() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusPublished}, nil)
+	s.repo.On(updateStatusMethod, ctx, "some-id", catalog.StatusUnpublished, (*time.Time)(nil)).Return(nil)
+
+	err := s.catalog.UnpublishBook(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), updateStatusMethod, ctx, "some-id", catalog.StatusUnpublished, (*time.Time)(nil))
+	s.feedCache.AssertCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestUnpublishBook_WhenScheduled() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Status: catalog.StatusScheduled}, nil)
+	s.repo.On(updateStatusMethod, ctx, "some-id", catalog.StatusDraft, (*time.Time)(nil)).Return(nil)
+
+	err := s.catalog.UnpublishBook(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), updateStatusMethod, ctx, "some-id", catalog.StatusDraft, (*time.Time)(nil))
+}
+
+func (s *CatalogTestSuite) TestPublishScheduledBooks() {
+	ctx := context.TODO()
+	s.repo.On(publishScheduledMethod, ctx, mock.AnythingOfType("time.Time")).Return(int64(2), nil)
+
+	err := s.catalog.PublishScheduledBooks(ctx)
+
+	assert.Nil(s.T(), err)
+	s.feedCache.AssertCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestPublishScheduledBooks_WhenNoneIsDue() {
+	ctx := context.TODO()
+	s.repo.On(publishScheduledMethod, ctx, mock.AnythingOfType("time.Time")).Return(int64(0), nil)
+
+	err := s.catalog.PublishScheduledBooks(ctx)
+
+	assert.Nil(s.T(), err)
+	s.feedCache.AssertNotCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestFindBooks_WithCategory() {
+	request := catalog.SearchBooks{Category: "fiction"}
+	page := request.CreatePage()
+	q := searchQuery(request)
+	q.And(query.Condition{Field: "category_id", Operator: query.In, Value: []string{"fiction-id", "fantasy-id"}})
+
+	s.categoryRepo.On(findBySlugMethod, context.TODO(), "fiction").Return(catalog.Category{ID: "fiction-id"}, nil)
+	s.categoryRepo.On(findSubtreeIDsMethod, context.TODO(), "fiction-id").Return([]string{"fiction-id", "fantasy-id"}, nil)
+	s.repo.On(findByQueryMethod, context.TODO(), q, page).Return(catalog.PaginatedBooks{Limit: 15}, nil)
+
+	_, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Nil(s.T(), err)
+
+	s.repo.AssertCalled(s.T(), findByQueryMethod, context.TODO(), q, page)
+}
+
+func (s *CatalogTestSuite) TestFindBooks_WithUnknownCategory() {
+	request := catalog.SearchBooks{Category: "fiction"}
+
+	s.categoryRepo.On(findBySlugMethod, context.TODO(), "fiction").Return(catalog.Category{}, fmt.Errorf("some error"))
+
+	_, err := s.catalog.FindBooks(context.TODO(), request)
+
+	assert.Error(s.T(), err)
+
+	s.repo.AssertNumberOfCalls(s.T(), findByQueryMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithUnknownCategory() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		CategoryIDs: []string{"category-1", "category-2"},
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.categoryRepo.On(findByIDsMethod, ctx, request.CategoryIDs).Return([]catalog.Category{{ID: "category-1"}}, nil)
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrUnknownCategory)
+
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithCategories() {
+	request := catalog.CreateBook{
+		Title:       "Clean Code",
+		Description: "A Craftsman Guide",
+		Price:       4000,
+		ReleaseDate: time.Date(2020, time.September, 28, 0, 0, 0, 0, time.UTC),
+		CategoryIDs: []string{"category-1"},
+	}
+
+	categories := []catalog.Category{{ID: "category-1", Name: "Software", Slug: "software"}}
+	book := request.Book("some-id")
+	book.Categories = categories
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.categoryRepo.On(findByIDsMethod, ctx, request.CategoryIDs).Return(categories, nil)
+	s.repo.On(createBookMethod, ctx, &book).Return(nil)
+	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
+
+	response, err := s.catalog.CreateBook(ctx, request)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []catalog.CategorySummaryResponse{{ID: "category-1", Name: "Software", Slug: "software"}}, response.Categories)
+
+	s.repo.AssertCalled(s.T(), createBookMethod, ctx, &book)
+}
+
+func (s *CatalogTestSuite) TestUpdateBook_WithCategories() {
+	request := catalog.UpdateBook{
+		ID:          "some-id",
+		CategoryIDs: []string{},
+	}
+	book := catalog.Book{
+		ID:         "some-id",
+		Categories: []catalog.Category{{ID: "category-1"}},
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+	s.categoryRepo.On(findByIDsMethod, ctx, request.CategoryIDs).Return([]catalog.Category{}, nil)
+
+	updated := request.Update(book)
+	updated.Categories = []catalog.Category{}
+	s.repo.On(updateBookMethod, ctx, &updated).Return(nil)
+
+	err := s.catalog.UpdateBook(ctx, request)
+
+	assert.Nil(s.T(), err)
+
+	s.repo.AssertCalled(s.T(), updateBookMethod, ctx, &updated)
+}
+
+func (s *CatalogTestSuite) TestFindCategories_WhenRepositoryFails() {
+	s.categoryRepo.On(findAllMethod, context.TODO()).Return(nil, fmt.Errorf("some error"))
+
+	_, err := s.catalog.FindCategories(context.TODO())
+
+	assert.Error(s.T(), err)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), countBooksMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestFindCategories_Successfully() {
+	parentID := "parent-id"
+	categories := []catalog.Category{
+		{ID: parentID, Name: "Fiction", Slug: "fiction"},
+		{ID: "child-id", Name: "Fantasy", Slug: "fantasy", ParentID: &parentID},
+	}
+	counts := map[string]int64{parentID: 2, "child-id": 1}
+
+	s.categoryRepo.On(findAllMethod, context.TODO()).Return(categories, nil)
+	s.categoryRepo.On(countBooksMethod, context.TODO()).Return(counts, nil)
+
+	expected := catalog.NewCategoryTreeResponse(categories, counts)
+	actual, err := s.catalog.FindCategories(context.TODO())
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *CatalogTestSuite) TestFindCategoryByID_Successfully() {
+	category := catalog.Category{ID: "some-id", Name: "Fiction", Slug: "fiction"}
+
+	s.categoryRepo.On(findByIdMethod, context.TODO(), "some-id").Return(category, nil)
+	s.categoryRepo.On(countBooksMethod, context.TODO()).Return(map[string]int64{"some-id": 4}, nil)
+
+	actual, err := s.catalog.FindCategoryByID(context.TODO(), "some-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.NewCategoryResponse(category, 4), actual)
+}
+
+func (s *CatalogTestSuite) TestCreateCategory_WithNonAdminUser() {
+	_, err := s.catalog.CreateCategory(context.TODO(), catalog.CreateCategory{Name: "Fiction"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), createMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateCategory_WhenParentIsNotFound() {
+	parentID := "parent-id"
+	request := catalog.CreateCategory{Name: "Fantasy", ParentID: &parentID}
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.categoryRepo.On(findByIdMethod, ctx, parentID).Return(catalog.Category{}, fmt.Errorf("some error"))
+
+	_, err := s.catalog.CreateCategory(ctx, request)
+
+	assert.Error(s.T(), err)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), createMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateCategory_Successfully() {
+	request := catalog.CreateCategory{Name: "Science Fiction"}
+	ctx := context.WithValue(context.Background(), "admin", true)
+	category := request.Category("some-id")
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.categoryRepo.On(createMethod, ctx, &category).Return(nil)
+
+	actual, err := s.catalog.CreateCategory(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "science-fiction", actual.Slug)
+
+	s.categoryRepo.AssertCalled(s.T(), createMethod, ctx, &category)
+}
+
+func (s *CatalogTestSuite) TestUpdateCategory_WhenNewParentIsDescendant() {
+	childID := "child-id"
+	request := catalog.UpdateCategory{ID: "some-id", ParentID: &childID}
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.categoryRepo.On(findByIdMethod, ctx, "some-id").Return(catalog.Category{ID: "some-id"}, nil)
+	s.categoryRepo.On(findByIdMethod, ctx, childID).Return(catalog.Category{ID: childID}, nil)
+	s.categoryRepo.On(findSubtreeIDsMethod, ctx, "some-id").Return([]string{"some-id", childID}, nil)
+
+	err := s.catalog.UpdateCategory(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrCategoryCycle)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), updateMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestUpdateCategory_Successfully() {
+	parentID := "parent-id"
+	request := catalog.UpdateCategory{ID: "some-id", ParentID: &parentID}
+	ctx := context.WithValue(context.Background(), "admin", true)
+	existing := catalog.Category{ID: "some-id", Name: "Fantasy", Slug: "fantasy"}
+	updated := request.Update(existing)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.categoryRepo.On(findByIdMethod, ctx, "some-id").Return(existing, nil)
+	s.categoryRepo.On(findByIdMethod, ctx, parentID).Return(catalog.Category{ID: parentID}, nil)
+	s.categoryRepo.On(findSubtreeIDsMethod, ctx, "some-id").Return([]string{"some-id"}, nil)
+	s.categoryRepo.On(updateMethod, ctx, &updated).Return(nil)
+
+	err := s.catalog.UpdateCategory(ctx, request)
+
+	assert.Nil(s.T(), err)
+
+	s.categoryRepo.AssertCalled(s.T(), updateMethod, ctx, &updated)
+}
+
+func (s *CatalogTestSuite) TestDeleteCategory_WithNonAdminUser() {
+	err := s.catalog.DeleteCategory(context.TODO(), "some-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), deleteMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestDeleteCategory_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.categoryRepo.On(deleteMethod, ctx, "some-id").Return(nil)
+
+	err := s.catalog.DeleteCategory(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+
+	s.categoryRepo.AssertCalled(s.T(), deleteMethod, ctx, "some-id")
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithAuthors() {
+	request := catalog.CreateBook{
+		Title:       "Good Omens",
+		Description: "The Nice and Accurate Prophecies of Agnes Nutter, Witch",
+		Price:       4000,
+		ReleaseDate: time.Date(1990, time.May, 1, 0, 0, 0, 0, time.UTC),
+		Authors: []catalog.BookAuthorRequest{
+			{AuthorID: "author-1"},
+			{AuthorID: "author-2", Role: catalog.RoleAuthor},
+		},
+	}
+
+	authors := []catalog.Author{{ID: "author-2", Name: "Neil Gaiman"}, {ID: "author-1", Name: "Terry Pratchett"}}
+	book := request.Book("some-id")
+	book.Authors = catalog.BookAuthors("some-id", request.Authors)
+	book.Authors[0].Author = authors[1]
+	book.Authors[1].Author = authors[0]
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.authorRepo.On(findByIDsMethod, ctx, []string{"author-1", "author-2"}).Return(authors, nil)
+	s.repo.On(createBookMethod, ctx, &book).Return(nil)
+	s.repo.On(findByIdMethod, ctx, book.ID).Return(book, nil)
+
+	response, err := s.catalog.CreateBook(ctx, request)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "Terry Pratchett", response.Authors[0].Name)
+	assert.Equal(s.T(), "Neil Gaiman", response.Authors[1].Name)
+
+	s.repo.AssertCalled(s.T(), createBookMethod, ctx, &book)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithUnknownAuthor() {
+	request := catalog.CreateBook{
+		Title:   "Clean Code",
+		Authors: []catalog.BookAuthorRequest{{AuthorID: "author-1"}},
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.authorRepo.On(findByIDsMethod, ctx, []string{"author-1"}).Return([]catalog.Author{}, nil)
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrUnknownAuthor)
+
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateBook_WithDuplicateAuthor() {
+	request := catalog.CreateBook{
+		Title: "Clean Code",
+		Authors: []catalog.BookAuthorRequest{
+			{AuthorID: "author-1", Role: catalog.RoleAuthor},
+			{AuthorID: "author-1"},
+		},
+	}
+
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+
+	_, err := s.catalog.CreateBook(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrDuplicateBookAuthor)
+
+	s.authorRepo.AssertNumberOfCalls(s.T(), findByIDsMethod, 0)
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestFindAuthorByID_WhenRepositoryFails() {
+	s.authorRepo.On(findByIdMethod, context.TODO(), "some-id").Return(catalog.Author{}, fmt.Errorf("some error"))
+
+	_, err := s.catalog.FindAuthorByID(context.TODO(), "some-id")
+
+	assert.Error(s.T(), err)
+
+	s.storageClient.AssertNumberOfCalls(s.T(), generateGetPreSignedUrlMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestFindAuthorByID_WithPhoto() {
+	photoID := "photo-id"
+	author := catalog.Author{ID: "some-id", Name: "Eric Evans", PhotoID: &photoID}
+
+	s.authorRepo.On(findByIdMethod, context.TODO(), "some-id").Return(author, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), photoID, catalog.AssetImage).Return("link", nil)
+
+	actual, err := s.catalog.FindAuthorByID(context.TODO(), "some-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.NewAuthorResponse(author, "link"), actual)
+}
+
+func (s *CatalogTestSuite) TestCreateAuthor_WithNonAdminUser() {
+	_, err := s.catalog.CreateAuthor(context.TODO(), catalog.CreateAuthor{Name: "Eric Evans"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+
+	s.authorRepo.AssertNumberOfCalls(s.T(), createMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestCreateAuthor_Successfully() {
+	request := catalog.CreateAuthor{Name: "Eric Evans"}
+	ctx := context.WithValue(context.Background(), "admin", true)
+	author := request.Author("some-id")
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.idGenerator.On(newIdMethod).Return("some-id")
+	s.authorRepo.On(createMethod, ctx, &author).Return(nil)
+	s.authorRepo.On(findByIdMethod, ctx, "some-id").Return(author, nil)
+
+	actual, err := s.catalog.CreateAuthor(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "eric-evans", actual.Slug)
+
+	s.authorRepo.AssertCalled(s.T(), createMethod, ctx, &author)
+}
+
+func (s *CatalogTestSuite) TestUpdateAuthor_Successfully() {
+	bio := "new bio"
+	request := catalog.UpdateAuthor{ID: "some-id", Bio: &bio}
+	ctx := context.WithValue(context.Background(), "admin", true)
+	existing := catalog.Author{ID: "some-id", Name: "Eric Evans", Slug: "eric-evans"}
+	updated := request.Update(existing)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.authorRepo.On(findByIdMethod, ctx, "some-id").Return(existing, nil)
+	s.authorRepo.On(updateMethod, ctx, &updated).Return(nil)
+
+	err := s.catalog.UpdateAuthor(ctx, request)
+
+	assert.Nil(s.T(), err)
+
+	s.authorRepo.AssertCalled(s.T(), updateMethod, ctx, &updated)
+}
+
+func (s *CatalogTestSuite) TestDeleteAuthor_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.authorRepo.On(deleteMethod, ctx, "some-id").Return(nil)
+
+	err := s.catalog.DeleteAuthor(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+
+	s.authorRepo.AssertCalled(s.T(), deleteMethod, ctx, "some-id")
+}
+
+func (s *CatalogTestSuite) TestImportBooks_WithNonAdminUser() {
+	_, err := s.catalog.ImportBooks(context.TODO(), catalog.ImportBooks{Format: catalog.ImportFormatCSV})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+
+	s.importParser.AssertNumberOfCalls(s.T(), parseMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestImportBooks_WhenFileCannotBeParsed() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ImportBooks{Format: catalog.ImportFormatCSV, File: strings.NewReader("")}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.importParser.On(parseMethod, request.Format, request.File).Return(nil, fmt.Errorf("wrapped: %w", catalog.ErrInvalidImportFile))
+
+	_, err := s.catalog.ImportBooks(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidImportFile)
+
+	s.categoryRepo.AssertNumberOfCalls(s.T(), findAllMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestImportBooks_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ImportBooks{Format: catalog.ImportFormatCSV, File: strings.NewReader("")}
+	rows := s.importRows()
+	isbn := "9780306406157"
+	existing := catalog.Book{ID: "existing-id", Title: "Old title", ISBN: &isbn, Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "content-id"}}}
+	author := catalog.Author{ID: "author-id", Name: "Frank Herbert", Slug: "frank-herbert"}
+
+	var updated, created *catalog.Book
+	s.mockImport(ctx, request, rows, existing, author)
+	s.repo.On(updateBookMethod, ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) { updated = args.Get(1).(*catalog.Book) })
+	s.repo.On(createBookMethod, ctx, mock.Anything).Return(nil).Run(func(args mock.Arguments) { created = args.Get(1).(*catalog.Book) })
+
+	actual, err := s.catalog.ImportBooks(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.False(s.T(), actual.DryRun)
+	assert.Equal(s.T(), 1, actual.Created)
+	assert.Equal(s.T(), 1, actual.Updated)
+	assert.Equal(s.T(), 2, actual.Failed)
+	assert.Equal(s.T(), catalog.ImportUpdated, actual.Rows[0].Status)
+	assert.Equal(s.T(), "existing-id", actual.Rows[0].BookID)
+	assert.Equal(s.T(), catalog.ImportCreated, actual.Rows[1].Status)
+	assert.Equal(s.T(), "new-book-id", actual.Rows[1].BookID)
+	assert.Equal(s.T(), []string{"invalid price \"free\""}, actual.Rows[2].Errors)
+	assert.Equal(s.T(), []string{"title is required"}, actual.Rows[3].Errors)
+
+	assert.Equal(s.T(), "Dune", updated.Title)
+	assert.Equal(s.T(), existing.Files, updated.Files)
+	assert.Equal(s.T(), "author-id", updated.Authors[0].AuthorID)
+	assert.Equal(s.T(), "science-fiction", updated.Categories[0].Slug)
+
+	assert.Equal(s.T(), "BK-2", *created.ExternalID)
+	assert.Equal(s.T(), catalog.StatusDraft, created.Status)
+	assert.Equal(s.T(), "new-author-id", created.Authors[0].AuthorID)
+	assert.Equal(s.T(), "Jane Doe", created.Authors[0].Author.Name)
+	assert.Equal(s.T(), "new-author-id", created.Authors[1].AuthorID)
+	assert.Equal(s.T(), catalog.RoleTranslator, created.Authors[1].Role)
+	assert.Len(s.T(), created.Authors, 2)
+
+	// The new authors are created by the repository along with the book
+	s.authorRepo.AssertNumberOfCalls(s.T(), createMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestImportBooks_InDryRunMode() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ImportBooks{Format: catalog.ImportFormatCSV, DryRun: true, File: strings.NewReader("")}
+	rows := s.importRows()
+	isbn := "9780306406157"
+	existing := catalog.Book{ID: "existing-id", ISBN: &isbn}
+	author := catalog.Author{ID: "author-id", Name: "Frank Herbert", Slug: "frank-herbert"}
+
+	s.mockImport(ctx, request, rows, existing, author)
+
+	actual, err := s.catalog.ImportBooks(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), actual.DryRun)
+	assert.Equal(s.T(), 1, actual.Created)
+	assert.Equal(s.T(), 1, actual.Updated)
+	assert.Equal(s.T(), 2, actual.Failed)
+	assert.Equal(s.T(), "existing-id", actual.Rows[0].BookID)
+	assert.Empty(s.T(), actual.Rows[1].BookID)
+
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+	s.repo.AssertNumberOfCalls(s.T(), updateBookMethod, 0)
+	s.authorRepo.AssertNumberOfCalls(s.T(), createMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestImportBooks_WithUnknownCategory() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ImportBooks{Format: catalog.ImportFormatCSV, File: strings.NewReader("")}
+	row := s.importRows()[1]
+	row.Categories = []string{"poetry"}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.validator.On(validateMethod, row).Return(nil)
+	s.importParser.On(parseMethod, request.Format, request.File).Return([]catalog.ImportRow{row}, nil)
+	s.categoryRepo.On(findAllMethod, ctx).Return([]catalog.Category{}, nil)
+	s.repo.On(findByQueryMethod, ctx, mock.Anything, mock.Anything).Return(catalog.PaginatedBooks{}, nil)
+	s.idGenerator.On(newIdMethod).Return("new-book-id")
+
+	actual, err := s.catalog.ImportBooks(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.ImportFailed, actual.Rows[0].Status)
+	assert.Len(s.T(), actual.Rows[0].Errors, 1)
+
+	s.repo.AssertNumberOfCalls(s.T(), createBookMethod, 0)
+}
+
+// importRows returns a row matching an existing book by ISBN, a new book, a row with a parse error and an invalid row.
+func (s *CatalogTestSuite) importRows() []catalog.ImportRow {
+	return []catalog.ImportRow{
+		{
+			Line:         2,
+			ISBN:         "978-0-306-40615-7",
+			Title:        "Dune",
+			Description:  "A desert planet",
+			Contributors: []catalog.ImportContributor{{Name: "Frank Herbert", Role: catalog.RoleAuthor}},
+			Price:        1299,
+			ReleaseDate:  time.Date(1965, 8, 1, 0, 0, 0, 0, time.UTC),
+			Categories:   []string{"science-fiction"},
+		},
+		{
+			Line:        3,
+			ExternalID:  "BK-2",
+			Title:       "Dune Messiah",
+			Description: "The sequel",
+			Contributors: []catalog.ImportContributor{
+				{Name: "Jane Doe", Role: catalog.RoleAuthor},
+				{Name: "Jane Doe", Role: catalog.RoleTranslator},
+				{Name: "Jane  Doe", Role: catalog.RoleAuthor},
+			},
+			Price:       1099,
+			ReleaseDate: time.Date(1969, 10, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{Line: 4, ExternalID: "BK-3", Errors: []string{"invalid price \"free\""}},
+		{Line: 5, ExternalID: "BK-4"},
+	}
+}
+
+func (s *CatalogTestSuite) mockImport(ctx context.Context, request catalog.ImportBooks, rows []catalog.ImportRow, existing catalog.Book, author catalog.Author) {
+	byISBN := *query.New().And(query.Condition{Field: "isbn", Operator: query.Equal, Value: "9780306406157"})
+	byExternalID := *query.New().And(query.Condition{Field: "external_id", Operator: query.Equal, Value: "BK-2"})
+	page := query.Page{Number: 1, Size: 1}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.validator.On(validateMethod, rows[0]).Return(nil)
+	s.validator.On(validateMethod, rows[1]).Return(nil)
+	s.validator.On(validateMethod, rows[3]).Return(fmt.Errorf("(Validate) failed validating struct: %w", errors.New("title is required")))
+	s.importParser.On(parseMethod, request.Format, request.File).Return(rows, nil)
+	s.categoryRepo.On(findAllMethod, ctx).Return([]catalog.Category{{ID: "category-id", Slug: "science-fiction"}}, nil)
+	s.repo.On(findByQueryMethod, ctx, byISBN, page).Return(catalog.PaginatedBooks{Books: []catalog.Book{existing}}, nil)
+	s.repo.On(findByQueryMethod, ctx, byExternalID, page).Return(catalog.PaginatedBooks{}, nil)
+	s.authorRepo.On(findBySlugsMethod, ctx, []string{"frank-herbert"}).Return([]catalog.Author{author}, nil)
+	s.authorRepo.On(findBySlugsMethod, ctx, []string{"jane-doe"}).Return([]catalog.Author{}, nil)
+	s.idGenerator.On(newIdMethod).Return("new-book-id").Once()
+	s.idGenerator.On(newIdMethod).Return("new-author-id").Once()
+}
+
+func (s *CatalogTestSuite) TestExportBooks_WithNonAdminUser() {
+	err := s.catalog.ExportBooks(context.TODO(), catalog.ExportBooks{Format: catalog.ExportFormatCSV}, &bytes.Buffer{})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+
+	s.exportEncoder.AssertNumberOfCalls(s.T(), newWriterMethod, 0)
+}
 
+func (s *CatalogTestSuite) TestExportBooks_InBatches() {
 	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ExportBooks{Format: catalog.ExportFormatJSONL}
+	output := &bytes.Buffer{}
+	writer := new(catalog.MockExportWriter)
+	first := catalog.Book{ID: "book-1", Images: []catalog.Image{{ID: "image-1"}}}
+	second := catalog.Book{ID: "book-2"}
 
 	s.validator.On(validateMethod, request).Return(nil)
-	s.repo.On(findByIdMethod, ctx, request.ID).Return(catalog.Book{}, fmt.Errorf("some error"))
+	s.exportEncoder.On(newWriterMethod, request.Format, output).Return(writer, nil)
+	onSale := *query.New().
+		And(query.Condition{Field: "archived", Operator: query.Equal, Value: false}).
+		And(query.Condition{Field: "status", Operator: query.Equal, Value: catalog.StatusPublished})
+	s.repo.On(findByQueryMethod, ctx, onSale, query.Page{Size: 100, Keyset: true}).
+		Return(catalog.PaginatedBooks{Books: []catalog.Book{first}, NextCursor: "next"}, nil)
+	s.repo.On(findByQueryMethod, ctx, onSale, query.Page{Size: 100, Keyset: true, Cursor: "next"}).
+		Return(catalog.PaginatedBooks{Books: []catalog.Book{second}}, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, "image-1", catalog.AssetImage).Return("image-link", nil)
+	writer.On(writeMethod, catalog.ExportedBook{Book: first, ImageLinks: []string{"image-link"}}).Return(nil)
+	writer.On(writeMethod, catalog.ExportedBook{Book: second, ImageLinks: []string{}}).Return(nil)
+	writer.On(closeMethod).Return(nil)
+
+	err := s.catalog.ExportBooks(ctx, request, output)
 
-	err := s.catalog.UpdateBook(ctx, request)
+	assert.Nil(s.T(), err)
+
+	s.repo.AssertNumberOfCalls(s.T(), findByQueryMethod, 2)
+	writer.AssertNumberOfCalls(s.T(), writeMethod, 2)
+	writer.AssertCalled(s.T(), closeMethod)
+}
+
+func (s *CatalogTestSuite) TestExportBooks_WhenWriterFails() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ExportBooks{Format: catalog.ExportFormatCSV}
+	output := &bytes.Buffer{}
+	writer := new(catalog.MockExportWriter)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.exportEncoder.On(newWriterMethod, request.Format, output).Return(writer, nil)
+	s.repo.On(findByQueryMethod, ctx, mock.Anything, mock.Anything).
+		Return(catalog.PaginatedBooks{Books: []catalog.Book{{ID: "book-1"}}, NextCursor: "next"}, nil)
+	writer.On(writeMethod, mock.Anything).Return(fmt.Errorf("some error"))
+
+	err := s.catalog.ExportBooks(ctx, request, output)
 
 	assert.Error(s.T(), err)
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
+	s.repo.AssertNumberOfCalls(s.T(), findByQueryMethod, 1)
+	writer.AssertNotCalled(s.T(), closeMethod)
 }
 
-func (s *CatalogTestSuite) TestUpdateBook_WhenUpdateFails() {
-	newTitle := "new title"
-	request := catalog.UpdateBook{
-		ID:    "some-id",
-		Title: &newTitle,
-	}
-	book := catalog.Book{
-		ID:    "some-id",
-		Title: "old-title",
-	}
+func (s *CatalogTestSuite) TestFindPublicFeed_WhenFeedIsCached() {
+	s.feedCache.On(getMethod, context.TODO()).Return([]byte("<rss/>"), true, nil)
+
+	actual, err := s.catalog.FindPublicFeed(context.TODO())
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []byte("<rss/>"), actual)
+
+	s.exportEncoder.AssertNumberOfCalls(s.T(), newWriterMethod, 0)
+}
+
+func (s *CatalogTestSuite) TestFindPublicFeed_WhenFeedIsNotCached() {
+	ctx := context.TODO()
+	writer := new(catalog.MockExportWriter)
+
+	s.feedCache.On(getMethod, ctx).Return(nil, false, nil)
+	s.exportEncoder.On(newWriterMethod, catalog.PublicFeedFormat, mock.Anything).Return(writer, nil).Run(func(args mock.Arguments) {
+		_, _ = args.Get(1).(io.Writer).Write([]byte("<rss/>"))
+	})
+	s.repo.On(findByQueryMethod, ctx, mock.Anything, mock.Anything).Return(catalog.PaginatedBooks{}, nil)
+	writer.On(closeMethod).Return(nil)
+	s.feedCache.On(saveMethod, ctx, []byte("<rss/>")).Return(nil)
+
+	actual, err := s.catalog.FindPublicFeed(ctx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []byte("<rss/>"), actual)
 
+	s.feedCache.AssertCalled(s.T(), saveMethod, ctx, []byte("<rss/>"))
+}
+
+func (s *CatalogTestSuite) TestDeleteBook_InvalidatesPublicFeed() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	s.repo.On(archiveMethod, ctx, "some-id").Return(nil)
+
+	err := s.catalog.DeleteBook(ctx, "some-id")
+
+	assert.Nil(s.T(), err)
+
+	s.feedCache.AssertCalled(s.T(), deleteMethod, ctx)
+}
+
+func (s *CatalogTestSuite) TestAttachBookSample_WithNonAdminUser() {
+	_, err := s.catalog.AttachBookSample(context.TODO(), catalog.AttachBookSample{BookID: "some-id"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), saveSampleMethod)
+}
+
+func (s *CatalogTestSuite) TestAttachBookSample_Successfully() {
 	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.AttachBookSample{BookID: "some-id", Format: catalog.FormatEPUB, ContentID: "sample-key", Size: 512}
+	sample := request.BookSample()
 
 	s.validator.On(validateMethod, request).Return(nil)
-	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Sample: &sample}, nil)
+	s.storageClient.On(headObjectMethod, ctx, "sample-key").Return(catalog.ObjectInfo{Size: 512, ContentType: "application/epub+zip"}, nil)
+	s.repo.On(saveSampleMethod, ctx, &sample).Return(nil)
 
-	updated := request.Update(book)
-	s.repo.On(updateBookMethod, ctx, &updated).Return(fmt.Errorf("some error"))
+	response, err := s.catalog.AttachBookSample(ctx, request)
 
-	err := s.catalog.UpdateBook(ctx, request)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), &catalog.BookFileResponse{Format: catalog.FormatEPUB, MediaType: "application/epub+zip", Size: 512}, response.Sample)
+	s.repo.AssertNumberOfCalls(s.T(), saveSampleMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WithNonAdminUser() {
+	_, err := s.catalog.GenerateBookSample(context.TODO(), catalog.GenerateBookSample{BookID: "some-id", Length: 10})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.sampler.AssertNotCalled(s.T(), generateMethod)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WhenNoFormatCanBeSampled() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Length: 10}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatMOBI, ContentID: "mobi-key"}}}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookFormatNotAvailable)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WhenContentCannotBeSampled() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Format: catalog.FormatPDF, Length: 10}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	content := io.NopCloser(strings.NewReader("not a pdf"))
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(getObjectMethod, ctx, "pdf-key").Return(content, nil)
+	s.sampler.On(generateMethod, catalog.FormatPDF, content, 10).Return(nil, catalog.ErrInvalidSampleSource)
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidSampleSource)
+	s.storageClient.AssertNotCalled(s.T(), putObjectMethod)
+	s.repo.AssertNotCalled(s.T(), saveSampleMethod)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WhenContentIsTooLarge() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Format: catalog.FormatPDF, Length: 10}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", Size: 11}}}
+	s.catalog.MaxProcessedSize = 10
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrContentTooLarge)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WhenStoredContentIsTooLarge() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Format: catalog.FormatPDF, Length: 10}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	s.catalog.MaxProcessedSize = 10
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(headObjectMethod, ctx, "pdf-key").Return(catalog.ObjectInfo{Size: 11}, nil)
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrContentTooLarge)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod, mock.Anything, mock.Anything)
+}
+
+func (s *CatalogTestSuite) TestGenerateBookSample_WhenSampleCannotBeStored() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Format: catalog.FormatPDF, Length: 10}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key"}}}
+	content := io.NopCloser(strings.NewReader("pdf content"))
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(getObjectMethod, ctx, "pdf-key").Return(content, nil)
+	s.sampler.On(generateMethod, catalog.FormatPDF, content, 10).Return([]byte("sample"), nil)
+	s.idGenerator.On(newIdMethod).Return("sample-key")
+	s.uploadRepo.On(trackMethod, ctx, []string{"sample-key"}).Return(nil)
+	s.storageClient.On(putObjectMethod, ctx, "sample-key", "application/pdf", bytes.NewReader([]byte("sample"))).Return(fmt.Errorf("some error"))
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
 
 	assert.Error(s.T(), err)
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"sample-key"})
+	s.uploadRepo.AssertNotCalled(s.T(), untrackMethod, mock.Anything, mock.Anything)
+	s.repo.AssertNotCalled(s.T(), saveSampleMethod, mock.Anything, mock.Anything)
+}
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
-	s.repo.AssertCalled(s.T(), updateBookMethod, ctx, &updated)
+func (s *CatalogTestSuite) TestGenerateBookSample_Successfully() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.GenerateBookSample{BookID: "some-id", Length: 3}
+	book := catalog.Book{ID: "some-id", Files: []catalog.BookFile{
+		{Format: catalog.FormatMOBI, ContentID: "mobi-key"},
+		{Format: catalog.FormatEPUB, ContentID: "epub-key"},
+	}, Sample: &catalog.BookSample{BookID: "some-id", ContentID: "old-sample-key"}}
+	content := io.NopCloser(strings.NewReader("epub content"))
+	sample := catalog.BookSample{
+		BookID:    "some-id",
+		Format:    catalog.FormatEPUB,
+		ContentID: "sample-key",
+		MediaType: "application/epub+zip",
+		Size:      6,
+		Generated: true,
+	}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(getObjectMethod, ctx, "epub-key").Return(content, nil)
+	s.sampler.On(generateMethod, catalog.FormatEPUB, content, 3).Return([]byte("sample"), nil)
+	s.idGenerator.On(newIdMethod).Return("sample-key")
+	s.uploadRepo.On(trackMethod, ctx, []string{"sample-key"}).Return(nil)
+	s.storageClient.On(putObjectMethod, ctx, "sample-key", "application/epub+zip", bytes.NewReader([]byte("sample"))).Return(nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"old-sample-key"}).Return(nil)
+	s.repo.On(saveSampleMethod, ctx, &sample).Return(nil)
+	s.uploadRepo.On(untrackMethod, ctx, []string{"sample-key"}).Return(nil)
+
+	_, err := s.catalog.GenerateBookSample(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"sample-key"})
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"old-sample-key"})
+	s.uploadRepo.AssertCalled(s.T(), untrackMethod, ctx, []string{"sample-key"})
+	s.storageClient.AssertNumberOfCalls(s.T(), putObjectMethod, 1)
+	s.repo.AssertNumberOfCalls(s.T(), saveSampleMethod, 1)
 }
 
-func (s *CatalogTestSuite) TestUpdateBook_Successfully() {
-	newTitle := "new title"
-	request := catalog.UpdateBook{
-		ID:    "some-id",
-		Title: &newTitle,
+func (s *CatalogTestSuite) TestGetBookSampleURL_WhenBookHasNoSample() {
+	s.repo.On(findByIdMethod, context.TODO(), "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+
+	_, err := s.catalog.GetBookSampleURL(context.TODO(), "some-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrSampleNotAvailable)
+	s.storageClient.AssertNotCalled(s.T(), generateGetPreSignedUrlMethod)
+}
+
+func (s *CatalogTestSuite) TestGetBookSampleURL_Successfully() {
+	book := catalog.Book{ID: "some-id", Sample: &catalog.BookSample{Format: catalog.FormatPDF, ContentID: "sample-key", MediaType: "application/pdf"}}
+	s.repo.On(findByIdMethod, context.TODO(), "some-id").Return(book, nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, context.TODO(), "sample-key", catalog.AssetSample).Return("https://sample", nil)
+
+	response, err := s.catalog.GetBookSampleURL(context.TODO(), "some-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), catalog.SampleResponse{URL: "https://sample", Format: catalog.FormatPDF, MediaType: "application/pdf"}, response)
+}
+
+func (s *CatalogTestSuite) TestGenerateImageVariants_Successfully() {
+	ctx := context.TODO()
+	content := io.NopCloser(strings.NewReader("image content"))
+	resized := []catalog.ResizedImage{
+		{MaxSize: 200, Data: []byte("thumbnail"), MediaType: "image/jpeg", Width: 200, Height: 150},
+		{MaxSize: 600, Data: []byte("medium"), MediaType: "image/jpeg", Width: 400, Height: 300},
+		{MaxSize: 1200, Data: []byte("large"), MediaType: "image/jpeg", Width: 400, Height: 300},
 	}
-	book := catalog.Book{
-		ID:    "some-id",
-		Title: "old-title",
+	expected := []catalog.ImageVariant{
+		{ImageID: "some-key", Name: catalog.VariantThumbnail, ContentID: "variants/some-key/thumbnail", MediaType: "image/jpeg", Width: 200, Height: 150},
+		{ImageID: "some-key", Name: catalog.VariantMedium, ContentID: "variants/some-key/medium", MediaType: "image/jpeg", Width: 400, Height: 300},
+		{ImageID: "some-key", Name: catalog.VariantLarge, ContentID: "variants/some-key/large", MediaType: "image/jpeg", Width: 400, Height: 300},
+	}
+
+	s.imageRepo.On(findWithoutVariantsMethod, ctx, mock.Anything).Return([]catalog.Image{{ID: "some-key"}}, nil)
+	s.storageClient.On(getObjectMethod, ctx, "some-key").Return(content, nil)
+	s.resizer.On(resizeMethod, content, []int{200, 600, 1200}).Return(resized, nil)
+	s.storageClient.On(putObjectMethod, ctx, mock.Anything, "image/jpeg", mock.Anything).Return(nil)
+	s.imageRepo.On(saveVariantsMethod, ctx, "some-key", expected).Return(nil)
+
+	err := s.catalog.GenerateImageVariants(ctx)
+
+	assert.Nil(s.T(), err)
+	s.storageClient.AssertCalled(s.T(), putObjectMethod, ctx, "variants/some-key/thumbnail", "image/jpeg", bytes.NewReader([]byte("thumbnail")))
+	s.storageClient.AssertNumberOfCalls(s.T(), putObjectMethod, 3)
+	s.imageRepo.AssertNumberOfCalls(s.T(), saveVariantsMethod, 1)
+}
+
+func (s *CatalogTestSuite) TestGenerateImageVariants_WhenImageIsInvalid() {
+	ctx := context.TODO()
+	content := io.NopCloser(strings.NewReader("not an image"))
+
+	s.imageRepo.On(findWithoutVariantsMethod, ctx, mock.Anything).Return([]catalog.Image{{ID: "some-key"}}, nil)
+	s.storageClient.On(getObjectMethod, ctx, "some-key").Return(content, nil)
+	s.resizer.On(resizeMethod, content, mock.Anything).Return(nil, catalog.ErrInvalidImage)
+	s.imageRepo.On(saveVariantsMethod, ctx, "some-key", []catalog.ImageVariant(nil)).Return(nil)
+
+	err := s.catalog.GenerateImageVariants(ctx)
+
+	assert.Nil(s.T(), err)
+	s.storageClient.AssertNotCalled(s.T(), putObjectMethod)
+	s.imageRepo.AssertCalled(s.T(), saveVariantsMethod, ctx, "some-key", []catalog.ImageVariant(nil))
+}
+
+func (s *CatalogTestSuite) TestGenerateImageVariants_WhenStorageFails() {
+	ctx := context.TODO()
+
+	s.imageRepo.On(findWithoutVariantsMethod, ctx, mock.Anything).Return([]catalog.Image{{ID: "some-key"}}, nil)
+	s.storageClient.On(getObjectMethod, ctx, "some-key").Return(nil, fmt.Errorf("some error"))
+	s.imageRepo.On(markVariantsFailedMethod, ctx, "some-key").Return(nil)
+
+	err := s.catalog.GenerateImageVariants(ctx)
+
+	assert.Nil(s.T(), err)
+	s.resizer.AssertNotCalled(s.T(), resizeMethod)
+	s.imageRepo.AssertNotCalled(s.T(), saveVariantsMethod)
+	s.imageRepo.AssertCalled(s.T(), markVariantsFailedMethod, ctx, "some-key")
+}
+
+func (s *CatalogTestSuite) TestGenerateImageVariants_WhenRepositoryFails() {
+	s.imageRepo.On(findWithoutVariantsMethod, context.TODO(), mock.Anything).Return(nil, fmt.Errorf("some error"))
+
+	err := s.catalog.GenerateImageVariants(context.TODO())
+
+	assert.Error(s.T(), err)
+	s.storageClient.AssertNotCalled(s.T(), getObjectMethod)
+}
+
+func (s *CatalogTestSuite) TestAddBookImage_WhenImageIsDuplicated() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.AddBookImage{BookID: "some-id", ImageRequest: catalog.ImageRequest{ID: "image-id"}}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id", Images: []catalog.Image{{ID: "image-id"}}}, nil)
+
+	_, err := s.catalog.AddBookImage(ctx, request)
+
+	assert.ErrorIs(s.T(), err, catalog.ErrDuplicateImage)
+	s.repo.AssertNotCalled(s.T(), saveImagesMethod)
+}
+
+func (s *CatalogTestSuite) TestAddBookImage_AsMainImage() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.AddBookImage{BookID: "some-id", ImageRequest: catalog.ImageRequest{ID: "image-id2", IsMain: true}}
+	book := catalog.Book{ID: "some-id", Images: []catalog.Image{{ID: "image-id", BookID: "some-id", IsMain: true}}}
+	expected := []catalog.Image{
+		{ID: "image-id", BookID: "some-id", Position: 0},
+		{ID: "image-id2", BookID: "some-id", Position: 1, IsMain: true},
 	}
 
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.storageClient.On(headObjectMethod, ctx, "image-id2").Return(catalog.ObjectInfo{Size: 2048, ContentType: "image/png"}, nil)
+	s.repo.On(saveImagesMethod, ctx, "some-id", expected).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, mock.Anything, mock.Anything).Return("some-link", nil)
+
+	_, err := s.catalog.AddBookImage(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), saveImagesMethod, ctx, "some-id", expected)
+}
+
+func (s *CatalogTestSuite) TestAddBookImage_WhenUploadIsNotAnImage() {
 	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.AddBookImage{BookID: "some-id", ImageRequest: catalog.ImageRequest{ID: "image-id"}}
 
 	s.validator.On(validateMethod, request).Return(nil)
-	s.repo.On(findByIdMethod, ctx, request.ID).Return(book, nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+	s.storageClient.On(headObjectMethod, ctx, "image-id").Return(catalog.ObjectInfo{Size: 2048, ContentType: "application/pdf"}, nil)
 
-	updated := request.Update(book)
-	s.repo.On(updateBookMethod, ctx, &updated).Return(nil)
+	_, err := s.catalog.AddBookImage(ctx, request)
 
-	err := s.catalog.UpdateBook(ctx, request)
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidUpload)
+	s.repo.AssertNotCalled(s.T(), saveImagesMethod)
+}
+
+func (s *CatalogTestSuite) TestRemoveBookImage_WhenMainImageIsRemoved() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	book := catalog.Book{ID: "some-id", Images: []catalog.Image{
+		{ID: "image-id", Position: 0, IsMain: true},
+		{ID: "image-id2", Position: 1},
+	}}
+
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.uploadRepo.On(trackMethod, ctx, []string{"image-id"}).Return(nil)
+	s.repo.On(saveImagesMethod, ctx, "some-id", []catalog.Image{{ID: "image-id2", Position: 0, IsMain: true}}).Return(nil)
+
+	err := s.catalog.RemoveBookImage(ctx, "some-id", "image-id")
 
 	assert.Nil(s.T(), err)
+	s.repo.AssertNumberOfCalls(s.T(), saveImagesMethod, 1)
+	s.uploadRepo.AssertCalled(s.T(), trackMethod, ctx, []string{"image-id"})
+}
 
-	s.validator.AssertNumberOfCalls(s.T(), validateMethod, 1)
-	s.repo.AssertCalled(s.T(), findByIdMethod, ctx, request.ID)
-	s.repo.AssertCalled(s.T(), updateBookMethod, ctx, &updated)
+func (s *CatalogTestSuite) TestRemoveBookImage_WhenImageIsNotFound() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(catalog.Book{ID: "some-id"}, nil)
+
+	err := s.catalog.RemoveBookImage(ctx, "some-id", "image-id")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrImageNotFound)
 }
 
-func (s *CatalogTestSuite) TestDeleteBook_WithNonAdminUser() {
-	id := "some-id"
+func (s *CatalogTestSuite) TestReorderBookImages_WhenOrderIsIncomplete() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	request := catalog.ReorderBookImages{BookID: "some-id", ImageIDs: []string{"image-id2"}}
+	book := catalog.Book{ID: "some-id", Images: []catalog.Image{{ID: "image-id"}, {ID: "image-id2"}}}
 
-	ctx := context.Background()
-	err := s.catalog.DeleteBook(ctx, id)
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
 
-	assert.Equal(s.T(), catalog.ErrForbiddenCatalogAccess, errors.Unwrap(err))
+	_, err := s.catalog.ReorderBookImages(ctx, request)
 
-	s.repo.AssertNotCalled(s.T(), deleteBookMethod, ctx, id)
+	assert.ErrorIs(s.T(), err, catalog.ErrInvalidImageOrder)
+	s.repo.AssertNotCalled(s.T(), saveImagesMethod)
 }
 
-func (s *CatalogTestSuite) TestDeleteBook_WhenRepositoryFails() {
-	id := "some-id"
+func (s *CatalogTestSuite) TestReorderBookImages_KeepsMainImage() {
 	ctx := context.WithValue(context.Background(), "admin", true)
-	s.repo.On(deleteBookMethod, ctx, id).Return(fmt.Errorf("some error"))
+	request := catalog.ReorderBookImages{BookID: "some-id", ImageIDs: []string{"image-id2", "image-id"}}
+	book := catalog.Book{ID: "some-id", Images: []catalog.Image{
+		{ID: "image-id", Position: 0, IsMain: true},
+		{ID: "image-id2", Position: 1},
+	}}
+	expected := []catalog.Image{
+		{ID: "image-id2", Position: 0},
+		{ID: "image-id", Position: 1, IsMain: true},
+	}
 
-	err := s.catalog.DeleteBook(ctx, id)
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.repo.On(saveImagesMethod, ctx, "some-id", expected).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, mock.Anything, mock.Anything).Return("some-link", nil)
 
-	assert.Error(s.T(), err)
+	_, err := s.catalog.ReorderBookImages(ctx, request)
 
-	s.repo.AssertCalled(s.T(), deleteBookMethod, ctx, id)
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), saveImagesMethod, ctx, "some-id", expected)
 }
 
-func (s *CatalogTestSuite) TestDeleteBook_Successfully() {
-	id := "some-id"
+func (s *CatalogTestSuite) TestSetMainBookImage_Successfully() {
 	ctx := context.WithValue(context.Background(), "admin", true)
-	s.repo.On(deleteBookMethod, ctx, id).Return(nil)
+	book := catalog.Book{ID: "some-id", Images: []catalog.Image{
+		{ID: "image-id", Position: 0, IsMain: true},
+		{ID: "image-id2", Position: 1},
+	}}
+	expected := []catalog.Image{
+		{ID: "image-id", Position: 0},
+		{ID: "image-id2", Position: 1, IsMain: true},
+	}
+
+	s.repo.On(findByIdMethod, ctx, "some-id").Return(book, nil)
+	s.repo.On(saveImagesMethod, ctx, "some-id", expected).Return(nil)
+	s.storageClient.On(generateGetPreSignedUrlMethod, ctx, mock.Anything, mock.Anything).Return("some-link", nil)
+
+	_, err := s.catalog.SetMainBookImage(ctx, "some-id", "image-id2")
 
-	err := s.catalog.DeleteBook(ctx, id)
 	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), saveImagesMethod, ctx, "some-id", expected)
+}
+
+func (s *CatalogTestSuite) TestSetMainBookImage_WhenNotAdmin() {
+	_, err := s.catalog.SetMainBookImage(context.TODO(), "some-id", "image-id")
 
-	s.repo.AssertCalled(s.T(), deleteBookMethod, ctx, id)
+	assert.ErrorIs(s.T(), err, catalog.ErrForbiddenCatalogAccess)
+	s.repo.AssertNotCalled(s.T(), findByIdMethod)
 }
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+type Category struct {
+	ID        string
+	Name      string
+	Slug      string
+	ParentID  *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c Category) IsRoot() bool {
+	return c.ParentID == nil
+}
+
+// Slugify converts a name into a lowercase, URL friendly identifier. Example: "Science Fiction" -> "science-fiction"
+func Slugify(name string) string {
+	var builder strings.Builder
+	pendingDash := false
+
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if pendingDash && builder.Len() > 0 {
+				builder.WriteRune('-')
+			}
+			builder.WriteRune(r)
+			pendingDash = false
+			continue
+		}
+		pendingDash = true
+	}
+
+	return builder.String()
+}
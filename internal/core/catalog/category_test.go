@@ -0,0 +1,34 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategory_IsRoot(t *testing.T) {
+	parentID := "parent-id"
+
+	assert.True(t, Category{}.IsRoot())
+	assert.False(t, Category{ParentID: &parentID}.IsRoot())
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "when name has spaces, then it should replace them with dashes", input: "Science Fiction", expected: "science-fiction"},
+		{name: "when name has symbols, then it should collapse them", input: "  Sci-Fi & Fantasy!! ", expected: "sci-fi-fantasy"},
+		{name: "when name has numbers, then it should keep them", input: "Top 10 Books", expected: "top-10-books"},
+		{name: "when name has accents, then it should keep the letters", input: "Ficção Científica", expected: "ficção-científica"},
+		{name: "when name is empty, then it should return an empty string", input: "", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Slugify(tc.input))
+		})
+	}
+}
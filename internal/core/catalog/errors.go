@@ -3,3 +3,27 @@ package catalog
 import "fmt"
 
 var ErrForbiddenCatalogAccess = fmt.Errorf("the access to this action is restricted to allowed users")
+var ErrCategoryCycle = fmt.Errorf("a category cannot be moved under itself or one of its subcategories")
+var ErrUnknownCategory = fmt.Errorf("one or more of the provided categories do not exist")
+var ErrUnknownAuthor = fmt.Errorf("one or more of the provided authors do not exist")
+var ErrDuplicateBookAuthor = fmt.Errorf("an author cannot be credited twice with the same role")
+var ErrInvalidISBN = fmt.Errorf("the provided isbn is not a valid ISBN-10 or ISBN-13")
+var ErrInvalidImportFile = fmt.Errorf("the provided import file cannot be read")
+var ErrBookFormatNotAvailable = fmt.Errorf("the book is not available in the requested format")
+var ErrLastBookFile = fmt.Errorf("the only file of a book cannot be removed")
+var ErrSampleNotAvailable = fmt.Errorf("the book has no sample")
+var ErrInvalidSampleSource = fmt.Errorf("a sample cannot be cut from the content of the book")
+var ErrInvalidWatermarkSource = fmt.Errorf("the content of the book cannot be watermarked")
+var ErrInvalidProtectionSource = fmt.Errorf("the content of the book cannot be encrypted")
+var ErrBookProtected = fmt.Errorf("the book is only delivered encrypted, with a license")
+var ErrBookNotProtected = fmt.Errorf("the book is not protected")
+var ErrProtectedBookWithoutEPUB = fmt.Errorf("a protected book must have an epub file")
+var ErrInvalidImage = fmt.Errorf("the image cannot be decoded")
+var ErrBookNotArchived = fmt.Errorf("only archived books can be purged")
+var ErrImageNotFound = fmt.Errorf("the image does not belong to the book")
+var ErrDuplicateImage = fmt.Errorf("the image is already an image of the book")
+var ErrInvalidImageOrder = fmt.Errorf("the new order must list every image of the book once")
+var ErrBookNotPublished = fmt.Errorf("the book is not published")
+var ErrUploadNotFound = fmt.Errorf("the uploaded content does not exist")
+var ErrContentTooLarge = fmt.Errorf("the content of the book is too large to be processed")
+var ErrInvalidUpload = fmt.Errorf("the uploaded content does not match the expected type, size or checksum")
@@ -0,0 +1,166 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/log"
+)
+
+// Formats of the catalog exports and product feeds.
+const (
+	ExportFormatCSV            = "csv"
+	ExportFormatJSONL          = "jsonl"
+	ExportFormatGoogleMerchant = "google_merchant"
+)
+
+// PublicFeedFormat is the format of the product feed shared with comparison sites and ad platforms.
+const PublicFeedFormat = ExportFormatGoogleMerchant
+
+// exportBatchSize is the number of books read from the repository at once while exporting.
+const exportBatchSize = 100
+
+// ExportedBook is a book with the links to its images, as written to an export.
+type ExportedBook struct {
+	Book       Book
+	ImageLinks []string
+}
+
+// ExportWriter writes the books of an export one by one. Close must be called to complete the export.
+type ExportWriter interface {
+	Write(book ExportedBook) error
+	Close() error
+}
+
+// ExportEncoder creates the writers of the export formats.
+type ExportEncoder interface {
+	NewWriter(format string, w io.Writer) (ExportWriter, error)
+}
+
+// FeedCache keeps the last generated public feed.
+type FeedCache interface {
+	Get(ctx context.Context) (feed []byte, found bool, err error)
+	Save(ctx context.Context, feed []byte) error
+	Delete(ctx context.Context) error
+}
+
+// ExportBooks writes the whole catalog to w in the requested format. Books are read in batches, so
+// the catalog is never loaded in memory at once.
+func (c *Catalog) ExportBooks(ctx context.Context, request ExportBooks, w io.Writer) error {
+	log.Infof(ctx, "new request for exporting books as %s", request.Format)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(ExportBooks) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(ExportBooks) failed validating request: %w", err)
+	}
+
+	if err := c.exportBooks(ctx, request.Format, w); err != nil {
+		return fmt.Errorf("(ExportBooks) failed exporting books: %w", err)
+	}
+
+	return nil
+}
+
+// FindPublicFeed returns the cached public feed, generating it when it's missing or a book changed since it was cached.
+func (c *Catalog) FindPublicFeed(ctx context.Context) ([]byte, error) {
+	log.Infof(ctx, "new request for fetching public feed")
+
+	feed, found, err := c.FeedCache.Get(ctx)
+	if err != nil {
+		log.Warnf(ctx, "failed reading cached feed: %v", err)
+	}
+
+	if found {
+		return feed, nil
+	}
+
+	feed, err = c.refreshPublicFeed(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("(FindPublicFeed) failed generating feed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// RefreshPublicFeed generates the public feed and replaces the cached one.
+func (c *Catalog) RefreshPublicFeed(ctx context.Context) error {
+	if _, err := c.refreshPublicFeed(ctx); err != nil {
+		return fmt.Errorf("(RefreshPublicFeed) failed generating feed: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Catalog) refreshPublicFeed(ctx context.Context) ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := c.exportBooks(ctx, PublicFeedFormat, &buffer); err != nil {
+		return nil, err
+	}
+
+	if err := c.FeedCache.Save(ctx, buffer.Bytes()); err != nil {
+		return nil, fmt.Errorf("(refreshPublicFeed) failed caching feed: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// invalidatePublicFeed discards the cached feed after a book changes, so the next request generates it again.
+// A failure is only logged, the feed is refreshed by the scheduled job anyway.
+func (c *Catalog) invalidatePublicFeed(ctx context.Context) {
+	if err := c.FeedCache.Delete(ctx); err != nil {
+		log.Warnf(ctx, "failed invalidating cached feed: %v", err)
+	}
+}
+
+// exportBooks writes the books on sale, archived and unpublished books are left out of the exports and the feed.
+func (c *Catalog) exportBooks(ctx context.Context, format string, w io.Writer) error {
+	writer, err := c.ExportEncoder.NewWriter(format, w)
+	if err != nil {
+		return fmt.Errorf("(exportBooks) failed creating %s writer: %w", format, err)
+	}
+
+	onSale := query.New().
+		And(query.Condition{Field: "archived", Operator: query.Equal, Value: false}).
+		And(query.Condition{Field: "status", Operator: query.Equal, Value: StatusPublished})
+	page := query.Page{Size: exportBatchSize, Keyset: true}
+	for {
+		paginated, err := c.Repository.FindByQuery(ctx, *onSale, page)
+		if err != nil {
+			return fmt.Errorf("(exportBooks) failed finding books: %w", err)
+		}
+
+		for _, book := range paginated.Books {
+			// The exports are cached as the feed, so their links are signed now instead of read from the
+			// link cache, where they may be close to expire
+			links := make([]string, 0, len(book.Images))
+			for _, img := range book.Images {
+				link, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, img.ID, AssetImage)
+				if err != nil {
+					return fmt.Errorf("(exportBooks) failed generating image links: %w", err)
+				}
+				links = append(links, link)
+			}
+
+			if err = writer.Write(ExportedBook{Book: book, ImageLinks: links}); err != nil {
+				return fmt.Errorf("(exportBooks) failed writing book %s: %w", book.ID, err)
+			}
+		}
+
+		if paginated.NextCursor == "" {
+			break
+		}
+		page.Cursor = paginated.NextCursor
+	}
+
+	if err = writer.Close(); err != nil {
+		return fmt.Errorf("(exportBooks) failed completing export: %w", err)
+	}
+
+	return nil
+}
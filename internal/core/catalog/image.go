@@ -0,0 +1,182 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// orderImages numbers the images in the order they are listed and keeps a single main image: the first one
+// marked as main, or the first image when none is.
+func orderImages(images []Image) []Image {
+	main := -1
+	for i := range images {
+		if images[i].IsMain && main < 0 {
+			main = i
+		}
+		images[i].Position = i
+		images[i].IsMain = false
+	}
+
+	if len(images) > 0 {
+		if main < 0 {
+			main = 0
+		}
+		images[main].IsMain = true
+	}
+
+	return images
+}
+
+// imageIndex returns the index of the image in the images of the book, or -1 when it's not one of them.
+func (b Book) imageIndex(id string) int {
+	for i, image := range b.Images {
+		if image.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddBookImage adds an image uploaded with a presigned URL after the existing images of the book.
+func (c *Catalog) AddBookImage(ctx context.Context, request AddBookImage) (BookResponse, error) {
+	log.Infof(ctx, "new request for adding image %s to book %s", request.ID, request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed validating request: %w", err)
+	}
+
+	book, err := c.Repository.FindByID(ctx, request.BookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed finding book %s: %w", request.BookID, err)
+	}
+
+	if book.imageIndex(request.ID) >= 0 {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed adding image %s: %w", request.ID, ErrDuplicateImage)
+	}
+
+	if err = c.verifyImages(ctx, book, []ImageRequest{request.ImageRequest}); err != nil {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed verifying image: %w", err)
+	}
+
+	images := append([]Image{}, book.Images...)
+	if request.IsMain {
+		// The new image takes over as the main one, as orderImages keeps the first marked
+		for i := range images {
+			images[i].IsMain = false
+		}
+	}
+	images = append(images, request.Image(book.ID))
+
+	if err = c.Repository.SaveImages(ctx, book.ID, orderImages(images)); err != nil {
+		return BookResponse{}, fmt.Errorf("(AddBookImage) failed saving images: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return c.FindBookByID(ctx, book.ID)
+}
+
+// RemoveBookImage removes an image of the book. When it was the main image, the first remaining one takes its place.
+func (c *Catalog) RemoveBookImage(ctx context.Context, bookID, imageID string) error {
+	log.Infof(ctx, "new request for removing image %s of book %s", imageID, bookID)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(RemoveBookImage) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	book, err := c.Repository.FindByID(ctx, bookID)
+	if err != nil {
+		return fmt.Errorf("(RemoveBookImage) failed finding book %s: %w", bookID, err)
+	}
+
+	index := book.imageIndex(imageID)
+	if index < 0 {
+		return fmt.Errorf("(RemoveBookImage) failed finding image %s: %w", imageID, ErrImageNotFound)
+	}
+
+	if err = c.trackRemovedUploads(ctx, []string{imageID}); err != nil {
+		return fmt.Errorf("(RemoveBookImage) failed tracking removed image: %w", err)
+	}
+
+	images := append(append([]Image{}, book.Images[:index]...), book.Images[index+1:]...)
+	if err = c.Repository.SaveImages(ctx, bookID, orderImages(images)); err != nil {
+		return fmt.Errorf("(RemoveBookImage) failed saving images: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return nil
+}
+
+// ReorderBookImages sorts the images of the book in the requested order. The main image is not changed.
+func (c *Catalog) ReorderBookImages(ctx context.Context, request ReorderBookImages) (BookResponse, error) {
+	log.Infof(ctx, "new request for reordering the images of book %s", request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed validating request: %w", err)
+	}
+
+	book, err := c.Repository.FindByID(ctx, request.BookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed finding book %s: %w", request.BookID, err)
+	}
+
+	if len(request.ImageIDs) != len(book.Images) {
+		return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed validating order of %d images: %w", len(book.Images), ErrInvalidImageOrder)
+	}
+
+	images := make([]Image, 0, len(book.Images))
+	for _, id := range request.ImageIDs {
+		index := book.imageIndex(id)
+		if index < 0 {
+			return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed finding image %s: %w", id, ErrInvalidImageOrder)
+		}
+		images = append(images, book.Images[index])
+	}
+
+	if err = c.Repository.SaveImages(ctx, book.ID, orderImages(images)); err != nil {
+		return BookResponse{}, fmt.Errorf("(ReorderBookImages) failed saving images: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return c.FindBookByID(ctx, book.ID)
+}
+
+// SetMainBookImage chooses the image used as the cover of the book.
+func (c *Catalog) SetMainBookImage(ctx context.Context, bookID, imageID string) (BookResponse, error) {
+	log.Infof(ctx, "new request for setting image %s as main image of book %s", imageID, bookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(SetMainBookImage) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	book, err := c.Repository.FindByID(ctx, bookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(SetMainBookImage) failed finding book %s: %w", bookID, err)
+	}
+
+	index := book.imageIndex(imageID)
+	if index < 0 {
+		return BookResponse{}, fmt.Errorf("(SetMainBookImage) failed finding image %s: %w", imageID, ErrImageNotFound)
+	}
+
+	images := append([]Image{}, book.Images...)
+	for i := range images {
+		images[i].IsMain = i == index
+	}
+
+	if err = c.Repository.SaveImages(ctx, bookID, orderImages(images)); err != nil {
+		return BookResponse{}, fmt.Errorf("(SetMainBookImage) failed saving images: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return c.FindBookByID(ctx, bookID)
+}
@@ -0,0 +1,101 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// presignConcurrency is the maximum number of links signed at the same time on a cache miss.
+const presignConcurrency = 8
+
+// LinkCache keeps the presigned links of the images, so they are not signed again on every request and browsers
+// and CDNs can cache the images. Links must expire from the cache well before their signature does.
+type LinkCache interface {
+	// GetLinks returns the cached links of the keys. Keys that are not cached are missing from the result.
+	GetLinks(ctx context.Context, keys []string) (map[string]string, error)
+	SaveLinks(ctx context.Context, links map[string]string) error
+}
+
+// presignImageLinks returns the presigned links of the images stored with the keys. Cached links are reused and
+// the missing ones are signed concurrently and cached. The cache is only an optimization, so its failures are logged.
+func (c *Catalog) presignImageLinks(ctx context.Context, keys []string) (map[string]string, error) {
+	if len(keys) == 0 {
+		return map[string]string{}, nil
+	}
+
+	links, err := c.LinkCache.GetLinks(ctx, keys)
+	if err != nil {
+		log.Warnf(ctx, "failed reading cached image links: %v", err)
+	}
+	if links == nil {
+		links = make(map[string]string, len(keys))
+	}
+
+	var missing []string
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if _, ok := links[key]; !ok && !seen[key] {
+			missing = append(missing, key)
+		}
+		seen[key] = true
+	}
+	if len(missing) == 0 {
+		return links, nil
+	}
+
+	signed, err := c.presignConcurrently(ctx, missing)
+	if err != nil {
+		return nil, fmt.Errorf("(presignImageLinks) failed signing %d links: %w", len(missing), err)
+	}
+
+	if err = c.LinkCache.SaveLinks(ctx, signed); err != nil {
+		log.Warnf(ctx, "failed caching image links: %v", err)
+	}
+
+	for key, link := range signed {
+		links[key] = link
+	}
+	return links, nil
+}
+
+// presignConcurrently signs the links of the keys with at most presignConcurrency signatures at the same time.
+// The first failure is returned once every signature has finished.
+func (c *Catalog) presignConcurrently(ctx context.Context, keys []string) (map[string]string, error) {
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	signed := make(map[string]string, len(keys))
+	slots := make(chan struct{}, presignConcurrency)
+
+	for _, key := range keys {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			link, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, key, AssetImage)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("(presignConcurrently) failed generating presigned url for %s: %w", key, err)
+				}
+				return
+			}
+			signed[key] = link
+		}(key)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return signed, nil
+}
@@ -0,0 +1,162 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// VariantName identifies a resized version of the images.
+type VariantName string
+
+const (
+	VariantThumbnail VariantName = "thumbnail"
+	VariantMedium    VariantName = "medium"
+	VariantLarge     VariantName = "large"
+	// VariantOriginal is the image as it was uploaded. It's never generated.
+	VariantOriginal VariantName = "original"
+)
+
+// imageVariantSizes are the generated variants with the maximum length of their longest side in pixels.
+var imageVariantSizes = []struct {
+	Name    VariantName
+	MaxSize int
+}{
+	{Name: VariantThumbnail, MaxSize: 200},
+	{Name: VariantMedium, MaxSize: 600},
+	{Name: VariantLarge, MaxSize: 1200},
+}
+
+// imageVariantsBatchSize is the number of images processed on each run of GenerateImageVariants.
+const imageVariantsBatchSize = 20
+
+// ImageVariant is a resized version of an image, stored under its own ContentID.
+type ImageVariant struct {
+	ImageID   string      `gorm:"primaryKey"`
+	Name      VariantName `gorm:"primaryKey"`
+	ContentID string
+	MediaType string
+	Width     int
+	Height    int
+	CreatedAt time.Time
+}
+
+// VariantKey is the storage key of the variant of an image, derived from the key of the original.
+func VariantKey(imageID string, name VariantName) string {
+	return fmt.Sprintf("variants/%s/%s", imageID, name)
+}
+
+// Variant returns the variant of the image with the given name.
+func (i Image) Variant(name VariantName) (ImageVariant, bool) {
+	for _, v := range i.Variants {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return ImageVariant{}, false
+}
+
+// ResizedImage is an image scaled down to fit a maximum size and encoded again.
+type ResizedImage struct {
+	MaxSize   int
+	Data      []byte
+	MediaType string
+	Width     int
+	Height    int
+}
+
+// ImageResizer scales the uploaded images down. The image is decoded once and resized to fit each of the sizes,
+// keeping its aspect ratio, and the results are returned in the order of the sizes. Images are never scaled up.
+type ImageResizer interface {
+	Resize(r io.Reader, sizes []int) ([]ResizedImage, error)
+}
+
+// ImageRepository keeps track of the variants generated for the images.
+type ImageRepository interface {
+	// FindWithoutVariants returns the images whose variants have not been generated yet, the ones that never failed
+	// first and then the ones that failed the longest ago.
+	FindWithoutVariants(ctx context.Context, limit int) ([]Image, error)
+	// SaveVariants replaces the variants of the image and marks it as processed, even when it has no variants.
+	SaveVariants(ctx context.Context, imageID string, variants []ImageVariant) error
+	// MarkVariantsFailed records that generating the variants of the image failed, which moves it to the end of the queue.
+	MarkVariantsFailed(ctx context.Context, imageID string) error
+}
+
+// GenerateImageVariants generates the variants of the images uploaded since the last run. Images that cannot be
+// decoded are marked as processed without variants, so they are served as uploaded and not retried. Other failures
+// are retried after the images waiting behind them, so a few broken images don't block the queue.
+func (c *Catalog) GenerateImageVariants(ctx context.Context) error {
+	images, err := c.ImageRepository.FindWithoutVariants(ctx, imageVariantsBatchSize)
+	if err != nil {
+		return fmt.Errorf("(GenerateImageVariants) failed finding images: %w", err)
+	}
+
+	for _, image := range images {
+		variants, err := c.generateImageVariants(ctx, image.ID)
+		if errors.Is(err, ErrInvalidImage) {
+			log.Warnf(ctx, "image %s has no variants: %v", image.ID, err)
+		} else if err != nil {
+			log.Errorf(ctx, "failed generating variants of image %s: %v", image.ID, err)
+			c.markVariantsFailed(ctx, image.ID)
+			continue
+		}
+
+		if err = c.ImageRepository.SaveVariants(ctx, image.ID, variants); err != nil {
+			log.Errorf(ctx, "failed saving variants of image %s: %v", image.ID, err)
+			c.markVariantsFailed(ctx, image.ID)
+		}
+	}
+
+	if len(images) > 0 {
+		log.Infof(ctx, "processed variants of %d images", len(images))
+	}
+
+	return nil
+}
+
+func (c *Catalog) markVariantsFailed(ctx context.Context, imageID string) {
+	if err := c.ImageRepository.MarkVariantsFailed(ctx, imageID); err != nil {
+		log.Errorf(ctx, "failed recording the failure of image %s: %v", imageID, err)
+	}
+}
+
+func (c *Catalog) generateImageVariants(ctx context.Context, imageID string) ([]ImageVariant, error) {
+	original, err := c.StorageClient.GetObject(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("(generateImageVariants) failed reading image: %w", err)
+	}
+	defer original.Close()
+
+	sizes := make([]int, 0, len(imageVariantSizes))
+	for _, s := range imageVariantSizes {
+		sizes = append(sizes, s.MaxSize)
+	}
+
+	resized, err := c.ImageResizer.Resize(original, sizes)
+	if err != nil {
+		return nil, fmt.Errorf("(generateImageVariants) failed resizing image: %w", err)
+	}
+
+	variants := make([]ImageVariant, 0, len(resized))
+	for i, r := range resized {
+		variant := ImageVariant{
+			ImageID:   imageID,
+			Name:      imageVariantSizes[i].Name,
+			ContentID: VariantKey(imageID, imageVariantSizes[i].Name),
+			MediaType: r.MediaType,
+			Width:     r.Width,
+			Height:    r.Height,
+		}
+		if err = c.StorageClient.PutObject(ctx, variant.ContentID, variant.MediaType, bytes.NewReader(r.Data)); err != nil {
+			return nil, fmt.Errorf("(generateImageVariants) failed storing %s variant: %w", variant.Name, err)
+		}
+		variants = append(variants, variant)
+	}
+
+	return variants, nil
+}
@@ -0,0 +1,324 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/log"
+)
+
+// Formats of the catalog files sent by publishers.
+const (
+	ImportFormatCSV  = "csv"
+	ImportFormatONIX = "onix"
+)
+
+type ImportStatus string
+
+const (
+	ImportCreated ImportStatus = "created"
+	ImportUpdated ImportStatus = "updated"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// ImportRow is a book read from a catalog file. It's matched with the existing books by ISBN or,
+// when the ISBN is missing or unknown, by ExternalID, the id the publisher uses for the book.
+type ImportRow struct {
+	Line         int
+	ExternalID   string              `validate:"required_without=ISBN,max=100"`
+	ISBN         string              `validate:"omitempty,book_isbn"`
+	Title        string              `validate:"required,max=100"`
+	Subtitle     string              `validate:"max=200"`
+	Description  string              `validate:"required"`
+	Contributors []ImportContributor `validate:"required,min=1,dive"`
+	Publisher    string              `validate:"max=100"`
+	Language     string              `validate:"omitempty,bcp47_language_tag"`
+	PageCount    int                 `validate:"gte=0"`
+	Edition      string              `validate:"max=50"`
+	Price        int                 `validate:"required,gt=0"`
+	ReleaseDate  time.Time           `validate:"required"`
+	// Categories are the slugs of the categories of the book
+	Categories []string `validate:"omitempty,dive,required"`
+	// Errors are the problems found while reading the row from the file. Rows with errors are not imported.
+	Errors []string `validate:"-"`
+}
+
+// ImportContributor is an author credited in an ImportRow. Authors are matched by the slug of their name.
+type ImportContributor struct {
+	Name string     `validate:"required,max=100"`
+	Role AuthorRole `validate:"required,oneof=author translator illustrator"`
+}
+
+// apply copies the data of the row into the book. Data that is not part of the row is kept.
+func (r ImportRow) apply(book Book) Book {
+	book.Title = r.Title
+	book.Subtitle = r.Subtitle
+	book.Description = r.Description
+	book.Publisher = r.Publisher
+	book.Language = r.Language
+	book.PageCount = r.PageCount
+	book.Edition = r.Edition
+	book.Price = r.Price
+	book.ReleaseDate = r.ReleaseDate
+
+	if isbn := normalizedISBN(r.ISBN); isbn != nil {
+		book.ISBN = isbn
+	}
+
+	if r.ExternalID != "" {
+		externalID := r.ExternalID
+		book.ExternalID = &externalID
+	}
+
+	return book
+}
+
+type ImportRowResult struct {
+	Line       int
+	BookID     string
+	ISBN       string
+	ExternalID string
+	Title      string
+	Status     ImportStatus
+	Errors     []string
+}
+
+type ImportReport struct {
+	DryRun bool
+	Rows   []ImportRowResult
+}
+
+func (r ImportReport) Count(status ImportStatus) int {
+	count := 0
+	for _, row := range r.Rows {
+		if row.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// ImportBooks creates or updates the books of a catalog file. Every row is imported on its own,
+// so a failed row doesn't prevent the others from being imported. Nothing is written in dry-run mode.
+func (c *Catalog) ImportBooks(ctx context.Context, request ImportBooks) (ImportReportResponse, error) {
+	log.Infof(ctx, "new request for importing books")
+
+	if !isAdmin(ctx) {
+		return ImportReportResponse{}, fmt.Errorf("(ImportBooks) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return ImportReportResponse{}, fmt.Errorf("(ImportBooks) failed validating request: %w", err)
+	}
+
+	rows, err := c.ImportParser.Parse(request.Format, request.File)
+	if err != nil {
+		return ImportReportResponse{}, fmt.Errorf("(ImportBooks) failed parsing file: %w", err)
+	}
+
+	categories, err := c.CategoryRepository.FindAll(ctx)
+	if err != nil {
+		return ImportReportResponse{}, fmt.Errorf("(ImportBooks) failed finding categories: %w", err)
+	}
+
+	importer := newBookImporter(c, categories, request.DryRun)
+	report := ImportReport{DryRun: request.DryRun, Rows: make([]ImportRowResult, 0, len(rows))}
+	for _, row := range rows {
+		report.Rows = append(report.Rows, importer.importRow(ctx, row))
+	}
+
+	if !request.DryRun && report.Count(ImportCreated)+report.Count(ImportUpdated) > 0 {
+		c.invalidatePublicFeed(ctx)
+	}
+
+	log.Infof(ctx, "books imported: %d created, %d updated, %d failed",
+		report.Count(ImportCreated), report.Count(ImportUpdated), report.Count(ImportFailed))
+
+	return NewImportReportResponse(report), nil
+}
+
+// bookImporter keeps the state shared by the rows of an import.
+type bookImporter struct {
+	*Catalog
+	dryRun           bool
+	categoriesBySlug map[string]Category
+	authorsBySlug    map[string]Author
+}
+
+func newBookImporter(c *Catalog, categories []Category, dryRun bool) *bookImporter {
+	categoriesBySlug := make(map[string]Category, len(categories))
+	for _, category := range categories {
+		categoriesBySlug[category.Slug] = category
+	}
+
+	return &bookImporter{
+		Catalog:          c,
+		dryRun:           dryRun,
+		categoriesBySlug: categoriesBySlug,
+		authorsBySlug:    make(map[string]Author),
+	}
+}
+
+func (i *bookImporter) importRow(ctx context.Context, row ImportRow) ImportRowResult {
+	result := ImportRowResult{Line: row.Line, ISBN: row.ISBN, ExternalID: row.ExternalID, Title: row.Title}
+	fail := func(errs ...string) ImportRowResult {
+		result.Status = ImportFailed
+		result.Errors = errs
+		return result
+	}
+
+	if len(row.Errors) > 0 {
+		return fail(row.Errors...)
+	}
+
+	if err := i.Validator.Validate(row); err != nil {
+		return fail(validationErrors(err)...)
+	}
+
+	book, found, err := i.findBook(ctx, row)
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	result.Status = ImportUpdated
+	if !found {
+		// Catalog files don't carry the content of the books, so they stay drafts until their files are uploaded
+		book = Book{ID: i.IDGenerator.NewID(), Status: StatusDraft}
+		result.Status = ImportCreated
+	}
+	book = row.apply(book)
+
+	if len(row.Categories) > 0 {
+		if book.Categories, err = i.findCategories(row.Categories); err != nil {
+			return fail(err.Error())
+		}
+	}
+
+	if book.Authors, err = i.findOrCreateAuthors(ctx, book.ID, row.Contributors); err != nil {
+		return fail(err.Error())
+	}
+
+	if i.dryRun {
+		if found {
+			result.BookID = book.ID
+		}
+		return result
+	}
+
+	if found {
+		err = i.Repository.Update(ctx, &book)
+	} else {
+		err = i.Repository.Create(ctx, &book)
+	}
+	if err != nil {
+		return fail(err.Error())
+	}
+
+	result.BookID = book.ID
+	return result
+}
+
+func (i *bookImporter) findBook(ctx context.Context, row ImportRow) (Book, bool, error) {
+	if isbn := normalizedISBN(row.ISBN); isbn != nil {
+		book, found, err := i.findBookBy(ctx, "isbn", *isbn)
+		if err != nil || found {
+			return book, found, err
+		}
+	}
+
+	if row.ExternalID != "" {
+		return i.findBookBy(ctx, "external_id", row.ExternalID)
+	}
+
+	return Book{}, false, nil
+}
+
+func (i *bookImporter) findBookBy(ctx context.Context, field, value string) (Book, bool, error) {
+	q := query.New().And(query.Condition{Field: field, Operator: query.Equal, Value: value})
+	paginated, err := i.Repository.FindByQuery(ctx, *q, query.Page{Number: 1, Size: 1})
+	if err != nil {
+		return Book{}, false, fmt.Errorf("(findBookBy) failed finding book by %s: %w", field, err)
+	}
+
+	if len(paginated.Books) == 0 {
+		return Book{}, false, nil
+	}
+
+	return paginated.Books[0], true, nil
+}
+
+func (i *bookImporter) findCategories(slugs []string) ([]Category, error) {
+	categories := make([]Category, 0, len(slugs))
+	for _, slug := range uniqueStrings(slugs) {
+		category, ok := i.categoriesBySlug[Slugify(slug)]
+		if !ok {
+			return nil, fmt.Errorf("(findCategories) failed finding category %s: %w", slug, ErrUnknownCategory)
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// findOrCreateAuthors links the contributors to the book. The authors that don't exist yet are only kept in memory,
+// the repository creates them along with the book, so a row that fails leaves no authors behind.
+func (i *bookImporter) findOrCreateAuthors(ctx context.Context, bookID string, contributors []ImportContributor) ([]BookAuthor, error) {
+	var missing []string
+	for _, contributor := range contributors {
+		if _, ok := i.authorsBySlug[Slugify(contributor.Name)]; !ok {
+			missing = append(missing, Slugify(contributor.Name))
+		}
+	}
+
+	if len(missing) > 0 {
+		authors, err := i.AuthorRepository.FindBySlugs(ctx, uniqueStrings(missing))
+		if err != nil {
+			return nil, fmt.Errorf("(findOrCreateAuthors) failed finding authors: %w", err)
+		}
+
+		for _, author := range authors {
+			i.authorsBySlug[author.Slug] = author
+		}
+	}
+
+	bookAuthors := make([]BookAuthor, 0, len(contributors))
+	credited := make(map[string]bool, len(contributors))
+	for _, contributor := range contributors {
+		slug := Slugify(contributor.Name)
+		author, ok := i.authorsBySlug[slug]
+		if !ok {
+			author = Author{ID: i.IDGenerator.NewID(), Name: contributor.Name, Slug: slug}
+			i.authorsBySlug[slug] = author
+		}
+
+		// The same person can be listed twice in a file, but it's credited only once per role
+		key := author.ID + "/" + string(contributor.Role)
+		if credited[key] {
+			continue
+		}
+		credited[key] = true
+
+		bookAuthors = append(bookAuthors, BookAuthor{
+			BookID:   bookID,
+			AuthorID: author.ID,
+			Role:     contributor.Role,
+			Position: len(bookAuthors),
+			Author:   author,
+		})
+	}
+
+	return bookAuthors, nil
+}
+
+// validationErrors splits the error of the Validator into one reason per invalid field.
+func validationErrors(err error) []string {
+	if unwrapped := errors.Unwrap(err); unwrapped != nil {
+		err = unwrapped
+	}
+
+	return strings.Split(err.Error(), "\n")
+}
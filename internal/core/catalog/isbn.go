@@ -0,0 +1,80 @@
+package catalog
+
+import (
+	"strings"
+)
+
+// NormalizeISBN validates an ISBN-10 or ISBN-13 and converts it to the ISBN-13 form without separators.
+// Hyphens and spaces are ignored, so "0-321-12521-5" and "978-0321125217" are normalized to "9780321125217".
+func NormalizeISBN(isbn string) (string, error) {
+	digits := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(isbn))
+
+	switch {
+	case len(digits) == 10 && validISBN10(digits):
+		isbn13 := "978" + digits[:9]
+		return isbn13 + string(isbn13CheckDigit(isbn13)), nil
+	case len(digits) == 13 && validISBN13(digits):
+		return digits, nil
+	default:
+		return "", ErrInvalidISBN
+	}
+}
+
+// ISBN10 returns the ISBN-10 form of a normalized ISBN-13. Only ISBNs with the 978 prefix have one.
+func ISBN10(isbn13 string) string {
+	if len(isbn13) != 13 || !strings.HasPrefix(isbn13, "978") {
+		return ""
+	}
+
+	sum := 0
+	for i, r := range isbn13[3:12] {
+		sum += int(r-'0') * (10 - i)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return isbn13[3:12] + "X"
+	}
+	return isbn13[3:12] + string(rune('0'+check))
+}
+
+func validISBN10(digits string) bool {
+	sum := 0
+	for i, r := range digits {
+		var value int
+		switch {
+		case r >= '0' && r <= '9':
+			value = int(r - '0')
+		case r == 'X' && i == 9:
+			value = 10
+		default:
+			return false
+		}
+		sum += value * (10 - i)
+	}
+
+	return sum%11 == 0
+}
+
+func validISBN13(digits string) bool {
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return isbn13CheckDigit(digits[:12]) == rune(digits[12])
+}
+
+func isbn13CheckDigit(first12 string) rune {
+	sum := 0
+	for i, r := range first12 {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += int(r-'0') * weight
+	}
+
+	return rune('0' + (10-sum%10)%10)
+}
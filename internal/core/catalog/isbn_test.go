@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeISBN(t *testing.T) {
+	tests := []struct {
+		Name          string
+		ISBN          string
+		Expected      string
+		ExpectedError error
+	}{
+		{Name: "isbn-13 with hyphens", ISBN: "978-0-321-12521-7", Expected: "9780321125217"},
+		{Name: "isbn-13 with spaces", ISBN: "978 0 321 12521 7", Expected: "9780321125217"},
+		{Name: "isbn-10 is converted", ISBN: "0-321-12521-5", Expected: "9780321125217"},
+		{Name: "isbn-10 with X check digit", ISBN: "0-8044-2957-x", Expected: "9780804429573"},
+		{Name: "isbn-13 with wrong checksum", ISBN: "9780321125218", ExpectedError: ErrInvalidISBN},
+		{Name: "isbn-10 with wrong checksum", ISBN: "0321125216", ExpectedError: ErrInvalidISBN},
+		{Name: "X is only allowed as the isbn-10 check digit", ISBN: "03211X5215", ExpectedError: ErrInvalidISBN},
+		{Name: "wrong length", ISBN: "978032112521", ExpectedError: ErrInvalidISBN},
+		{Name: "empty", ISBN: "", ExpectedError: ErrInvalidISBN},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := NormalizeISBN(tc.ISBN)
+			assert.Equal(t, tc.ExpectedError, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestISBN10(t *testing.T) {
+	assert.Equal(t, "0321125215", ISBN10("9780321125217"))
+	assert.Equal(t, "080442957X", ISBN10("9780804429573"))
+	assert.Equal(t, "", ISBN10("9791032300824"))
+}
@@ -4,6 +4,7 @@ package catalog
 
 import (
 	context "context"
+	time "time"
 
 	query "github.com/ebookstore/internal/core/query"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,24 @@ type MockRepository struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields: ctx, id
+func (_m *MockRepository) Archive(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, book
 func (_m *MockRepository) Create(ctx context.Context, book *Book) error {
 	ret := _m.Called(ctx, book)
@@ -50,6 +69,24 @@ func (_m *MockRepository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
+// DeleteFile provides a mock function with given fields: ctx, bookID, format
+func (_m *MockRepository) DeleteFile(ctx context.Context, bookID string, format BookFormat) error {
+	ret := _m.Called(ctx, bookID, format)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, BookFormat) error); ok {
+		r0 = rf(ctx, bookID, format)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *MockRepository) FindByID(ctx context.Context, id string) (Book, error) {
 	ret := _m.Called(ctx, id)
@@ -106,6 +143,106 @@ func (_m *MockRepository) FindByQuery(ctx context.Context, _a1 query.Query, page
 	return r0, r1
 }
 
+// PublishScheduled provides a mock function with given fields: ctx, now
+func (_m *MockRepository) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishScheduled")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *MockRepository) Restore(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Restore")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveFile provides a mock function with given fields: ctx, file
+func (_m *MockRepository) SaveFile(ctx context.Context, file *BookFile) error {
+	ret := _m.Called(ctx, file)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BookFile) error); ok {
+		r0 = rf(ctx, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveImages provides a mock function with given fields: ctx, bookID, images
+func (_m *MockRepository) SaveImages(ctx context.Context, bookID string, images []Image) error {
+	ret := _m.Called(ctx, bookID, images)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveImages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []Image) error); ok {
+		r0 = rf(ctx, bookID, images)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSample provides a mock function with given fields: ctx, sample
+func (_m *MockRepository) SaveSample(ctx context.Context, sample *BookSample) error {
+	ret := _m.Called(ctx, sample)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSample")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *BookSample) error); ok {
+		r0 = rf(ctx, sample)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, book
 func (_m *MockRepository) Update(ctx context.Context, book *Book) error {
 	ret := _m.Called(ctx, book)
@@ -124,6 +261,24 @@ func (_m *MockRepository) Update(ctx context.Context, book *Book) error {
 	return r0
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, status, publishAt
+func (_m *MockRepository) UpdateStatus(ctx context.Context, id string, status PublicationStatus, publishAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, PublicationStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRepository(t interface {
@@ -4,6 +4,7 @@ package catalog
 
 import (
 	context "context"
+	io "io"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,9 +14,27 @@ type MockStorageClient struct {
 	mock.Mock
 }
 
-// GenerateGetPreSignedUrl provides a mock function with given fields: ctx, key
-func (_m *MockStorageClient) GenerateGetPreSignedUrl(ctx context.Context, key string) (string, error) {
-	ret := _m.Called(ctx, key)
+// DeleteObjects provides a mock function with given fields: ctx, keys
+func (_m *MockStorageClient) DeleteObjects(ctx context.Context, keys []string) error {
+	ret := _m.Called(ctx, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteObjects")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GenerateGetPreSignedUrl provides a mock function with given fields: ctx, key, asset
+func (_m *MockStorageClient) GenerateGetPreSignedUrl(ctx context.Context, key string, asset Asset) (string, error) {
+	ret := _m.Called(ctx, key, asset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GenerateGetPreSignedUrl")
@@ -23,13 +42,71 @@ func (_m *MockStorageClient) GenerateGetPreSignedUrl(ctx context.Context, key st
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, Asset) (string, error)); ok {
+		return rf(ctx, key, asset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, Asset) string); ok {
+		r0 = rf(ctx, key, asset)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, Asset) error); ok {
+		r1 = rf(ctx, key, asset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GeneratePutPreSignedUrl provides a mock function with given fields: ctx, key, object
+func (_m *MockStorageClient) GeneratePutPreSignedUrl(ctx context.Context, key string, object ObjectInfo) (string, error) {
+	ret := _m.Called(ctx, key, object)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GeneratePutPreSignedUrl")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ObjectInfo) (string, error)); ok {
+		return rf(ctx, key, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ObjectInfo) string); ok {
+		r0 = rf(ctx, key, object)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ObjectInfo) error); ok {
+		r1 = rf(ctx, key, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetObject provides a mock function with given fields: ctx, key
+func (_m *MockStorageClient) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObject")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
 		return rf(ctx, key)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
 		r0 = rf(ctx, key)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -41,23 +118,53 @@ func (_m *MockStorageClient) GenerateGetPreSignedUrl(ctx context.Context, key st
 	return r0, r1
 }
 
-// GeneratePutPreSignedUrl provides a mock function with given fields: ctx, key
-func (_m *MockStorageClient) GeneratePutPreSignedUrl(ctx context.Context, key string) (string, error) {
+// GetObjectRange provides a mock function with given fields: ctx, key, offset
+func (_m *MockStorageClient) GetObjectRange(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectRange")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, key, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, key, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, key, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HeadObject provides a mock function with given fields: ctx, key
+func (_m *MockStorageClient) HeadObject(ctx context.Context, key string) (ObjectInfo, error) {
 	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GeneratePutPreSignedUrl")
+		panic("no return value specified for HeadObject")
 	}
 
-	var r0 string
+	var r0 ObjectInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ObjectInfo, error)); ok {
 		return rf(ctx, key)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) ObjectInfo); ok {
 		r0 = rf(ctx, key)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(ObjectInfo)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -69,6 +176,54 @@ func (_m *MockStorageClient) GeneratePutPreSignedUrl(ctx context.Context, key st
 	return r0, r1
 }
 
+// ListObjects provides a mock function with given fields: ctx, prefix
+func (_m *MockStorageClient) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListObjects")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PutObject provides a mock function with given fields: ctx, key, contentType, body
+func (_m *MockStorageClient) PutObject(ctx context.Context, key string, contentType string, body io.Reader) error {
+	ret := _m.Called(ctx, key, contentType, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutObject")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) error); ok {
+		r0 = rf(ctx, key, contentType, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockStorageClient creates a new instance of MockStorageClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockStorageClient(t interface {
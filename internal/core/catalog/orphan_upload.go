@@ -0,0 +1,160 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// orphanUploadsBatchSize is the maximum number of orphan uploads deleted, or reported on a dry run, on each run.
+const orphanUploadsBatchSize = 500
+
+// Upload is an object of the storage that may not be referenced by the catalog. Presigned uploads are tracked
+// from the time the URL is generated, and removed images from the time they are removed.
+type Upload struct {
+	Key       string `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+// UploadRepository keeps track of the uploads until they are found to be referenced or are deleted as orphans.
+type UploadRepository interface {
+	// Track starts tracking the objects, or tracks them again from now when they are already tracked.
+	Track(ctx context.Context, keys []string) error
+	// FindOrphans returns the uploads tracked before the given time that no book file, sample, image or author photo references.
+	FindOrphans(ctx context.Context, before time.Time, limit int) ([]Upload, error)
+	// UntrackReferenced stops tracking the uploads tracked before the given time that are referenced and returns how many.
+	UntrackReferenced(ctx context.Context, before time.Time) (int64, error)
+	Untrack(ctx context.Context, keys []string) error
+}
+
+// CollectOrphanUploads deletes the uploads older than the grace period that nothing references, along with the
+// variants generated for them and the copies stamped or encrypted from them. Referenced uploads are no longer tracked.
+// A dry run only reports the orphan uploads.
+func (c *Catalog) CollectOrphanUploads(ctx context.Context, request CollectOrphanUploads) (OrphanUploadsReportResponse, error) {
+	before := time.Now().Add(-request.GracePeriod)
+
+	orphans, err := c.UploadRepository.FindOrphans(ctx, before, orphanUploadsBatchSize)
+	if err != nil {
+		return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed finding orphan uploads: %w", err)
+	}
+
+	report := NewOrphanUploadsReportResponse(request.DryRun, orphans)
+	if request.DryRun {
+		return report, nil
+	}
+
+	if len(orphans) > 0 {
+		keys := make([]string, 0, len(orphans))
+		objects := make([]string, 0, len(orphans)*(len(imageVariantSizes)+1))
+		for _, orphan := range orphans {
+			keys = append(keys, orphan.Key)
+			objects = append(objects, orphan.Key)
+			for _, v := range imageVariantSizes {
+				objects = append(objects, VariantKey(orphan.Key, v.Name))
+			}
+
+			copies, err := c.uploadCopies(ctx, orphan.Key)
+			if err != nil {
+				return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed listing copies of %s: %w", orphan.Key, err)
+			}
+			objects = append(objects, copies...)
+		}
+
+		if err = c.StorageClient.DeleteObjects(ctx, objects); err != nil {
+			return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed deleting orphan uploads: %w", err)
+		}
+
+		if err = c.ProtectedContentRepository.Delete(ctx, keys); err != nil {
+			return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed deleting protected contents: %w", err)
+		}
+
+		if err = c.UploadRepository.Untrack(ctx, keys); err != nil {
+			return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed untracking deleted uploads: %w", err)
+		}
+		log.Infof(ctx, "deleted %d orphan uploads", len(orphans))
+	}
+
+	if report.Referenced, err = c.UploadRepository.UntrackReferenced(ctx, before); err != nil {
+		return OrphanUploadsReportResponse{}, fmt.Errorf("(CollectOrphanUploads) failed untracking referenced uploads: %w", err)
+	}
+
+	return report, nil
+}
+
+// uploadCopies returns the keys of the copies stamped for the buyers of the upload and of its encrypted packages.
+func (c *Catalog) uploadCopies(ctx context.Context, key string) ([]string, error) {
+	copies, err := c.StorageClient.ListObjects(ctx, watermarkPrefix(key))
+	if err != nil {
+		return nil, fmt.Errorf("(uploadCopies) failed listing stamped copies: %w", err)
+	}
+
+	prefix := protectedPrefix(key)
+	packages, err := c.StorageClient.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("(uploadCopies) failed listing encrypted packages: %w", err)
+	}
+	for _, p := range packages {
+		// the prefix also starts the keys of the packages of the uploads whose key starts with this one
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			copies = append(copies, p)
+		}
+	}
+
+	return copies, nil
+}
+
+// removedImageKeys returns the keys of the images of the book that are not kept in the given images.
+func removedImageKeys(book Book, images []Image) []string {
+	kept := make(map[string]bool, len(images))
+	for _, image := range images {
+		kept[image.ID] = true
+	}
+
+	var removed []string
+	for _, image := range book.Images {
+		if !kept[image.ID] {
+			removed = append(removed, image.ID)
+		}
+	}
+	return removed
+}
+
+// replacedKey returns the previous key when it's replaced by another one.
+func replacedKey(previous, current string) []string {
+	if previous == "" || previous == current {
+		return nil
+	}
+	return []string{previous}
+}
+
+// bookUploadKeys returns the keys of every image, file and sample of the book.
+func bookUploadKeys(book Book) []string {
+	keys := make([]string, 0, len(book.Images)+len(book.Files)+1)
+	for _, image := range book.Images {
+		keys = append(keys, image.ID)
+	}
+	for _, file := range book.Files {
+		keys = append(keys, file.ContentID)
+	}
+	if book.Sample != nil {
+		keys = append(keys, book.Sample.ContentID)
+	}
+	return keys
+}
+
+// trackRemovedUploads tracks objects that are no longer referenced, so they are collected as orphans. Objects that
+// are still referenced when they are collected, because the change failed or they are used elsewhere, are kept.
+func (c *Catalog) trackRemovedUploads(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := c.UploadRepository.Track(ctx, keys); err != nil {
+		return fmt.Errorf("(trackRemovedUploads) failed tracking %d uploads: %w", len(keys), err)
+	}
+
+	return nil
+}
@@ -5,4 +5,10 @@ type PaginatedBooks struct {
 	Limit      int
 	Offset     int
 	TotalBooks int64
+	// Keyset is true when the books were fetched using cursor pagination
+	Keyset bool
+	// IncludeTotal is true when TotalBooks was also calculated for a keyset page
+	IncludeTotal bool
+	NextCursor   string
+	PrevCursor   string
 }
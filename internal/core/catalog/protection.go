@@ -0,0 +1,173 @@
+package catalog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// ProtectedContent is the EPUB of a protected book encrypted with its content key. It's encrypted once, from the
+// file with the ContentID, and delivered to every buyer, who get the content key in their license.
+type ProtectedContent struct {
+	ContentID string `gorm:"primaryKey"`
+	BookID    string
+	// PackageKey is the key of the encrypted package in the storage
+	PackageKey string
+	// Key is the content key sealed by the ContentProtector. It's never stored in clear
+	Key []byte
+	// Size and Checksum, the SHA-256 in hex, describe the encrypted package
+	Size      int64
+	Checksum  string
+	CreatedAt time.Time
+}
+
+// ProtectedBookContent is the protected content of a book with a temporary link to it.
+type ProtectedBookContent struct {
+	ProtectedContent
+	URL string
+}
+
+// ContentProtector encrypts the EPUBs with a new content key each.
+type ContentProtector interface {
+	// Protect writes the EPUB read from r encrypted to w, and returns its content key sealed.
+	Protect(r io.Reader, w io.Writer) ([]byte, error)
+}
+
+type ProtectedContentRepository interface {
+	// FindByContentID returns the protected content encrypted from the file, or nil when it was not encrypted yet.
+	FindByContentID(ctx context.Context, contentID string) (*ProtectedContent, error)
+	// Create saves the protected content unless the file was already encrypted, so the first saved content key is kept.
+	Create(ctx context.Context, content *ProtectedContent) error
+	// Delete deletes the protected contents encrypted from the files.
+	Delete(ctx context.Context, contentIDs []string) error
+}
+
+// ProtectedKey is the key of an encrypted package of the content. A new upload of the content gets a new key, so it's
+// encrypted again, and each encryption stores its package under its own key, so none overwrites another.
+func ProtectedKey(contentID, packageID string) string {
+	return fmt.Sprintf("%s/%s", protectedPrefix(contentID), packageID)
+}
+
+// protectedPrefix starts the keys of every package encrypted from the content. The packages encrypted before each
+// one got its own key were stored with the prefix as key.
+func protectedPrefix(contentID string) string {
+	return fmt.Sprintf("protected/%s", contentID)
+}
+
+// validateProtection checks a protected book has an EPUB, the only format delivered encrypted.
+func validateProtection(book Book) error {
+	if _, ok := book.File(FormatEPUB); book.Protected && !ok {
+		return ErrProtectedBookWithoutEPUB
+	}
+	return nil
+}
+
+// GetProtectedBookContent returns the encrypted EPUB of a protected book with a link to it, encrypting it on the first
+// request. Protected books are only delivered in this form, so buyers need a license to read them.
+func (c *Catalog) GetProtectedBookContent(ctx context.Context, id string) (ProtectedBookContent, error) {
+	log.Infof(ctx, "new request for getting protected content of book %s", id)
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed finding book %s: %w", id, err)
+	}
+
+	if !book.Protected {
+		return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed validating book %s: %w", id, ErrBookNotProtected)
+	}
+
+	file, ok := book.File(FormatEPUB)
+	if !ok {
+		return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed finding epub file of book %s: %w", id, ErrBookFormatNotAvailable)
+	}
+
+	content, err := c.ProtectedContentRepository.FindByContentID(ctx, file.ContentID)
+	if err != nil {
+		return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed finding protected content %s: %w", file.ContentID, err)
+	}
+
+	if content == nil {
+		if content, err = c.protectContent(ctx, file); err != nil {
+			return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed protecting content: %w", err)
+		}
+	}
+
+	url, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, content.PackageKey, AssetBookFile)
+	if err != nil {
+		return ProtectedBookContent{}, fmt.Errorf("(GetProtectedBookContent) failed generating presigned url: %w", err)
+	}
+
+	return ProtectedBookContent{ProtectedContent: *content, URL: url}, nil
+}
+
+// protectContent encrypts the EPUB file and stores the package before its content key. When the file is encrypted
+// concurrently, the first saved content key is kept along with its package, and the other packages are discarded.
+func (c *Catalog) protectContent(ctx context.Context, file BookFile) (*ProtectedContent, error) {
+	epub, err := c.openProcessedContent(ctx, file)
+	if err != nil {
+		return nil, fmt.Errorf("(protectContent) failed reading content %s: %w", file.ContentID, err)
+	}
+	defer epub.Close()
+
+	// the package is written to a temporary file rather than held in memory, since EPUBs may be large
+	out, err := ioutil.TempFile("", "protected-*")
+	if err != nil {
+		return nil, fmt.Errorf("(protectContent) failed creating temporary file: %w", err)
+	}
+	defer os.Remove(out.Name())
+	defer out.Close()
+
+	hash := sha256.New()
+	sealed, err := c.ContentProtector.Protect(epub, io.MultiWriter(out, hash))
+	if err != nil {
+		return nil, fmt.Errorf("(protectContent) failed encrypting content %s: %w", file.ContentID, err)
+	}
+
+	size, err := out.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("(protectContent) failed reading protected content size: %w", err)
+	}
+	if _, err = out.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("(protectContent) failed rewinding protected content: %w", err)
+	}
+
+	key := ProtectedKey(file.ContentID, c.IDGenerator.NewID())
+	if err = c.StorageClient.PutObject(ctx, key, file.MediaType, out); err != nil {
+		return nil, fmt.Errorf("(protectContent) failed storing protected content %s: %w", file.ContentID, err)
+	}
+
+	content := &ProtectedContent{
+		ContentID:  file.ContentID,
+		BookID:     file.BookID,
+		PackageKey: key,
+		Key:        sealed,
+		Size:       size,
+		Checksum:   hex.EncodeToString(hash.Sum(nil)),
+	}
+	if err = c.ProtectedContentRepository.Create(ctx, content); err != nil {
+		return nil, fmt.Errorf("(protectContent) failed saving protected content %s: %w", file.ContentID, err)
+	}
+
+	saved, err := c.ProtectedContentRepository.FindByContentID(ctx, file.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("(protectContent) failed finding protected content %s: %w", file.ContentID, err)
+	}
+	if saved == nil {
+		return nil, fmt.Errorf("(protectContent) failed finding saved protected content %s", file.ContentID)
+	}
+
+	if saved.PackageKey != key {
+		if err = c.StorageClient.DeleteObjects(ctx, []string{key}); err != nil {
+			log.Warnf(ctx, "failed deleting discarded protected content %s: %v", key, err)
+		}
+	}
+
+	return saved, nil
+}
@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// PublishBook lists the book in the catalog. A publish time in the future schedules the book instead, and it's
+// published by PublishScheduledBooks once the time comes.
+func (c *Catalog) PublishBook(ctx context.Context, request PublishBook) (BookResponse, error) {
+	log.Infof(ctx, "new request for publishing book %s", request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(PublishBook) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(PublishBook) failed validating request: %w", err)
+	}
+
+	now := time.Now()
+	status, publishAt := StatusPublished, now
+	if request.PublishAt != nil && request.PublishAt.After(now) {
+		status, publishAt = StatusScheduled, *request.PublishAt
+	}
+
+	if err := c.Repository.UpdateStatus(ctx, request.BookID, status, &publishAt); err != nil {
+		return BookResponse{}, fmt.Errorf("(PublishBook) failed updating status: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return c.FindBookByID(ctx, request.BookID)
+}
+
+// UnpublishBook withdraws the book from the catalog. Scheduled books go back to drafts, as they were never published.
+func (c *Catalog) UnpublishBook(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for unpublishing book %s", id)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(UnpublishBook) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("(UnpublishBook) failed finding book %s: %w", id, err)
+	}
+
+	status := StatusUnpublished
+	if !book.Released() {
+		status = StatusDraft
+	}
+
+	if err = c.Repository.UpdateStatus(ctx, id, status, nil); err != nil {
+		return fmt.Errorf("(UnpublishBook) failed updating status: %w", err)
+	}
+	c.invalidatePublicFeed(ctx)
+
+	return nil
+}
+
+// PublishScheduledBooks publishes the scheduled books whose publish time has come.
+func (c *Catalog) PublishScheduledBooks(ctx context.Context) error {
+	published, err := c.Repository.PublishScheduled(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("(PublishScheduledBooks) failed publishing books: %w", err)
+	}
+
+	if published > 0 {
+		log.Infof(ctx, "published %d scheduled books", published)
+		c.invalidatePublicFeed(ctx)
+	}
+
+	return nil
+}
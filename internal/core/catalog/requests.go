@@ -1,17 +1,40 @@
 package catalog
 
 import (
+	"io"
+	"strings"
 	"time"
 
 	"github.com/ebookstore/internal/core/query"
 )
 
 type SearchBooks struct {
-	Title       string `form:"title"`
-	Description string `form:"description"`
-	AuthorName  string `form:"authorName"`
-	Page        int    `form:"page"`
-	PerPage     int    `form:"perPage"`
+	Title        string `form:"title"`
+	Description  string `form:"description"`
+	AuthorName   string `form:"authorName"`
+	AuthorID     string `form:"authorId"`
+	Category     string `form:"category"`
+	ISBN         string `form:"isbn"`
+	Publisher    string `form:"publisher"`
+	Language     string `form:"language"`
+	Edition      string `form:"edition"`
+	MinPages     int    `form:"minPages"`
+	MaxPages     int    `form:"maxPages"`
+	// Accessibility filters the books supporting the given accessibility feature
+	Accessibility string `form:"accessibility"`
+	// MinRating filters the books whose average rating is at least the given value
+	MinRating    float64 `form:"minRating"`
+	Page         int    `form:"page"`
+	PerPage      int    `form:"perPage"`
+	Pagination   string `form:"pagination"`
+	Cursor       string `form:"cursor"`
+	IncludeTotal bool   `form:"includeTotal"`
+	// Archived lists the archived books instead of the ones on sale, only for admins
+	Archived bool `form:"archived"`
+	// Status filters the books by publication status, only for admins. Other users only find published books
+	Status PublicationStatus `form:"status"`
+	// ImageVariant is the variant of the images linked in the results, thumbnails unless requested otherwise
+	ImageVariant VariantName `form:"imageVariant"`
 }
 
 func (s *SearchBooks) CreateQuery() query.Query {
@@ -29,6 +52,47 @@ func (s *SearchBooks) CreateQuery() query.Query {
 		q.And(query.Condition{Field: "author_name", Operator: query.Match, Value: s.AuthorName})
 	}
 
+	if s.AuthorID != "" {
+		q.And(query.Condition{Field: "author_id", Operator: query.Equal, Value: s.AuthorID})
+	}
+
+	if s.ISBN != "" {
+		isbn, err := NormalizeISBN(s.ISBN)
+		if err != nil {
+			// An invalid ISBN can't match any book, but it is still used to filter the results
+			isbn = s.ISBN
+		}
+		q.And(query.Condition{Field: "isbn", Operator: query.Equal, Value: isbn})
+	}
+
+	if s.Publisher != "" {
+		q.And(query.Condition{Field: "publisher", Operator: query.Match, Value: s.Publisher})
+	}
+
+	if s.Language != "" {
+		q.And(query.Condition{Field: "language", Operator: query.Equal, Value: s.Language})
+	}
+
+	if s.Edition != "" {
+		q.And(query.Condition{Field: "edition", Operator: query.Equal, Value: s.Edition})
+	}
+
+	if s.MinPages > 0 {
+		q.And(query.Condition{Field: "page_count", Operator: query.GreaterOrEqual, Value: s.MinPages})
+	}
+
+	if s.MaxPages > 0 {
+		q.And(query.Condition{Field: "page_count", Operator: query.LessOrEqual, Value: s.MaxPages})
+	}
+
+	if s.Accessibility != "" {
+		q.And(query.Condition{Field: "accessibility_feature", Operator: query.Equal, Value: s.Accessibility})
+	}
+
+	if s.MinRating > 0 {
+		q.And(query.Condition{Field: "rating_average", Operator: query.GreaterOrEqual, Value: s.MinRating})
+	}
+
 	return *q
 }
 
@@ -43,23 +107,135 @@ func (s *SearchBooks) CreatePage() query.Page {
 		p.Size = s.PerPage
 	}
 
+	if s.Pagination == query.CursorPagination || s.Cursor != "" {
+		p.Keyset = true
+		p.Cursor = s.Cursor
+		p.IncludeTotal = s.IncludeTotal
+	}
+
 	return p
 }
 
 type CreateBook struct {
 	Title       string    `json:"title" validate:"required,max=100"`
+	Subtitle    string    `json:"subtitle" validate:"max=200"`
 	Description string    `json:"description" validate:"required"`
-	AuthorName  string    `json:"authorName" validate:"required,max=100"`
-	ContentID   string    `json:"contentId" validate:"required,max=100"`
 	Price       int       `json:"price" validate:"required,gt=0"`
 	ReleaseDate time.Time `json:"releaseDate" validate:"required"`
 
-	Images []ImageRequest `json:"images"`
+	ISBN                  string   `json:"isbn" validate:"omitempty,book_isbn"`
+	Publisher             string   `json:"publisher" validate:"max=100"`
+	Language              string   `json:"language" validate:"omitempty,bcp47_language_tag"`
+	PageCount             int      `json:"pageCount" validate:"gte=0"`
+	Edition               string   `json:"edition" validate:"max=50"`
+	AccessibilityFeatures []string `json:"accessibilityFeatures" validate:"omitempty,dive,accessibility_feature"`
+	Watermarked           bool     `json:"watermarked"`
+	Protected             bool     `json:"protected"`
+
+	Images      []ImageRequest      `json:"images"`
+	Files       []BookFileRequest   `json:"files" validate:"required,min=1,unique=Format,dive"`
+	Authors     []BookAuthorRequest `json:"authors" validate:"required,min=1,dive"`
+	CategoryIDs []string            `json:"categoryIds" validate:"omitempty,dive,required"`
+}
+
+// BookFileRequest references the content of a format uploaded with a presigned URL.
+type BookFileRequest struct {
+	Format    BookFormat `json:"format" validate:"required,oneof=pdf epub mobi"`
+	ContentID string     `json:"contentId" validate:"required,max=100"`
+	Size      int64      `json:"size" validate:"required,gt=0"`
+	Checksum  string     `json:"checksum" validate:"required,hexadecimal,len=64"`
+}
+
+func (r BookFileRequest) BookFile(bookID string) BookFile {
+	return BookFile{
+		BookID:    bookID,
+		Format:    r.Format,
+		ContentID: r.ContentID,
+		MediaType: r.Format.MediaType(),
+		Size:      r.Size,
+		Checksum:  strings.ToLower(r.Checksum),
+	}
+}
+
+// ObjectInfo is the object the file is expected to have been uploaded as.
+func (r BookFileRequest) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Size: r.Size, ContentType: r.Format.MediaType(), Checksum: r.Checksum}
+}
+
+// SaveBookFile adds the file of a format to a book or replaces the existing one.
+type SaveBookFile struct {
+	BookID string `json:"-"`
+	BookFileRequest
+}
+
+// AttachBookSample sets a sample uploaded with a presigned URL as the sample of a book.
+type AttachBookSample struct {
+	BookID    string     `json:"-"`
+	Format    BookFormat `json:"format" validate:"required,oneof=pdf epub mobi"`
+	ContentID string     `json:"contentId" validate:"required,max=100"`
+	Size      int64      `json:"size" validate:"required,gt=0"`
 }
 
+func (r AttachBookSample) BookSample() BookSample {
+	return BookSample{
+		BookID:    r.BookID,
+		Format:    r.Format,
+		ContentID: r.ContentID,
+		MediaType: r.Format.MediaType(),
+		Size:      r.Size,
+	}
+}
+
+// PresignUpload describes the object that will be uploaded with a presigned URL. Checksum is the hex encoded SHA-256
+// of the content and is optional.
+type PresignUpload struct {
+	ContentType string `json:"contentType" validate:"required,max=100"`
+	Size        int64  `json:"size" validate:"required,gt=0"`
+	Checksum    string `json:"checksum" validate:"omitempty,hexadecimal,len=64"`
+}
+
+func (r PresignUpload) ObjectInfo() ObjectInfo {
+	return ObjectInfo{Size: r.Size, ContentType: r.ContentType, Checksum: strings.ToLower(r.Checksum)}
+}
+
+// CollectOrphanUploads deletes the orphan uploads tracked for longer than the grace period, or only reports them on a dry run.
+type CollectOrphanUploads struct {
+	GracePeriod time.Duration
+	DryRun      bool
+}
+
+// GenerateBookSample cuts the sample of a book from its content. Length is the number of pages of a PDF
+// or the number of spine items of an EPUB. An empty format uses the preferred format of the book.
+type GenerateBookSample struct {
+	BookID string     `json:"-"`
+	Format BookFormat `json:"format" validate:"omitempty,oneof=pdf epub"`
+	Length int        `json:"length" validate:"required,min=1,max=100"`
+}
+
+type BookAuthorRequest struct {
+	AuthorID string     `json:"authorId" validate:"required,max=36"`
+	Role     AuthorRole `json:"role" validate:"omitempty,oneof=author translator illustrator"`
+}
+
+// BookAuthors links the requested authors to the book in the order they were provided. The role defaults to author.
+func BookAuthors(bookID string, requests []BookAuthorRequest) []BookAuthor {
+	authors := make([]BookAuthor, 0, len(requests))
+	for i, r := range requests {
+		role := r.Role
+		if role == "" {
+			role = RoleAuthor
+		}
+		authors = append(authors, BookAuthor{BookID: bookID, AuthorID: r.AuthorID, Role: role, Position: i})
+	}
+	return authors
+}
+
+// ImageRequest references an image uploaded with a presigned URL. IsMain chooses it as the cover of the book,
+// which is the first image when none is chosen.
 type ImageRequest struct {
-	ID          string `json:"id"`
+	ID          string `json:"id" validate:"required,max=100"`
 	Description string `json:"description"`
+	IsMain      bool   `json:"isMain"`
 }
 
 func (r ImageRequest) Image(bookId string) Image {
@@ -67,33 +243,110 @@ func (r ImageRequest) Image(bookId string) Image {
 		ID:          r.ID,
 		Description: r.Description,
 		BookID:      bookId,
+		IsMain:      r.IsMain,
 	}
 }
 
+// AddBookImage adds an image after the existing images of a book.
+type AddBookImage struct {
+	BookID string `json:"-"`
+	ImageRequest
+}
+
+// ReorderBookImages lists every image of a book in the new order.
+type ReorderBookImages struct {
+	BookID   string   `json:"-"`
+	ImageIDs []string `json:"imageIds" validate:"required,min=1,unique"`
+}
+
+// newImages numbers the requested images in the order they were provided.
+func newImages(bookID string, requests []ImageRequest) []Image {
+	images := make([]Image, 0, len(requests))
+	for _, img := range requests {
+		images = append(images, img.Image(bookID))
+	}
+	return orderImages(images)
+}
+
 func (c CreateBook) Book(id string) Book {
-	images := make([]Image, 0, len(c.Images))
-	for _, img := range c.Images {
-		images = append(images, img.Image(id))
+	files := make([]BookFile, 0, len(c.Files))
+	for _, file := range c.Files {
+		files = append(files, file.BookFile(id))
 	}
 
 	return Book{
-		ID:          id,
-		Title:       c.Title,
-		Description: c.Description,
-		AuthorName:  c.AuthorName,
-		ContentID:   c.ContentID,
-		Price:       c.Price,
-		ReleaseDate: c.ReleaseDate,
-		Images:      images,
+		ID:                    id,
+		Title:                 c.Title,
+		Subtitle:              c.Subtitle,
+		Description:           c.Description,
+		ISBN:                  normalizedISBN(c.ISBN),
+		Publisher:             c.Publisher,
+		Language:              c.Language,
+		PageCount:             c.PageCount,
+		Edition:               c.Edition,
+		AccessibilityFeatures: newAccessibilityFeatures(id, c.AccessibilityFeatures),
+		Files:                 files,
+		Price:                 c.Price,
+		Watermarked:           c.Watermarked,
+		Protected:             c.Protected,
+		ReleaseDate:           c.ReleaseDate,
+		Images:                newImages(id, c.Images),
+		Status:                StatusDraft,
+	}
+}
+
+// PublishBook publishes a book now, or schedules it when PublishAt is in the future.
+type PublishBook struct {
+	BookID    string     `json:"-"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+// normalizedISBN returns nil for an empty or invalid ISBN. Requests are validated before, so it only happens for empty ones.
+func normalizedISBN(isbn string) *string {
+	normalized, err := NormalizeISBN(isbn)
+	if err != nil {
+		return nil
+	}
+	return &normalized
+}
+
+func newAccessibilityFeatures(bookID string, features []string) []AccessibilityFeature {
+	if len(features) == 0 {
+		return nil
+	}
+
+	accessibilityFeatures := make([]AccessibilityFeature, 0, len(features))
+	for _, feature := range uniqueStrings(features) {
+		accessibilityFeatures = append(accessibilityFeatures, AccessibilityFeature{BookID: bookID, Feature: feature})
 	}
+	return accessibilityFeatures
 }
 
 type UpdateBook struct {
 	ID          string
 	Title       *string `validate:"omitempty,max=100"`
+	Subtitle    *string `json:"subtitle" validate:"omitempty,max=200"`
 	Description *string `validate:"omitempty"`
-	AuthorName  *string `validate:"omitempty,max=100"`
-	Images      []ImageRequest
+	// Price changes the book price, and the customers who wishlisted the book are notified when it drops
+	Price *int `json:"price" validate:"omitempty,gt=0"`
+	// ISBN removes the book ISBN when it is empty
+	ISBN      *string `json:"isbn" validate:"omitempty,book_isbn"`
+	Publisher *string `json:"publisher" validate:"omitempty,max=100"`
+	Language  *string `json:"language" validate:"omitempty,bcp47_language_tag"`
+	PageCount *int    `json:"pageCount" validate:"omitempty,gte=0"`
+	Edition   *string `json:"edition" validate:"omitempty,max=50"`
+	// Watermarked turns the watermarking of the downloads on or off
+	Watermarked *bool `json:"watermarked"`
+	// Protected turns the encrypted delivery on or off
+	Protected *bool `json:"protected"`
+	// AccessibilityFeatures replaces the book accessibility features when it is not nil
+	AccessibilityFeatures []string `json:"accessibilityFeatures" validate:"omitempty,dive,accessibility_feature"`
+	// Images replaces the book images when it is not nil
+	Images []ImageRequest `json:"images"`
+	// Authors replaces the book authors when it is not nil
+	Authors []BookAuthorRequest `json:"authors" validate:"omitempty,min=1,dive"`
+	// CategoryIDs replaces the book categories when it is not nil
+	CategoryIDs []string `json:"categoryIds" validate:"omitempty,dive,required"`
 }
 
 func (u UpdateBook) Update(existing Book) Book {
@@ -107,16 +360,167 @@ func (u UpdateBook) Update(existing Book) Book {
 		updated.Description = *u.Description
 	}
 
-	if u.AuthorName != nil && *u.AuthorName != "" {
-		updated.AuthorName = *u.AuthorName
+	if u.Price != nil {
+		updated.Price = *u.Price
+	}
+
+	if u.Subtitle != nil {
+		updated.Subtitle = *u.Subtitle
+	}
+
+	if u.ISBN != nil {
+		updated.ISBN = normalizedISBN(*u.ISBN)
+	}
+
+	if u.Publisher != nil {
+		updated.Publisher = *u.Publisher
+	}
+
+	if u.Language != nil {
+		updated.Language = *u.Language
+	}
+
+	if u.PageCount != nil {
+		updated.PageCount = *u.PageCount
+	}
+
+	if u.Edition != nil {
+		updated.Edition = *u.Edition
+	}
+
+	if u.Watermarked != nil {
+		updated.Watermarked = *u.Watermarked
+	}
+
+	if u.Protected != nil {
+		updated.Protected = *u.Protected
+	}
+
+	if u.AccessibilityFeatures != nil {
+		updated.AccessibilityFeatures = newAccessibilityFeatures(existing.ID, u.AccessibilityFeatures)
+	}
+
+	if u.Images != nil {
+		updated.Images = newImages(existing.ID, u.Images)
+	}
+
+	return updated
+}
+
+type CreateCategory struct {
+	Name     string  `json:"name" validate:"required,max=100"`
+	Slug     string  `json:"slug" validate:"omitempty,max=100"`
+	ParentID *string `json:"parentId" validate:"omitempty,max=36"`
+}
+
+func (c CreateCategory) Category(id string) Category {
+	slug := c.Slug
+	if slug == "" {
+		slug = c.Name
+	}
+
+	return Category{
+		ID:       id,
+		Name:     c.Name,
+		Slug:     Slugify(slug),
+		ParentID: c.ParentID,
+	}
+}
+
+type UpdateCategory struct {
+	ID       string  `json:"-"`
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	Slug     *string `json:"slug" validate:"omitempty,max=100"`
+	ParentID *string `json:"parentId" validate:"omitempty,max=36"`
+	// MoveToRoot detaches the category from its parent
+	MoveToRoot bool `json:"moveToRoot"`
+}
+
+func (u UpdateCategory) Update(existing Category) Category {
+	updated := existing
+
+	if u.Name != nil && *u.Name != "" {
+		updated.Name = *u.Name
+	}
+
+	if u.Slug != nil && *u.Slug != "" {
+		updated.Slug = Slugify(*u.Slug)
 	}
 
-	images := make([]Image, 0, len(u.Images))
-	for _, img := range u.Images {
-		images = append(images, img.Image(existing.ID))
+	if u.ParentID != nil && *u.ParentID != "" {
+		updated.ParentID = u.ParentID
 	}
 
-	updated.Images = images
+	if u.MoveToRoot {
+		updated.ParentID = nil
+	}
 
 	return updated
 }
+
+type CreateAuthor struct {
+	Name    string  `json:"name" validate:"required,max=100"`
+	Slug    string  `json:"slug" validate:"omitempty,max=100"`
+	Bio     string  `json:"bio"`
+	PhotoID *string `json:"photoId" validate:"omitempty,max=50"`
+}
+
+func (c CreateAuthor) Author(id string) Author {
+	slug := c.Slug
+	if slug == "" {
+		slug = c.Name
+	}
+
+	return Author{
+		ID:      id,
+		Name:    c.Name,
+		Slug:    Slugify(slug),
+		Bio:     c.Bio,
+		PhotoID: c.PhotoID,
+	}
+}
+
+type UpdateAuthor struct {
+	ID      string  `json:"-"`
+	Name    *string `json:"name" validate:"omitempty,max=100"`
+	Slug    *string `json:"slug" validate:"omitempty,max=100"`
+	Bio     *string `json:"bio"`
+	PhotoID *string `json:"photoId" validate:"omitempty,max=50"`
+}
+
+func (u UpdateAuthor) Update(existing Author) Author {
+	updated := existing
+
+	if u.Name != nil && *u.Name != "" {
+		updated.Name = *u.Name
+	}
+
+	if u.Slug != nil && *u.Slug != "" {
+		updated.Slug = Slugify(*u.Slug)
+	}
+
+	if u.Bio != nil {
+		updated.Bio = *u.Bio
+	}
+
+	if u.PhotoID != nil {
+		updated.PhotoID = u.PhotoID
+		// An empty id removes the photo
+		if *u.PhotoID == "" {
+			updated.PhotoID = nil
+		}
+	}
+
+	return updated
+}
+
+// ImportBooks is a catalog file to import. In dry-run mode the file is only validated.
+type ImportBooks struct {
+	Format string    `form:"format" validate:"required,oneof=csv onix"`
+	DryRun bool      `form:"dryRun"`
+	File   io.Reader `form:"-" validate:"required"`
+}
+
+type ExportBooks struct {
+	Format string `form:"format" validate:"required,oneof=csv jsonl google_merchant"`
+}
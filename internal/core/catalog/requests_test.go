@@ -89,6 +89,67 @@ func (s *SearchBooksTestSuite) TestCreatePage_WithPerPage() {
 	assert.Equal(s.T(), expected, actual)
 }
 
+func (s *SearchBooksTestSuite) TestCreatePage_WithCursorPagination() {
+	dto := SearchBooks{Pagination: "cursor", IncludeTotal: true}
+
+	expected := query.Page{
+		Number:       1,
+		Size:         15,
+		Keyset:       true,
+		IncludeTotal: true,
+	}
+	actual := dto.CreatePage()
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *SearchBooksTestSuite) TestCreatePage_WithCursor() {
+	dto := SearchBooks{Cursor: "some-cursor", PerPage: 20}
+
+	expected := query.Page{
+		Number: 1,
+		Size:   20,
+		Keyset: true,
+		Cursor: "some-cursor",
+	}
+	actual := dto.CreatePage()
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *SearchBooksTestSuite) TestCreateQuery_WithISBN() {
+	dto := SearchBooks{ISBN: "0-321-12521-5"}
+
+	expected := *query.New().And(query.Condition{Field: "isbn", Operator: query.Equal, Value: "9780321125217"})
+	actual := dto.CreateQuery()
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *SearchBooksTestSuite) TestCreateQuery_WithBibliographicMetadata() {
+	dto := SearchBooks{Publisher: "Addison", Language: "en", Edition: "2nd", MinPages: 100, MaxPages: 500, Accessibility: "alternativeText"}
+
+	expected := *query.New().
+		And(query.Condition{Field: "publisher", Operator: query.Match, Value: "Addison"}).
+		And(query.Condition{Field: "language", Operator: query.Equal, Value: "en"}).
+		And(query.Condition{Field: "edition", Operator: query.Equal, Value: "2nd"}).
+		And(query.Condition{Field: "page_count", Operator: query.GreaterOrEqual, Value: 100}).
+		And(query.Condition{Field: "page_count", Operator: query.LessOrEqual, Value: 500}).
+		And(query.Condition{Field: "accessibility_feature", Operator: query.Equal, Value: "alternativeText"})
+	actual := dto.CreateQuery()
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *SearchBooksTestSuite) TestCreateQuery_WithMinRating() {
+	dto := SearchBooks{MinRating: 4.5}
+
+	expected := *query.New().And(query.Condition{Field: "rating_average", Operator: query.GreaterOrEqual, Value: 4.5})
+	actual := dto.CreateQuery()
+
+	assert.Equal(s.T(), expected, actual)
+}
+
 type CreateBookTestSuite struct {
 	suite.Suite
 }
@@ -102,9 +163,10 @@ func (s *CreateBookTestSuite) TestBook() {
 	dto := CreateBook{
 		Title:       "some-title",
 		Description: "description",
-		AuthorName:  "fake name",
 		Price:       10000,
-		ContentID:   "some-content-id",
+		Files: []BookFileRequest{
+			{Format: FormatEPUB, ContentID: "some-content-id", Size: 1024, Checksum: "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08"},
+		},
 		Images: []ImageRequest{
 			{
 				ID:          "some-id",
@@ -118,17 +180,27 @@ func (s *CreateBookTestSuite) TestBook() {
 		ID:          id,
 		Title:       "some-title",
 		Description: "description",
-		AuthorName:  "fake name",
-		ContentID:   "some-content-id",
+		Files: []BookFile{
+			{
+				BookID:    id,
+				Format:    FormatEPUB,
+				ContentID: "some-content-id",
+				MediaType: "application/epub+zip",
+				Size:      1024,
+				Checksum:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			},
+		},
 		Images: []Image{
 			{
 				ID:          "some-id",
 				Description: "some description",
 				BookID:      id,
+				IsMain:      true,
 			},
 		},
 		Price:       10000,
 		ReleaseDate: time.Date(2021, time.September, 29, 17, 28, 0, 0, time.UTC),
+		Status:      StatusDraft,
 	}
 
 	actual := dto.Book(id)
@@ -136,6 +208,34 @@ func (s *CreateBookTestSuite) TestBook() {
 	assert.Equal(s.T(), expected, actual)
 }
 
+func (s *CreateBookTestSuite) TestBook_WithBibliographicMetadata() {
+	dto := CreateBook{
+		Title:                 "Domain-Driven Design",
+		ISBN:                  "0-321-12521-5",
+		Language:              "en-US",
+		PageCount:             560,
+		AccessibilityFeatures: []string{"tableOfContents", "tableOfContents", "index"},
+	}
+
+	actual := dto.Book("some-id")
+
+	s.Require().NotNil(actual.ISBN)
+	assert.Equal(s.T(), "9780321125217", *actual.ISBN)
+	assert.Equal(s.T(), "en-US", actual.Language)
+	assert.Equal(s.T(), 560, actual.PageCount)
+	assert.Equal(s.T(), []AccessibilityFeature{
+		{BookID: "some-id", Feature: "tableOfContents"},
+		{BookID: "some-id", Feature: "index"},
+	}, actual.AccessibilityFeatures)
+}
+
+func (s *CreateBookTestSuite) TestBook_WithoutISBN() {
+	actual := CreateBook{Title: "Domain-Driven Design"}.Book("some-id")
+
+	assert.Nil(s.T(), actual.ISBN)
+	assert.Nil(s.T(), actual.AccessibilityFeatures)
+}
+
 type UpdateBookTestSuite struct {
 	suite.Suite
 }
@@ -188,18 +288,18 @@ func (s *UpdateBookTestSuite) TestUpdate_WithDescription() {
 	assert.Equal(s.T(), expected, actual)
 }
 
-func (s *UpdateBookTestSuite) TestUpdate_WithAuthorName() {
+func (s *UpdateBookTestSuite) TestUpdate_WithPrice() {
 	book := Book{
-		ID:         "some-id",
-		AuthorName: "other-name",
-		Images:     []Image{},
+		ID:     "some-id",
+		Price:  5000,
+		Images: []Image{},
 	}
 
-	authorName := "new name"
-	dto := UpdateBook{AuthorName: &authorName}
+	price := 4000
+	dto := UpdateBook{Price: &price}
 
 	expected := book
-	expected.AuthorName = authorName
+	expected.Price = price
 	actual := dto.Update(book)
 
 	assert.Equal(s.T(), expected, actual)
@@ -207,19 +307,19 @@ func (s *UpdateBookTestSuite) TestUpdate_WithAuthorName() {
 
 func (s *UpdateBookTestSuite) TestUpdate_WithMultipleFields() {
 	book := Book{
-		ID:         "some-id",
-		Title:      "other-title",
-		AuthorName: "other-name",
-		Images:     []Image{},
+		ID:          "some-id",
+		Title:       "other-title",
+		Description: "other-description",
+		Images:      []Image{},
 	}
 
 	title := "title"
-	authorName := "new name"
-	dto := UpdateBook{Title: &title, AuthorName: &authorName}
+	description := "new description"
+	dto := UpdateBook{Title: &title, Description: &description}
 
 	expected := book
 	expected.Title = title
-	expected.AuthorName = authorName
+	expected.Description = description
 	actual := dto.Update(book)
 
 	assert.Equal(s.T(), expected, actual)
@@ -227,9 +327,8 @@ func (s *UpdateBookTestSuite) TestUpdate_WithMultipleFields() {
 
 func (s *UpdateBookTestSuite) TestUpdate_WithImages() {
 	book := Book{
-		ID:         "some-id",
-		Title:      "other-title",
-		AuthorName: "other-name",
+		ID:    "some-id",
+		Title: "other-title",
 		Images: []Image{
 			{
 				ID:          "some-id",
@@ -240,18 +339,71 @@ func (s *UpdateBookTestSuite) TestUpdate_WithImages() {
 	}
 
 	title := "title"
-	authorName := "new name"
-	dto := UpdateBook{Title: &title, AuthorName: &authorName}
+	dto := UpdateBook{Title: &title}
 
 	expected := book
 	expected.Title = title
-	expected.AuthorName = authorName
-	expected.Images = []Image{}
 	actual := dto.Update(book)
 
 	assert.Equal(s.T(), expected, actual)
 }
 
+func (s *UpdateBookTestSuite) TestUpdate_ReplacesImages() {
+	book := Book{
+		ID:     "some-id",
+		Images: []Image{{ID: "image-id", BookID: "some-id", IsMain: true}},
+	}
+
+	dto := UpdateBook{Images: []ImageRequest{{ID: "image-id2"}, {ID: "image-id3", IsMain: true}}}
+
+	expected := book
+	expected.Images = []Image{
+		{ID: "image-id2", BookID: "some-id", Position: 0},
+		{ID: "image-id3", BookID: "some-id", Position: 1, IsMain: true},
+	}
+	actual := dto.Update(book)
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *UpdateBookTestSuite) TestUpdate_WithBibliographicMetadata() {
+	isbn := "9780321125217"
+	book := Book{
+		ID:                    "some-id",
+		ISBN:                  &isbn,
+		Publisher:             "Addison-Wesley",
+		AccessibilityFeatures: []AccessibilityFeature{{BookID: "some-id", Feature: "index"}},
+		Images:                []Image{},
+	}
+
+	emptyISBN := ""
+	pageCount := 320
+	dto := UpdateBook{ISBN: &emptyISBN, PageCount: &pageCount, AccessibilityFeatures: []string{}}
+
+	expected := book
+	expected.ISBN = nil
+	expected.PageCount = pageCount
+	expected.AccessibilityFeatures = nil
+	actual := dto.Update(book)
+
+	assert.Equal(s.T(), expected, actual)
+}
+
+func TestBookAuthors(t *testing.T) {
+	requests := []BookAuthorRequest{
+		{AuthorID: "author-1"},
+		{AuthorID: "author-2", Role: RoleTranslator},
+	}
+
+	expected := []BookAuthor{
+		{BookID: "book-id", AuthorID: "author-1", Role: RoleAuthor, Position: 0},
+		{BookID: "book-id", AuthorID: "author-2", Role: RoleTranslator, Position: 1},
+	}
+	actual := BookAuthors("book-id", requests)
+
+	assert.Equal(t, expected, actual)
+}
+
 func TestImageRequest_Image(t *testing.T) {
 	id := "id"
 	description := "some description"
@@ -272,3 +424,58 @@ func TestImageRequest_Image(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestCreateCategory_Category(t *testing.T) {
+	parentID := "parent-id"
+	dto := CreateCategory{Name: "Science Fiction", ParentID: &parentID}
+
+	expected := Category{ID: "some-id", Name: "Science Fiction", Slug: "science-fiction", ParentID: &parentID}
+	actual := dto.Category("some-id")
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestCreateCategory_CategoryWithSlug(t *testing.T) {
+	dto := CreateCategory{Name: "Science Fiction", Slug: "Sci Fi"}
+
+	actual := dto.Category("some-id")
+
+	assert.Equal(t, "sci-fi", actual.Slug)
+}
+
+func TestUpdateCategory_Update(t *testing.T) {
+	parentID := "parent-id"
+	name := "Fantasy"
+	existing := Category{ID: "some-id", Name: "Fiction", Slug: "fiction", ParentID: &parentID}
+
+	actual := UpdateCategory{Name: &name}.Update(existing)
+	assert.Equal(t, Category{ID: "some-id", Name: "Fantasy", Slug: "fiction", ParentID: &parentID}, actual)
+
+	actual = UpdateCategory{MoveToRoot: true}.Update(existing)
+	assert.Nil(t, actual.ParentID)
+}
+
+func TestCreateAuthor_Author(t *testing.T) {
+	photoID := "photo-id"
+	dto := CreateAuthor{Name: "Ursula K. Le Guin", Bio: "Earthsea", PhotoID: &photoID}
+
+	expected := Author{ID: "some-id", Name: "Ursula K. Le Guin", Slug: "ursula-k-le-guin", Bio: "Earthsea", PhotoID: &photoID}
+	actual := dto.Author("some-id")
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUpdateAuthor_Update(t *testing.T) {
+	photoID := "photo-id"
+	existing := Author{ID: "some-id", Name: "Eric Evans", Slug: "eric-evans", Bio: "bio", PhotoID: &photoID}
+
+	bio := ""
+	actual := UpdateAuthor{Bio: &bio}.Update(existing)
+	assert.Equal(t, "", actual.Bio)
+	assert.Equal(t, &photoID, actual.PhotoID)
+
+	noPhoto := ""
+	actual = UpdateAuthor{PhotoID: &noPhoto}.Update(existing)
+	assert.Nil(t, actual.PhotoID)
+	assert.Equal(t, "bio", actual.Bio)
+}
@@ -2,80 +2,408 @@ package catalog
 
 import (
 	"math"
+	"sort"
 	"time"
 )
 
 type BookResponse struct {
-	ID          string          `json:"id"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	AuthorName  string          `json:"authorName"`
-	Images      []ImageResponse `json:"images"`
-	MainImageID string          `json:"mainImageId"`
-	Price       int             `json:"price"`
-	ReleaseDate time.Time       `json:"releaseDate"`
-	CreatedAt   time.Time       `json:"createdAt"`
-	UpdatedAt   time.Time       `json:"updatedAt"`
-}
-
-func NewBookResponse(book Book, links []string) BookResponse {
+	ID                    string                    `json:"id"`
+	Title                 string                    `json:"title"`
+	Subtitle              string                    `json:"subtitle"`
+	Description           string                    `json:"description"`
+	ISBN                  string                    `json:"isbn,omitempty"`
+	ISBN10                string                    `json:"isbn10,omitempty"`
+	ExternalID            string                    `json:"externalId,omitempty"`
+	Publisher             string                    `json:"publisher"`
+	Language              string                    `json:"language"`
+	PageCount             int                       `json:"pageCount"`
+	Edition               string                    `json:"edition"`
+	AccessibilityFeatures []string                  `json:"accessibilityFeatures"`
+	Authors               []BookAuthorResponse      `json:"authors"`
+	Images                []ImageResponse           `json:"images"`
+	MainImageID           string                    `json:"mainImageId"`
+	Categories            []CategorySummaryResponse `json:"categories"`
+	Formats               []BookFileResponse        `json:"formats"`
+	Sample                *BookFileResponse         `json:"sample,omitempty"`
+	Price                 int                       `json:"price"`
+	Watermarked           bool                      `json:"watermarked"`
+	Protected             bool                      `json:"protected"`
+	RatingAverage         float64                   `json:"ratingAverage"`
+	RatingCount           int                       `json:"ratingCount"`
+	ReleaseDate           time.Time                 `json:"releaseDate"`
+	Status                PublicationStatus         `json:"status"`
+	PublishAt             *time.Time                `json:"publishAt,omitempty"`
+	ArchivedAt            *time.Time                `json:"archivedAt,omitempty"`
+	CreatedAt             time.Time                 `json:"createdAt"`
+	UpdatedAt             time.Time                 `json:"updatedAt"`
+}
+
+func NewBookResponse(book Book, links []ImageLinks) BookResponse {
 	images := make([]ImageResponse, 0, len(book.Images))
 	for i := range book.Images {
 		images = append(images, NewImageResponse(book.Images[i], links[i]))
 	}
 
+	authors := make([]BookAuthorResponse, 0, len(book.Authors))
+	for _, author := range book.Authors {
+		authors = append(authors, NewBookAuthorResponse(author))
+	}
+
+	categories := make([]CategorySummaryResponse, 0, len(book.Categories))
+	for _, category := range book.Categories {
+		categories = append(categories, NewCategorySummaryResponse(category))
+	}
+
+	formats := make([]BookFileResponse, 0, len(book.Files))
+	for _, file := range book.Files {
+		formats = append(formats, NewBookFileResponse(file))
+	}
+
+	var sample *BookFileResponse
+	if book.Sample != nil {
+		sample = &BookFileResponse{Format: book.Sample.Format, MediaType: book.Sample.MediaType, Size: book.Sample.Size}
+	}
+
+	var isbn string
+	if book.ISBN != nil {
+		isbn = *book.ISBN
+	}
+
+	var externalID string
+	if book.ExternalID != nil {
+		externalID = *book.ExternalID
+	}
+
 	return BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		AuthorName:  book.AuthorName,
-		Images:      images,
-		MainImageID: book.MainImageID(),
-		Price:       book.Price,
-		ReleaseDate: book.ReleaseDate,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
+		ID:                    book.ID,
+		Title:                 book.Title,
+		Subtitle:              book.Subtitle,
+		Description:           book.Description,
+		ISBN:                  isbn,
+		ISBN10:                ISBN10(isbn),
+		ExternalID:            externalID,
+		Publisher:             book.Publisher,
+		Language:              book.Language,
+		PageCount:             book.PageCount,
+		Edition:               book.Edition,
+		AccessibilityFeatures: book.Features(),
+		Authors:               authors,
+		Images:                images,
+		MainImageID:           book.MainImageID(),
+		Categories:            categories,
+		Formats:               formats,
+		Sample:                sample,
+		Price:                 book.Price,
+		Watermarked:           book.Watermarked,
+		Protected:             book.Protected,
+		RatingAverage:         book.RatingAverage,
+		RatingCount:           book.RatingCount,
+		ReleaseDate:           book.ReleaseDate,
+		Status:                book.Status,
+		PublishAt:             book.PublishAt,
+		ArchivedAt:            book.ArchivedAt,
+		CreatedAt:             book.CreatedAt,
+		UpdatedAt:             book.UpdatedAt,
 	}
 }
 
+// OnSale reports whether the book can be bought: it must be published and not archived.
+func (b BookResponse) OnSale() bool {
+	return b.Status == StatusPublished && b.ArchivedAt == nil
+}
+
+// Upcoming reports whether the book is released after the given time. Until then it's sold as a pre-order.
+func (b BookResponse) Upcoming(now time.Time) bool {
+	return b.ReleaseDate.After(now)
+}
+
+// BookFileResponse describes a format the book can be downloaded in.
+type BookFileResponse struct {
+	Format    BookFormat `json:"format"`
+	MediaType string     `json:"mediaType"`
+	Size      int64      `json:"size"`
+}
+
+func NewBookFileResponse(file BookFile) BookFileResponse {
+	return BookFileResponse{
+		Format:    file.Format,
+		MediaType: file.MediaType,
+		Size:      file.Size,
+	}
+}
+
+// SampleResponse is a link to download the sample of a book.
+type SampleResponse struct {
+	URL       string     `json:"url"`
+	Format    BookFormat `json:"format"`
+	MediaType string     `json:"mediaType"`
+}
+
+func NewSampleResponse(sample BookSample, url string) SampleResponse {
+	return SampleResponse{
+		URL:       url,
+		Format:    sample.Format,
+		MediaType: sample.MediaType,
+	}
+}
+
+// ImageLinks are the presigned links of an image. Link points to the variant requested for the listing, and
+// Variants, when present, to each of the generated variants.
+type ImageLinks struct {
+	Link     string
+	Variants map[VariantName]string
+}
+
 type ImageResponse struct {
-	ID          string `json:"id"`
-	Link        string `json:"link"`
-	Description string `json:"description"`
+	ID          string                 `json:"id"`
+	Link        string                 `json:"link"`
+	Description string                 `json:"description"`
+	Variants    map[VariantName]string `json:"variants,omitempty"`
 }
 
-func NewImageResponse(image Image, link string) ImageResponse {
+func NewImageResponse(image Image, links ImageLinks) ImageResponse {
 	return ImageResponse{
 		ID:          image.ID,
-		Link:        link,
+		Link:        links.Link,
 		Description: image.Description,
+		Variants:    links.Variants,
 	}
 }
 
 type PaginatedBooksResponse struct {
 	Results     []BookResponse `json:"results"`
-	CurrentPage int            `json:"currentPage"`
+	CurrentPage *int           `json:"currentPage"`
 	PerPage     int            `json:"perPage"`
-	TotalPages  int            `json:"totalPages"`
-	TotalItems  int64          `json:"totalItems"`
+	TotalPages  *int           `json:"totalPages"`
+	TotalItems  *int64         `json:"totalItems"`
+	NextCursor  string         `json:"nextCursor,omitempty"`
+	PrevCursor  string         `json:"prevCursor,omitempty"`
 }
 
-func NewPaginatedBooksResponse(paginatedBooks PaginatedBooks, imageLinks map[string][]string) PaginatedBooksResponse {
+func NewPaginatedBooksResponse(paginatedBooks PaginatedBooks, imageLinks map[string][]ImageLinks) PaginatedBooksResponse {
 	books := make([]BookResponse, 0, len(paginatedBooks.Books))
 	for _, b := range paginatedBooks.Books {
 		books = append(books, NewBookResponse(b, imageLinks[b.ID]))
 	}
 
-	return PaginatedBooksResponse{
-		Results:     books,
-		CurrentPage: (paginatedBooks.Offset / paginatedBooks.Limit) + 1,
-		PerPage:     paginatedBooks.Limit,
-		TotalPages:  int(math.Ceil(float64(paginatedBooks.TotalBooks) / float64(paginatedBooks.Limit))),
-		TotalItems:  paginatedBooks.TotalBooks,
+	response := PaginatedBooksResponse{
+		Results:    books,
+		PerPage:    paginatedBooks.Limit,
+		NextCursor: paginatedBooks.NextCursor,
+		PrevCursor: paginatedBooks.PrevCursor,
 	}
+
+	// Page numbers are meaningless when paginating by cursor
+	if !paginatedBooks.Keyset {
+		currentPage := (paginatedBooks.Offset / paginatedBooks.Limit) + 1
+		response.CurrentPage = &currentPage
+	}
+
+	if !paginatedBooks.Keyset || paginatedBooks.IncludeTotal {
+		totalPages := int(math.Ceil(float64(paginatedBooks.TotalBooks) / float64(paginatedBooks.Limit)))
+		response.TotalPages = &totalPages
+		response.TotalItems = &paginatedBooks.TotalBooks
+	}
+
+	return response
 }
 
 type PresignURLResponse struct {
 	ID  string `json:"id"`
 	URL string `json:"url"`
 }
+
+type CategorySummaryResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+func NewCategorySummaryResponse(category Category) CategorySummaryResponse {
+	return CategorySummaryResponse{
+		ID:   category.ID,
+		Name: category.Name,
+		Slug: category.Slug,
+	}
+}
+
+type CategoryResponse struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+	ParentID  *string            `json:"parentId"`
+	BookCount int64              `json:"bookCount"`
+	Children  []CategoryResponse `json:"children"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+func NewCategoryResponse(category Category, bookCount int64) CategoryResponse {
+	return CategoryResponse{
+		ID:        category.ID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		ParentID:  category.ParentID,
+		BookCount: bookCount,
+		Children:  []CategoryResponse{},
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
+
+// NewCategoryTreeResponse arranges the categories into trees sorted by name.
+// bookCounts must contain the number of books of each category including its descendants.
+func NewCategoryTreeResponse(categories []Category, bookCounts map[string]int64) []CategoryResponse {
+	childrenByParentID := make(map[string][]Category)
+	existing := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		existing[c.ID] = true
+	}
+
+	var roots []Category
+	for _, c := range categories {
+		if c.IsRoot() || !existing[*c.ParentID] {
+			roots = append(roots, c)
+			continue
+		}
+		childrenByParentID[*c.ParentID] = append(childrenByParentID[*c.ParentID], c)
+	}
+
+	var build func(nodes []Category) []CategoryResponse
+	build = func(nodes []Category) []CategoryResponse {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+		responses := make([]CategoryResponse, 0, len(nodes))
+		for _, node := range nodes {
+			response := NewCategoryResponse(node, bookCounts[node.ID])
+			response.Children = build(childrenByParentID[node.ID])
+			responses = append(responses, response)
+		}
+		return responses
+	}
+
+	return build(roots)
+}
+
+type BookAuthorResponse struct {
+	ID   string     `json:"id"`
+	Name string     `json:"name"`
+	Slug string     `json:"slug"`
+	Role AuthorRole `json:"role"`
+}
+
+func NewBookAuthorResponse(bookAuthor BookAuthor) BookAuthorResponse {
+	return BookAuthorResponse{
+		ID:   bookAuthor.AuthorID,
+		Name: bookAuthor.Author.Name,
+		Slug: bookAuthor.Author.Slug,
+		Role: bookAuthor.Role,
+	}
+}
+
+type AuthorResponse struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Slug      string               `json:"slug"`
+	Bio       string               `json:"bio"`
+	PhotoURL  string               `json:"photoUrl,omitempty"`
+	Books     []AuthorBookResponse `json:"books"`
+	CreatedAt time.Time            `json:"createdAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+type AuthorBookResponse struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Role        AuthorRole `json:"role"`
+	ReleaseDate time.Time  `json:"releaseDate"`
+}
+
+// NewAuthorResponse creates the response of an author with its books sorted from the newest to the oldest release.
+func NewAuthorResponse(author Author, photoURL string) AuthorResponse {
+	books := make([]AuthorBookResponse, 0, len(author.Books))
+	for _, b := range author.Books {
+		books = append(books, AuthorBookResponse{
+			ID:          b.BookID,
+			Title:       b.Book.Title,
+			Role:        b.Role,
+			ReleaseDate: b.Book.ReleaseDate,
+		})
+	}
+
+	sort.SliceStable(books, func(i, j int) bool { return books[i].ReleaseDate.After(books[j].ReleaseDate) })
+
+	return AuthorResponse{
+		ID:        author.ID,
+		Name:      author.Name,
+		Slug:      author.Slug,
+		Bio:       author.Bio,
+		PhotoURL:  photoURL,
+		Books:     books,
+		CreatedAt: author.CreatedAt,
+		UpdatedAt: author.UpdatedAt,
+	}
+}
+
+type ImportReportResponse struct {
+	DryRun  bool                `json:"dryRun"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Failed  int                 `json:"failed"`
+	Rows    []ImportRowResponse `json:"rows"`
+}
+
+type ImportRowResponse struct {
+	Line       int          `json:"line"`
+	BookID     string       `json:"bookId,omitempty"`
+	ISBN       string       `json:"isbn,omitempty"`
+	ExternalID string       `json:"externalId,omitempty"`
+	Title      string       `json:"title"`
+	Status     ImportStatus `json:"status"`
+	Errors     []string     `json:"errors,omitempty"`
+}
+
+func NewImportReportResponse(report ImportReport) ImportReportResponse {
+	rows := make([]ImportRowResponse, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, ImportRowResponse{
+			Line:       row.Line,
+			BookID:     row.BookID,
+			ISBN:       row.ISBN,
+			ExternalID: row.ExternalID,
+			Title:      row.Title,
+			Status:     row.Status,
+			Errors:     row.Errors,
+		})
+	}
+
+	return ImportReportResponse{
+		DryRun:  report.DryRun,
+		Created: report.Count(ImportCreated),
+		Updated: report.Count(ImportUpdated),
+		Failed:  report.Count(ImportFailed),
+		Rows:    rows,
+	}
+}
+
+// OrphanUploadsReportResponse lists the orphan uploads deleted by a run, or the ones a dry run would delete.
+// Referenced is the number of uploads no longer tracked because they are referenced.
+type OrphanUploadsReportResponse struct {
+	DryRun     bool                   `json:"dryRun"`
+	Orphans    []OrphanUploadResponse `json:"orphans"`
+	Referenced int64                  `json:"referenced"`
+}
+
+type OrphanUploadResponse struct {
+	Key       string    `json:"key"`
+	TrackedAt time.Time `json:"trackedAt"`
+}
+
+func NewOrphanUploadsReportResponse(dryRun bool, orphans []Upload) OrphanUploadsReportResponse {
+	responses := make([]OrphanUploadResponse, 0, len(orphans))
+	for _, orphan := range orphans {
+		responses = append(responses, OrphanUploadResponse{Key: orphan.Key, TrackedAt: orphan.CreatedAt})
+	}
+
+	return OrphanUploadsReportResponse{DryRun: dryRun, Orphans: responses}
+}
@@ -1,6 +1,7 @@
 package catalog
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -8,12 +9,26 @@ import (
 )
 
 func TestNewBookResponse(t *testing.T) {
+	isbn := "9780132350884"
 	book := Book{
 		ID:          "some-id",
 		Title:       "Clean Code",
+		Subtitle:    "A Handbook of Agile Software Craftsmanship",
 		Description: "Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		ContentID:   "some-key2",
+		ISBN:        &isbn,
+		Publisher:   "Prentice Hall",
+		Language:    "en",
+		PageCount:   464,
+		Edition:     "1st",
+		AccessibilityFeatures: []AccessibilityFeature{
+			{BookID: "some-id", Feature: "tableOfContents"},
+		},
+		Authors: []BookAuthor{
+			{BookID: "some-id", AuthorID: "author-id", Role: RoleAuthor, Author: Author{ID: "author-id", Name: "Robert C. Martin", Slug: "robert-c-martin"}},
+		},
+		Files: []BookFile{
+			{BookID: "some-id", Format: FormatPDF, ContentID: "some-key2", MediaType: "application/pdf", Size: 2048},
+		},
 		Images: []Image{
 			{
 				ID:          "some-id",
@@ -21,17 +36,32 @@ func TestNewBookResponse(t *testing.T) {
 				BookID:      "some-id",
 			},
 		},
-		Price:       40000,
-		ReleaseDate: time.Date(2020, time.September, 23, 0, 0, 0, 0, time.UTC),
-		CreatedAt:   time.Date(2022, time.September, 23, 0, 0, 0, 0, time.UTC),
-		UpdatedAt:   time.Date(2022, time.September, 23, 20, 0, 0, 0, time.UTC),
+		Categories: []Category{
+			{ID: "category-id", Name: "Software Engineering", Slug: "software-engineering"},
+		},
+		Price:         40000,
+		RatingAverage: 4.5,
+		RatingCount:   12,
+		ReleaseDate:   time.Date(2020, time.September, 23, 0, 0, 0, 0, time.UTC),
+		CreatedAt:     time.Date(2022, time.September, 23, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:     time.Date(2022, time.September, 23, 20, 0, 0, 0, time.UTC),
 	}
 
 	expected := BookResponse{
-		ID:          book.ID,
-		Title:       book.Title,
-		Description: book.Description,
-		AuthorName:  book.AuthorName,
+		ID:                    book.ID,
+		Title:                 book.Title,
+		Subtitle:              book.Subtitle,
+		Description:           book.Description,
+		ISBN:                  "9780132350884",
+		ISBN10:                "0132350882",
+		Publisher:             "Prentice Hall",
+		Language:              "en",
+		PageCount:             464,
+		Edition:               "1st",
+		AccessibilityFeatures: []string{"tableOfContents"},
+		Authors: []BookAuthorResponse{
+			{ID: "author-id", Name: "Robert C. Martin", Slug: "robert-c-martin", Role: RoleAuthor},
+		},
 		Images: []ImageResponse{
 			{
 				ID:          "some-id",
@@ -40,13 +70,21 @@ func TestNewBookResponse(t *testing.T) {
 			},
 		},
 		MainImageID: book.MainImageID(),
-		Price:       book.Price,
-		ReleaseDate: book.ReleaseDate,
-		CreatedAt:   book.CreatedAt,
-		UpdatedAt:   book.UpdatedAt,
+		Categories: []CategorySummaryResponse{
+			{ID: "category-id", Name: "Software Engineering", Slug: "software-engineering"},
+		},
+		Formats: []BookFileResponse{
+			{Format: FormatPDF, MediaType: "application/pdf", Size: 2048},
+		},
+		Price:         book.Price,
+		RatingAverage: 4.5,
+		RatingCount:   12,
+		ReleaseDate:   book.ReleaseDate,
+		CreatedAt:     book.CreatedAt,
+		UpdatedAt:     book.UpdatedAt,
 	}
 
-	actual := NewBookResponse(book, []string{"link"})
+	actual := NewBookResponse(book, []ImageLinks{{Link: "link"}})
 
 	assert.Equal(t, expected, actual)
 }
@@ -62,9 +100,10 @@ func TestNewImageResponse(t *testing.T) {
 		ID:          image.ID,
 		Description: image.Description,
 		Link:        "link",
+		Variants:    map[VariantName]string{VariantThumbnail: "thumbnail-link"},
 	}
 
-	actual := NewImageResponse(image, "link")
+	actual := NewImageResponse(image, ImageLinks{Link: "link", Variants: map[VariantName]string{VariantThumbnail: "thumbnail-link"}})
 
 	assert.Equal(t, expected, actual)
 }
@@ -74,8 +113,6 @@ func TestNewPaginatedBooksResponse(t *testing.T) {
 		ID:          "some-id",
 		Title:       "Clean Code",
 		Description: "Craftsman Guide",
-		AuthorName:  "Robert C. Martin",
-		ContentID:   "some-key2",
 		Price:       40000,
 		ReleaseDate: time.Date(2020, time.September, 23, 0, 0, 0, 0, time.UTC),
 		CreatedAt:   time.Date(2022, time.September, 23, 0, 0, 0, 0, time.UTC),
@@ -86,7 +123,6 @@ func TestNewPaginatedBooksResponse(t *testing.T) {
 		ID:          "some-id2",
 		Title:       "Clean Coder",
 		Description: "Professional Guide",
-		AuthorName:  "Robert C. Martin",
 		Images: []Image{
 			{
 				ID:          "some-id",
@@ -94,7 +130,6 @@ func TestNewPaginatedBooksResponse(t *testing.T) {
 				BookID:      "some-id2",
 			},
 		},
-		ContentID:   "some-key22",
 		Price:       45000,
 		ReleaseDate: time.Date(2020, time.September, 22, 0, 0, 0, 0, time.UTC),
 		CreatedAt:   time.Date(2022, time.September, 22, 0, 0, 0, 0, time.UTC),
@@ -105,8 +140,6 @@ func TestNewPaginatedBooksResponse(t *testing.T) {
 		ID:          "some-id4",
 		Title:       "Clean Architecture",
 		Description: "Architecture Guide",
-		AuthorName:  "Robert C. Martin",
-		ContentID:   "some-key23",
 		Price:       60000,
 		ReleaseDate: time.Date(2021, time.September, 23, 0, 0, 0, 0, time.UTC),
 		CreatedAt:   time.Date(2023, time.September, 23, 0, 0, 0, 0, time.UTC),
@@ -120,14 +153,132 @@ func TestNewPaginatedBooksResponse(t *testing.T) {
 		TotalBooks: 3,
 	}
 
+	currentPage, totalPages, totalItems := 1, 1, int64(3)
 	expected := PaginatedBooksResponse{
-		Results:     []BookResponse{NewBookResponse(book1, nil), NewBookResponse(book2, []string{"link"}), NewBookResponse(book3, nil)},
-		CurrentPage: 1,
+		Results:     []BookResponse{NewBookResponse(book1, nil), NewBookResponse(book2, []ImageLinks{{Link: "link"}}), NewBookResponse(book3, nil)},
+		CurrentPage: &currentPage,
 		PerPage:     10,
-		TotalPages:  1,
-		TotalItems:  3,
+		TotalPages:  &totalPages,
+		TotalItems:  &totalItems,
+	}
+	actual := NewPaginatedBooksResponse(paginatedBooks, map[string][]ImageLinks{"some-id2": {{Link: "link"}}})
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewPaginatedBooksResponse_EmptyPage(t *testing.T) {
+	paginatedBooks := PaginatedBooks{Limit: 10}
+
+	actual, err := json.Marshal(NewPaginatedBooksResponse(paginatedBooks, map[string][]ImageLinks{}))
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"results":[],"currentPage":1,"perPage":10,"totalPages":0,"totalItems":0}`, string(actual))
+}
+
+func TestNewPaginatedBooksResponse_WithKeyset(t *testing.T) {
+	book := Book{
+		ID:          "some-id",
+		Title:       "Clean Code",
+		Description: "Craftsman Guide",
+		Price:       40000,
 	}
-	actual := NewPaginatedBooksResponse(paginatedBooks, map[string][]string{"some-id2": {"link"}})
+
+	paginatedBooks := PaginatedBooks{
+		Books:      []Book{book},
+		Limit:      1,
+		Keyset:     true,
+		NextCursor: "next-cursor",
+		PrevCursor: "prev-cursor",
+	}
+
+	expected := PaginatedBooksResponse{
+		Results:    []BookResponse{NewBookResponse(book, nil)},
+		PerPage:    1,
+		NextCursor: "next-cursor",
+		PrevCursor: "prev-cursor",
+	}
+	actual := NewPaginatedBooksResponse(paginatedBooks, map[string][]ImageLinks{})
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewCategoryTreeResponse(t *testing.T) {
+	fictionID := "fiction-id"
+	scienceFictionID := "science-fiction-id"
+
+	fiction := Category{ID: fictionID, Name: "Fiction", Slug: "fiction"}
+	scienceFiction := Category{ID: scienceFictionID, Name: "Science Fiction", Slug: "science-fiction", ParentID: &fictionID}
+	fantasy := Category{ID: "fantasy-id", Name: "Fantasy", Slug: "fantasy", ParentID: &fictionID}
+	cyberpunk := Category{ID: "cyberpunk-id", Name: "Cyberpunk", Slug: "cyberpunk", ParentID: &scienceFictionID}
+	computing := Category{ID: "computing-id", Name: "Computing", Slug: "computing"}
+
+	counts := map[string]int64{fictionID: 3, scienceFictionID: 2, "cyberpunk-id": 1, "fantasy-id": 1}
+
+	cyberpunkResponse := NewCategoryResponse(cyberpunk, 1)
+	scienceFictionResponse := NewCategoryResponse(scienceFiction, 2)
+	scienceFictionResponse.Children = []CategoryResponse{cyberpunkResponse}
+	fantasyResponse := NewCategoryResponse(fantasy, 1)
+	fictionResponse := NewCategoryResponse(fiction, 3)
+	fictionResponse.Children = []CategoryResponse{fantasyResponse, scienceFictionResponse}
+
+	expected := []CategoryResponse{NewCategoryResponse(computing, 0), fictionResponse}
+	actual := NewCategoryTreeResponse([]Category{cyberpunk, fiction, scienceFiction, fantasy, computing}, counts)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewAuthorResponse(t *testing.T) {
+	author := Author{
+		ID:   "author-id",
+		Name: "Eric Evans",
+		Slug: "eric-evans",
+		Bio:  "Domain-Driven Design",
+		Books: []BookAuthor{
+			{BookID: "book-1", Role: RoleAuthor, Book: Book{Title: "Domain-Driven Design", ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC)}},
+			{BookID: "book-2", Role: RoleTranslator, Book: Book{Title: "DDD Reference", ReleaseDate: time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)}},
+		},
+	}
+
+	expected := AuthorResponse{
+		ID:       "author-id",
+		Name:     "Eric Evans",
+		Slug:     "eric-evans",
+		Bio:      "Domain-Driven Design",
+		PhotoURL: "link",
+		Books: []AuthorBookResponse{
+			{ID: "book-2", Title: "DDD Reference", Role: RoleTranslator, ReleaseDate: time.Date(2015, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "book-1", Title: "Domain-Driven Design", Role: RoleAuthor, ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	actual := NewAuthorResponse(author, "link")
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewImportReportResponse(t *testing.T) {
+	report := ImportReport{
+		DryRun: true,
+		Rows: []ImportRowResult{
+			{Line: 2, BookID: "book-1", ISBN: "9780132350884", Title: "Clean Code", Status: ImportUpdated},
+			{Line: 3, ExternalID: "BK-2", Title: "Refactoring", Status: ImportCreated},
+			{Line: 4, ExternalID: "BK-3", Status: ImportFailed, Errors: []string{"invalid price"}},
+		},
+	}
+
+	expected := ImportReportResponse{
+		DryRun:  true,
+		Created: 1,
+		Updated: 1,
+		Failed:  1,
+		Rows: []ImportRowResponse{
+			{Line: 2, BookID: "book-1", ISBN: "9780132350884", Title: "Clean Code", Status: ImportUpdated},
+			{Line: 3, ExternalID: "BK-2", Title: "Refactoring", Status: ImportCreated},
+			{Line: 4, ExternalID: "BK-3", Status: ImportFailed, Errors: []string{"invalid price"}},
+		},
+	}
+
+	actual := NewImportReportResponse(report)
 
 	assert.Equal(t, expected, actual)
 }
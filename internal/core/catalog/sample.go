@@ -0,0 +1,180 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// sampleFormats are the formats a sample can be generated from, in the order they are preferred.
+var sampleFormats = []BookFormat{FormatPDF, FormatEPUB}
+
+// BookSample is the free preview of a book. It's either uploaded by an admin or generated from the book content,
+// and can be downloaded without purchasing the book.
+type BookSample struct {
+	BookID    string `gorm:"primaryKey"`
+	Format    BookFormat
+	ContentID string
+	MediaType string
+	Size      int64
+	Generated bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SampleGenerator cuts a sample from the beginning of a book content. The length is the number of pages
+// of a PDF or the number of spine items, usually chapters, of an EPUB.
+type SampleGenerator interface {
+	Generate(format BookFormat, r io.Reader, length int) ([]byte, error)
+}
+
+// AttachBookSample sets a sample uploaded with a presigned URL as the sample of the book.
+func (c *Catalog) AttachBookSample(ctx context.Context, request AttachBookSample) (BookResponse, error) {
+	log.Infof(ctx, "new request for attaching the sample of book %s", request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed validating request: %w", err)
+	}
+
+	book, err := c.Repository.FindByID(ctx, request.BookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed finding book %s: %w", request.BookID, err)
+	}
+
+	object := ObjectInfo{Size: request.Size, ContentType: request.Format.MediaType()}
+	if err = c.verifyUpload(ctx, request.ContentID, object); err != nil {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed verifying sample: %w", err)
+	}
+
+	sample := request.BookSample()
+	if err = c.trackReplacedSample(ctx, book, sample); err != nil {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed tracking replaced sample: %w", err)
+	}
+
+	if err = c.Repository.SaveSample(ctx, &sample); err != nil {
+		return BookResponse{}, fmt.Errorf("(AttachBookSample) failed saving sample: %w", err)
+	}
+
+	return c.FindBookByID(ctx, request.BookID)
+}
+
+// GenerateBookSample cuts the sample of the book from its content in the requested format, or in the preferred one
+// when no format is requested, and stores it as the sample of the book.
+func (c *Catalog) GenerateBookSample(ctx context.Context, request GenerateBookSample) (BookResponse, error) {
+	log.Infof(ctx, "new request for generating the sample of book %s", request.BookID)
+
+	if !isAdmin(ctx) {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed validating access conditions: %w", ErrForbiddenCatalogAccess)
+	}
+
+	if err := c.Validator.Validate(request); err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed validating request: %w", err)
+	}
+
+	book, err := c.Repository.FindByID(ctx, request.BookID)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed finding book %s: %w", request.BookID, err)
+	}
+
+	file, ok := sampleSource(book, request.Format)
+	if !ok {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed finding %q file of book %s: %w", request.Format, request.BookID, ErrBookFormatNotAvailable)
+	}
+
+	content, err := c.openProcessedContent(ctx, file)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed reading content %s: %w", file.ContentID, err)
+	}
+	defer content.Close()
+
+	generated, err := c.SampleGenerator.Generate(file.Format, content, request.Length)
+	if err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed generating sample: %w", err)
+	}
+
+	sample := BookSample{
+		BookID:    book.ID,
+		Format:    file.Format,
+		ContentID: c.IDGenerator.NewID(),
+		MediaType: file.Format.MediaType(),
+		Size:      int64(len(generated)),
+		Generated: true,
+	}
+	// the sample is tracked like a presigned upload before it's stored, so it's collected if it's never saved
+	if err = c.UploadRepository.Track(ctx, []string{sample.ContentID}); err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed tracking sample: %w", err)
+	}
+
+	if err = c.StorageClient.PutObject(ctx, sample.ContentID, sample.MediaType, bytes.NewReader(generated)); err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed storing sample: %w", err)
+	}
+
+	if err = c.trackReplacedSample(ctx, book, sample); err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed tracking replaced sample: %w", err)
+	}
+
+	if err = c.Repository.SaveSample(ctx, &sample); err != nil {
+		return BookResponse{}, fmt.Errorf("(GenerateBookSample) failed saving sample: %w", err)
+	}
+
+	if err = c.UploadRepository.Untrack(ctx, []string{sample.ContentID}); err != nil {
+		log.Warnf(ctx, "failed untracking saved sample %s: %v", sample.ContentID, err)
+	}
+
+	return c.FindBookByID(ctx, request.BookID)
+}
+
+// trackReplacedSample tracks the current sample of the book when the given one replaces it.
+func (c *Catalog) trackReplacedSample(ctx context.Context, book Book, sample BookSample) error {
+	if book.Sample == nil {
+		return nil
+	}
+	return c.trackRemovedUploads(ctx, replacedKey(book.Sample.ContentID, sample.ContentID))
+}
+
+// GetBookSampleURL returns a link to the sample of the book. Samples are free, so no purchase is checked.
+func (c *Catalog) GetBookSampleURL(ctx context.Context, id string) (SampleResponse, error) {
+	log.Infof(ctx, "new request for generating book sample url %s", id)
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return SampleResponse{}, fmt.Errorf("(GetBookSampleURL) failed finding book %s: %w", id, err)
+	}
+
+	if !book.Released() && !isAdmin(ctx) {
+		return SampleResponse{}, fmt.Errorf("(GetBookSampleURL) failed validating status of book %s: %w", id, ErrBookNotPublished)
+	}
+
+	if book.Sample == nil {
+		return SampleResponse{}, fmt.Errorf("(GetBookSampleURL) failed finding sample of book %s: %w", id, ErrSampleNotAvailable)
+	}
+
+	url, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, book.Sample.ContentID, AssetSample)
+	if err != nil {
+		return SampleResponse{}, fmt.Errorf("(GetBookSampleURL) failed generating presigned url: %w", err)
+	}
+
+	return NewSampleResponse(*book.Sample, url), nil
+}
+
+// sampleSource returns the file of the book a sample can be generated from.
+func sampleSource(book Book, format BookFormat) (BookFile, bool) {
+	if format != "" {
+		return book.File(format)
+	}
+
+	for _, f := range sampleFormats {
+		if file, ok := book.File(f); ok {
+			return file, true
+		}
+	}
+	return BookFile{}, false
+}
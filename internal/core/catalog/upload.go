@@ -0,0 +1,113 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	maxImageUploadSize = 10 << 20
+	maxFileUploadSize  = 500 << 20
+)
+
+// uploadContentTypes are the content types that can be uploaded with a presigned URL with their maximum size in bytes.
+var uploadContentTypes = map[string]int64{
+	"image/jpeg":                     maxImageUploadSize,
+	"image/png":                      maxImageUploadSize,
+	"image/gif":                      maxImageUploadSize,
+	"image/webp":                     maxImageUploadSize,
+	"application/pdf":                maxFileUploadSize,
+	"application/epub+zip":           maxFileUploadSize,
+	"application/x-mobipocket-ebook": maxFileUploadSize,
+}
+
+// ObjectInfo describes an object of the storage. Checksum is the hex encoded SHA-256 of the content, and is empty
+// when the object was uploaded without one.
+type ObjectInfo struct {
+	Size        int64
+	ContentType string
+	Checksum    string
+}
+
+// GeneratePutPreSignedUrl generates a URL to upload a single object. The storage rejects uploads of another
+// content type or size, or with another checksum when one is requested.
+func (c *Catalog) GeneratePutPreSignedUrl(ctx context.Context, request PresignUpload) (PresignURLResponse, error) {
+	if err := c.Validator.Validate(request); err != nil {
+		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) failed validating request: %w", err)
+	}
+
+	maxSize, ok := uploadContentTypes[request.ContentType]
+	if !ok {
+		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) content type %q is not allowed: %w", request.ContentType, ErrInvalidUpload)
+	}
+	if request.Size > maxSize {
+		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) size %d exceeds %d bytes: %w", request.Size, maxSize, ErrInvalidUpload)
+	}
+
+	// The upload is tracked before the URL exists, so it's collected if it's never referenced
+	id := c.IDGenerator.NewID()
+	if err := c.UploadRepository.Track(ctx, []string{id}); err != nil {
+		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) failed tracking upload: %w", err)
+	}
+
+	url, err := c.StorageClient.GeneratePutPreSignedUrl(ctx, id, request.ObjectInfo())
+	if err != nil {
+		return PresignURLResponse{}, fmt.Errorf("(GeneratePutPreSignedUrl) failed generating presigned url: %w", err)
+	}
+
+	return PresignURLResponse{ID: id, URL: url}, nil
+}
+
+// verifyUpload checks the object uploaded with the key has the given content type. The size and the checksum
+// are only compared when they are given.
+func (c *Catalog) verifyUpload(ctx context.Context, key string, expected ObjectInfo) error {
+	info, err := c.StorageClient.HeadObject(ctx, key)
+	if err != nil {
+		return fmt.Errorf("(verifyUpload) failed inspecting upload %s: %w", key, err)
+	}
+
+	if info.ContentType != expected.ContentType {
+		return fmt.Errorf("(verifyUpload) upload %s has content type %q instead of %q: %w", key, info.ContentType, expected.ContentType, ErrInvalidUpload)
+	}
+	if expected.Size > 0 && info.Size != expected.Size {
+		return fmt.Errorf("(verifyUpload) upload %s has %d bytes instead of %d: %w", key, info.Size, expected.Size, ErrInvalidUpload)
+	}
+	if expected.Checksum != "" && info.Checksum != "" && !strings.EqualFold(info.Checksum, expected.Checksum) {
+		return fmt.Errorf("(verifyUpload) upload %s checksum does not match: %w", key, ErrInvalidUpload)
+	}
+
+	return nil
+}
+
+// verifyImages checks the images were uploaded as one of the allowed image types. Images already attached to
+// the book were verified when they were added, so they are skipped.
+func (c *Catalog) verifyImages(ctx context.Context, book Book, images []ImageRequest) error {
+	for _, image := range images {
+		if book.imageIndex(image.ID) >= 0 {
+			continue
+		}
+
+		info, err := c.StorageClient.HeadObject(ctx, image.ID)
+		if err != nil {
+			return fmt.Errorf("(verifyImages) failed inspecting image %s: %w", image.ID, err)
+		}
+
+		if _, ok := uploadContentTypes[info.ContentType]; !ok || !strings.HasPrefix(info.ContentType, "image/") {
+			return fmt.Errorf("(verifyImages) image %s has content type %q: %w", image.ID, info.ContentType, ErrInvalidUpload)
+		}
+	}
+
+	return nil
+}
+
+// verifyBookFiles checks the files were uploaded with the media type of their format, and with the declared size and checksum.
+func (c *Catalog) verifyBookFiles(ctx context.Context, files []BookFileRequest) error {
+	for _, file := range files {
+		if err := c.verifyUpload(ctx, file.ContentID, file.ObjectInfo()); err != nil {
+			return fmt.Errorf("(verifyBookFiles) failed verifying %s file: %w", file.Format, err)
+		}
+	}
+
+	return nil
+}
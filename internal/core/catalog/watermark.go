@@ -0,0 +1,181 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// Watermark identifies the buyer a copy of a book is stamped for.
+type Watermark struct {
+	Name    string
+	Email   string
+	OrderID string
+	Time    time.Time
+}
+
+// WatermarkFunc returns the mark of the buyer a copy is stamped for. It's only called when the copy is stamped, on the
+// first download of a watermarked book, so the buyer is not looked up for the other downloads.
+type WatermarkFunc func(ctx context.Context) (Watermark, error)
+
+// Watermarker stamps a copy of a book content with the buyer it's delivered to, as a visible footer and as
+// metadata hidden in the file.
+type Watermarker interface {
+	Watermark(format BookFormat, r io.Reader, mark Watermark) ([]byte, error)
+}
+
+// WatermarkKey is the key of the copy of the content stamped for an order. A new upload of the content gets a new key,
+// so its copies are stamped again.
+func WatermarkKey(contentID, orderID string) string {
+	return watermarkPrefix(contentID) + orderID
+}
+
+// watermarkPrefix starts the keys of every copy stamped from the content.
+func watermarkPrefix(contentID string) string {
+	return fmt.Sprintf("watermarks/%s/", contentID)
+}
+
+// GetWatermarkedBookContentURL returns a link to the book content like GetBookContentURL, but to a copy stamped for
+// the buyer of the order when the book is watermarked and the content is a PDF. Protected books are refused, since
+// their buyers must get the encrypted content.
+func (c *Catalog) GetWatermarkedBookContentURL(ctx context.Context, id string, format BookFormat, orderID string, mark WatermarkFunc) (string, error) {
+	log.Infof(ctx, "new request for generating watermarked book content url %s", id)
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("(GetWatermarkedBookContentURL) failed finding book %s: %w", id, err)
+	}
+
+	if book.Protected {
+		return "", fmt.Errorf("(GetWatermarkedBookContentURL) failed validating book %s: %w", id, ErrBookProtected)
+	}
+
+	file, ok := book.File(format)
+	if !ok {
+		return "", fmt.Errorf("(GetWatermarkedBookContentURL) failed finding %q file of book %s: %w", format, id, ErrBookFormatNotAvailable)
+	}
+
+	key := file.ContentID
+	if isWatermarked(book, file) {
+		if key, _, err = c.watermarkedCopy(ctx, file, orderID, mark); err != nil {
+			return "", fmt.Errorf("(GetWatermarkedBookContentURL) failed watermarking content: %w", err)
+		}
+	}
+
+	contentUrl, err := c.StorageClient.GenerateGetPreSignedUrl(ctx, key, AssetBookFile)
+	if err != nil {
+		return "", fmt.Errorf("(GetWatermarkedBookContentURL) failed generating presigned url: %w", err)
+	}
+
+	return contentUrl, nil
+}
+
+// OpenWatermarkedBookContent opens the book content like OpenBookContent, but the copy stamped for the buyer when the
+// book is watermarked and the content is a PDF. Protected books are refused like in GetWatermarkedBookContentURL.
+func (c *Catalog) OpenWatermarkedBookContent(ctx context.Context, id string, format BookFormat, orderID string, mark WatermarkFunc) (BookContent, error) {
+	log.Infof(ctx, "new request for opening watermarked book content %s", id)
+
+	book, err := c.Repository.FindByID(ctx, id)
+	if err != nil {
+		return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed finding book %s: %w", id, err)
+	}
+
+	if book.Protected {
+		return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed validating book %s: %w", id, ErrBookProtected)
+	}
+
+	file, ok := book.File(format)
+	if !ok {
+		return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed finding %q file of book %s: %w", format, id, ErrBookFormatNotAvailable)
+	}
+
+	if !isWatermarked(book, file) {
+		content, err := c.bookContent(ctx, book, file)
+		if err != nil {
+			return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed opening %q file of book %s: %w", format, id, err)
+		}
+
+		return content, nil
+	}
+
+	key, object, err := c.watermarkedCopy(ctx, file, orderID, mark)
+	if err != nil {
+		return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed watermarking content: %w", err)
+	}
+
+	// the size of the copy replaces the size of the file, so the file is not inspected
+	file.Size = object.Size
+	content, err := c.bookContent(ctx, book, file)
+	if err != nil {
+		return BookContent{}, fmt.Errorf("(OpenWatermarkedBookContent) failed opening %q file of book %s: %w", format, id, err)
+	}
+	content.ReadSeekCloser = &objectReader{ctx: ctx, storage: c.StorageClient, key: key, size: object.Size}
+	content.Size = object.Size
+	content.ETag = fmt.Sprintf("%q", key)
+	return content, nil
+}
+
+// watermarkedCopy returns the key of the copy of the file stamped for the order, stamping it on the first download.
+// The copy keeps the time of that first download.
+func (c *Catalog) watermarkedCopy(ctx context.Context, file BookFile, orderID string, mark WatermarkFunc) (string, ObjectInfo, error) {
+	key := WatermarkKey(file.ContentID, orderID)
+
+	object, err := c.StorageClient.HeadObject(ctx, key)
+	if err == nil {
+		return key, object, nil
+	}
+	if !errors.Is(err, ErrUploadNotFound) {
+		return "", ObjectInfo{}, fmt.Errorf("(watermarkedCopy) failed finding copy %s: %w", key, err)
+	}
+
+	watermark, err := mark(ctx)
+	if err != nil {
+		return "", ObjectInfo{}, fmt.Errorf("(watermarkedCopy) failed finding watermark of order %s: %w", orderID, err)
+	}
+
+	content, err := c.openProcessedContent(ctx, file)
+	if err != nil {
+		return "", ObjectInfo{}, fmt.Errorf("(watermarkedCopy) failed reading content %s: %w", file.ContentID, err)
+	}
+	defer content.Close()
+
+	stamped, err := c.Watermarker.Watermark(file.Format, content, watermark)
+	if err != nil {
+		return "", ObjectInfo{}, fmt.Errorf("(watermarkedCopy) failed stamping content %s: %w", file.ContentID, err)
+	}
+
+	if err = c.StorageClient.PutObject(ctx, key, file.MediaType, bytes.NewReader(stamped)); err != nil {
+		return "", ObjectInfo{}, fmt.Errorf("(watermarkedCopy) failed storing copy %s: %w", key, err)
+	}
+
+	return key, ObjectInfo{Size: int64(len(stamped)), ContentType: file.MediaType}, nil
+}
+
+// verifyWatermarkSource stamps the file once without keeping the copy when the book is watermarked, so a PDF that cannot
+// be stamped, like an encrypted one, is refused when it's saved rather than when its buyers download it.
+func (c *Catalog) verifyWatermarkSource(ctx context.Context, book Book, file BookFile) error {
+	if !isWatermarked(book, file) {
+		return nil
+	}
+
+	content, err := c.openProcessedContent(ctx, file)
+	if err != nil {
+		return fmt.Errorf("(verifyWatermarkSource) failed reading content %s: %w", file.ContentID, err)
+	}
+	defer content.Close()
+
+	if _, err = c.Watermarker.Watermark(file.Format, content, Watermark{}); err != nil {
+		return fmt.Errorf("(verifyWatermarkSource) failed stamping content %s: %w", file.ContentID, err)
+	}
+
+	return nil
+}
+
+func isWatermarked(book Book, file BookFile) bool {
+	return book.Watermarked && file.Format == FormatPDF
+}
@@ -0,0 +1,57 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = fmt.Errorf("the provided cursor is not valid")
+
+// Cursor is a struct that represents a position in a result set sorted by a key and an ID.
+// It is used for keyset pagination and it is exposed to the clients as an opaque string.
+type Cursor struct {
+	SortKey  time.Time `json:"k"`
+	ID       string    `json:"i"`
+	Backward bool      `json:"b,omitempty"`
+}
+
+// NewCursor creates a cursor pointing after (or before, when backward is true) the given key.
+func NewCursor(sortKey time.Time, id string, backward bool) Cursor {
+	return Cursor{SortKey: sortKey, ID: id, Backward: backward}
+}
+
+// ParseCursor decodes a cursor previously encoded by Encode. An empty string is the start cursor.
+func ParseCursor(encoded string) (Cursor, error) {
+	if encoded == "" {
+		return Cursor{}, nil
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("(ParseCursor) failed decoding cursor: %w", ErrInvalidCursor)
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(bytes, &cursor); err != nil || cursor.ID == "" {
+		return Cursor{}, fmt.Errorf("(ParseCursor) failed unmarshalling cursor: %w", ErrInvalidCursor)
+	}
+
+	return cursor, nil
+}
+
+// Start returns true if the cursor points to the beginning of the result set.
+func (c Cursor) Start() bool {
+	return c.ID == ""
+}
+
+// Encode returns the opaque representation of the cursor.
+func (c Cursor) Encode() string {
+	if c.Start() {
+		return ""
+	}
+
+	bytes, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_EncodeAndParse(t *testing.T) {
+	cursor := NewCursor(time.Date(2022, time.September, 23, 10, 30, 0, 1000, time.UTC), "some-id", true)
+
+	actual, err := ParseCursor(cursor.Encode())
+
+	assert.Nil(t, err)
+	assert.True(t, cursor.SortKey.Equal(actual.SortKey))
+	assert.Equal(t, cursor.ID, actual.ID)
+	assert.Equal(t, cursor.Backward, actual.Backward)
+}
+
+func TestCursor_Start(t *testing.T) {
+	assert.True(t, Cursor{}.Start())
+	assert.Equal(t, "", Cursor{}.Encode())
+	assert.False(t, NewCursor(time.Now(), "some-id", false).Start())
+}
+
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoded  string
+		expected Cursor
+		err      error
+	}{
+		{
+			name:     "when cursor is empty, then it should return the start cursor",
+			encoded:  "",
+			expected: Cursor{},
+		},
+		{
+			name:    "when cursor is not base64, then it should return an error",
+			encoded: "%%%",
+			err:     ErrInvalidCursor,
+		},
+		{
+			name:    "when cursor is not a valid json, then it should return an error",
+			encoded: "c29tZS12YWx1ZQ",
+			err:     ErrInvalidCursor,
+		},
+		{
+			name:    "when cursor has no id, then it should return an error",
+			encoded: "e30",
+			err:     ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseCursor(tc.encoded)
+
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
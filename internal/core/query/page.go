@@ -4,8 +4,16 @@ package query
 type Page struct {
 	Number int
 	Size int
+	// Keyset switches the page from LIMIT/OFFSET to keyset pagination starting at Cursor.
+	Keyset bool
+	Cursor string
+	// IncludeTotal requests the total count for keyset pages. Offset pages are always counted.
+	IncludeTotal bool
 }
 
+// CursorPagination is the pagination mode clients use to request keyset pages.
+const CursorPagination = "cursor"
+
 // DefaultPage configuration
 var DefaultPage = Page {
 	Number: 1,
@@ -16,3 +24,8 @@ var DefaultPage = Page {
 func (p Page) Offset() int {
 	return (p.Number - 1) * p.Size
 }
+
+// Counted returns true if the total number of items must be calculated for the page.
+func (p Page) Counted() bool {
+	return !p.Keyset || p.IncludeTotal
+}
@@ -33,4 +33,36 @@ func TestPage_Offset(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestPage_Counted(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     Page
+		expected bool
+	}{
+		{
+			name:     "when page is offset based, then it should return true",
+			page:     Page{Number: 1, Size: 15},
+			expected: true,
+		},
+		{
+			name:     "when page is keyset based, then it should return false",
+			page:     Page{Size: 15, Keyset: true},
+			expected: false,
+		},
+		{
+			name:     "when page is keyset based and the total is requested, then it should return true",
+			page:     Page{Size: 15, Keyset: true, IncludeTotal: true},
+			expected: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.page.Counted()
+			if actual != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -9,6 +9,9 @@ const (
 	Equal    ComparisonOperator = "="
 	Match ComparisonOperator = "MATCH"
 	NotEqual ComparisonOperator = "!="
+	In       ComparisonOperator = "IN"
+	GreaterOrEqual ComparisonOperator = ">="
+	LessOrEqual    ComparisonOperator = "<="
 )
 
 // LogicalOperator is a string that represents a logical operator like AND, OR, etc.
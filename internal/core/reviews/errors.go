@@ -0,0 +1,6 @@
+package reviews
+
+import "fmt"
+
+var ErrReviewRequiresPurchase = fmt.Errorf("only buyers of a book can review it")
+var ErrForbiddenReviewAccess = fmt.Errorf("the access to this review is restricted to allowed users")
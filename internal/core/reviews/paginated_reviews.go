@@ -0,0 +1,8 @@
+package reviews
+
+type PaginatedReviews struct {
+	Reviews      []Review
+	Limit        int
+	Offset       int
+	TotalReviews int64
+}
@@ -0,0 +1,96 @@
+package reviews
+
+import "github.com/ebookstore/internal/core/query"
+
+type SearchReviews struct {
+	BookID  string `form:"-"`
+	Status  string `form:"status"`
+	UserID  string `form:"userId"`
+	Page    int    `form:"page"`
+	PerPage int    `form:"perPage"`
+}
+
+func (s *SearchReviews) CreateQuery() query.Query {
+	q := query.New()
+
+	if s.BookID != "" {
+		q.And(query.Condition{Field: "book_id", Operator: query.Equal, Value: s.BookID})
+	}
+
+	if s.Status != "" {
+		q.And(query.Condition{Field: "status", Operator: query.Equal, Value: s.Status})
+	}
+
+	if s.UserID != "" {
+		q.And(query.Condition{Field: "user_id", Operator: query.Equal, Value: s.UserID})
+	}
+
+	return *q
+}
+
+func (s *SearchReviews) CreatePage() query.Page {
+	p := query.DefaultPage
+
+	if s.Page > 0 {
+		p.Number = s.Page
+	}
+
+	if s.PerPage > 0 {
+		p.Size = s.PerPage
+	}
+
+	return p
+}
+
+type CreateReview struct {
+	BookID string `json:"-"`
+	Rating int    `json:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" validate:"max=150"`
+	Body   string `json:"body" validate:"max=5000"`
+}
+
+// Review creates a review pending moderation.
+func (c *CreateReview) Review(id, userID string) Review {
+	return Review{
+		ID:     id,
+		BookID: c.BookID,
+		UserID: userID,
+		Rating: c.Rating,
+		Title:  c.Title,
+		Body:   c.Body,
+		Status: Pending,
+	}
+}
+
+type UpdateReview struct {
+	ID     string  `json:"-"`
+	Rating *int    `json:"rating" validate:"omitempty,min=1,max=5"`
+	Title  *string `json:"title" validate:"omitempty,max=150"`
+	Body   *string `json:"body" validate:"omitempty,max=5000"`
+}
+
+// Update applies the changes to the review. Edited reviews go back to the moderation queue.
+func (u *UpdateReview) Update(existing Review) Review {
+	updated := existing
+
+	if u.Rating != nil {
+		updated.Rating = *u.Rating
+	}
+
+	if u.Title != nil {
+		updated.Title = *u.Title
+	}
+
+	if u.Body != nil {
+		updated.Body = *u.Body
+	}
+
+	updated.Status = Pending
+
+	return updated
+}
+
+type ModerateReview struct {
+	ID     string           `json:"-"`
+	Action ModerationAction `json:"action" validate:"required,oneof=approve reject hide"`
+}
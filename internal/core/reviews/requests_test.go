@@ -0,0 +1,68 @@
+package reviews
+
+import (
+	"testing"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchReviews_CreateQuery_WithEmptyData(t *testing.T) {
+	dto := SearchReviews{}
+
+	expected := *query.New()
+	actual := dto.CreateQuery()
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestSearchReviews_CreateQuery_WithAllFields(t *testing.T) {
+	dto := SearchReviews{BookID: "book-id", Status: "PENDING", UserID: "user-id"}
+
+	expected := *query.New().
+		And(query.Condition{Field: "book_id", Operator: query.Equal, Value: "book-id"}).
+		And(query.Condition{Field: "status", Operator: query.Equal, Value: "PENDING"}).
+		And(query.Condition{Field: "user_id", Operator: query.Equal, Value: "user-id"})
+	actual := dto.CreateQuery()
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestSearchReviews_CreatePage(t *testing.T) {
+	dto := SearchReviews{Page: 2, PerPage: 20}
+
+	expected := query.Page{Number: 2, Size: 20}
+	actual := dto.CreatePage()
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestCreateReview_Review(t *testing.T) {
+	dto := CreateReview{BookID: "book-id", Rating: 4, Title: "Great", Body: "A must read"}
+
+	expected := Review{
+		ID:     "review-id",
+		BookID: "book-id",
+		UserID: "user-id",
+		Rating: 4,
+		Title:  "Great",
+		Body:   "A must read",
+		Status: Pending,
+	}
+	actual := dto.Review("review-id", "user-id")
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestUpdateReview_Update(t *testing.T) {
+	rating := 2
+	body := "Not that good on a second read"
+	dto := UpdateReview{Rating: &rating, Body: &body}
+
+	existing := Review{ID: "review-id", Rating: 4, Title: "Great", Body: "A must read", Status: Approved}
+
+	expected := Review{ID: "review-id", Rating: 2, Title: "Great", Body: body, Status: Pending}
+	actual := dto.Update(existing)
+
+	assert.Equal(t, expected, actual)
+}
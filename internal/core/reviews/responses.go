@@ -0,0 +1,55 @@
+package reviews
+
+import (
+	"math"
+	"time"
+)
+
+type ReviewResponse struct {
+	ID        string    `json:"id"`
+	BookID    string    `json:"bookId"`
+	UserID    string    `json:"userId"`
+	Rating    int       `json:"rating"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func NewReviewResponse(review Review) ReviewResponse {
+	return ReviewResponse{
+		ID:        review.ID,
+		BookID:    review.BookID,
+		UserID:    review.UserID,
+		Rating:    review.Rating,
+		Title:     review.Title,
+		Body:      review.Body,
+		Status:    string(review.Status),
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+}
+
+type PaginatedReviewsResponse struct {
+	Results     []ReviewResponse `json:"results"`
+	CurrentPage int              `json:"currentPage"`
+	PerPage     int              `json:"perPage"`
+	TotalPages  int              `json:"totalPages"`
+	TotalItems  int64            `json:"totalItems"`
+}
+
+func NewPaginatedReviewsResponse(paginatedReviews PaginatedReviews) PaginatedReviewsResponse {
+	reviews := make([]ReviewResponse, 0, len(paginatedReviews.Reviews))
+	for _, r := range paginatedReviews.Reviews {
+		reviews = append(reviews, NewReviewResponse(r))
+	}
+
+	return PaginatedReviewsResponse{
+		Results:     reviews,
+		CurrentPage: (paginatedReviews.Offset / paginatedReviews.Limit) + 1,
+		PerPage:     paginatedReviews.Limit,
+		TotalPages:  int(math.Ceil(float64(paginatedReviews.TotalReviews) / float64(paginatedReviews.Limit))),
+		TotalItems:  paginatedReviews.TotalReviews,
+	}
+}
@@ -0,0 +1,54 @@
+package reviews
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReviewResponse(t *testing.T) {
+	review := Review{
+		ID:        "review-id",
+		BookID:    "book-id",
+		UserID:    "user-id",
+		Rating:    5,
+		Title:     "Great",
+		Body:      "A must read",
+		Status:    Approved,
+		CreatedAt: time.Date(2024, time.January, 10, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC),
+	}
+
+	expected := ReviewResponse{
+		ID:        "review-id",
+		BookID:    "book-id",
+		UserID:    "user-id",
+		Rating:    5,
+		Title:     "Great",
+		Body:      "A must read",
+		Status:    "APPROVED",
+		CreatedAt: review.CreatedAt,
+		UpdatedAt: review.UpdatedAt,
+	}
+	actual := NewReviewResponse(review)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewPaginatedReviewsResponse(t *testing.T) {
+	paginated := PaginatedReviews{
+		Reviews:      []Review{{ID: "review-id1"}, {ID: "review-id2"}},
+		Limit:        2,
+		Offset:       2,
+		TotalReviews: 5,
+	}
+
+	actual := NewPaginatedReviewsResponse(paginated)
+
+	assert.Len(t, actual.Results, 2)
+	assert.Equal(t, 2, actual.CurrentPage)
+	assert.Equal(t, 2, actual.PerPage)
+	assert.Equal(t, 3, actual.TotalPages)
+	assert.Equal(t, int64(5), actual.TotalItems)
+}
@@ -0,0 +1,51 @@
+package reviews
+
+import "time"
+
+type Status string
+
+const (
+	Pending  Status = "PENDING"
+	Approved Status = "APPROVED"
+	Rejected Status = "REJECTED"
+	Hidden   Status = "HIDDEN"
+)
+
+type ModerationAction string
+
+const (
+	Approve ModerationAction = "approve"
+	Reject  ModerationAction = "reject"
+	Hide    ModerationAction = "hide"
+)
+
+var moderationStatuses = map[ModerationAction]Status{
+	Approve: Approved,
+	Reject:  Rejected,
+	Hide:    Hidden,
+}
+
+// Review is the rating and the optional text a verified buyer left for a book.
+// Only approved reviews are public and counted in the rating of the book.
+type Review struct {
+	ID        string
+	BookID    string
+	UserID    string
+	Rating    int
+	Title     string
+	Body      string
+	Status    Status
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Moderate moves the review to the status resulting from the given moderation action.
+func (r *Review) Moderate(action ModerationAction) {
+	if status, ok := moderationStatuses[action]; ok {
+		r.Status = status
+	}
+}
+
+func (r *Review) WrittenBy(userID string) bool {
+	return r.UserID == userID
+}
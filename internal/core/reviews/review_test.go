@@ -0,0 +1,36 @@
+package reviews
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReview_Moderate(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   ModerationAction
+		expected Status
+	}{
+		{name: "approve", action: Approve, expected: Approved},
+		{name: "reject", action: Reject, expected: Rejected},
+		{name: "hide", action: Hide, expected: Hidden},
+		{name: "unknown action", action: "publish", expected: Pending},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			review := Review{Status: Pending}
+			review.Moderate(tc.action)
+
+			assert.Equal(t, tc.expected, review.Status)
+		})
+	}
+}
+
+func TestReview_WrittenBy(t *testing.T) {
+	review := Review{UserID: "user-id"}
+
+	assert.True(t, review.WrittenBy("user-id"))
+	assert.False(t, review.WrittenBy("other-user-id"))
+}
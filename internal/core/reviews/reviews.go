@@ -0,0 +1,184 @@
+package reviews
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/log"
+)
+
+// Repository stores the reviews. Implementations must keep the rating of the reviewed
+// book in sync with its approved reviews whenever a review is created, updated or deleted.
+type Repository interface {
+	FindByQuery(ctx context.Context, q query.Query, p query.Page) (PaginatedReviews, error)
+	FindByID(ctx context.Context, id string) (Review, error)
+	Create(ctx context.Context, review *Review) error
+	Update(ctx context.Context, review *Review) error
+	Delete(ctx context.Context, id string) error
+}
+
+type ShopService interface {
+	HasPurchased(ctx context.Context, userID, bookID string) (bool, error)
+}
+
+type IDGenerator interface {
+	NewID() string
+}
+
+type Validator interface {
+	Validate(i interface{}) error
+}
+
+type Config struct {
+	Repository  Repository
+	ShopService ShopService
+	IDGenerator IDGenerator
+	Validator   Validator
+}
+
+type Reviews struct {
+	Config
+}
+
+func New(c Config) *Reviews {
+	return &Reviews{Config: c}
+}
+
+// FindBookReviews returns the approved reviews of a book.
+func (r *Reviews) FindBookReviews(ctx context.Context, request SearchReviews) (PaginatedReviewsResponse, error) {
+	log.Infof(ctx, "new request for fetching reviews of book %s", request.BookID)
+
+	request.Status = string(Approved)
+	paginatedReviews, err := r.Repository.FindByQuery(ctx, request.CreateQuery(), request.CreatePage())
+	if err != nil {
+		return PaginatedReviewsResponse{}, fmt.Errorf("(FindBookReviews) failed fetching reviews: %w", err)
+	}
+
+	return NewPaginatedReviewsResponse(paginatedReviews), nil
+}
+
+// FindReviews returns the reviews of every status. It's used by admins to go through the moderation queue.
+func (r *Reviews) FindReviews(ctx context.Context, request SearchReviews) (PaginatedReviewsResponse, error) {
+	log.Infof(ctx, "new request for fetching reviews")
+
+	if !isAdmin(ctx) {
+		return PaginatedReviewsResponse{}, fmt.Errorf("(FindReviews) failed validating access: %w", ErrForbiddenReviewAccess)
+	}
+
+	paginatedReviews, err := r.Repository.FindByQuery(ctx, request.CreateQuery(), request.CreatePage())
+	if err != nil {
+		return PaginatedReviewsResponse{}, fmt.Errorf("(FindReviews) failed fetching reviews: %w", err)
+	}
+
+	return NewPaginatedReviewsResponse(paginatedReviews), nil
+}
+
+// CreateReview creates a review for the current user, who must have bought the book.
+func (r *Reviews) CreateReview(ctx context.Context, request CreateReview) (ReviewResponse, error) {
+	log.Infof(ctx, "new request for reviewing book %s", request.BookID)
+
+	if err := r.Validator.Validate(request); err != nil {
+		return ReviewResponse{}, fmt.Errorf("(CreateReview) failed validating request: %w", err)
+	}
+
+	purchased, err := r.ShopService.HasPurchased(ctx, userId(ctx), request.BookID)
+	if err != nil {
+		return ReviewResponse{}, fmt.Errorf("(CreateReview) failed checking purchase: %w", err)
+	}
+
+	if !purchased {
+		return ReviewResponse{}, fmt.Errorf("(CreateReview) failed validating purchase: %w", ErrReviewRequiresPurchase)
+	}
+
+	review := request.Review(r.IDGenerator.NewID(), userId(ctx))
+	if err = r.Repository.Create(ctx, &review); err != nil {
+		return ReviewResponse{}, fmt.Errorf("(CreateReview) failed creating review: %w", err)
+	}
+
+	return NewReviewResponse(review), nil
+}
+
+// UpdateReview updates a review of the current user and sends it back to moderation.
+func (r *Reviews) UpdateReview(ctx context.Context, request UpdateReview) error {
+	log.Infof(ctx, "new request for updating review %s", request.ID)
+
+	if err := r.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(UpdateReview) failed validating request: %w", err)
+	}
+
+	existing, err := r.Repository.FindByID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("(UpdateReview) failed finding review: %w", err)
+	}
+
+	if !existing.WrittenBy(userId(ctx)) {
+		return fmt.Errorf("(UpdateReview) failed validating access: %w", ErrForbiddenReviewAccess)
+	}
+
+	updated := request.Update(existing)
+	if err = r.Repository.Update(ctx, &updated); err != nil {
+		return fmt.Errorf("(UpdateReview) failed updating review: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteReview deletes a review. Users can delete their own reviews, admins can delete any review.
+func (r *Reviews) DeleteReview(ctx context.Context, id string) error {
+	log.Infof(ctx, "new request for deleting review %s", id)
+
+	existing, err := r.Repository.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("(DeleteReview) failed finding review: %w", err)
+	}
+
+	if !existing.WrittenBy(userId(ctx)) && !isAdmin(ctx) {
+		return fmt.Errorf("(DeleteReview) failed validating access: %w", ErrForbiddenReviewAccess)
+	}
+
+	if err = r.Repository.Delete(ctx, id); err != nil {
+		return fmt.Errorf("(DeleteReview) failed deleting review: %w", err)
+	}
+
+	return nil
+}
+
+// ModerateReview approves, rejects or hides a review.
+func (r *Reviews) ModerateReview(ctx context.Context, request ModerateReview) error {
+	log.Infof(ctx, "new request for moderating review %s", request.ID)
+
+	if !isAdmin(ctx) {
+		return fmt.Errorf("(ModerateReview) failed validating access: %w", ErrForbiddenReviewAccess)
+	}
+
+	if err := r.Validator.Validate(request); err != nil {
+		return fmt.Errorf("(ModerateReview) failed validating request: %w", err)
+	}
+
+	review, err := r.Repository.FindByID(ctx, request.ID)
+	if err != nil {
+		return fmt.Errorf("(ModerateReview) failed finding review: %w", err)
+	}
+
+	review.Moderate(request.Action)
+	if err = r.Repository.Update(ctx, &review); err != nil {
+		return fmt.Errorf("(ModerateReview) failed updating review: %w", err)
+	}
+
+	return nil
+}
+
+func isAdmin(ctx context.Context) bool {
+	admin, ok := ctx.Value("admin").(bool)
+	if !ok {
+		return false
+	}
+
+	return admin
+}
+
+func userId(ctx context.Context) string {
+	id, _ := ctx.Value("userId").(string)
+	return id
+}
@@ -0,0 +1,238 @@
+package reviews_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/ebookstore/internal/core/reviews"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	findByQueryMethod  = "FindByQuery"
+	findByIDMethod     = "FindByID"
+	createMethod       = "Create"
+	updateMethod       = "Update"
+	deleteMethod       = "Delete"
+	hasPurchasedMethod = "HasPurchased"
+	newIDMethod        = "NewID"
+	validateMethod     = "Validate"
+)
+
+type ReviewsTestSuite struct {
+	suite.Suite
+	repo        *reviews.MockRepository
+	shopService *reviews.MockShopService
+	idGenerator *reviews.MockIDGenerator
+	validator   *reviews.MockValidator
+	reviews     *reviews.Reviews
+}
+
+func (s *ReviewsTestSuite) SetupTest() {
+	s.repo = new(reviews.MockRepository)
+	s.shopService = new(reviews.MockShopService)
+	s.idGenerator = new(reviews.MockIDGenerator)
+	s.validator = new(reviews.MockValidator)
+
+	s.reviews = reviews.New(reviews.Config{
+		Repository:  s.repo,
+		ShopService: s.shopService,
+		IDGenerator: s.idGenerator,
+		Validator:   s.validator,
+	})
+}
+
+func TestReviews(t *testing.T) {
+	suite.Run(t, new(ReviewsTestSuite))
+}
+
+func customerContext() context.Context {
+	return context.WithValue(context.Background(), "userId", "user-id")
+}
+
+func adminContext() context.Context {
+	ctx := context.WithValue(context.Background(), "userId", "admin-id")
+	return context.WithValue(ctx, "admin", true)
+}
+
+func (s *ReviewsTestSuite) TestFindBookReviews_OnlyApproved() {
+	ctx := context.Background()
+	request := reviews.SearchReviews{BookID: "book-id", Status: string(reviews.Pending)}
+
+	expectedRequest := reviews.SearchReviews{BookID: "book-id", Status: string(reviews.Approved)}
+	paginated := reviews.PaginatedReviews{Reviews: []reviews.Review{{ID: "review-id"}}, Limit: 15}
+	s.repo.On(findByQueryMethod, ctx, expectedRequest.CreateQuery(), expectedRequest.CreatePage()).Return(paginated, nil)
+
+	actual, err := s.reviews.FindBookReviews(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), reviews.NewPaginatedReviewsResponse(paginated), actual)
+	s.repo.AssertNumberOfCalls(s.T(), findByQueryMethod, 1)
+}
+
+func (s *ReviewsTestSuite) TestFindReviews_Unauthorized() {
+	_, err := s.reviews.FindReviews(customerContext(), reviews.SearchReviews{Status: string(reviews.Pending)})
+
+	assert.ErrorIs(s.T(), err, reviews.ErrForbiddenReviewAccess)
+	s.repo.AssertNotCalled(s.T(), findByQueryMethod)
+}
+
+func (s *ReviewsTestSuite) TestFindReviews_Successfully() {
+	ctx := adminContext()
+	request := reviews.SearchReviews{Status: string(reviews.Pending)}
+
+	paginated := reviews.PaginatedReviews{Reviews: []reviews.Review{{ID: "review-id"}}, Limit: 15}
+	s.repo.On(findByQueryMethod, ctx, request.CreateQuery(), request.CreatePage()).Return(paginated, nil)
+
+	actual, err := s.reviews.FindReviews(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), reviews.NewPaginatedReviewsResponse(paginated), actual)
+}
+
+func (s *ReviewsTestSuite) TestCreateReview_WithValidationError() {
+	ctx := customerContext()
+	request := reviews.CreateReview{BookID: "book-id", Rating: 6}
+
+	s.validator.On(validateMethod, request).Return(fmt.Errorf("some error"))
+
+	_, err := s.reviews.CreateReview(ctx, request)
+
+	assert.Error(s.T(), err)
+	s.shopService.AssertNotCalled(s.T(), hasPurchasedMethod)
+	s.repo.AssertNotCalled(s.T(), createMethod)
+}
+
+func (s *ReviewsTestSuite) TestCreateReview_WithoutPurchase() {
+	ctx := customerContext()
+	request := reviews.CreateReview{BookID: "book-id", Rating: 5}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.shopService.On(hasPurchasedMethod, ctx, "user-id", "book-id").Return(false, nil)
+
+	_, err := s.reviews.CreateReview(ctx, request)
+
+	assert.ErrorIs(s.T(), err, reviews.ErrReviewRequiresPurchase)
+	s.repo.AssertNotCalled(s.T(), createMethod)
+}
+
+func (s *ReviewsTestSuite) TestCreateReview_WithRepositoryError() {
+	ctx := customerContext()
+	request := reviews.CreateReview{BookID: "book-id", Rating: 5}
+	review := request.Review("review-id", "user-id")
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.shopService.On(hasPurchasedMethod, ctx, "user-id", "book-id").Return(true, nil)
+	s.idGenerator.On(newIDMethod).Return("review-id")
+	s.repo.On(createMethod, ctx, &review).Return(fmt.Errorf("some error"))
+
+	_, err := s.reviews.CreateReview(ctx, request)
+
+	assert.Error(s.T(), err)
+}
+
+func (s *ReviewsTestSuite) TestCreateReview_Successfully() {
+	ctx := customerContext()
+	request := reviews.CreateReview{BookID: "book-id", Rating: 5, Title: "Great"}
+	review := request.Review("review-id", "user-id")
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.shopService.On(hasPurchasedMethod, ctx, "user-id", "book-id").Return(true, nil)
+	s.idGenerator.On(newIDMethod).Return("review-id")
+	s.repo.On(createMethod, ctx, &review).Return(nil)
+
+	actual, err := s.reviews.CreateReview(ctx, request)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), reviews.NewReviewResponse(review), actual)
+}
+
+func (s *ReviewsTestSuite) TestUpdateReview_FromAnotherUser() {
+	ctx := customerContext()
+	rating := 3
+	request := reviews.UpdateReview{ID: "review-id", Rating: &rating}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(reviews.Review{ID: "review-id", UserID: "other-user-id"}, nil)
+
+	err := s.reviews.UpdateReview(ctx, request)
+
+	assert.ErrorIs(s.T(), err, reviews.ErrForbiddenReviewAccess)
+	s.repo.AssertNotCalled(s.T(), updateMethod)
+}
+
+func (s *ReviewsTestSuite) TestUpdateReview_Successfully() {
+	ctx := customerContext()
+	rating := 3
+	request := reviews.UpdateReview{ID: "review-id", Rating: &rating}
+	existing := reviews.Review{ID: "review-id", UserID: "user-id", Rating: 5, Status: reviews.Approved}
+	updated := request.Update(existing)
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(existing, nil)
+	s.repo.On(updateMethod, ctx, &updated).Return(nil)
+
+	err := s.reviews.UpdateReview(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertCalled(s.T(), updateMethod, ctx, &updated)
+}
+
+func (s *ReviewsTestSuite) TestDeleteReview_FromAnotherUser() {
+	ctx := customerContext()
+
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(reviews.Review{ID: "review-id", UserID: "other-user-id"}, nil)
+
+	err := s.reviews.DeleteReview(ctx, "review-id")
+
+	assert.ErrorIs(s.T(), err, reviews.ErrForbiddenReviewAccess)
+	s.repo.AssertNotCalled(s.T(), deleteMethod)
+}
+
+func (s *ReviewsTestSuite) TestDeleteReview_ByAdmin() {
+	ctx := adminContext()
+
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(reviews.Review{ID: "review-id", UserID: "user-id"}, nil)
+	s.repo.On(deleteMethod, ctx, "review-id").Return(nil)
+
+	err := s.reviews.DeleteReview(ctx, "review-id")
+
+	assert.Nil(s.T(), err)
+}
+
+func (s *ReviewsTestSuite) TestDeleteReview_WithRepositoryError() {
+	ctx := customerContext()
+
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(reviews.Review{}, fmt.Errorf("some error"))
+
+	err := s.reviews.DeleteReview(ctx, "review-id")
+
+	assert.Error(s.T(), err)
+	s.repo.AssertNotCalled(s.T(), deleteMethod)
+}
+
+func (s *ReviewsTestSuite) TestModerateReview_Unauthorized() {
+	err := s.reviews.ModerateReview(customerContext(), reviews.ModerateReview{ID: "review-id", Action: reviews.Approve})
+
+	assert.ErrorIs(s.T(), err, reviews.ErrForbiddenReviewAccess)
+	s.repo.AssertNotCalled(s.T(), findByIDMethod)
+}
+
+func (s *ReviewsTestSuite) TestModerateReview_Successfully() {
+	ctx := adminContext()
+	request := reviews.ModerateReview{ID: "review-id", Action: reviews.Hide}
+
+	s.validator.On(validateMethod, request).Return(nil)
+	s.repo.On(findByIDMethod, ctx, "review-id").Return(reviews.Review{ID: "review-id", Status: reviews.Approved}, nil)
+	s.repo.On(updateMethod, ctx, mock.MatchedBy(func(r *reviews.Review) bool {
+		return r.ID == "review-id" && r.Status == reviews.Hidden
+	})).Return(nil)
+
+	err := s.reviews.ModerateReview(ctx, request)
+
+	assert.Nil(s.T(), err)
+	s.repo.AssertNumberOfCalls(s.T(), updateMethod, 1)
+}
@@ -0,0 +1,118 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/log"
+)
+
+// Download is a purchased book streamed by the API, or the part of it requested when a download is resumed.
+type Download struct {
+	ID      string
+	UserID  string
+	OrderID string
+	ItemID  string
+	Format  catalog.BookFormat
+	// Offset is the first byte sent and Bytes the number of bytes sent from there
+	Offset int64
+	Bytes  int64
+	// Size is the size of the whole file. The download is completed when its last byte was sent
+	Size      int64
+	Completed bool
+	CreatedAt time.Time
+}
+
+// StreamOrderItemContent opens the content of a purchased book, so the API streams it instead of handing out a link
+// to the storage. The download is recorded when the content is closed, with the bytes read until then.
+func (s *Shop) StreamOrderItemContent(ctx context.Context, request DownloadOrderContentRequest) (catalog.BookContent, error) {
+	log.Infof(ctx, "streaming content for item %s of order %s", request.ItemID, request.OrderID)
+
+	if err := s.authorizeDownload(ctx, request); err != nil {
+		return catalog.BookContent{}, fmt.Errorf("(StreamOrderItemContent) failed authorizing download: %w", err)
+	}
+
+	content, err := s.CatalogService.OpenWatermarkedBookContent(ctx, request.ItemID, catalog.BookFormat(request.Format), request.OrderID, s.watermark(request.OrderID))
+	if err != nil {
+		return catalog.BookContent{}, fmt.Errorf("(StreamOrderItemContent) failed opening book content: %w", err)
+	}
+
+	content.ReadSeekCloser = &downloadStream{
+		ReadSeekCloser: content.ReadSeekCloser,
+		ctx:            detachedContext{ctx},
+		repository:     s.DownloadRepository,
+		download: Download{
+			ID:      s.IDGenerator.NewID(),
+			UserID:  userId(ctx),
+			OrderID: request.OrderID,
+			ItemID:  request.ItemID,
+			Format:  content.Format,
+			Size:    content.Size,
+		},
+	}
+
+	return content, nil
+}
+
+// downloadStream counts the bytes read from the content and records the download when it's closed. Nothing is
+// recorded when nothing was read, like when the client already had the content.
+type downloadStream struct {
+	io.ReadSeekCloser
+	ctx        context.Context
+	repository DownloadRepository
+	download   Download
+	offset     int64
+	started    bool
+}
+
+func (d *downloadStream) Read(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.download.Offset = d.offset
+	}
+
+	n, err := d.ReadSeekCloser.Read(p)
+	d.offset += int64(n)
+	d.download.Bytes += int64(n)
+	if d.offset >= d.download.Size {
+		d.download.Completed = true
+	}
+
+	return n, err
+}
+
+func (d *downloadStream) Seek(offset int64, whence int) (int64, error) {
+	position, err := d.ReadSeekCloser.Seek(offset, whence)
+	if err == nil {
+		d.offset = position
+	}
+
+	return position, err
+}
+
+func (d *downloadStream) Close() error {
+	err := d.ReadSeekCloser.Close()
+
+	if d.started {
+		if saveErr := d.repository.Save(d.ctx, &d.download); saveErr != nil {
+			log.Errorf(d.ctx, "failed recording download of item %s of order %s: %v", d.download.ItemID, d.download.OrderID, saveErr)
+		}
+	}
+
+	return err
+}
+
+// detachedContext keeps the values of the request, like the correlation ID, without being cancelled with it, so the
+// downloads cut by the client are recorded as well.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+
+func (detachedContext) Done() <-chan struct{} { return nil }
+
+func (detachedContext) Err() error { return nil }
@@ -7,3 +7,9 @@ var ErrForbiddenOrderAccess = fmt.Errorf("the access to this order is restricted
 var ErrItemAlreadyInCart = fmt.Errorf("item already in cart")
 var ErrItemNotFoundInCart = fmt.Errorf("item not found in cart")
 var ErrItemNotFoundInOrder = fmt.Errorf("item not found in order")
+var ErrItemAlreadyInWishlist = fmt.Errorf("item already in wishlist")
+var ErrItemNotAvailable = fmt.Errorf("the item is no longer sold")
+var ErrItemNotFoundInWishlist = fmt.Errorf("item not found in wishlist")
+var ErrItemNotReleased = fmt.Errorf("the book is not released yet")
+var ErrLicensePassphraseRequired = fmt.Errorf("a license passphrase must be set to download protected books")
+var ErrLicenseExpired = fmt.Errorf("the license of the book expired")
@@ -5,6 +5,10 @@ type Item struct {
 	Name           string
 	Price          int64
 	PreviewImageID string
+	// PreOrder is set when the book is ordered before its release date, it can't be downloaded until then.
+	// ReleaseNotified is set once the customer was emailed that the pre-ordered book is released.
+	PreOrder        bool
+	ReleaseNotified bool
 	// OrderID it is only defined when the item is part of an order
 	OrderID string `gorm:"primaryKey"`
 }
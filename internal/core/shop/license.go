@@ -0,0 +1,142 @@
+package shop
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// LicenseMediaType is the media type of the license documents, which the reading apps open to get the encrypted
+// publication.
+const LicenseMediaType = "application/vnd.readium.lcp.license.v1.0+json"
+
+// License grants the buyer of a protected book the rights to read it. It's issued when the order is paid, and
+// delivered as a license document on every download.
+type License struct {
+	ID      string
+	UserID  string
+	OrderID string
+	BookID  string
+	// Print is the number of pages that can be printed and Copy the number of characters that can be copied. Nil is
+	// unlimited
+	Print *int
+	Copy  *int
+	// Start and End limit when the book can be read, it never expires when End is nil
+	Start     time.Time
+	End       *time.Time
+	CreatedAt time.Time
+}
+
+func (l License) Expired(now time.Time) bool {
+	return l.End != nil && !now.Before(*l.End)
+}
+
+// LicenseRights are the rights of the new licenses. Nil limits and a zero Duration are unlimited.
+type LicenseRights struct {
+	Print    *int
+	Copy     *int
+	Duration time.Duration
+}
+
+// findOrIssueLicense returns the license of the buyer for the book of the order, issuing it when it was not issued
+// with the payment.
+func (s *Shop) findOrIssueLicense(ctx context.Context, userID, orderID, bookID string) (License, error) {
+	license, err := s.LicenseRepository.FindByOrderItem(ctx, orderID, bookID)
+	if err != nil {
+		return License{}, fmt.Errorf("(findOrIssueLicense) failed finding license: %w", err)
+	}
+	if license != nil {
+		return *license, nil
+	}
+
+	issued, err := s.issueLicense(ctx, userID, orderID, bookID)
+	if err != nil {
+		// a concurrent download may have issued the license first, the buyer then gets that one
+		license, findErr := s.LicenseRepository.FindByOrderItem(ctx, orderID, bookID)
+		if findErr != nil || license == nil {
+			return License{}, fmt.Errorf("(findOrIssueLicense) failed issuing license: %w", err)
+		}
+
+		return *license, nil
+	}
+
+	return issued, nil
+}
+
+func (s *Shop) issueLicense(ctx context.Context, userID, orderID, bookID string) (License, error) {
+	now := time.Now()
+	license := License{
+		ID:      s.IDGenerator.NewID(),
+		UserID:  userID,
+		OrderID: orderID,
+		BookID:  bookID,
+		Print:   s.LicenseRights.Print,
+		Copy:    s.LicenseRights.Copy,
+		Start:   now,
+	}
+	if s.LicenseRights.Duration > 0 {
+		end := now.Add(s.LicenseRights.Duration)
+		license.End = &end
+	}
+
+	if err := s.LicenseRepository.Create(ctx, &license); err != nil {
+		return License{}, fmt.Errorf("(issueLicense) failed creating license: %w", err)
+	}
+
+	return license, nil
+}
+
+// downloadProtected returns a link to the encrypted EPUB of a protected book along with the license document of the
+// buyer, which carries the content key encrypted with the license passphrase.
+func (s *Shop) downloadProtected(ctx context.Context, request DownloadOrderContentRequest) (DownloadResponse, error) {
+	buyer, err := s.OrderRepository.FindBuyer(ctx, request.OrderID)
+	if err != nil {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed finding buyer of order %s: %w", request.OrderID, err)
+	}
+
+	if buyer.LicenseKey == "" {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed validating buyer %s: %w", buyer.UserID, ErrLicensePassphraseRequired)
+	}
+
+	license, err := s.findOrIssueLicense(ctx, buyer.UserID, request.OrderID, request.ItemID)
+	if err != nil {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed finding license: %w", err)
+	}
+
+	if license.Expired(time.Now()) {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed validating license %s: %w", license.ID, ErrLicenseExpired)
+	}
+
+	content, err := s.CatalogService.GetProtectedBookContent(ctx, request.ItemID)
+	if err != nil {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed getting protected content: %w", err)
+	}
+
+	document, err := s.LicenseIssuer.Issue(license, buyer, content)
+	if err != nil {
+		return DownloadResponse{}, fmt.Errorf("(downloadProtected) failed issuing license document %s: %w", license.ID, err)
+	}
+
+	return DownloadResponse{URL: content.URL, License: document}, nil
+}
+
+// issueLicenses issues the licenses of the protected books of a paid order. The failures are only logged, the licenses
+// are issued again on the first download.
+func (s *Shop) issueLicenses(ctx context.Context, order Order) {
+	for _, item := range order.Items {
+		book, err := s.CatalogService.FindBookByID(ctx, item.ID)
+		if err != nil {
+			log.Warnf(ctx, "(issueLicenses) failed finding book %s: %v", item.ID, err)
+			continue
+		}
+		if !book.Protected {
+			continue
+		}
+
+		if _, err = s.issueLicense(ctx, order.UserID, order.ID, item.ID); err != nil {
+			log.Warnf(ctx, "(issueLicenses) failed issuing license for book %s of order %s: %v", item.ID, order.ID, err)
+		}
+	}
+}
@@ -43,23 +43,53 @@ func (_m *MockCatalogService) FindBookByID(ctx context.Context, bookId string) (
 	return r0, r1
 }
 
-// GetBookContentURL provides a mock function with given fields: ctx, bookId
-func (_m *MockCatalogService) GetBookContentURL(ctx context.Context, bookId string) (string, error) {
+// FindBooksByIDs provides a mock function with given fields: ctx, ids
+func (_m *MockCatalogService) FindBooksByIDs(ctx context.Context, ids []string) ([]catalog.BookResponse, error) {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBooksByIDs")
+	}
+
+	var r0 []catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]catalog.BookResponse, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []catalog.BookResponse); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]catalog.BookResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProtectedBookContent provides a mock function with given fields: ctx, bookId
+func (_m *MockCatalogService) GetProtectedBookContent(ctx context.Context, bookId string) (catalog.ProtectedBookContent, error) {
 	ret := _m.Called(ctx, bookId)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetBookContentURL")
+		panic("no return value specified for GetProtectedBookContent")
 	}
 
-	var r0 string
+	var r0 catalog.ProtectedBookContent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (catalog.ProtectedBookContent, error)); ok {
 		return rf(ctx, bookId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) catalog.ProtectedBookContent); ok {
 		r0 = rf(ctx, bookId)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(catalog.ProtectedBookContent)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
@@ -71,6 +101,62 @@ func (_m *MockCatalogService) GetBookContentURL(ctx context.Context, bookId stri
 	return r0, r1
 }
 
+// GetWatermarkedBookContentURL provides a mock function with given fields: ctx, bookId, format, orderID, mark
+func (_m *MockCatalogService) GetWatermarkedBookContentURL(ctx context.Context, bookId string, format catalog.BookFormat, orderID string, mark catalog.WatermarkFunc) (string, error) {
+	ret := _m.Called(ctx, bookId, format, orderID, mark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWatermarkedBookContentURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) (string, error)); ok {
+		return rf(ctx, bookId, format, orderID, mark)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) string); ok {
+		r0 = rf(ctx, bookId, format, orderID, mark)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) error); ok {
+		r1 = rf(ctx, bookId, format, orderID, mark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OpenWatermarkedBookContent provides a mock function with given fields: ctx, bookId, format, orderID, mark
+func (_m *MockCatalogService) OpenWatermarkedBookContent(ctx context.Context, bookId string, format catalog.BookFormat, orderID string, mark catalog.WatermarkFunc) (catalog.BookContent, error) {
+	ret := _m.Called(ctx, bookId, format, orderID, mark)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenWatermarkedBookContent")
+	}
+
+	var r0 catalog.BookContent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) (catalog.BookContent, error)); ok {
+		return rf(ctx, bookId, format, orderID, mark)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) catalog.BookContent); ok {
+		r0 = rf(ctx, bookId, format, orderID, mark)
+	} else {
+		r0 = ret.Get(0).(catalog.BookContent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, catalog.BookFormat, string, catalog.WatermarkFunc) error); ok {
+		r1 = rf(ctx, bookId, format, orderID, mark)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockCatalogService creates a new instance of MockCatalogService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCatalogService(t interface {
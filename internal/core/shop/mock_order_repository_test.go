@@ -4,6 +4,7 @@ package shop
 
 import (
 	context "context"
+	time "time"
 
 	query "github.com/ebookstore/internal/core/query"
 	mock "github.com/stretchr/testify/mock"
@@ -32,6 +33,34 @@ func (_m *MockOrderRepository) Create(ctx context.Context, order *Order) error {
 	return r0
 }
 
+// FindBuyer provides a mock function with given fields: ctx, orderID
+func (_m *MockOrderRepository) FindBuyer(ctx context.Context, orderID string) (Buyer, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBuyer")
+	}
+
+	var r0 Buyer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Buyer, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) Buyer); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		r0 = ret.Get(0).(Buyer)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *MockOrderRepository) FindByID(ctx context.Context, id string) (Order, error) {
 	ret := _m.Called(ctx, id)
@@ -88,6 +117,82 @@ func (_m *MockOrderRepository) FindByQuery(ctx context.Context, q query.Query, p
 	return r0, r1
 }
 
+// FindReleases provides a mock function with given fields: ctx, now
+func (_m *MockOrderRepository) FindReleases(ctx context.Context, now time.Time) ([]Release, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindReleases")
+	}
+
+	var r0 []Release
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]Release, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []Release); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Release)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasPaidItem provides a mock function with given fields: ctx, userID, itemID
+func (_m *MockOrderRepository) HasPaidItem(ctx context.Context, userID string, itemID string) (bool, error) {
+	ret := _m.Called(ctx, userID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasPaidItem")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return rf(ctx, userID, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, userID, itemID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkReleaseNotified provides a mock function with given fields: ctx, orderID, bookID
+func (_m *MockOrderRepository) MarkReleaseNotified(ctx context.Context, orderID string, bookID string) error {
+	ret := _m.Called(ctx, orderID, bookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkReleaseNotified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, orderID, bookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, order
 func (_m *MockOrderRepository) Update(ctx context.Context, order *Order) error {
 	ret := _m.Called(ctx, order)
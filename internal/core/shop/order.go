@@ -1,6 +1,9 @@
 package shop
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type OrderStatus string
 
@@ -38,10 +41,40 @@ func (o *Order) TotalPrice() int64 {
 }
 
 func (o *Order) HasItem(itemID string) bool {
+	_, ok := o.Item(itemID)
+	return ok
+}
+
+func (o *Order) Item(itemID string) (Item, bool) {
 	for _, i := range o.Items {
 		if i.ID == itemID {
-			return true
+			return i, true
 		}
 	}
-	return false
+	return Item{}, false
+}
+
+// Release is a pre-ordered book that was released, along with the customer who ordered it.
+type Release struct {
+	OrderID   string
+	UserID    string
+	Email     string
+	FirstName string
+	BookID    string
+	Name      string
+}
+
+// Buyer is the customer who placed an order.
+type Buyer struct {
+	UserID    string
+	Email     string
+	FirstName string
+	LastName  string
+	// LicenseKey and LicenseHint come from the license passphrase of the buyer, LicenseKey is empty when it was not set
+	LicenseKey  string
+	LicenseHint string
+}
+
+func (b Buyer) Name() string {
+	return strings.TrimSpace(b.FirstName + " " + b.LastName)
 }
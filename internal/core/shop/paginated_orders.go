@@ -5,4 +5,10 @@ type PaginatedOrders struct {
 	Limit       int
 	Offset      int
 	TotalOrders int64
+	// Keyset is true when the orders were fetched using cursor pagination
+	Keyset bool
+	// IncludeTotal is true when TotalOrders was also calculated for a keyset page
+	IncludeTotal bool
+	NextCursor   string
+	PrevCursor   string
 }
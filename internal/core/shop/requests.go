@@ -3,9 +3,12 @@ package shop
 import "github.com/ebookstore/internal/core/query"
 
 type SearchOrders struct {
-	Status  string `form:"status"`
-	Page    int    `form:"page"`
-	PerPage int    `form:"perPage"`
+	Status       string `form:"status"`
+	Page         int    `form:"page"`
+	PerPage      int    `form:"perPage"`
+	Pagination   string `form:"pagination"`
+	Cursor       string `form:"cursor"`
+	IncludeTotal bool   `form:"includeTotal"`
 }
 
 func (s *SearchOrders) CreateQuery() query.Query {
@@ -29,6 +32,12 @@ func (s *SearchOrders) CreatePage() query.Page {
 		p.Size = s.PerPage
 	}
 
+	if s.Pagination == query.CursorPagination || s.Cursor != "" {
+		p.Keyset = true
+		p.Cursor = s.Cursor
+		p.IncludeTotal = s.IncludeTotal
+	}
+
 	return p
 }
 
@@ -46,4 +55,6 @@ type HandleStripeWebhook struct {
 type DownloadOrderContentRequest struct {
 	OrderID string `form:"orderId"`
 	ItemID  string `form:"itemId"`
+	// Format is the format of the downloaded file. The preferred format available is used when it's empty
+	Format string `form:"format"`
 }
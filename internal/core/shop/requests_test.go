@@ -50,3 +50,18 @@ func TestSearchOrders_CreatePage_WithPerPage(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestSearchOrders_CreatePage_WithCursor(t *testing.T) {
+	dto := SearchOrders{Cursor: "some-cursor", IncludeTotal: true}
+
+	expected := query.Page{
+		Number:       1,
+		Size:         15,
+		Keyset:       true,
+		Cursor:       "some-cursor",
+		IncludeTotal: true,
+	}
+	actual := dto.CreatePage()
+
+	assert.Equal(t, expected, actual)
+}
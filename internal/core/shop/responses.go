@@ -1,8 +1,11 @@
 package shop
 
 import (
+	"encoding/json"
 	"math"
 	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
 )
 
 type OrderResponse struct {
@@ -38,10 +41,12 @@ func NewOrderResponse(order Order) OrderResponse {
 
 type PaginatedOrdersResponse struct {
 	Results     []OrderResponse `json:"results"`
-	CurrentPage int             `json:"currentPage"`
+	CurrentPage *int            `json:"currentPage"`
 	PerPage     int             `json:"perPage"`
-	TotalPages  int             `json:"totalPages"`
-	TotalItems  int64           `json:"totalItems"`
+	TotalPages  *int            `json:"totalPages"`
+	TotalItems  *int64          `json:"totalItems"`
+	NextCursor  string          `json:"nextCursor,omitempty"`
+	PrevCursor  string          `json:"prevCursor,omitempty"`
 }
 
 func NewPaginatedOrdersResponse(paginatedOrders PaginatedOrders) PaginatedOrdersResponse {
@@ -50,17 +55,52 @@ func NewPaginatedOrdersResponse(paginatedOrders PaginatedOrders) PaginatedOrders
 		orders = append(orders, NewOrderResponse(o))
 	}
 
-	return PaginatedOrdersResponse{
-		Results:     orders,
-		CurrentPage: (paginatedOrders.Offset / paginatedOrders.Limit) + 1,
-		PerPage:     paginatedOrders.Limit,
-		TotalPages:  int(math.Ceil(float64(paginatedOrders.TotalOrders) / float64(paginatedOrders.Limit))),
-		TotalItems:  paginatedOrders.TotalOrders,
+	response := PaginatedOrdersResponse{
+		Results:    orders,
+		PerPage:    paginatedOrders.Limit,
+		NextCursor: paginatedOrders.NextCursor,
+		PrevCursor: paginatedOrders.PrevCursor,
+	}
+
+	// Page numbers are meaningless when paginating by cursor
+	if !paginatedOrders.Keyset {
+		currentPage := (paginatedOrders.Offset / paginatedOrders.Limit) + 1
+		response.CurrentPage = &currentPage
+	}
+
+	if !paginatedOrders.Keyset || paginatedOrders.IncludeTotal {
+		totalPages := int(math.Ceil(float64(paginatedOrders.TotalOrders) / float64(paginatedOrders.Limit)))
+		response.TotalPages = &totalPages
+		response.TotalItems = &paginatedOrders.TotalOrders
+	}
+
+	return response
+}
+
+// LibraryResponse lists the books a user has paid for.
+type LibraryResponse struct {
+	Books []LibraryBookResponse `json:"books"`
+}
+
+// LibraryBookResponse is a purchased book with the order its content can be downloaded from.
+type LibraryBookResponse struct {
+	OrderID   string               `json:"orderId"`
+	OrderedAt time.Time            `json:"orderedAt"`
+	Book      catalog.BookResponse `json:"book"`
+}
+
+func NewLibraryBookResponse(order Order, book catalog.BookResponse) LibraryBookResponse {
+	return LibraryBookResponse{
+		OrderID:   order.ID,
+		OrderedAt: order.CreatedAt,
+		Book:      book,
 	}
 }
 
 type DownloadResponse struct {
 	URL string `json:"url"`
+	// License is the license document of the protected books, the URL links to their encrypted content
+	License json.RawMessage `json:"license,omitempty"`
 }
 
 type CartResponse struct {
@@ -92,6 +132,7 @@ type ItemResponse struct {
 	Name           string
 	Price          int64  `json:"price"`
 	PreviewImageID string `json:"previewImageId"`
+	PreOrder       bool   `json:"preOrder"`
 }
 
 func newItemResponse(item Item) ItemResponse {
@@ -100,5 +141,43 @@ func newItemResponse(item Item) ItemResponse {
 		Name:           item.Name,
 		Price:          item.Price,
 		PreviewImageID: item.PreviewImageID,
+		PreOrder:       item.PreOrder,
+	}
+}
+
+type WishlistResponse struct {
+	UserID string                 `json:"userId"`
+	Items  []WishlistItemResponse `json:"items"`
+}
+
+func NewWishlistResponse(wishlist Wishlist) WishlistResponse {
+	items := make([]WishlistItemResponse, 0, len(wishlist.Items))
+	for _, i := range wishlist.Items {
+		items = append(items, newWishlistItemResponse(i))
+	}
+
+	return WishlistResponse{
+		UserID: wishlist.UserID,
+		Items:  items,
+	}
+}
+
+type WishlistItemResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	PreviewImageID string    `json:"previewImageId"`
+	AddedPrice     int64     `json:"addedPrice"`
+	Price          int64     `json:"price"`
+	AddedAt        time.Time `json:"addedAt"`
+}
+
+func newWishlistItemResponse(item WishlistItem) WishlistItemResponse {
+	return WishlistItemResponse{
+		ID:             item.BookID,
+		Name:           item.Name,
+		PreviewImageID: item.PreviewImageID,
+		AddedPrice:     item.Price,
+		Price:          item.CurrentPrice,
+		AddedAt:        item.CreatedAt,
 	}
 }
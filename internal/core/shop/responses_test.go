@@ -79,12 +79,44 @@ func TestNewPaginatedOrdersResponse(t *testing.T) {
 		TotalOrders: 3,
 	}
 
+	currentPage, totalPages, totalItems := 1, 1, int64(3)
 	expected := PaginatedOrdersResponse{
 		Results:     []OrderResponse{NewOrderResponse(order1), NewOrderResponse(order2), NewOrderResponse(order3)},
-		CurrentPage: 1,
+		CurrentPage: &currentPage,
 		PerPage:     10,
-		TotalPages:  1,
-		TotalItems:  3,
+		TotalPages:  &totalPages,
+		TotalItems:  &totalItems,
+	}
+
+	actual := NewPaginatedOrdersResponse(paginatedOrders)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewPaginatedOrdersResponse_WithKeyset(t *testing.T) {
+	order := Order{
+		ID:     "order-id1",
+		Status: Paid,
+		Items:  []Item{{Price: 10}},
+		UserID: "user-id1",
+	}
+
+	paginatedOrders := PaginatedOrders{
+		Orders:       []Order{order},
+		Limit:        1,
+		TotalOrders:  5,
+		Keyset:       true,
+		IncludeTotal: true,
+		NextCursor:   "next-cursor",
+	}
+
+	totalPages, totalItems := 5, int64(5)
+	expected := PaginatedOrdersResponse{
+		Results:    []OrderResponse{NewOrderResponse(order)},
+		PerPage:    1,
+		TotalPages: &totalPages,
+		TotalItems: &totalItems,
+		NextCursor: "next-cursor",
 	}
 
 	actual := NewPaginatedOrdersResponse(paginatedOrders)
@@ -131,3 +163,23 @@ func TestNewCartResponse(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestNewWishlistResponse(t *testing.T) {
+	addedAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	wishlist := Wishlist{
+		UserID: "user-id",
+		Items: []WishlistItem{
+			{UserID: "user-id", BookID: "book-id", Name: "Clean Code", PreviewImageID: "image-id", Price: 5000, CurrentPrice: 4000, CreatedAt: addedAt},
+		},
+	}
+
+	expected := WishlistResponse{
+		UserID: "user-id",
+		Items: []WishlistItemResponse{
+			{ID: "book-id", Name: "Clean Code", PreviewImageID: "image-id", AddedPrice: 5000, Price: 4000, AddedAt: addedAt},
+		},
+	}
+	actual := NewWishlistResponse(wishlist)
+
+	assert.Equal(t, expected, actual)
+}
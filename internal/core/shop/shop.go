@@ -2,7 +2,9 @@ package shop
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/core/query"
@@ -14,6 +16,13 @@ type OrderRepository interface {
 	FindByID(ctx context.Context, id string) (Order, error)
 	Create(ctx context.Context, order *Order) error
 	Update(ctx context.Context, order *Order) error
+	// HasPaidItem returns true if the user has a paid order containing the item.
+	HasPaidItem(ctx context.Context, userID, itemID string) (bool, error)
+	// FindReleases returns the paid pre-orders of the books released by the given time, whose customers were not
+	// notified yet.
+	FindReleases(ctx context.Context, now time.Time) ([]Release, error)
+	MarkReleaseNotified(ctx context.Context, orderID, bookID string) error
+	FindBuyer(ctx context.Context, orderID string) (Buyer, error)
 }
 
 type CartRepository interface {
@@ -22,13 +31,52 @@ type CartRepository interface {
 	DeleteByUserID(ctx context.Context, userID string) error
 }
 
+type WishlistRepository interface {
+	FindByUserID(ctx context.Context, userID string) (Wishlist, error)
+	AddItem(ctx context.Context, item *WishlistItem) error
+	RemoveItem(ctx context.Context, userID, bookID string) error
+	// FindPriceDrops returns the wishlisted books that got cheaper since they were added or last notified.
+	FindPriceDrops(ctx context.Context) ([]PriceDrop, error)
+	MarkNotified(ctx context.Context, userID, bookID string, price int64) error
+	// ResetNotifiedPrices forgets the notified price of the books that got more expensive again, so their next drop is notified.
+	ResetNotifiedPrices(ctx context.Context) error
+}
+
+type EmailClient interface {
+	SendPriceDropEmail(ctx context.Context, drop PriceDrop) error
+	SendReleaseEmail(ctx context.Context, release Release) error
+}
+
 type PaymentClient interface {
 	CreatePaymentIntentForOrder(ctx context.Context, order *Order) error
 }
 
 type CatalogService interface {
 	FindBookByID(ctx context.Context, bookId string) (catalog.BookResponse, error)
-	GetBookContentURL(ctx context.Context, bookId string) (string, error)
+	// FindBooksByIDs returns the known books among the given ids, whatever their status.
+	FindBooksByIDs(ctx context.Context, ids []string) ([]catalog.BookResponse, error)
+	// GetWatermarkedBookContentURL and OpenWatermarkedBookContent stamp the content for the order with the mark when
+	// the book is watermarked, and serve it untouched otherwise.
+	GetWatermarkedBookContentURL(ctx context.Context, bookId string, format catalog.BookFormat, orderID string, mark catalog.WatermarkFunc) (string, error)
+	OpenWatermarkedBookContent(ctx context.Context, bookId string, format catalog.BookFormat, orderID string, mark catalog.WatermarkFunc) (catalog.BookContent, error)
+	GetProtectedBookContent(ctx context.Context, bookId string) (catalog.ProtectedBookContent, error)
+}
+
+// DownloadRepository records the downloads streamed by the API.
+type DownloadRepository interface {
+	Save(ctx context.Context, download *Download) error
+}
+
+type LicenseRepository interface {
+	Create(ctx context.Context, license *License) error
+	// FindByOrderItem returns the license of the book of the order, or nil when it was not issued.
+	FindByOrderItem(ctx context.Context, orderID, bookID string) (*License, error)
+}
+
+// LicenseIssuer renders the license document the reading apps open, with the content key of the protected content
+// encrypted for the license key of the buyer.
+type LicenseIssuer interface {
+	Issue(license License, buyer Buyer, content catalog.ProtectedBookContent) ([]byte, error)
 }
 
 type IDGenerator interface {
@@ -39,13 +87,22 @@ type Validator interface {
 	Validate(i interface{}) error
 }
 
+// libraryBatchSize is the number of orders fetched at a time when building the library.
+const libraryBatchSize = 50
+
 type Config struct {
-	OrderRepository OrderRepository
-	CartRepository  CartRepository
-	PaymentClient   PaymentClient
-	CatalogService  CatalogService
-	IDGenerator     IDGenerator
-	Validator       Validator
+	OrderRepository    OrderRepository
+	CartRepository     CartRepository
+	WishlistRepository WishlistRepository
+	DownloadRepository DownloadRepository
+	LicenseRepository  LicenseRepository
+	EmailClient        EmailClient
+	PaymentClient      PaymentClient
+	CatalogService     CatalogService
+	LicenseIssuer      LicenseIssuer
+	LicenseRights      LicenseRights
+	IDGenerator        IDGenerator
+	Validator          Validator
 }
 
 type Shop struct {
@@ -98,6 +155,16 @@ func (s *Shop) CreateOrder(ctx context.Context) (OrderResponse, error) {
 		return OrderResponse{}, fmt.Errorf("(CreateOrder) failed finding cart: %w", err)
 	}
 
+	now := time.Now()
+	for i, item := range cart.Items {
+		book, err := s.findAvailableBook(ctx, item.ID)
+		if err != nil {
+			return OrderResponse{}, fmt.Errorf("(CreateOrder) failed checking item %s: %w", item.ID, err)
+		}
+		// The release date may have changed since the book was added to the cart
+		cart.Items[i].PreOrder = book.Upcoming(now)
+	}
+
 	order := cart.CreateOrder(s.IDGenerator.NewID())
 
 	if err = s.PaymentClient.CreatePaymentIntentForOrder(ctx, &order); err != nil {
@@ -128,35 +195,151 @@ func (s *Shop) CompleteOrder(ctx context.Context, orderID string) error {
 		return fmt.Errorf("(CompleteOrder) failed updating order %s: %w", orderID, err)
 	}
 
+	s.issueLicenses(ctx, order)
+
 	return nil
 }
 
 func (s *Shop) DownloadOrderItemContent(ctx context.Context, request DownloadOrderContentRequest) (DownloadResponse, error) {
 	log.Infof(ctx, "getting content for item %s of order %s", request.ItemID, request.OrderID)
 
+	if err := s.authorizeDownload(ctx, request); err != nil {
+		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed authorizing download: %w", err)
+	}
+
+	url, err := s.CatalogService.GetWatermarkedBookContentURL(ctx, request.ItemID, catalog.BookFormat(request.Format), request.OrderID, s.watermark(request.OrderID))
+	// The protected books are only delivered as encrypted EPUBs, with their license
+	if errors.Is(err, catalog.ErrBookProtected) && (request.Format == "" || catalog.BookFormat(request.Format) == catalog.FormatEPUB) {
+		return s.downloadProtected(ctx, request)
+	}
+	if err != nil {
+		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed getting book content: %w", err)
+	}
+
+	return DownloadResponse{URL: url}, nil
+}
+
+// watermark identifies the buyer of the order, for the books that are stamped with who downloads them. The buyer is
+// only looked up when the catalog stamps a copy.
+func (s *Shop) watermark(orderID string) catalog.WatermarkFunc {
+	return func(ctx context.Context) (catalog.Watermark, error) {
+		buyer, err := s.OrderRepository.FindBuyer(ctx, orderID)
+		if err != nil {
+			return catalog.Watermark{}, fmt.Errorf("(watermark) failed finding buyer of order %s: %w", orderID, err)
+		}
+
+		return catalog.Watermark{Name: buyer.Name(), Email: buyer.Email, OrderID: orderID, Time: time.Now()}, nil
+	}
+}
+
+// authorizeDownload checks the item belongs to a completed order of the current user, and it's released when it was
+// pre-ordered.
+func (s *Shop) authorizeDownload(ctx context.Context, request DownloadOrderContentRequest) error {
 	order, err := s.OrderRepository.FindByID(ctx, request.OrderID)
 	if err != nil {
-		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed finding order by id %s: %w", request.OrderID, err)
+		return fmt.Errorf("(authorizeDownload) failed finding order by id %s: %w", request.OrderID, err)
 	}
 
 	if !order.Completed() {
-		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed validating order conditions %s: %w", request.OrderID, ErrOrderNotCompleted)
+		return fmt.Errorf("(authorizeDownload) failed validating order conditions %s: %w", request.OrderID, ErrOrderNotCompleted)
 	}
 
 	if !order.HasItem(request.ItemID) {
-		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed validating order conditions %s: %w", request.OrderID, ErrItemNotFoundInOrder)
+		return fmt.Errorf("(authorizeDownload) failed validating order conditions %s: %w", request.OrderID, ErrItemNotFoundInOrder)
 	}
 
 	if !s.isUserAllowedToReadOrder(ctx, order) {
-		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed validating user conditions: %w", ErrForbiddenOrderAccess)
+		return fmt.Errorf("(authorizeDownload) failed validating user conditions: %w", ErrForbiddenOrderAccess)
 	}
 
-	url, err := s.CatalogService.GetBookContentURL(ctx, request.ItemID)
+	if item, _ := order.Item(request.ItemID); item.PreOrder {
+		if err = s.checkReleased(ctx, request.ItemID); err != nil {
+			return fmt.Errorf("(authorizeDownload) failed validating release of item %s: %w", request.ItemID, err)
+		}
+	}
+
+	return nil
+}
+
+// HasPurchased returns true if the user has paid for the book.
+func (s *Shop) HasPurchased(ctx context.Context, userID, bookID string) (bool, error) {
+	purchased, err := s.OrderRepository.HasPaidItem(ctx, userID, bookID)
 	if err != nil {
-		return DownloadResponse{}, fmt.Errorf("(DownloadOrderItemContent) failed getting book content: %w", err)
+		return false, fmt.Errorf("(HasPurchased) failed checking paid orders: %w", err)
 	}
 
-	return DownloadResponse{URL: url}, nil
+	return purchased, nil
+}
+
+// FindLibrary returns every book the current user has paid for, most recently ordered first.
+func (s *Shop) FindLibrary(ctx context.Context) (LibraryResponse, error) {
+	log.Infof(ctx, "getting library")
+
+	q := query.New().
+		And(query.Condition{Field: "user_id", Operator: query.Equal, Value: userId(ctx)}).
+		And(query.Condition{Field: "status", Operator: query.Equal, Value: string(Paid)})
+	page := query.Page{Size: libraryBatchSize, Keyset: true}
+
+	response := LibraryResponse{Books: []LibraryBookResponse{}}
+	added := make(map[string]bool)
+
+	for {
+		paginatedOrders, err := s.OrderRepository.FindByQuery(ctx, *q, page)
+		if err != nil {
+			return LibraryResponse{}, fmt.Errorf("(FindLibrary) failed fetching orders: %w", err)
+		}
+
+		// The books of each batch of orders are fetched at once, the first order of a book is the one listed
+		var ids []string
+		orders := make(map[string]Order)
+		for _, order := range paginatedOrders.Orders {
+			for _, item := range order.Items {
+				if _, ok := orders[item.ID]; ok || added[item.ID] {
+					continue
+				}
+
+				orders[item.ID] = order
+				ids = append(ids, item.ID)
+			}
+		}
+
+		booksByID, err := s.findBooksByIDs(ctx, ids)
+		if err != nil {
+			return LibraryResponse{}, fmt.Errorf("(FindLibrary) failed finding books: %w", err)
+		}
+
+		// Books removed from the catalog are no longer listed
+		for _, id := range ids {
+			if book, ok := booksByID[id]; ok {
+				added[id] = true
+				response.Books = append(response.Books, NewLibraryBookResponse(orders[id], book))
+			}
+		}
+
+		if paginatedOrders.NextCursor == "" {
+			return response, nil
+		}
+		page.Cursor = paginatedOrders.NextCursor
+	}
+}
+
+// findBooksByIDs returns the known books among the given ids by id.
+func (s *Shop) findBooksByIDs(ctx context.Context, ids []string) (map[string]catalog.BookResponse, error) {
+	if len(ids) == 0 {
+		return map[string]catalog.BookResponse{}, nil
+	}
+
+	books, err := s.CatalogService.FindBooksByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("(findBooksByIDs) failed finding books: %w", err)
+	}
+
+	booksByID := make(map[string]catalog.BookResponse, len(books))
+	for _, book := range books {
+		booksByID[book.ID] = book
+	}
+
+	return booksByID, nil
 }
 
 func (s *Shop) GetCart(ctx context.Context) (CartResponse, error) {
@@ -184,7 +367,11 @@ func (s *Shop) AddItemToCart(ctx context.Context, itemID string) (CartResponse,
 	if err != nil {
 		return CartResponse{}, fmt.Errorf("(AddItemToCart) failed finding item by id %s: %w", itemID, err)
 	}
-	item := Item{ID: itemID, Name: book.Title, Price: int64(book.Price), PreviewImageID: book.MainImageID}
+
+	if !book.OnSale() {
+		return CartResponse{}, fmt.Errorf("(AddItemToCart) failed validating item %s: %w", itemID, ErrItemNotAvailable)
+	}
+	item := Item{ID: itemID, Name: book.Title, Price: int64(book.Price), PreviewImageID: book.MainImageID, PreOrder: book.Upcoming(time.Now())}
 
 	if err = cart.AddItem(item); err != nil {
 		return CartResponse{}, fmt.Errorf("(AddItemToCart) failed adding item to cart: %w", err)
@@ -216,6 +403,179 @@ func (s *Shop) RemoveItemFromCart(ctx context.Context, itemID string) (CartRespo
 	return NewCartResponse(*cart), nil
 }
 
+func (s *Shop) GetWishlist(ctx context.Context) (WishlistResponse, error) {
+	log.Infof(ctx, "getting wishlist")
+
+	wishlist, err := s.WishlistRepository.FindByUserID(ctx, userId(ctx))
+	if err != nil {
+		return WishlistResponse{}, fmt.Errorf("(GetWishlist) failed finding wishlist: %w", err)
+	}
+
+	return NewWishlistResponse(wishlist), nil
+}
+
+// AddItemToWishlist saves a book to the current user's wishlist along with its current price.
+func (s *Shop) AddItemToWishlist(ctx context.Context, bookID string) (WishlistResponse, error) {
+	log.Infof(ctx, "adding item to wishlist")
+
+	wishlist, err := s.WishlistRepository.FindByUserID(ctx, userId(ctx))
+	if err != nil {
+		return WishlistResponse{}, fmt.Errorf("(AddItemToWishlist) failed finding wishlist: %w", err)
+	}
+
+	if wishlist.HasItem(bookID) {
+		return WishlistResponse{}, fmt.Errorf("(AddItemToWishlist) failed validating wishlist conditions: %w", ErrItemAlreadyInWishlist)
+	}
+
+	book, err := s.CatalogService.FindBookByID(ctx, bookID)
+	if err != nil {
+		return WishlistResponse{}, fmt.Errorf("(AddItemToWishlist) failed finding item by id %s: %w", bookID, err)
+	}
+
+	if !book.OnSale() {
+		return WishlistResponse{}, fmt.Errorf("(AddItemToWishlist) failed validating item %s: %w", bookID, ErrItemNotAvailable)
+	}
+
+	item := WishlistItem{
+		UserID:         userId(ctx),
+		BookID:         bookID,
+		Name:           book.Title,
+		PreviewImageID: book.MainImageID,
+		Price:          int64(book.Price),
+		CurrentPrice:   int64(book.Price),
+	}
+
+	if err = s.WishlistRepository.AddItem(ctx, &item); err != nil {
+		return WishlistResponse{}, fmt.Errorf("(AddItemToWishlist) failed saving item: %w", err)
+	}
+	wishlist.Items = append(wishlist.Items, item)
+
+	return NewWishlistResponse(wishlist), nil
+}
+
+func (s *Shop) RemoveItemFromWishlist(ctx context.Context, bookID string) error {
+	log.Infof(ctx, "removing item from wishlist")
+
+	wishlist, err := s.WishlistRepository.FindByUserID(ctx, userId(ctx))
+	if err != nil {
+		return fmt.Errorf("(RemoveItemFromWishlist) failed finding wishlist: %w", err)
+	}
+
+	if !wishlist.HasItem(bookID) {
+		return fmt.Errorf("(RemoveItemFromWishlist) failed validating wishlist conditions: %w", ErrItemNotFoundInWishlist)
+	}
+
+	if err = s.WishlistRepository.RemoveItem(ctx, userId(ctx), bookID); err != nil {
+		return fmt.Errorf("(RemoveItemFromWishlist) failed removing item: %w", err)
+	}
+
+	return nil
+}
+
+// AddWishlistItemToCart adds a book of the current user's wishlist to the cart. The book stays in the wishlist.
+func (s *Shop) AddWishlistItemToCart(ctx context.Context, bookID string) (CartResponse, error) {
+	log.Infof(ctx, "adding wishlist item to cart")
+
+	wishlist, err := s.WishlistRepository.FindByUserID(ctx, userId(ctx))
+	if err != nil {
+		return CartResponse{}, fmt.Errorf("(AddWishlistItemToCart) failed finding wishlist: %w", err)
+	}
+
+	if !wishlist.HasItem(bookID) {
+		return CartResponse{}, fmt.Errorf("(AddWishlistItemToCart) failed validating wishlist conditions: %w", ErrItemNotFoundInWishlist)
+	}
+
+	cart, err := s.AddItemToCart(ctx, bookID)
+	if err != nil {
+		return CartResponse{}, fmt.Errorf("(AddWishlistItemToCart) failed adding item to cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// NotifyPriceDrops emails the owners of the wishlisted books that got cheaper.
+// A failed notification doesn't stop the others, it's retried in the next run.
+func (s *Shop) NotifyPriceDrops(ctx context.Context) error {
+	if err := s.WishlistRepository.ResetNotifiedPrices(ctx); err != nil {
+		return fmt.Errorf("(NotifyPriceDrops) failed resetting notified prices: %w", err)
+	}
+
+	drops, err := s.WishlistRepository.FindPriceDrops(ctx)
+	if err != nil {
+		return fmt.Errorf("(NotifyPriceDrops) failed finding price drops: %w", err)
+	}
+
+	log.Infof(ctx, "notifying %d wishlist price drops", len(drops))
+
+	for _, drop := range drops {
+		if err = s.EmailClient.SendPriceDropEmail(ctx, drop); err != nil {
+			log.Warnf(ctx, "(NotifyPriceDrops) failed sending email to user %s: %v", drop.UserID, err)
+			continue
+		}
+
+		if err = s.WishlistRepository.MarkNotified(ctx, drop.UserID, drop.BookID, drop.NewPrice); err != nil {
+			log.Warnf(ctx, "(NotifyPriceDrops) failed marking item %s as notified: %v", drop.BookID, err)
+		}
+	}
+
+	return nil
+}
+
+// NotifyReleases emails the customers who pre-ordered the books released since the last run.
+// A failed notification doesn't stop the others, it's retried in the next run.
+func (s *Shop) NotifyReleases(ctx context.Context) error {
+	releases, err := s.OrderRepository.FindReleases(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("(NotifyReleases) failed finding releases: %w", err)
+	}
+
+	if len(releases) > 0 {
+		log.Infof(ctx, "notifying %d pre-order releases", len(releases))
+	}
+
+	for _, release := range releases {
+		if err = s.EmailClient.SendReleaseEmail(ctx, release); err != nil {
+			log.Warnf(ctx, "(NotifyReleases) failed sending email to user %s: %v", release.UserID, err)
+			continue
+		}
+
+		if err = s.OrderRepository.MarkReleaseNotified(ctx, release.OrderID, release.BookID); err != nil {
+			log.Warnf(ctx, "(NotifyReleases) failed marking item %s of order %s as notified: %v", release.BookID, release.OrderID, err)
+		}
+	}
+
+	return nil
+}
+
+// findAvailableBook fails when the book was archived or unpublished after it was added to the cart.
+func (s *Shop) findAvailableBook(ctx context.Context, bookID string) (catalog.BookResponse, error) {
+	book, err := s.CatalogService.FindBookByID(ctx, bookID)
+	if err != nil {
+		return catalog.BookResponse{}, fmt.Errorf("(findAvailableBook) failed finding item by id %s: %w", bookID, err)
+	}
+
+	if !book.OnSale() {
+		return catalog.BookResponse{}, fmt.Errorf("(findAvailableBook) failed validating item %s: %w", bookID, ErrItemNotAvailable)
+	}
+
+	return book, nil
+}
+
+// checkReleased fails until the release date of a pre-ordered book. The date is read from the catalog,
+// as it may have been moved since the order.
+func (s *Shop) checkReleased(ctx context.Context, bookID string) error {
+	book, err := s.CatalogService.FindBookByID(ctx, bookID)
+	if err != nil {
+		return fmt.Errorf("(checkReleased) failed finding item by id %s: %w", bookID, err)
+	}
+
+	if book.Upcoming(time.Now()) {
+		return fmt.Errorf("(checkReleased) failed validating release date %s: %w", book.ReleaseDate.Format(time.RFC3339), ErrItemNotReleased)
+	}
+
+	return nil
+}
+
 func (s *Shop) findOrCreateCart(ctx context.Context) (*Cart, error) {
 	cart, err := s.CartRepository.FindByUserID(ctx, userId(ctx))
 	if err != nil {
@@ -4,7 +4,10 @@ package shop_test
 import (
 	"context"
 	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ebookstore/internal/core/catalog"
 	"github.com/stretchr/testify/mock"
@@ -21,17 +24,44 @@ const (
 	createOrderMethod         = "Create"
 	updateOrderMethod         = "Update"
 	findBookByID              = "FindBookByID"
+	findBooksByIDs            = "FindBooksByIDs"
 	findCartByUserIDMethod    = "FindByUserID"
 	deleteCartByUserIDMethod  = "DeleteByUserID"
 	createPaymentIntentMethod = "CreatePaymentIntentForOrder"
-	getBookContent            = "GetBookContentURL"
+	getBookContent            = "GetWatermarkedBookContentURL"
 	newIdMethod               = "NewID"
+	hasPaidItemMethod         = "HasPaidItem"
+	findWishlistMethod        = "FindByUserID"
+	addWishlistItemMethod     = "AddItem"
+	removeWishlistItemMethod  = "RemoveItem"
+	findPriceDropsMethod      = "FindPriceDrops"
+	markNotifiedMethod        = "MarkNotified"
+	resetNotifiedPricesMethod = "ResetNotifiedPrices"
+	sendPriceDropEmailMethod  = "SendPriceDropEmail"
+	saveCartMethod            = "Save"
+	findReleasesMethod        = "FindReleases"
+	markReleaseNotifiedMethod = "MarkReleaseNotified"
+	sendReleaseEmailMethod    = "SendReleaseEmail"
+	openBookContentMethod     = "OpenWatermarkedBookContent"
+	saveDownloadMethod        = "Save"
+	findBuyerMethod           = "FindBuyer"
+	createLicenseMethod       = "Create"
+	findLicenseMethod         = "FindByOrderItem"
+	getProtectedContentMethod = "GetProtectedBookContent"
+	issueLicenseMethod        = "Issue"
 )
 
+var licensePrintLimit = 10
+
 type ShopTestSuite struct {
 	suite.Suite
 	orderRepo      *shop.MockOrderRepository
 	cartRepo       *shop.MockCartRepository
+	wishlistRepo   *shop.MockWishlistRepository
+	downloadRepo   *shop.MockDownloadRepository
+	licenseRepo    *shop.MockLicenseRepository
+	licenseIssuer  *shop.MockLicenseIssuer
+	emailClient    *shop.MockEmailClient
 	paymentClient  *shop.MockPaymentClient
 	catalogService *shop.MockCatalogService
 	idGenerator    *shop.MockIDGenerator
@@ -42,18 +72,29 @@ type ShopTestSuite struct {
 func (s *ShopTestSuite) SetupTest() {
 	s.orderRepo = new(shop.MockOrderRepository)
 	s.cartRepo = new(shop.MockCartRepository)
+	s.wishlistRepo = new(shop.MockWishlistRepository)
+	s.downloadRepo = new(shop.MockDownloadRepository)
+	s.licenseRepo = new(shop.MockLicenseRepository)
+	s.licenseIssuer = new(shop.MockLicenseIssuer)
+	s.emailClient = new(shop.MockEmailClient)
 	s.paymentClient = new(shop.MockPaymentClient)
 	s.catalogService = new(shop.MockCatalogService)
 	s.idGenerator = new(shop.MockIDGenerator)
 	s.validator = new(shop.MockValidator)
 
 	s.shop = shop.New(shop.Config{
-		OrderRepository: s.orderRepo,
-		CartRepository:  s.cartRepo,
-		PaymentClient:   s.paymentClient,
-		CatalogService:  s.catalogService,
-		IDGenerator:     s.idGenerator,
-		Validator:       s.validator,
+		OrderRepository:    s.orderRepo,
+		CartRepository:     s.cartRepo,
+		WishlistRepository: s.wishlistRepo,
+		DownloadRepository: s.downloadRepo,
+		LicenseRepository:  s.licenseRepo,
+		EmailClient:        s.emailClient,
+		PaymentClient:      s.paymentClient,
+		CatalogService:     s.catalogService,
+		LicenseIssuer:      s.licenseIssuer,
+		LicenseRights:      shop.LicenseRights{Print: &licensePrintLimit, Duration: time.Hour},
+		IDGenerator:        s.idGenerator,
+		Validator:          s.validator,
 	})
 }
 
@@ -192,6 +233,25 @@ func (s *ShopTestSuite) TestCreateOrder_WhenCartIsNotFound() {
 	s.orderRepo.AssertExpectations(s.T())
 }
 
+func (s *ShopTestSuite) TestCreateOrder_WhenItemIsArchived() {
+	userId := "some-user-id"
+	ctx := context.WithValue(context.Background(), "userId", userId)
+	archivedAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	cart := &shop.Cart{
+		ID:    "some-cart-id",
+		Items: []shop.Item{{ID: "some-item-id", Name: "some-title", Price: 100}},
+	}
+
+	s.cartRepo.On(findCartByUserIDMethod, ctx, userId).Return(cart, nil)
+	s.catalogService.On(findBookByID, ctx, "some-item-id").Return(catalog.BookResponse{ID: "some-item-id", Status: catalog.StatusPublished, ArchivedAt: &archivedAt}, nil)
+
+	_, err := s.shop.CreateOrder(ctx)
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotAvailable)
+	s.paymentClient.AssertNotCalled(s.T(), createPaymentIntentMethod)
+	s.orderRepo.AssertNotCalled(s.T(), createOrderMethod)
+}
+
 func (s *ShopTestSuite) TestCreateOrder_WhenPaymentClientFails() {
 	userId := "some-user-id"
 	ctx := context.WithValue(context.Background(), "userId", userId)
@@ -276,6 +336,32 @@ func (s *ShopTestSuite) TestCreateOrder_Successfully() {
 	s.catalogService.AssertExpectations(s.T())
 }
 
+func (s *ShopTestSuite) TestCreateOrder_MarksPreOrders() {
+	userId := "some-user-id"
+	ctx := context.WithValue(context.Background(), "userId", userId)
+
+	cart := &shop.Cart{
+		ID:    "some-cart-id",
+		Items: []shop.Item{{ID: "upcoming-id"}, {ID: "released-id"}},
+	}
+	upcoming := catalog.BookResponse{ID: "upcoming-id", Status: catalog.StatusPublished, ReleaseDate: time.Now().Add(24 * time.Hour)}
+	released := catalog.BookResponse{ID: "released-id", Status: catalog.StatusPublished, ReleaseDate: time.Now().Add(-24 * time.Hour)}
+
+	s.idGenerator.On(newIdMethod).Return("some-order-id")
+	s.cartRepo.On(findCartByUserIDMethod, ctx, userId).Return(cart, nil)
+	s.catalogService.On(findBookByID, ctx, "upcoming-id").Return(upcoming, nil)
+	s.catalogService.On(findBookByID, ctx, "released-id").Return(released, nil)
+	s.paymentClient.On(createPaymentIntentMethod, ctx, mock.Anything).Return(nil)
+	s.orderRepo.On(createOrderMethod, ctx, mock.Anything).Return(nil)
+	s.cartRepo.On(deleteCartByUserIDMethod, ctx, userId).Return(nil)
+
+	response, err := s.shop.CreateOrder(ctx)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), response.Items[0].PreOrder)
+	assert.False(s.T(), response.Items[1].PreOrder)
+}
+
 func (s *ShopTestSuite) TestCompleteOrder_WhenOrderCouldNotBeFound() {
 	id := "some-order-id"
 	s.orderRepo.On(findOrderByIDMethod, context.TODO(), id).Return(shop.Order{}, fmt.Errorf("some error"))
@@ -319,6 +405,42 @@ func (s *ShopTestSuite) TestCompleteOrder_Successfully() {
 	s.orderRepo.AssertCalled(s.T(), updateOrderMethod, context.TODO(), &order)
 }
 
+func (s *ShopTestSuite) TestCompleteOrder_IssuesLicensesOfProtectedBooks() {
+	order := shop.Order{
+		ID:     "some-id",
+		Items:  []shop.Item{{ID: "protected-book-id"}, {ID: "some-book-id"}},
+		UserID: "some-user-id",
+	}
+	s.orderRepo.On(findOrderByIDMethod, context.TODO(), order.ID).Return(order, nil)
+	s.orderRepo.On(updateOrderMethod, context.TODO(), mock.Anything).Return(nil)
+	s.catalogService.On(findBookByID, context.TODO(), "protected-book-id").Return(catalog.BookResponse{ID: "protected-book-id", Protected: true}, nil)
+	s.catalogService.On(findBookByID, context.TODO(), "some-book-id").Return(catalog.BookResponse{ID: "some-book-id"}, nil)
+	s.idGenerator.On(newIdMethod).Return("license-id")
+	isLicense := mock.MatchedBy(func(license *shop.License) bool {
+		return license.ID == "license-id" && license.UserID == order.UserID && license.OrderID == order.ID &&
+			license.BookID == "protected-book-id" && *license.Print == licensePrintLimit && license.Copy == nil &&
+			license.End.Sub(license.Start) == time.Hour
+	})
+	s.licenseRepo.On(createLicenseMethod, context.TODO(), isLicense).Return(nil)
+
+	err := s.shop.CompleteOrder(context.TODO(), order.ID)
+
+	assert.Nil(s.T(), err)
+	s.licenseRepo.AssertNumberOfCalls(s.T(), createLicenseMethod, 1)
+}
+
+func (s *ShopTestSuite) TestCompleteOrder_WhenLicenseFails() {
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "protected-book-id"}}, UserID: "some-user-id"}
+	s.orderRepo.On(findOrderByIDMethod, context.TODO(), order.ID).Return(order, nil)
+	s.orderRepo.On(updateOrderMethod, context.TODO(), mock.Anything).Return(nil)
+	s.catalogService.On(findBookByID, context.TODO(), "protected-book-id").Return(catalog.BookResponse{}, fmt.Errorf("some error"))
+
+	err := s.shop.CompleteOrder(context.TODO(), order.ID)
+
+	assert.Nil(s.T(), err)
+	s.licenseRepo.AssertNotCalled(s.T(), createLicenseMethod, mock.Anything, mock.Anything)
+}
+
 func (s *ShopTestSuite) TestDownloadOrderItemContent_WhenOrderCouldNotBeFound() {
 	order := shop.Order{
 		ID:    "some-id",
@@ -379,7 +501,7 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_WithError() {
 		Status: shop.Paid,
 	}
 	s.orderRepo.On(findOrderByIDMethod, context.TODO(), order.ID).Return(order, nil)
-	s.catalogService.On(getBookContent, context.TODO(), "some-book-id").
+	s.catalogService.On(getBookContent, context.TODO(), "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).
 		Return("", fmt.Errorf("some error"))
 
 	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: order.Items[0].ID}
@@ -388,7 +510,7 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_WithError() {
 	assert.Error(s.T(), err)
 
 	s.orderRepo.AssertCalled(s.T(), findOrderByIDMethod, context.TODO(), order.ID)
-	s.catalogService.AssertCalled(s.T(), getBookContent, context.TODO(), "some-book-id")
+	s.catalogService.AssertCalled(s.T(), getBookContent, context.TODO(), "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything)
 }
 
 func (s *ShopTestSuite) TestDownloadOrderItemContent_NonAdmin_Forbidden() {
@@ -424,7 +546,7 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_NonAdmin_Successfully() {
 		Status: shop.Paid,
 	}
 	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
-	s.catalogService.On(getBookContent, ctx, "some-book-id").
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).
 		Return("test", nil)
 
 	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: order.Items[0].ID}
@@ -434,7 +556,7 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_NonAdmin_Successfully() {
 	assert.Equal(s.T(), "test", response.URL)
 
 	s.orderRepo.AssertCalled(s.T(), findOrderByIDMethod, ctx, order.ID)
-	s.catalogService.AssertCalled(s.T(), getBookContent, ctx, "some-book-id")
+	s.catalogService.AssertCalled(s.T(), getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything)
 }
 
 func (s *ShopTestSuite) TestDownloadOrderItemContent_Admin_Successfully() {
@@ -449,7 +571,7 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_Admin_Successfully() {
 	}
 
 	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
-	s.catalogService.On(getBookContent, ctx, "some-book-id").
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).
 		Return("test", nil)
 
 	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: order.Items[0].ID}
@@ -459,7 +581,71 @@ func (s *ShopTestSuite) TestDownloadOrderItemContent_Admin_Successfully() {
 	assert.Equal(s.T(), "test", response.URL)
 
 	s.orderRepo.AssertCalled(s.T(), findOrderByIDMethod, ctx, order.ID)
-	s.catalogService.AssertCalled(s.T(), getBookContent, ctx, "some-book-id")
+	s.catalogService.AssertCalled(s.T(), getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_WhenPreOrderIsNotReleased() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	order := shop.Order{
+		ID:     "some-id",
+		Items:  []shop.Item{{ID: "some-book-id", PreOrder: true}},
+		UserID: "some-user-id",
+		Status: shop.Paid,
+	}
+	book := catalog.BookResponse{ID: "some-book-id", ReleaseDate: time.Now().Add(24 * time.Hour)}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(findBookByID, ctx, "some-book-id").Return(book, nil)
+
+	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id"}
+
+	_, err := s.shop.DownloadOrderItemContent(ctx, request)
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotReleased)
+	s.catalogService.AssertNotCalled(s.T(), getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_WhenPreOrderIsReleased() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	order := shop.Order{
+		ID:     "some-id",
+		Items:  []shop.Item{{ID: "some-book-id", PreOrder: true}},
+		UserID: "some-user-id",
+		Status: shop.Paid,
+	}
+	book := catalog.BookResponse{ID: "some-book-id", ReleaseDate: time.Now().Add(-time.Minute)}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(findBookByID, ctx, "some-book-id").Return(book, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).Return("test", nil)
+
+	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id"}
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, request)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "test", response.URL)
+}
+
+func (s *ShopTestSuite) TestHasPurchased_WithError() {
+	ctx := context.TODO()
+	s.orderRepo.On(hasPaidItemMethod, ctx, "user-id", "book-id").Return(false, fmt.Errorf("some error"))
+
+	_, err := s.shop.HasPurchased(ctx, "user-id", "book-id")
+
+	assert.Error(s.T(), err)
+}
+
+func (s *ShopTestSuite) TestHasPurchased_Successfully() {
+	ctx := context.TODO()
+	s.orderRepo.On(hasPaidItemMethod, ctx, "user-id", "book-id").Return(true, nil)
+
+	purchased, err := s.shop.HasPurchased(ctx, "user-id", "book-id")
+
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), purchased)
 }
 
 func (s *ShopTestSuite) TestGetCart_WhenCartCouldNotBeFound() {
@@ -500,14 +686,42 @@ func (s *ShopTestSuite) TestAddItemToCart_WhenBookCouldNotBeFound() {
 	s.cartRepo.AssertExpectations(s.T())
 }
 
+func (s *ShopTestSuite) TestAddItemToCart_WhenBookIsArchived() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	itemId := "some-item-id"
+	archivedAt := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	s.cartRepo.On(findCartByUserIDMethod, ctx, "some-user-id").Return(&shop.Cart{}, nil)
+	s.catalogService.On(findBookByID, ctx, itemId).Return(catalog.BookResponse{ID: itemId, Status: catalog.StatusPublished, ArchivedAt: &archivedAt}, nil)
+
+	_, err := s.shop.AddItemToCart(ctx, itemId)
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotAvailable)
+	s.cartRepo.AssertNotCalled(s.T(), saveCartMethod, ctx, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestAddItemToCart_WhenBookIsUnpublished() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	itemId := "some-item-id"
+
+	s.cartRepo.On(findCartByUserIDMethod, ctx, "some-user-id").Return(&shop.Cart{}, nil)
+	s.catalogService.On(findBookByID, ctx, itemId).Return(catalog.BookResponse{ID: itemId, Status: catalog.StatusUnpublished}, nil)
+
+	_, err := s.shop.AddItemToCart(ctx, itemId)
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotAvailable)
+	s.cartRepo.AssertNotCalled(s.T(), saveCartMethod, ctx, mock.Anything)
+}
+
 func (s *ShopTestSuite) TestAddItemToCart_WhenCartDoesNotExist() {
 	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
 	itemId := "some-item-id"
 
 	book := catalog.BookResponse{
-		ID:    itemId,
-		Title: "some-title",
-		Price: 100,
+		ID:     itemId,
+		Title:  "some-title",
+		Price:  100,
+		Status: catalog.StatusPublished,
 	}
 
 	s.cartRepo.On(findCartByUserIDMethod, ctx, "some-user-id").Return(nil, fmt.Errorf("some error"))
@@ -533,9 +747,10 @@ func (s *ShopTestSuite) TestAddItemToCart_WhenCartExists() {
 	itemId := "some-item-id"
 
 	book := catalog.BookResponse{
-		ID:    itemId,
-		Title: "some-title",
-		Price: 100,
+		ID:     itemId,
+		Title:  "some-title",
+		Price:  100,
+		Status: catalog.StatusPublished,
 	}
 
 	cart := &shop.Cart{
@@ -561,9 +776,10 @@ func (s *ShopTestSuite) TestAddItemToCart_WhenCartCouldNotBeSaved() {
 	itemId := "some-item-id"
 
 	book := catalog.BookResponse{
-		ID:    itemId,
-		Title: "some-title",
-		Price: 100,
+		ID:     itemId,
+		Title:  "some-title",
+		Price:  100,
+		Status: catalog.StatusPublished,
 	}
 
 	cart := &shop.Cart{
@@ -638,3 +854,445 @@ func (s *ShopTestSuite) TestRemoveItemFromCart_Successfully() {
 	assert.Equal(s.T(), shop.NewCartResponse(*cart), response)
 	s.cartRepo.AssertExpectations(s.T())
 }
+
+func (s *ShopTestSuite) TestAddItemToWishlist_WhenItemIsAlreadyInWishlist() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	wishlist := shop.Wishlist{UserID: "user-id", Items: []shop.WishlistItem{{UserID: "user-id", BookID: "book-id"}}}
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(wishlist, nil)
+
+	_, err := s.shop.AddItemToWishlist(ctx, "book-id")
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemAlreadyInWishlist)
+	s.catalogService.AssertNotCalled(s.T(), findBookByID)
+	s.wishlistRepo.AssertNotCalled(s.T(), addWishlistItemMethod)
+}
+
+func (s *ShopTestSuite) TestAddItemToWishlist_Successfully() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(shop.Wishlist{UserID: "user-id"}, nil)
+	s.catalogService.On(findBookByID, ctx, "book-id").Return(catalog.BookResponse{ID: "book-id", Title: "Clean Code", Price: 4000, Status: catalog.StatusPublished}, nil)
+
+	item := shop.WishlistItem{UserID: "user-id", BookID: "book-id", Name: "Clean Code", Price: 4000, CurrentPrice: 4000}
+	s.wishlistRepo.On(addWishlistItemMethod, ctx, &item).Return(nil)
+
+	response, err := s.shop.AddItemToWishlist(ctx, "book-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), shop.NewWishlistResponse(shop.Wishlist{UserID: "user-id", Items: []shop.WishlistItem{item}}), response)
+}
+
+func (s *ShopTestSuite) TestRemoveItemFromWishlist_WhenItemIsNotInWishlist() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(shop.Wishlist{UserID: "user-id"}, nil)
+
+	err := s.shop.RemoveItemFromWishlist(ctx, "book-id")
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotFoundInWishlist)
+	s.wishlistRepo.AssertNotCalled(s.T(), removeWishlistItemMethod)
+}
+
+func (s *ShopTestSuite) TestRemoveItemFromWishlist_Successfully() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	wishlist := shop.Wishlist{UserID: "user-id", Items: []shop.WishlistItem{{UserID: "user-id", BookID: "book-id"}}}
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(wishlist, nil)
+	s.wishlistRepo.On(removeWishlistItemMethod, ctx, "user-id", "book-id").Return(nil)
+
+	err := s.shop.RemoveItemFromWishlist(ctx, "book-id")
+
+	assert.Nil(s.T(), err)
+}
+
+func (s *ShopTestSuite) TestAddWishlistItemToCart_WhenItemIsNotInWishlist() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(shop.Wishlist{UserID: "user-id"}, nil)
+
+	_, err := s.shop.AddWishlistItemToCart(ctx, "book-id")
+
+	assert.ErrorIs(s.T(), err, shop.ErrItemNotFoundInWishlist)
+	s.cartRepo.AssertNotCalled(s.T(), saveCartMethod)
+}
+
+func (s *ShopTestSuite) TestAddWishlistItemToCart_Successfully() {
+	ctx := context.WithValue(context.Background(), "userId", "user-id")
+	wishlist := shop.Wishlist{UserID: "user-id", Items: []shop.WishlistItem{{UserID: "user-id", BookID: "book-id"}}}
+	cart := &shop.Cart{ID: "cart-id", UserID: "user-id"}
+
+	s.wishlistRepo.On(findWishlistMethod, ctx, "user-id").Return(wishlist, nil)
+	s.cartRepo.On(findCartByUserIDMethod, ctx, "user-id").Return(cart, nil)
+	s.catalogService.On(findBookByID, ctx, "book-id").Return(catalog.BookResponse{ID: "book-id", Title: "Clean Code", Price: 4000, Status: catalog.StatusPublished}, nil)
+	s.cartRepo.On(saveCartMethod, ctx, cart).Return(nil)
+
+	response, err := s.shop.AddWishlistItemToCart(ctx, "book-id")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "book-id", response.Items[0].ID)
+	assert.Equal(s.T(), int64(4000), response.TotalPrice)
+}
+
+func (s *ShopTestSuite) TestNotifyPriceDrops_WithResetError() {
+	ctx := context.TODO()
+	s.wishlistRepo.On(resetNotifiedPricesMethod, ctx).Return(fmt.Errorf("some error"))
+
+	err := s.shop.NotifyPriceDrops(ctx)
+
+	assert.Error(s.T(), err)
+	s.wishlistRepo.AssertNotCalled(s.T(), findPriceDropsMethod, ctx)
+}
+
+func (s *ShopTestSuite) TestNotifyPriceDrops_WithRepositoryError() {
+	ctx := context.TODO()
+	s.wishlistRepo.On(resetNotifiedPricesMethod, ctx).Return(nil)
+	s.wishlistRepo.On(findPriceDropsMethod, ctx).Return(nil, fmt.Errorf("some error"))
+
+	err := s.shop.NotifyPriceDrops(ctx)
+
+	assert.Error(s.T(), err)
+}
+
+func (s *ShopTestSuite) TestNotifyPriceDrops_ContinuesAfterEmailFailure() {
+	ctx := context.TODO()
+	drop1 := shop.PriceDrop{UserID: "user-id1", BookID: "book-id", OldPrice: 5000, NewPrice: 4000}
+	drop2 := shop.PriceDrop{UserID: "user-id2", BookID: "book-id", OldPrice: 4500, NewPrice: 4000}
+
+	s.wishlistRepo.On(resetNotifiedPricesMethod, ctx).Return(nil)
+	s.wishlistRepo.On(findPriceDropsMethod, ctx).Return([]shop.PriceDrop{drop1, drop2}, nil)
+	s.emailClient.On(sendPriceDropEmailMethod, ctx, drop1).Return(fmt.Errorf("some error"))
+	s.emailClient.On(sendPriceDropEmailMethod, ctx, drop2).Return(nil)
+	s.wishlistRepo.On(markNotifiedMethod, ctx, "user-id2", "book-id", int64(4000)).Return(nil)
+
+	err := s.shop.NotifyPriceDrops(ctx)
+
+	assert.Nil(s.T(), err)
+	s.wishlistRepo.AssertNotCalled(s.T(), markNotifiedMethod, ctx, "user-id1", "book-id", int64(4000))
+	s.wishlistRepo.AssertNumberOfCalls(s.T(), markNotifiedMethod, 1)
+}
+
+func (s *ShopTestSuite) TestNotifyReleases_WithRepositoryError() {
+	ctx := context.TODO()
+	s.orderRepo.On(findReleasesMethod, ctx, mock.AnythingOfType("time.Time")).Return(nil, fmt.Errorf("some error"))
+
+	err := s.shop.NotifyReleases(ctx)
+
+	assert.Error(s.T(), err)
+}
+
+func (s *ShopTestSuite) TestNotifyReleases_ContinuesAfterEmailFailure() {
+	ctx := context.TODO()
+	release1 := shop.Release{OrderID: "order-id1", UserID: "user-id1", BookID: "book-id"}
+	release2 := shop.Release{OrderID: "order-id2", UserID: "user-id2", BookID: "book-id"}
+
+	s.orderRepo.On(findReleasesMethod, ctx, mock.AnythingOfType("time.Time")).Return([]shop.Release{release1, release2}, nil)
+	s.emailClient.On(sendReleaseEmailMethod, ctx, release1).Return(fmt.Errorf("some error"))
+	s.emailClient.On(sendReleaseEmailMethod, ctx, release2).Return(nil)
+	s.orderRepo.On(markReleaseNotifiedMethod, ctx, "order-id2", "book-id").Return(nil)
+
+	err := s.shop.NotifyReleases(ctx)
+
+	assert.Nil(s.T(), err)
+	s.orderRepo.AssertNotCalled(s.T(), markReleaseNotifiedMethod, ctx, "order-id1", "book-id")
+	s.orderRepo.AssertNumberOfCalls(s.T(), markReleaseNotifiedMethod, 1)
+}
+
+func (s *ShopTestSuite) TestFindLibrary_Successfully() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	q := *query.New().
+		And(query.Condition{Field: "user_id", Operator: query.Equal, Value: "some-user-id"}).
+		And(query.Condition{Field: "status", Operator: query.Equal, Value: "PAID"})
+
+	firstPage := query.Page{Size: 50, Keyset: true}
+	secondPage := query.Page{Size: 50, Keyset: true, Cursor: "next"}
+
+	newest := shop.Order{ID: "order-2", Items: []shop.Item{{ID: "book-2"}, {ID: "book-1"}}}
+	oldest := shop.Order{ID: "order-1", Items: []shop.Item{{ID: "book-1"}}}
+
+	s.orderRepo.On(findOrdersByQueryMethod, ctx, q, firstPage).Return(shop.PaginatedOrders{Orders: []shop.Order{newest}, NextCursor: "next"}, nil)
+	s.orderRepo.On(findOrdersByQueryMethod, ctx, q, secondPage).Return(shop.PaginatedOrders{Orders: []shop.Order{oldest}}, nil)
+	s.catalogService.On(findBooksByIDs, ctx, []string{"book-2", "book-1"}).
+		Return([]catalog.BookResponse{{ID: "book-1"}, {ID: "book-2"}}, nil)
+
+	expected := shop.LibraryResponse{Books: []shop.LibraryBookResponse{
+		shop.NewLibraryBookResponse(newest, catalog.BookResponse{ID: "book-2"}),
+		shop.NewLibraryBookResponse(newest, catalog.BookResponse{ID: "book-1"}),
+	}}
+	actual, err := s.shop.FindLibrary(ctx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+	s.catalogService.AssertNumberOfCalls(s.T(), findBooksByIDs, 1)
+	s.catalogService.AssertNotCalled(s.T(), findBookByID)
+}
+
+func (s *ShopTestSuite) TestFindLibrary_SkipsRemovedBooks() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	order := shop.Order{ID: "order-1", Items: []shop.Item{{ID: "book-1"}, {ID: "removed-id"}}}
+	s.orderRepo.On(findOrdersByQueryMethod, ctx, mock.Anything, mock.Anything).Return(shop.PaginatedOrders{Orders: []shop.Order{order}}, nil)
+	s.catalogService.On(findBooksByIDs, ctx, []string{"book-1", "removed-id"}).Return([]catalog.BookResponse{{ID: "book-1"}}, nil)
+
+	actual, err := s.shop.FindLibrary(ctx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []shop.LibraryBookResponse{shop.NewLibraryBookResponse(order, catalog.BookResponse{ID: "book-1"})}, actual.Books)
+}
+
+func (s *ShopTestSuite) TestFindLibrary_WhenBooksCouldNotBeFound() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	order := shop.Order{ID: "order-1", Items: []shop.Item{{ID: "book-1"}}}
+	s.orderRepo.On(findOrdersByQueryMethod, ctx, mock.Anything, mock.Anything).Return(shop.PaginatedOrders{Orders: []shop.Order{order}}, nil)
+	s.catalogService.On(findBooksByIDs, ctx, []string{"book-1"}).Return(nil, fmt.Errorf("some error"))
+
+	_, err := s.shop.FindLibrary(ctx)
+
+	assert.Error(s.T(), err)
+}
+
+func (s *ShopTestSuite) TestFindLibrary_WithError() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	s.orderRepo.On(findOrdersByQueryMethod, ctx, mock.Anything, mock.Anything).Return(shop.PaginatedOrders{}, fmt.Errorf("some error"))
+
+	_, err := s.shop.FindLibrary(ctx)
+
+	assert.Error(s.T(), err)
+	s.catalogService.AssertNotCalled(s.T(), findBooksByIDs)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_WithFormat() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+
+	order := shop.Order{
+		ID:     "some-id",
+		Items:  []shop.Item{{ID: "some-book-id"}},
+		UserID: "some-user-id",
+		Status: shop.Paid,
+	}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return("epub-url", nil)
+
+	request := shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"}
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, request)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "epub-url", response.URL)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_WatermarksForBuyer() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	buyer := shop.Buyer{UserID: "some-user-id", Email: "jane@ebookstore.com", FirstName: "Jane", LastName: "Doe"}
+	var mark catalog.Watermark
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(buyer, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatPDF, order.ID, mock.Anything).Return("pdf-url", nil).Run(func(args mock.Arguments) {
+		mark, _ = args.Get(4).(catalog.WatermarkFunc)(ctx)
+	})
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "pdf"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "pdf-url", response.URL)
+	assert.Equal(s.T(), "Jane Doe", mark.Name)
+	assert.Equal(s.T(), buyer.Email, mark.Email)
+	assert.Equal(s.T(), order.ID, mark.OrderID)
+	assert.False(s.T(), mark.Time.IsZero())
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_DoesNotFindBuyerWhenNotWatermarked() {
+	ctx := context.WithValue(context.Background(), "admin", true)
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return("epub-url", nil)
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "epub-url", response.URL)
+	s.orderRepo.AssertNotCalled(s.T(), findBuyerMethod, mock.Anything, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBook() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	buyer := shop.Buyer{UserID: "some-user-id", LicenseKey: "license-key", LicenseHint: "hint"}
+	content := catalog.ProtectedBookContent{ProtectedContent: catalog.ProtectedContent{ContentID: "epub-key"}, URL: "protected-url"}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(buyer, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).
+		Return("", fmt.Errorf("some error: %w", catalog.ErrBookProtected))
+	s.licenseRepo.On(findLicenseMethod, ctx, order.ID, "some-book-id").Return(nil, nil)
+	s.idGenerator.On(newIdMethod).Return("license-id")
+	s.licenseRepo.On(createLicenseMethod, ctx, mock.AnythingOfType("*shop.License")).Return(nil)
+	s.catalogService.On(getProtectedContentMethod, ctx, "some-book-id").Return(content, nil)
+	isLicense := mock.MatchedBy(func(license shop.License) bool {
+		return license.ID == "license-id" && license.BookID == "some-book-id"
+	})
+	s.licenseIssuer.On(issueLicenseMethod, isLicense, buyer, content).Return([]byte(`{"id":"license-id"}`), nil)
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "protected-url", response.URL)
+	assert.JSONEq(s.T(), `{"id":"license-id"}`, string(response.License))
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBookWithIssuedLicense() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	buyer := shop.Buyer{UserID: "some-user-id", LicenseKey: "license-key"}
+	license := shop.License{ID: "license-id", OrderID: order.ID, BookID: "some-book-id", Start: time.Now()}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(buyer, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).Return("", catalog.ErrBookProtected)
+	s.licenseRepo.On(findLicenseMethod, ctx, order.ID, "some-book-id").Return(&license, nil)
+	s.catalogService.On(getProtectedContentMethod, ctx, "some-book-id").Return(catalog.ProtectedBookContent{URL: "protected-url"}, nil)
+	s.licenseIssuer.On(issueLicenseMethod, license, buyer, mock.Anything).Return([]byte(`{}`), nil)
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "protected-url", response.URL)
+	s.licenseRepo.AssertNotCalled(s.T(), createLicenseMethod, mock.Anything, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBookWithLicenseIssuedConcurrently() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	buyer := shop.Buyer{UserID: "some-user-id", LicenseKey: "license-key"}
+	license := shop.License{ID: "concurrent-license-id", OrderID: order.ID, BookID: "some-book-id", Start: time.Now()}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(buyer, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return("", catalog.ErrBookProtected)
+	s.licenseRepo.On(findLicenseMethod, ctx, order.ID, "some-book-id").Return(nil, nil).Once()
+	s.idGenerator.On(newIdMethod).Return("license-id")
+	s.licenseRepo.On(createLicenseMethod, ctx, mock.AnythingOfType("*shop.License")).Return(fmt.Errorf("duplicate key"))
+	s.licenseRepo.On(findLicenseMethod, ctx, order.ID, "some-book-id").Return(&license, nil)
+	s.catalogService.On(getProtectedContentMethod, ctx, "some-book-id").Return(catalog.ProtectedBookContent{URL: "protected-url"}, nil)
+	s.licenseIssuer.On(issueLicenseMethod, license, buyer, mock.Anything).Return([]byte(`{"id":"concurrent-license-id"}`), nil)
+
+	response, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+
+	assert.NoError(s.T(), err)
+	assert.JSONEq(s.T(), `{"id":"concurrent-license-id"}`, string(response.License))
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBookWithoutPassphrase() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(shop.Buyer{UserID: "some-user-id"}, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return("", catalog.ErrBookProtected)
+
+	_, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+
+	assert.ErrorIs(s.T(), err, shop.ErrLicensePassphraseRequired)
+	s.catalogService.AssertNotCalled(s.T(), getProtectedContentMethod, mock.Anything, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBookWithExpiredLicense() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	end := time.Now().Add(-time.Hour)
+	license := shop.License{ID: "license-id", Start: end.Add(-time.Hour), End: &end}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.orderRepo.On(findBuyerMethod, ctx, order.ID).Return(shop.Buyer{UserID: "some-user-id", LicenseKey: "license-key"}, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return("", catalog.ErrBookProtected)
+	s.licenseRepo.On(findLicenseMethod, ctx, order.ID, "some-book-id").Return(&license, nil)
+
+	_, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+
+	assert.ErrorIs(s.T(), err, shop.ErrLicenseExpired)
+}
+
+func (s *ShopTestSuite) TestDownloadOrderItemContent_ProtectedBookOtherFormat() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(getBookContent, ctx, "some-book-id", catalog.FormatPDF, order.ID, mock.Anything).Return("", catalog.ErrBookProtected)
+
+	_, err := s.shop.DownloadOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "pdf"})
+
+	assert.ErrorIs(s.T(), err, catalog.ErrBookProtected)
+	s.licenseRepo.AssertNotCalled(s.T(), findLicenseMethod, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestStreamOrderItemContent_WhenOrderIsNotPaid() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Pending}
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+
+	_, err := s.shop.StreamOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id"})
+
+	assert.ErrorIs(s.T(), err, shop.ErrOrderNotCompleted)
+	s.catalogService.AssertNotCalled(s.T(), openBookContentMethod, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (s *ShopTestSuite) TestStreamOrderItemContent_RecordsResumedDownload() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(openBookContentMethod, ctx, "some-book-id", catalog.FormatEPUB, order.ID, mock.Anything).Return(catalog.BookContent{
+		ReadSeekCloser: nopSeekCloser{strings.NewReader("0123456789")},
+		Format:         catalog.FormatEPUB,
+		Size:           10,
+	}, nil)
+	s.idGenerator.On(newIdMethod).Return("download-id")
+	s.downloadRepo.On(saveDownloadMethod, mock.Anything, mock.Anything).Return(nil)
+
+	content, err := s.shop.StreamOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id", Format: "epub"})
+	assert.NoError(s.T(), err)
+
+	_, err = content.Seek(4, io.SeekStart)
+	assert.NoError(s.T(), err)
+	read, err := io.ReadAll(content)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "456789", string(read))
+	assert.NoError(s.T(), content.Close())
+
+	s.downloadRepo.AssertCalled(s.T(), saveDownloadMethod, mock.Anything, &shop.Download{
+		ID:        "download-id",
+		UserID:    "some-user-id",
+		OrderID:   order.ID,
+		ItemID:    "some-book-id",
+		Format:    catalog.FormatEPUB,
+		Offset:    4,
+		Bytes:     6,
+		Size:      10,
+		Completed: true,
+	})
+}
+
+func (s *ShopTestSuite) TestStreamOrderItemContent_DoesNotRecordUnreadContent() {
+	ctx := context.WithValue(context.Background(), "userId", "some-user-id")
+	order := shop.Order{ID: "some-id", Items: []shop.Item{{ID: "some-book-id"}}, UserID: "some-user-id", Status: shop.Paid}
+	s.orderRepo.On(findOrderByIDMethod, ctx, order.ID).Return(order, nil)
+	s.catalogService.On(openBookContentMethod, ctx, "some-book-id", catalog.BookFormat(""), order.ID, mock.Anything).Return(catalog.BookContent{
+		ReadSeekCloser: nopSeekCloser{strings.NewReader("0123456789")},
+		Size:           10,
+	}, nil)
+	s.idGenerator.On(newIdMethod).Return("download-id")
+
+	content, err := s.shop.StreamOrderItemContent(ctx, shop.DownloadOrderContentRequest{OrderID: order.ID, ItemID: "some-book-id"})
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), content.Close())
+
+	s.downloadRepo.AssertNotCalled(s.T(), saveDownloadMethod, mock.Anything, mock.Anything)
+}
+
+type nopSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopSeekCloser) Close() error {
+	return nil
+}
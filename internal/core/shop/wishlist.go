@@ -0,0 +1,44 @@
+package shop
+
+import "time"
+
+// Wishlist holds the books a user saved for later. Unlike the Cart, it never expires.
+type Wishlist struct {
+	UserID string
+	Items  []WishlistItem
+}
+
+// WishlistItem is a book in a wishlist. Price is the price of the book when it was added,
+// and it's used as reference to notify the user when the book gets cheaper.
+type WishlistItem struct {
+	UserID         string `gorm:"primaryKey"`
+	BookID         string `gorm:"primaryKey"`
+	Name           string
+	PreviewImageID string
+	Price          int64
+	// CurrentPrice is the price the book has now. It's read from the catalog and never stored.
+	CurrentPrice int64 `gorm:"->"`
+	// NotifiedPrice is the last price the user was notified about. It avoids sending the same drop twice.
+	NotifiedPrice *int64
+	CreatedAt     time.Time
+}
+
+func (w *Wishlist) HasItem(bookID string) bool {
+	for _, i := range w.Items {
+		if i.BookID == bookID {
+			return true
+		}
+	}
+	return false
+}
+
+// PriceDrop is a wishlisted book whose price is lower than when it was added and than the last notified price.
+type PriceDrop struct {
+	UserID    string
+	Email     string
+	FirstName string
+	BookID    string
+	Name      string
+	OldPrice  int64
+	NewPrice  int64
+}
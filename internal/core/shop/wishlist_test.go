@@ -0,0 +1,18 @@
+package shop
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWishlist_HasItem(t *testing.T) {
+	wishlist := Wishlist{
+		Items: []WishlistItem{
+			{BookID: "book-id"},
+		},
+	}
+
+	assert.True(t, wishlist.HasItem("book-id"))
+	assert.False(t, wishlist.HasItem("other-book-id"))
+}
@@ -0,0 +1,10 @@
+package config
+
+import (
+	"github.com/ebookstore/internal/platform/exporter"
+	"github.com/spf13/viper"
+)
+
+func NewStoreURL() exporter.StoreURL {
+	return exporter.StoreURL(viper.GetString("STORE_URL"))
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/ebookstore/internal/platform/lcp"
+	"github.com/spf13/viper"
+)
+
+func NewLCPSecret() lcp.Secret {
+	return []byte(viper.GetString("LCP_SECRET"))
+}
+
+func NewLCPProvider() lcp.Provider {
+	return lcp.Provider(viper.GetString("LCP_PROVIDER"))
+}
+
+// NewLicenseRights returns the rights of the new licenses. The limits left empty are unlimited.
+func NewLicenseRights() shop.LicenseRights {
+	return shop.LicenseRights{
+		Print:    optionalInt("LICENSE_PRINT_LIMIT"),
+		Copy:     optionalInt("LICENSE_COPY_LIMIT"),
+		Duration: time.Hour * 24 * time.Duration(viper.GetInt("LICENSE_DURATION")),
+	}
+}
+
+func optionalInt(key string) *int {
+	if viper.GetString(key) == "" {
+		return nil
+	}
+
+	value := viper.GetInt(key)
+	return &value
+}
@@ -19,3 +19,7 @@ func NewServerAddr() server.Addr {
 func NewServerTimeout() server.Timeout {
 	return server.Timeout(viper.GetInt64("SERVER_TIMEOUT") * int64(time.Second))
 }
+
+func NewDownloadStreaming() server.DownloadStreaming {
+	return server.DownloadStreaming(viper.GetBool("DOWNLOAD_STREAMING"))
+}
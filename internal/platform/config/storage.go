@@ -1,8 +1,14 @@
 package config
 
 import (
+	"context"
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go/service/cloudfront/sign"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/log"
 	"github.com/ebookstore/internal/platform/storage"
 	"github.com/spf13/viper"
 )
@@ -18,3 +24,55 @@ func NewS3Client(cfg *aws.Config) *s3.Client {
 func NewPresignClient(client *s3.Client) *s3.PresignClient {
 	return s3.NewPresignClient(client)
 }
+
+// NewLocalStorageDir returns the directory of the local storage. The objects are kept in the bucket when it's empty.
+func NewLocalStorageDir() storage.LocalStorageDir {
+	return storage.LocalStorageDir(viper.GetString("LOCAL_STORAGE_DIR"))
+}
+
+func NewLocalStorageURL() storage.LocalStorageURL {
+	return storage.LocalStorageURL(viper.GetString("LOCAL_STORAGE_URL"))
+}
+
+func NewLocalStorageSecret() storage.LocalStorageSecret {
+	return []byte(viper.GetString("LOCAL_STORAGE_SECRET"))
+}
+
+// NewCDNDomain returns the domain of the CDN serving the bucket. The assets are served from the bucket when it's empty.
+func NewCDNDomain() storage.CDNDomain {
+	return storage.CDNDomain(viper.GetString("CDN_DOMAIN"))
+}
+
+// NewCDNSigner loads the private key of the CDN key pair. It's only needed when an asset is linked with signed URLs, and
+// the CDN configuration is rejected at startup when such an asset has no key.
+func NewCDNSigner() *sign.URLSigner {
+	path := viper.GetString("CDN_PRIVATE_KEY_PATH")
+	if path == "" {
+		return nil
+	}
+
+	key, err := sign.LoadPEMPrivKeyFile(path)
+	if err != nil {
+		log.Fatalf(context.TODO(), "loading the CDN private key has failed: %v", err)
+		return nil
+	}
+
+	return sign.NewURLSigner(viper.GetString("CDN_KEY_PAIR_ID"), key)
+}
+
+func NewCDNLinkExpiration() storage.CDNLinkExpiration {
+	return storage.CDNLinkExpiration(time.Minute * time.Duration(viper.GetInt("CDN_LINK_EXPIRATION")))
+}
+
+func NewCDNSourceIP() storage.CDNSourceIP {
+	return storage.CDNSourceIP(viper.GetString("CDN_SOURCE_IP"))
+}
+
+// NewCDNLinks returns how the links to each asset are generated: presigned, public, signed or signed-policy.
+func NewCDNLinks() storage.CDNLinks {
+	return storage.CDNLinks{
+		catalog.AssetImage:    storage.LinkMode(viper.GetString("CDN_IMAGE_LINKS")),
+		catalog.AssetBookFile: storage.LinkMode(viper.GetString("CDN_FILE_LINKS")),
+		catalog.AssetSample:   storage.LinkMode(viper.GetString("CDN_SAMPLE_LINKS")),
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/ses"
 	"github.com/aws/aws-sdk-go-v2/service/ses/types"
 	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/shop"
 	"github.com/ebookstore/internal/log"
 	"github.com/spf13/viper"
 )
@@ -22,6 +23,14 @@ const (
 						<p>You've reset your password successfully!</p>
 						<p>Your new password is: {{.NewPassword}}</p>
 						<p>If you did ask for this change, please contact us!</p>`
+	priceDropSubject      = "A book in your wishlist is cheaper!"
+	priceDropBodyTemplate = `<h1> Hello, {{.FirstName}}!<h1/>
+						<p>The price of {{.Name}} dropped from {{.OldPrice}} to {{.NewPrice}}.</p>
+						<p>It's still in your wishlist, you can add it to your cart anytime!</p>`
+	releaseSubject      = "Your pre-ordered book is available!"
+	releaseBodyTemplate = `<h1> Hello, {{.FirstName}}!<h1/>
+						<p>{{.Name}} has been released, you can download it from your library now.</p>
+						<p>Thanks for your pre-order!</p>`
 )
 
 type Email struct {
@@ -38,17 +47,80 @@ func (e *Email) SendPasswordResetEmail(ctx context.Context, user auth.User, newP
 
 	log.Infof(ctx, "sending password reset email")
 
-	sourceEmail := viper.GetString("AWS_SES_SOURCE_EMAIL")
 	messageBody, err := e.getMessageBody(user, newPassword)
 	if err != nil {
 		return fmt.Errorf("(SendPasswordResetEmail) failed getting email message body: %w", err)
 	}
 
+	if err = e.send(ctx, user.Email, subject, messageBody); err != nil {
+		return fmt.Errorf("(SendPasswordResetEmail) failed sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Email) SendPriceDropEmail(ctx context.Context, drop shop.PriceDrop) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	log.Infof(ctx, "sending price drop email")
+
+	messageBody := bytes.NewBufferString("")
+	tmpl := template.Must(template.New("Price Drop Template").Parse(priceDropBodyTemplate))
+	err := tmpl.Execute(messageBody, struct {
+		FirstName string
+		Name      string
+		OldPrice  string
+		NewPrice  string
+	}{
+		FirstName: drop.FirstName,
+		Name:      drop.Name,
+		OldPrice:  formatPrice(drop.OldPrice),
+		NewPrice:  formatPrice(drop.NewPrice),
+	})
+	if err != nil {
+		return fmt.Errorf("(SendPriceDropEmail) failed parsing template: %w", err)
+	}
+
+	if err = e.send(ctx, drop.Email, priceDropSubject, messageBody.String()); err != nil {
+		return fmt.Errorf("(SendPriceDropEmail) failed sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Email) SendReleaseEmail(ctx context.Context, release shop.Release) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	log.Infof(ctx, "sending release email")
+
+	messageBody := bytes.NewBufferString("")
+	tmpl := template.Must(template.New("Release Template").Parse(releaseBodyTemplate))
+	err := tmpl.Execute(messageBody, struct {
+		FirstName string
+		Name      string
+	}{
+		FirstName: release.FirstName,
+		Name:      release.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("(SendReleaseEmail) failed parsing template: %w", err)
+	}
+
+	if err = e.send(ctx, release.Email, releaseSubject, messageBody.String()); err != nil {
+		return fmt.Errorf("(SendReleaseEmail) failed sending email: %w", err)
+	}
+
+	return nil
+}
+
+func (e *Email) send(ctx context.Context, to, subject, messageBody string) error {
 	input := &ses.SendEmailInput{
 		Destination: &types.Destination{
 			CcAddresses: nil,
 			ToAddresses: []string{
-				user.Email,
+				to,
 			},
 		},
 		Message: &types.Message{
@@ -63,14 +135,16 @@ func (e *Email) SendPasswordResetEmail(ctx context.Context, user auth.User, newP
 				Data:    aws.String(subject),
 			},
 		},
-		Source: aws.String(sourceEmail),
+		Source: aws.String(viper.GetString("AWS_SES_SOURCE_EMAIL")),
 	}
 
-	if _, err = e.Client.SendEmail(ctx, input); err != nil {
-		return fmt.Errorf("(SendPasswordResetEmail) failed sending email: %w", err)
-	}
+	_, err := e.Client.SendEmail(ctx, input)
+	return err
+}
 
-	return nil
+// formatPrice formats a price in cents.
+func formatPrice(price int64) string {
+	return fmt.Sprintf("$%d.%02d", price/100, price%100)
 }
 
 func (e *Email) getMessageBody(user auth.User, newPassword string) (string, error) {
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// csvHeader uses the same column names as the catalog import, so an export can be edited and imported back.
+var csvHeader = []string{
+	"id", "isbn", "title", "subtitle", "description", "authors", "publisher", "language",
+	"page_count", "edition", "price", "release_date", "categories", "link", "image_link",
+}
+
+type csvWriter struct {
+	writer   *csv.Writer
+	bookLink func(catalog.Book) string
+}
+
+func newCSVWriter(w io.Writer, bookLink func(catalog.Book) string) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer, bookLink: bookLink}, nil
+}
+
+func (w *csvWriter) Write(exported catalog.ExportedBook) error {
+	book := exported.Book
+
+	var imageLink string
+	if len(exported.ImageLinks) > 0 {
+		imageLink = exported.ImageLinks[0]
+	}
+
+	return w.writer.Write([]string{
+		book.ID,
+		isbn(book),
+		book.Title,
+		book.Subtitle,
+		book.Description,
+		strings.Join(book.AuthorNames(catalog.RoleAuthor), ";"),
+		book.Publisher,
+		book.Language,
+		strconv.Itoa(book.PageCount),
+		book.Edition,
+		formatPrice(book.Price),
+		book.ReleaseDate.Format("2006-01-02"),
+		strings.Join(categorySlugs(book), ";"),
+		w.bookLink(book),
+		imageLink,
+	})
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// StoreURL is the base URL of the store front, used to build the links to the books.
+type StoreURL string
+
+type Config struct {
+	StoreURL StoreURL
+}
+
+// Encoder creates the writers of the catalog export formats.
+type Encoder struct {
+	Config
+}
+
+func NewEncoder(c Config) *Encoder {
+	return &Encoder{Config: c}
+}
+
+func (e *Encoder) NewWriter(format string, w io.Writer) (catalog.ExportWriter, error) {
+	switch format {
+	case catalog.ExportFormatCSV:
+		return newCSVWriter(w, e.bookLink)
+	case catalog.ExportFormatJSONL:
+		return newJSONLWriter(w, e.bookLink), nil
+	case catalog.ExportFormatGoogleMerchant:
+		return newMerchantWriter(w, string(e.StoreURL), e.bookLink)
+	default:
+		return nil, fmt.Errorf("(NewWriter) unsupported format %s", format)
+	}
+}
+
+func (e *Encoder) bookLink(book catalog.Book) string {
+	return strings.TrimSuffix(string(e.StoreURL), "/") + "/books/" + book.ID
+}
+
+// formatPrice formats a price in cents as a decimal amount in currency units.
+func formatPrice(cents int) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+func isbn(book catalog.Book) string {
+	if book.ISBN == nil {
+		return ""
+	}
+	return *book.ISBN
+}
+
+func categorySlugs(book catalog.Book) []string {
+	slugs := make([]string, 0, len(book.Categories))
+	for _, category := range book.Categories {
+		slugs = append(slugs, category.Slug)
+	}
+	return slugs
+}
@@ -0,0 +1,136 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportedBook() catalog.ExportedBook {
+	isbn := "9780321125217"
+	return catalog.ExportedBook{
+		Book: catalog.Book{
+			ID:          "book-1",
+			Title:       "Domain-Driven Design",
+			Description: "Tackling Complexity in the Heart of Software",
+			ISBN:        &isbn,
+			Publisher:   "Addison-Wesley",
+			Language:    "en",
+			PageCount:   560,
+			Price:       5499,
+			ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC),
+			Authors: []catalog.BookAuthor{
+				{Role: catalog.RoleAuthor, Author: catalog.Author{Name: "Eric Evans"}},
+				{Role: catalog.RoleTranslator, Author: catalog.Author{Name: "Jane Doe"}},
+			},
+			Categories: []catalog.Category{{Name: "Software", Slug: "software"}},
+		},
+		ImageLinks: []string{"https://images/1", "https://images/2"},
+	}
+}
+
+func export(t *testing.T, format string, books ...catalog.ExportedBook) string {
+	var buffer bytes.Buffer
+	writer, err := NewEncoder(Config{StoreURL: "https://store.com/"}).NewWriter(format, &buffer)
+	require.NoError(t, err)
+
+	for _, book := range books {
+		require.NoError(t, writer.Write(book))
+	}
+	require.NoError(t, writer.Close())
+
+	return buffer.String()
+}
+
+func TestEncoder_CSV(t *testing.T) {
+	actual := export(t, catalog.ExportFormatCSV, exportedBook())
+
+	expected := "id,isbn,title,subtitle,description,authors,publisher,language,page_count,edition,price,release_date,categories,link,image_link\n" +
+		"book-1,9780321125217,Domain-Driven Design,,Tackling Complexity in the Heart of Software,Eric Evans,Addison-Wesley,en,560,,54.99,2003-08-20,software,https://store.com/books/book-1,https://images/1\n"
+	assert.Equal(t, expected, actual)
+}
+
+func TestEncoder_JSONL(t *testing.T) {
+	second := exportedBook()
+	second.Book.ID = "book-2"
+	second.ImageLinks = nil
+
+	actual := export(t, catalog.ExportFormatJSONL, exportedBook(), second)
+
+	lines := strings.Split(strings.TrimSpace(actual), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"id": "book-1",
+		"isbn": "9780321125217",
+		"title": "Domain-Driven Design",
+		"description": "Tackling Complexity in the Heart of Software",
+		"authors": ["Eric Evans"],
+		"publisher": "Addison-Wesley",
+		"language": "en",
+		"pageCount": 560,
+		"price": 5499,
+		"releaseDate": "2003-08-20T00:00:00Z",
+		"categories": ["software"],
+		"link": "https://store.com/books/book-1",
+		"imageLinks": ["https://images/1", "https://images/2"]
+	}`, lines[0])
+	assert.Contains(t, lines[1], `"imageLinks":[]`)
+}
+
+func TestEncoder_GoogleMerchant(t *testing.T) {
+	preorder := exportedBook()
+	preorder.Book.ID = "book-2"
+	preorder.Book.ISBN = nil
+	preorder.Book.ReleaseDate = time.Now().AddDate(1, 0, 0)
+	preorder.ImageLinks = nil
+
+	actual := export(t, catalog.ExportFormatGoogleMerchant, exportedBook(), preorder)
+
+	var feed struct {
+		Channel struct {
+			Link  string `xml:"link"`
+			Items []struct {
+				ID                   string   `xml:"id"`
+				Link                 string   `xml:"link"`
+				ImageLink            string   `xml:"image_link"`
+				AdditionalImageLinks []string `xml:"additional_image_link"`
+				Availability         string   `xml:"availability"`
+				Price                string   `xml:"price"`
+				GTIN                 string   `xml:"gtin"`
+				IdentifierExists     string   `xml:"identifier_exists"`
+				ProductType          string   `xml:"product_type"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	require.NoError(t, xml.Unmarshal([]byte(actual), &feed))
+
+	assert.Equal(t, "https://store.com/", feed.Channel.Link)
+	require.Len(t, feed.Channel.Items, 2)
+
+	first := feed.Channel.Items[0]
+	assert.Equal(t, "book-1", first.ID)
+	assert.Equal(t, "https://store.com/books/book-1", first.Link)
+	assert.Equal(t, "https://images/1", first.ImageLink)
+	assert.Equal(t, []string{"https://images/2"}, first.AdditionalImageLinks)
+	assert.Equal(t, "in_stock", first.Availability)
+	assert.Equal(t, "54.99 USD", first.Price)
+	assert.Equal(t, "9780321125217", first.GTIN)
+	assert.Equal(t, "Software", first.ProductType)
+
+	second := feed.Channel.Items[1]
+	assert.Equal(t, "preorder", second.Availability)
+	assert.Equal(t, "no", second.IdentifierExists)
+	assert.Empty(t, second.ImageLink)
+}
+
+func TestEncoder_UnsupportedFormat(t *testing.T) {
+	_, err := NewEncoder(Config{}).NewWriter("xlsx", &bytes.Buffer{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,67 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+type jsonlBook struct {
+	ID          string    `json:"id"`
+	ISBN        string    `json:"isbn,omitempty"`
+	Title       string    `json:"title"`
+	Subtitle    string    `json:"subtitle,omitempty"`
+	Description string    `json:"description"`
+	Authors     []string  `json:"authors"`
+	Publisher   string    `json:"publisher,omitempty"`
+	Language    string    `json:"language,omitempty"`
+	PageCount   int       `json:"pageCount,omitempty"`
+	Edition     string    `json:"edition,omitempty"`
+	Price       int       `json:"price"`
+	ReleaseDate time.Time `json:"releaseDate"`
+	Categories  []string  `json:"categories"`
+	Link        string    `json:"link"`
+	ImageLinks  []string  `json:"imageLinks"`
+}
+
+// jsonlWriter writes one JSON object per line. Prices are kept in cents, like in the API responses.
+type jsonlWriter struct {
+	encoder  *json.Encoder
+	bookLink func(catalog.Book) string
+}
+
+func newJSONLWriter(w io.Writer, bookLink func(catalog.Book) string) *jsonlWriter {
+	return &jsonlWriter{encoder: json.NewEncoder(w), bookLink: bookLink}
+}
+
+func (w *jsonlWriter) Write(exported catalog.ExportedBook) error {
+	book := exported.Book
+	imageLinks := exported.ImageLinks
+	if imageLinks == nil {
+		imageLinks = []string{}
+	}
+
+	return w.encoder.Encode(jsonlBook{
+		ID:          book.ID,
+		ISBN:        isbn(book),
+		Title:       book.Title,
+		Subtitle:    book.Subtitle,
+		Description: book.Description,
+		Authors:     book.AuthorNames(catalog.RoleAuthor),
+		Publisher:   book.Publisher,
+		Language:    book.Language,
+		PageCount:   book.PageCount,
+		Edition:     book.Edition,
+		Price:       book.Price,
+		ReleaseDate: book.ReleaseDate,
+		Categories:  categorySlugs(book),
+		Link:        w.bookLink(book),
+		ImageLinks:  imageLinks,
+	})
+}
+
+func (w *jsonlWriter) Close() error {
+	return nil
+}
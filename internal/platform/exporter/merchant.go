@@ -0,0 +1,128 @@
+package exporter
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+const (
+	merchantNamespace = "http://base.google.com/ns/1.0"
+	// merchantBooksCategory is the "Media > Books" category of the Google product taxonomy
+	merchantBooksCategory = "784"
+	// merchantMaxAdditionalImages is the number of additional images accepted by Google Merchant
+	merchantMaxAdditionalImages = 10
+	merchantCurrency            = "USD"
+)
+
+type merchantItem struct {
+	XMLName               xml.Name `xml:"item"`
+	ID                    string   `xml:"g:id"`
+	Title                 string   `xml:"g:title"`
+	Description           string   `xml:"g:description"`
+	Link                  string   `xml:"g:link"`
+	ImageLink             string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks  []string `xml:"g:additional_image_link,omitempty"`
+	Availability          string   `xml:"g:availability"`
+	AvailabilityDate      string   `xml:"g:availability_date,omitempty"`
+	Price                 string   `xml:"g:price"`
+	Condition             string   `xml:"g:condition"`
+	GTIN                  string   `xml:"g:gtin,omitempty"`
+	Brand                 string   `xml:"g:brand,omitempty"`
+	IdentifierExists      string   `xml:"g:identifier_exists,omitempty"`
+	GoogleProductCategory string   `xml:"g:google_product_category"`
+	ProductType           string   `xml:"g:product_type,omitempty"`
+}
+
+// merchantWriter writes an RSS 2.0 feed with the Google Merchant product attributes. The channel is
+// opened when the writer is created and closed by Close, so the items can be written one by one.
+type merchantWriter struct {
+	w        io.Writer
+	encoder  *xml.Encoder
+	bookLink func(catalog.Book) string
+	now      func() time.Time
+}
+
+func newMerchantWriter(w io.Writer, storeURL string, bookLink func(catalog.Book) string) (*merchantWriter, error) {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return nil, err
+	}
+
+	if _, err := io.WriteString(w, `<rss version="2.0" xmlns:g="`+merchantNamespace+`"><channel>`); err != nil {
+		return nil, err
+	}
+
+	encoder := xml.NewEncoder(w)
+	channel := []struct {
+		name  string
+		value string
+	}{
+		{"title", "Ebook Store"},
+		{"link", storeURL},
+		{"description", "Ebooks available in the store"},
+	}
+	for _, element := range channel {
+		if err := encoder.EncodeElement(element.value, xml.StartElement{Name: xml.Name{Local: element.name}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &merchantWriter{w: w, encoder: encoder, bookLink: bookLink, now: time.Now}, nil
+}
+
+func (w *merchantWriter) Write(exported catalog.ExportedBook) error {
+	book := exported.Book
+	item := merchantItem{
+		ID:                    book.ID,
+		Title:                 book.Title,
+		Description:           book.Description,
+		Link:                  w.bookLink(book),
+		Availability:          "in_stock",
+		Price:                 formatPrice(book.Price) + " " + merchantCurrency,
+		Condition:             "new",
+		GTIN:                  isbn(book),
+		Brand:                 book.Publisher,
+		GoogleProductCategory: merchantBooksCategory,
+		ProductType:           strings.Join(categoryNames(book), ", "),
+	}
+
+	if item.GTIN == "" {
+		item.IdentifierExists = "no"
+	}
+
+	// Books are sold before their release date as pre-orders
+	if book.ReleaseDate.After(w.now()) {
+		item.Availability = "preorder"
+		item.AvailabilityDate = book.ReleaseDate.Format(time.RFC3339)
+	}
+
+	if links := exported.ImageLinks; len(links) > 0 {
+		item.ImageLink = links[0]
+		item.AdditionalImageLinks = links[1:]
+		if len(item.AdditionalImageLinks) > merchantMaxAdditionalImages {
+			item.AdditionalImageLinks = item.AdditionalImageLinks[:merchantMaxAdditionalImages]
+		}
+	}
+
+	return w.encoder.Encode(item)
+}
+
+func (w *merchantWriter) Close() error {
+	if err := w.encoder.Flush(); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w.w, "</channel></rss>\n")
+	return err
+}
+
+func categoryNames(book catalog.Book) []string {
+	names := make([]string, 0, len(book.Categories))
+	for _, category := range book.Categories {
+		names = append(names, category.Name)
+	}
+	return names
+}
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// secretLength is the number of random bytes of each secret.
+const secretLength = 32
+
+type SecretGenerator struct{}
+
+func NewSecretGenerator() *SecretGenerator {
+	return &SecretGenerator{}
+}
+
+// NewSecret returns an URL safe secret read from the cryptographically secure random generator.
+func (g *SecretGenerator) NewSecret() string {
+	bytes := make([]byte, secretLength)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes)
+}
@@ -0,0 +1,15 @@
+package generator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSecretGenerator_NewSecret(t *testing.T) {
+	generator := NewSecretGenerator()
+
+	secret := generator.NewSecret()
+
+	assert.Len(t, secret, 43)
+	assert.NotEqual(t, secret, generator.NewSecret())
+}
@@ -0,0 +1,86 @@
+package imaging
+
+import (
+	"image"
+	"math"
+)
+
+// contribution is the weight of a source pixel in a destination pixel.
+type contribution struct {
+	index  int
+	weight float64
+}
+
+// boxWeights maps each destination pixel to the source pixels it covers, weighted by how much of each
+// one is covered. Averaging the covered area is a box filter, which is accurate enough when scaling down.
+func boxWeights(srcLen, dstLen int) [][]contribution {
+	scale := float64(srcLen) / float64(dstLen)
+	weights := make([][]contribution, dstLen)
+
+	for d := range weights {
+		start := float64(d) * scale
+		end := math.Min(float64(d+1)*scale, float64(srcLen))
+
+		for i := int(start); float64(i) < end; i++ {
+			covered := math.Min(end, float64(i+1)) - math.Max(start, float64(i))
+			if covered > 0 {
+				weights[d] = append(weights[d], contribution{index: i, weight: covered / scale})
+			}
+		}
+	}
+
+	return weights
+}
+
+// resize scales the image down to the given size, resizing the rows first and then the columns.
+// The channels are averaged premultiplied by the alpha, as stored by image.RGBA.
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	srcWidth, srcHeight := src.Bounds().Dx(), src.Bounds().Dy()
+
+	columns := boxWeights(srcWidth, width)
+	rows := make([]float64, srcHeight*width*4)
+	for y := 0; y < srcHeight; y++ {
+		line := src.Pix[y*src.Stride:]
+		for x, weights := range columns {
+			var r, g, b, a float64
+			for _, c := range weights {
+				p := line[c.index*4:]
+				r += float64(p[0]) * c.weight
+				g += float64(p[1]) * c.weight
+				b += float64(p[2]) * c.weight
+				a += float64(p[3]) * c.weight
+			}
+			o := (y*width + x) * 4
+			rows[o], rows[o+1], rows[o+2], rows[o+3] = r, g, b, a
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y, weights := range boxWeights(srcHeight, height) {
+		for x := 0; x < width; x++ {
+			var r, g, b, a float64
+			for _, c := range weights {
+				p := rows[(c.index*width+x)*4:]
+				r += p[0] * c.weight
+				g += p[1] * c.weight
+				b += p[2] * c.weight
+				a += p[3] * c.weight
+			}
+			o := y*dst.Stride + x*4
+			dst.Pix[o], dst.Pix[o+1], dst.Pix[o+2], dst.Pix[o+3] = clamp(r), clamp(g), clamp(b), clamp(a)
+		}
+	}
+
+	return dst
+}
+
+func clamp(v float64) uint8 {
+	v = math.Round(v)
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
@@ -0,0 +1,124 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	// Registers the GIF decoder, the other formats are imported for their encoders
+	_ "image/gif"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+const (
+	// maxPixels rejects images that would take too much memory once decoded, about 160MB as RGBA
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+// Resizer scales images down with the standard library only. Images with transparency are encoded
+// as PNG and the rest as JPEG. WebP is not produced, as there is no encoder in the standard library.
+type Resizer struct{}
+
+func NewResizer() *Resizer {
+	return &Resizer{}
+}
+
+// Resize decodes a JPEG, PNG or GIF image and scales it down to fit each of the sizes. Each size is resized from
+// the next larger result instead of the original, which is much faster and looks the same with a box filter.
+func (r *Resizer) Resize(reader io.Reader, sizes []int) ([]catalog.ResizedImage, error) {
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("(Resize) failed reading image: %w", err)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("(Resize) failed decoding image config: %v: %w", err, catalog.ErrInvalidImage)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("(Resize) image of %dx%d pixels is not supported: %w", config.Width, config.Height, catalog.ErrInvalidImage)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("(Resize) failed decoding image: %v: %w", err, catalog.ErrInvalidImage)
+	}
+	src := toRGBA(decoded)
+	opaque := src.Opaque()
+
+	order := make([]int, len(sizes))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return sizes[order[i]] > sizes[order[j]] })
+
+	results := make([]catalog.ResizedImage, len(sizes))
+	for _, i := range order {
+		width, height := fit(src.Bounds().Dx(), src.Bounds().Dy(), sizes[i])
+		if width != src.Bounds().Dx() || height != src.Bounds().Dy() {
+			src = resize(src, width, height)
+		}
+
+		data, mediaType, err := encode(src, opaque)
+		if err != nil {
+			return nil, fmt.Errorf("(Resize) failed encoding image of %d pixels: %w", sizes[i], err)
+		}
+
+		results[i] = catalog.ResizedImage{MaxSize: sizes[i], Data: data, MediaType: mediaType, Width: width, Height: height}
+	}
+
+	return results, nil
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Bounds().Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// fit returns the size of an image scaled to fit maxSize on its longest side. Images are never scaled up.
+func fit(width, height, maxSize int) (int, int) {
+	if width <= maxSize && height <= maxSize {
+		return width, height
+	}
+
+	if width >= height {
+		return maxSize, max(1, (height*maxSize+width/2)/width)
+	}
+	return max(1, (width*maxSize+height/2)/height), maxSize
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func encode(img *image.RGBA, opaque bool) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if opaque {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "image/jpeg", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), "image/png", nil
+}
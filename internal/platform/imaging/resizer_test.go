@@ -0,0 +1,78 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testImage(t *testing.T, width, height int, fill color.Color) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestResizer_Resize(t *testing.T) {
+	content := testImage(t, 800, 400, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+
+	resized, err := NewResizer().Resize(bytes.NewReader(content), []int{100, 1000, 300})
+	require.NoError(t, err)
+	require.Len(t, resized, 3)
+
+	expected := []struct{ maxSize, width, height int }{{100, 100, 50}, {1000, 800, 400}, {300, 300, 150}}
+	for i, e := range expected {
+		assert.Equal(t, e.maxSize, resized[i].MaxSize)
+		assert.Equal(t, e.width, resized[i].Width)
+		assert.Equal(t, e.height, resized[i].Height)
+		assert.Equal(t, "image/jpeg", resized[i].MediaType)
+
+		decoded, err := jpeg.Decode(bytes.NewReader(resized[i].Data))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, e.width, e.height), decoded.Bounds())
+	}
+}
+
+func TestResizer_ResizeKeepsTransparency(t *testing.T) {
+	content := testImage(t, 300, 600, color.NRGBA{R: 255, A: 128})
+
+	resized, err := NewResizer().Resize(bytes.NewReader(content), []int{200})
+	require.NoError(t, err)
+
+	assert.Equal(t, "image/png", resized[0].MediaType)
+	assert.Equal(t, 100, resized[0].Width)
+	assert.Equal(t, 200, resized[0].Height)
+
+	decoded, err := png.Decode(bytes.NewReader(resized[0].Data))
+	require.NoError(t, err)
+	r, _, _, a := decoded.At(50, 100).RGBA()
+	assert.InDelta(t, 128, a>>8, 1)
+	assert.InDelta(t, 128, r>>8, 1)
+}
+
+func TestResizer_ResizeInvalidImage(t *testing.T) {
+	_, err := NewResizer().Resize(strings.NewReader("not an image"), []int{200})
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidImage)
+}
+
+func TestBoxWeights(t *testing.T) {
+	weights := boxWeights(3, 2)
+
+	assert.Equal(t, []contribution{{index: 0, weight: 2.0 / 3}, {index: 1, weight: 1.0 / 3}}, weights[0])
+	assert.Equal(t, []contribution{{index: 1, weight: 1.0 / 3}, {index: 2, weight: 2.0 / 3}}, weights[1])
+}
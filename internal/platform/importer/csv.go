@@ -0,0 +1,131 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// csvColumns are the columns of a catalog CSV file. The file must start with a header row naming them,
+// in any order. Only title is always needed, the rest of the rules are checked when the rows are validated.
+var csvColumns = []string{
+	"external_id", "isbn", "title", "subtitle", "description", "authors", "publisher",
+	"language", "page_count", "edition", "price", "release_date", "categories",
+}
+
+func parseCSV(r io.Reader) ([]catalog.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	// Rows with a wrong number of fields are reported as failed rows
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("missing title column")
+	}
+
+	for name := range columns {
+		if !isCSVColumn(name) {
+			return nil, fmt.Errorf("unknown column %s", name)
+		}
+	}
+
+	var rows []catalog.ImportRow
+	// Rows are numbered like in a spreadsheet, the header being the first one
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			rows = append(rows, catalog.ImportRow{Line: line, Errors: []string{parseErr.Err.Error()}})
+			continue
+		}
+
+		rows = append(rows, csvRow(line, record, columns))
+	}
+
+	return rows, nil
+}
+
+func csvRow(line int, record []string, columns map[string]int) catalog.ImportRow {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row := catalog.ImportRow{
+		Line:        line,
+		ExternalID:  field("external_id"),
+		ISBN:        field("isbn"),
+		Title:       field("title"),
+		Subtitle:    field("subtitle"),
+		Description: field("description"),
+		Publisher:   field("publisher"),
+		Language:    field("language"),
+		Edition:     field("edition"),
+		Categories:  splitList(field("categories")),
+	}
+
+	for _, name := range splitList(field("authors")) {
+		row.Contributors = append(row.Contributors, catalog.ImportContributor{Name: name, Role: catalog.RoleAuthor})
+	}
+
+	if value := field("page_count"); value != "" {
+		pageCount, err := strconv.Atoi(value)
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid page count %q", value))
+		}
+		row.PageCount = pageCount
+	}
+
+	if value := field("price"); value != "" {
+		price, err := parsePrice(value)
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		row.Price = price
+	}
+
+	if value := field("release_date"); value != "" {
+		releaseDate, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid release date %q", value))
+		}
+		row.ReleaseDate = releaseDate
+	}
+
+	return row
+}
+
+func isCSVColumn(name string) bool {
+	for _, column := range csvColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,224 @@
+package importer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// ONIX 3.0 code lists used to read the products.
+const (
+	onixISBN13             = "15" // List 5: ProductIDType
+	onixDistinctiveTitle   = "01" // List 15: TitleType
+	onixDescription        = "03" // List 153: TextType
+	onixLanguageOfText     = "01" // List 22: LanguageRole
+	onixMainContentPages   = "00" // List 23: ExtentType
+	onixPublicationDate    = "01" // List 163: PublishingDateRole
+	onixDateFormatYYYYMMDD = "00" // List 55: DateFormat
+	onixPublisherRole      = "01" // List 45: PublishingRole
+)
+
+// onixContributorRoles maps the List 17 contributor roles to the roles of the catalog. Other roles are ignored.
+var onixContributorRoles = map[string]catalog.AuthorRole{
+	"A01": catalog.RoleAuthor,
+	"B06": catalog.RoleTranslator,
+	"A12": catalog.RoleIllustrator,
+}
+
+// onixLanguages maps the ISO 639-2/B codes used by ONIX to the ISO 639-1 codes used by the catalog.
+var onixLanguages = map[string]string{
+	"eng": "en",
+	"spa": "es",
+	"fre": "fr",
+	"ger": "de",
+	"ita": "it",
+	"por": "pt",
+	"dut": "nl",
+	"cat": "ca",
+	"jpn": "ja",
+	"chi": "zh",
+}
+
+type onixMessage struct {
+	Products []onixProduct `xml:"Product"`
+}
+
+type onixProduct struct {
+	RecordReference    string `xml:"RecordReference"`
+	ProductIdentifiers []struct {
+		Type  string `xml:"ProductIDType"`
+		Value string `xml:"IDValue"`
+	} `xml:"ProductIdentifier"`
+	DescriptiveDetail struct {
+		TitleDetails []struct {
+			Type    string `xml:"TitleType"`
+			Element struct {
+				Text     string `xml:"TitleText"`
+				Prefix   string `xml:"TitlePrefix"`
+				Without  string `xml:"TitleWithoutPrefix"`
+				Subtitle string `xml:"Subtitle"`
+			} `xml:"TitleElement"`
+		} `xml:"TitleDetail"`
+		Contributors []struct {
+			Role          []string `xml:"ContributorRole"`
+			PersonName    string   `xml:"PersonName"`
+			CorporateName string   `xml:"CorporateName"`
+		} `xml:"Contributor"`
+		EditionStatement string `xml:"EditionStatement"`
+		Languages        []struct {
+			Role string `xml:"LanguageRole"`
+			Code string `xml:"LanguageCode"`
+		} `xml:"Language"`
+		Extents []struct {
+			Type  string `xml:"ExtentType"`
+			Value string `xml:"ExtentValue"`
+		} `xml:"Extent"`
+	} `xml:"DescriptiveDetail"`
+	CollateralDetail struct {
+		TextContents []struct {
+			Type string `xml:"TextType"`
+			Text string `xml:"Text"`
+		} `xml:"TextContent"`
+	} `xml:"CollateralDetail"`
+	PublishingDetail struct {
+		Publishers []struct {
+			Role string `xml:"PublishingRole"`
+			Name string `xml:"PublisherName"`
+		} `xml:"Publisher"`
+		PublishingDates []struct {
+			Role   string `xml:"PublishingDateRole"`
+			Format string `xml:"dateformat,attr"`
+			Date   string `xml:"Date"`
+		} `xml:"PublishingDate"`
+	} `xml:"PublishingDetail"`
+	ProductSupply struct {
+		Prices []struct {
+			Amount string `xml:"PriceAmount"`
+		} `xml:"SupplyDetail>Price"`
+	} `xml:"ProductSupply"`
+}
+
+// parseONIX reads the products of an ONIX 3.0 message with reference tags. The line of a row is the
+// position of the product in the message.
+func parseONIX(r io.Reader) ([]catalog.ImportRow, error) {
+	var message onixMessage
+	if err := xml.NewDecoder(r).Decode(&message); err != nil {
+		return nil, err
+	}
+
+	rows := make([]catalog.ImportRow, 0, len(message.Products))
+	for i, product := range message.Products {
+		rows = append(rows, onixRow(i+1, product))
+	}
+
+	return rows, nil
+}
+
+func onixRow(line int, product onixProduct) catalog.ImportRow {
+	detail := product.DescriptiveDetail
+	row := catalog.ImportRow{
+		Line:       line,
+		ExternalID: strings.TrimSpace(product.RecordReference),
+		Edition:    strings.TrimSpace(detail.EditionStatement),
+	}
+
+	for _, id := range product.ProductIdentifiers {
+		if id.Type == onixISBN13 {
+			row.ISBN = strings.TrimSpace(id.Value)
+		}
+	}
+
+	for _, title := range detail.TitleDetails {
+		if title.Type != onixDistinctiveTitle {
+			continue
+		}
+
+		row.Title = strings.TrimSpace(title.Element.Text)
+		if row.Title == "" {
+			row.Title = strings.TrimSpace(title.Element.Prefix + " " + title.Element.Without)
+		}
+		row.Subtitle = strings.TrimSpace(title.Element.Subtitle)
+	}
+
+	for _, contributor := range detail.Contributors {
+		name := strings.TrimSpace(contributor.PersonName)
+		if name == "" {
+			name = strings.TrimSpace(contributor.CorporateName)
+		}
+
+		for _, code := range contributor.Role {
+			if role, ok := onixContributorRoles[code]; ok {
+				row.Contributors = append(row.Contributors, catalog.ImportContributor{Name: name, Role: role})
+			}
+		}
+	}
+
+	for _, language := range detail.Languages {
+		if language.Role != onixLanguageOfText {
+			continue
+		}
+
+		code := strings.ToLower(strings.TrimSpace(language.Code))
+		if mapped, ok := onixLanguages[code]; ok {
+			code = mapped
+		}
+		row.Language = code
+	}
+
+	for _, extent := range detail.Extents {
+		if extent.Type != onixMainContentPages {
+			continue
+		}
+
+		pageCount, err := strconv.Atoi(strings.TrimSpace(extent.Value))
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid page count %q", extent.Value))
+		}
+		row.PageCount = pageCount
+	}
+
+	for _, text := range product.CollateralDetail.TextContents {
+		if text.Type == onixDescription {
+			row.Description = strings.TrimSpace(text.Text)
+		}
+	}
+
+	for _, publisher := range product.PublishingDetail.Publishers {
+		if publisher.Role == onixPublisherRole {
+			row.Publisher = strings.TrimSpace(publisher.Name)
+		}
+	}
+
+	for _, date := range product.PublishingDetail.PublishingDates {
+		if date.Role != onixPublicationDate {
+			continue
+		}
+
+		if date.Format != "" && date.Format != onixDateFormatYYYYMMDD {
+			row.Errors = append(row.Errors, fmt.Sprintf("unsupported date format %q", date.Format))
+			continue
+		}
+
+		releaseDate, err := time.Parse("20060102", strings.TrimSpace(date.Date))
+		if err != nil {
+			row.Errors = append(row.Errors, fmt.Sprintf("invalid publication date %q", date.Date))
+		}
+		row.ReleaseDate = releaseDate
+	}
+
+	// Only the first price is imported, the catalog has a single price per book
+	if prices := product.ProductSupply.Prices; len(prices) > 0 {
+		price, err := parsePrice(prices[0].Amount)
+		if err != nil {
+			row.Errors = append(row.Errors, err.Error())
+		}
+		row.Price = price
+	}
+
+	return row
+}
@@ -0,0 +1,64 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// Parser reads the books of the catalog files sent by publishers.
+type Parser struct{}
+
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+// Parse reads all the rows of the file. A row that cannot be read is returned with its errors,
+// so it is reported without stopping the import. An error is only returned when the file itself cannot be read.
+func (p *Parser) Parse(format string, r io.Reader) ([]catalog.ImportRow, error) {
+	var (
+		rows []catalog.ImportRow
+		err  error
+	)
+
+	switch format {
+	case catalog.ImportFormatCSV:
+		rows, err = parseCSV(r)
+	case catalog.ImportFormatONIX:
+		rows, err = parseONIX(r)
+	default:
+		return nil, fmt.Errorf("(Parse) unsupported format %s: %w", format, catalog.ErrInvalidImportFile)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("(Parse) failed reading %s file: %v: %w", format, err, catalog.ErrInvalidImportFile)
+	}
+
+	return rows, nil
+}
+
+// parsePrice converts a decimal amount in currency units, like 12.99, to cents.
+func parsePrice(value string) (int, error) {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid price %q", value)
+	}
+
+	return int(math.Round(amount * 100)), nil
+}
+
+// splitList splits a list of values separated by semicolons, ignoring the empty ones.
+func splitList(value string) []string {
+	var values []string
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,139 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseCSV(t *testing.T) {
+	file := `external_id,isbn,title,subtitle,description,authors,publisher,language,page_count,edition,price,release_date,categories
+BK-1,978-0-306-40615-7,Dune,,A desert planet,Frank Herbert,Chilton,en,412,1st,12.99,1965-08-01,science-fiction;classics
+BK-2,,"Good Omens, again",,Armageddon,Terry Pratchett; Neil Gaiman,,,abc,,free,08/01/1965,
+`
+
+	rows, err := NewParser().Parse(catalog.ImportFormatCSV, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, catalog.ImportRow{
+		Line:         2,
+		ExternalID:   "BK-1",
+		ISBN:         "978-0-306-40615-7",
+		Title:        "Dune",
+		Description:  "A desert planet",
+		Contributors: []catalog.ImportContributor{{Name: "Frank Herbert", Role: catalog.RoleAuthor}},
+		Publisher:    "Chilton",
+		Language:     "en",
+		PageCount:    412,
+		Edition:      "1st",
+		Price:        1299,
+		ReleaseDate:  time.Date(1965, 8, 1, 0, 0, 0, 0, time.UTC),
+		Categories:   []string{"science-fiction", "classics"},
+	}, rows[0])
+
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, "Good Omens, again", rows[1].Title)
+	assert.Equal(t, []catalog.ImportContributor{
+		{Name: "Terry Pratchett", Role: catalog.RoleAuthor},
+		{Name: "Neil Gaiman", Role: catalog.RoleAuthor},
+	}, rows[1].Contributors)
+	assert.Equal(t, []string{`invalid page count "abc"`, `invalid price "free"`, `invalid release date "08/01/1965"`}, rows[1].Errors)
+}
+
+func TestParser_ParseCSVWithoutRequiredColumns(t *testing.T) {
+	_, err := NewParser().Parse(catalog.ImportFormatCSV, strings.NewReader("isbn,price\n9780306406157,10\n"))
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidImportFile)
+}
+
+func TestParser_ParseCSVWithUnknownColumns(t *testing.T) {
+	_, err := NewParser().Parse(catalog.ImportFormatCSV, strings.NewReader("title,author\nDune,Frank Herbert\n"))
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidImportFile)
+}
+
+func TestParser_ParseONIX(t *testing.T) {
+	file := `<?xml version="1.0" encoding="UTF-8"?>
+<ONIXMessage release="3.0" xmlns="http://ns.editeur.org/onix/3.0/reference">
+  <Header><Sender><SenderName>Chilton</SenderName></Sender></Header>
+  <Product>
+    <RecordReference>com.chilton.dune</RecordReference>
+    <ProductIdentifier><ProductIDType>01</ProductIDType><IDValue>D-1</IDValue></ProductIdentifier>
+    <ProductIdentifier><ProductIDType>15</ProductIDType><IDValue>9780306406157</IDValue></ProductIdentifier>
+    <DescriptiveDetail>
+      <TitleDetail>
+        <TitleType>01</TitleType>
+        <TitleElement><TitleText>Dune</TitleText><Subtitle>Book one</Subtitle></TitleElement>
+      </TitleDetail>
+      <Contributor><ContributorRole>A01</ContributorRole><PersonName>Frank Herbert</PersonName></Contributor>
+      <Contributor><ContributorRole>B06</ContributorRole><PersonName>Jane Doe</PersonName></Contributor>
+      <Contributor><ContributorRole>B01</ContributorRole><PersonName>John Editor</PersonName></Contributor>
+      <EditionStatement>Anniversary edition</EditionStatement>
+      <Language><LanguageRole>01</LanguageRole><LanguageCode>eng</LanguageCode></Language>
+      <Extent><ExtentType>00</ExtentType><ExtentValue>412</ExtentValue><ExtentUnit>03</ExtentUnit></Extent>
+    </DescriptiveDetail>
+    <CollateralDetail>
+      <TextContent><TextType>03</TextType><Text>A desert planet</Text></TextContent>
+    </CollateralDetail>
+    <PublishingDetail>
+      <Publisher><PublishingRole>01</PublishingRole><PublisherName>Chilton</PublisherName></Publisher>
+      <PublishingDate><PublishingDateRole>01</PublishingDateRole><Date>19650801</Date></PublishingDate>
+    </PublishingDetail>
+    <ProductSupply>
+      <SupplyDetail>
+        <Price><PriceAmount>12.99</PriceAmount><CurrencyCode>USD</CurrencyCode></Price>
+        <Price><PriceAmount>10.99</PriceAmount><CurrencyCode>GBP</CurrencyCode></Price>
+      </SupplyDetail>
+    </ProductSupply>
+  </Product>
+  <Product>
+    <RecordReference>com.chilton.broken</RecordReference>
+    <PublishingDetail>
+      <PublishingDate><PublishingDateRole>01</PublishingDateRole><Date>1965</Date></PublishingDate>
+    </PublishingDetail>
+  </Product>
+</ONIXMessage>`
+
+	rows, err := NewParser().Parse(catalog.ImportFormatONIX, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, catalog.ImportRow{
+		Line:        1,
+		ExternalID:  "com.chilton.dune",
+		ISBN:        "9780306406157",
+		Title:       "Dune",
+		Subtitle:    "Book one",
+		Description: "A desert planet",
+		Contributors: []catalog.ImportContributor{
+			{Name: "Frank Herbert", Role: catalog.RoleAuthor},
+			{Name: "Jane Doe", Role: catalog.RoleTranslator},
+		},
+		Publisher:   "Chilton",
+		Language:    "en",
+		PageCount:   412,
+		Edition:     "Anniversary edition",
+		Price:       1299,
+		ReleaseDate: time.Date(1965, 8, 1, 0, 0, 0, 0, time.UTC),
+	}, rows[0])
+
+	assert.Equal(t, 2, rows[1].Line)
+	assert.Equal(t, []string{`invalid publication date "1965"`}, rows[1].Errors)
+}
+
+func TestParser_ParseInvalidONIX(t *testing.T) {
+	_, err := NewParser().Parse(catalog.ImportFormatONIX, strings.NewReader("<ONIXMessage><Product>"))
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidImportFile)
+}
+
+func TestParser_ParseUnsupportedFormat(t *testing.T) {
+	_, err := NewParser().Parse("xlsx", strings.NewReader(""))
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidImportFile)
+}
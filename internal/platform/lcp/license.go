@@ -0,0 +1,152 @@
+package lcp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+)
+
+// ProfileBasic is the encryption profile of the licenses, the one of LCP without the certificates of the authority.
+const ProfileBasic = "http://readium.org/lcp/basic-profile"
+
+// Provider identifies the store in the licenses, usually its URL.
+type Provider string
+
+type license struct {
+	ID         string            `json:"id"`
+	Issued     time.Time         `json:"issued"`
+	Provider   Provider          `json:"provider"`
+	Encryption licenseEncryption `json:"encryption"`
+	Links      []licenseLink     `json:"links"`
+	User       licenseUser       `json:"user"`
+	Rights     licenseRights     `json:"rights"`
+}
+
+type licenseEncryption struct {
+	Profile    string            `json:"profile"`
+	ContentKey licenseContentKey `json:"content_key"`
+	UserKey    licenseUserKey    `json:"user_key"`
+}
+
+type licenseContentKey struct {
+	Algorithm      string `json:"algorithm"`
+	EncryptedValue string `json:"encrypted_value"`
+}
+
+type licenseUserKey struct {
+	Algorithm string `json:"algorithm"`
+	TextHint  string `json:"text_hint"`
+	KeyCheck  string `json:"key_check"`
+}
+
+type licenseLink struct {
+	Rel    string `json:"rel"`
+	Href   string `json:"href"`
+	Type   string `json:"type,omitempty"`
+	Length int64  `json:"length,omitempty"`
+	Hash   string `json:"hash,omitempty"`
+}
+
+type licenseUser struct {
+	ID string `json:"id"`
+}
+
+type licenseRights struct {
+	Print *int       `json:"print,omitempty"`
+	Copy  *int       `json:"copy,omitempty"`
+	Start time.Time  `json:"start"`
+	End   *time.Time `json:"end,omitempty"`
+}
+
+// Issuer renders the license documents of the protected books.
+type Issuer struct {
+	secret   Secret
+	provider Provider
+}
+
+func NewIssuer(secret Secret, provider Provider) *Issuer {
+	return &Issuer{secret: secret, provider: provider}
+}
+
+// Issue renders the license document of the buyer. The content key is unsealed with the secret and encrypted again
+// with the license key of the buyer, the SHA-256 of their passphrase, so only their reading apps can decrypt it. The
+// key check is the license ID encrypted with the same key, so the apps can tell a wrong passphrase.
+func (i *Issuer) Issue(l shop.License, buyer shop.Buyer, content catalog.ProtectedBookContent) ([]byte, error) {
+	userKey, err := hex.DecodeString(buyer.LicenseKey)
+	if err != nil || len(userKey) != sha256.Size {
+		return nil, fmt.Errorf("(Issue) invalid license key of user %s", buyer.UserID)
+	}
+
+	contentKey, err := open(i.secret, content.Key)
+	if err != nil {
+		return nil, fmt.Errorf("(Issue) failed unsealing content key of %s: %w", content.ContentID, err)
+	}
+
+	encryptedKey, err := encrypt(userKey, contentKey)
+	if err != nil {
+		return nil, fmt.Errorf("(Issue) failed encrypting content key: %w", err)
+	}
+
+	keyCheck, err := encrypt(userKey, []byte(l.ID))
+	if err != nil {
+		return nil, fmt.Errorf("(Issue) failed encrypting key check: %w", err)
+	}
+
+	checksum, err := hex.DecodeString(content.Checksum)
+	if err != nil {
+		return nil, fmt.Errorf("(Issue) invalid checksum of %s: %w", content.ContentID, err)
+	}
+
+	document := license{
+		ID:       l.ID,
+		Issued:   l.CreatedAt.UTC(),
+		Provider: i.provider,
+		Encryption: licenseEncryption{
+			Profile: ProfileBasic,
+			ContentKey: licenseContentKey{
+				Algorithm:      AlgorithmAES256CBC,
+				EncryptedValue: base64.StdEncoding.EncodeToString(encryptedKey),
+			},
+			UserKey: licenseUserKey{
+				Algorithm: AlgorithmSHA256,
+				TextHint:  buyer.LicenseHint,
+				KeyCheck:  base64.StdEncoding.EncodeToString(keyCheck),
+			},
+		},
+		Links: []licenseLink{{
+			Rel:    "publication",
+			Href:   content.URL,
+			Type:   "application/epub+zip",
+			Length: content.Size,
+			Hash:   base64.StdEncoding.EncodeToString(checksum),
+		}},
+		User: licenseUser{ID: buyer.UserID},
+		Rights: licenseRights{
+			Print: l.Print,
+			Copy:  l.Copy,
+			Start: l.Start.UTC(),
+			End:   utc(l.End),
+		},
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("(Issue) failed encoding license %s: %w", l.ID, err)
+	}
+
+	return data, nil
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
@@ -0,0 +1,67 @@
+package lcp
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssuer_Issue(t *testing.T) {
+	contentKey := make([]byte, contentKeySize)
+	sealed, err := seal(testSecret, contentKey)
+	require.NoError(t, err)
+
+	printLimit := 10
+	start := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+	end := start.Add(time.Hour * 24 * 30)
+	license := shop.License{ID: "license-id", UserID: "user-id", OrderID: "order-id", BookID: "book-id", Print: &printLimit, Start: start, End: &end, CreatedAt: start}
+	buyer := shop.Buyer{UserID: "user-id", LicenseKey: auth.LicenseKey("my license passphrase"), LicenseHint: "the usual one"}
+	checksum := sha256.Sum256([]byte("encrypted"))
+	content := catalog.ProtectedBookContent{
+		ProtectedContent: catalog.ProtectedContent{ContentID: "epub-key", Key: sealed, Size: 9, Checksum: hex.EncodeToString(checksum[:])},
+		URL:              "https://some-url",
+	}
+
+	document, err := NewIssuer(testSecret, "https://ebookstore.com").Issue(license, buyer, content)
+	require.NoError(t, err)
+
+	var issued map[string]interface{}
+	require.NoError(t, json.Unmarshal(document, &issued))
+	assert.Equal(t, "license-id", issued["id"])
+	assert.Equal(t, "https://ebookstore.com", issued["provider"])
+	assert.Equal(t, map[string]interface{}{"id": "user-id"}, issued["user"])
+	assert.Equal(t, map[string]interface{}{"print": 10.0, "start": "2026-10-19T09:30:00Z", "end": "2026-11-18T09:30:00Z"}, issued["rights"])
+
+	link := issued["links"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "publication", link["rel"])
+	assert.Equal(t, "https://some-url", link["href"])
+	assert.Equal(t, base64.StdEncoding.EncodeToString(checksum[:]), link["hash"])
+
+	// The reading apps derive the user key from the passphrase to decrypt the content key
+	userKey := sha256.Sum256([]byte("my license passphrase"))
+	encryption := issued["encryption"].(map[string]interface{})
+	userKeyInfo := encryption["user_key"].(map[string]interface{})
+	assert.Equal(t, "the usual one", userKeyInfo["text_hint"])
+	keyCheck, err := base64.StdEncoding.DecodeString(userKeyInfo["key_check"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "license-id", string(decrypt(t, userKey[:], keyCheck)))
+
+	encryptedKey, err := base64.StdEncoding.DecodeString(encryption["content_key"].(map[string]interface{})["encrypted_value"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, contentKey, decrypt(t, userKey[:], encryptedKey))
+}
+
+func TestIssuer_IssueWithoutLicenseKey(t *testing.T) {
+	_, err := NewIssuer(testSecret, "https://ebookstore.com").Issue(shop.License{ID: "license-id"}, shop.Buyer{UserID: "user-id"}, catalog.ProtectedBookContent{})
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,307 @@
+// Package lcp protects the EPUBs in the way of Readium LCP: the resources of the publication are encrypted with a
+// content key, and every buyer gets a license document carrying that key encrypted with a key derived from their
+// passphrase. The licenses are not signed with a certificate of the LCP authority, so only readers that trust the
+// store can open them.
+package lcp
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+const (
+	// AlgorithmAES256CBC encrypts the resources and the content key, with the IV prepended to the ciphertext.
+	AlgorithmAES256CBC = "http://www.w3.org/2001/04/xmlenc#aes256-cbc"
+	// AlgorithmSHA256 derives the user key from the passphrase.
+	AlgorithmSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+	// EncryptedContentKeyType is the type of the reference from the encrypted resources to the key of the license.
+	EncryptedContentKeyType = "http://readium.org/2014/01/lcp#EncryptedContentKey"
+	// LicensePath is where the reading apps look for a license embedded in the publication.
+	LicensePath = "META-INF/license.lcpl"
+
+	contentKeySize      = 32
+	containerPath       = "META-INF/container.xml"
+	encryptionPath      = "META-INF/encryption.xml"
+	mimetypePath        = "mimetype"
+	contentKeyReference = "license.lcpl#/encryption/content_key"
+)
+
+// Secret seals the content keys while they are stored. Any length is accepted, it's hashed into an AES-256 key.
+type Secret []byte
+
+type container struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+// Protector encrypts the EPUBs with a new content key each.
+type Protector struct {
+	secret Secret
+}
+
+func NewProtector(secret Secret) *Protector {
+	return &Protector{secret: secret}
+}
+
+// Protect encrypts every resource of the EPUB but the ones the reading apps need to open it: the mimetype, the files of
+// META-INF and the package document. The encrypted resources are listed in META-INF/encryption.xml and stored without
+// compression, since the ciphertext doesn't compress. The EPUB is spooled to a temporary file and the package is
+// written to w as it's encrypted, so neither is held in memory. The content key is returned sealed with the secret.
+func (p *Protector) Protect(r io.Reader, w io.Writer) ([]byte, error) {
+	source, err := ioutil.TempFile("", "lcp-source-*")
+	if err != nil {
+		return nil, fmt.Errorf("(Protect) failed creating temporary file: %w", err)
+	}
+	defer os.Remove(source.Name())
+	defer source.Close()
+
+	size, err := io.Copy(source, r)
+	if err != nil {
+		return nil, fmt.Errorf("(Protect) failed reading content: %w", err)
+	}
+
+	key, err := p.protect(source, size, w)
+	if err != nil {
+		return nil, fmt.Errorf("(Protect) failed encrypting content: %v: %w", err, catalog.ErrInvalidProtectionSource)
+	}
+
+	sealed, err := seal(p.secret, key)
+	if err != nil {
+		return nil, fmt.Errorf("(Protect) failed sealing content key: %w", err)
+	}
+
+	return sealed, nil
+}
+
+func (p *Protector) protect(source io.ReaderAt, size int64, out io.Writer) ([]byte, error) {
+	archive, err := zip.NewReader(source, size)
+	if err != nil {
+		return nil, err
+	}
+
+	packagePath, err := findPackagePath(archive)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range archive.File {
+		if f.Name == encryptionPath || f.Name == LicensePath {
+			return nil, fmt.Errorf("the publication is already encrypted or obfuscated")
+		}
+	}
+
+	key := make([]byte, contentKeySize)
+	if _, err = rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	w := zip.NewWriter(out)
+
+	mimetype, err := w.CreateHeader(&zip.FileHeader{Name: mimetypePath, Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return nil, err
+	}
+
+	var encrypted []string
+	for _, f := range archive.File {
+		if f.Name == mimetypePath || strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+
+		clear := isClear(f.Name, packagePath)
+		method := zip.Deflate
+		if !clear {
+			method = zip.Store
+			encrypted = append(encrypted, f.Name)
+		}
+
+		entry, err := w.CreateHeader(&zip.FileHeader{Name: f.Name, Method: method, Modified: f.Modified})
+		if err != nil {
+			return nil, err
+		}
+		if err = copyFile(entry, f, key, clear); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := w.Create(encryptionPath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = entry.Write(encryptionDocument(encrypted)); err != nil {
+		return nil, err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// copyFile copies the file of the archive to the entry, encrypted with the key unless it's left clear.
+func copyFile(entry io.Writer, f *zip.File, key []byte, clear bool) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if clear {
+		_, err = io.Copy(entry, r)
+		return err
+	}
+	return encryptTo(entry, key, r)
+}
+
+func findPackagePath(archive *zip.Reader) (string, error) {
+	f, err := archive.Open(containerPath)
+	if err != nil {
+		return "", fmt.Errorf("missing %s: %v", containerPath, err)
+	}
+	defer f.Close()
+
+	var c container
+	if err = xml.NewDecoder(f).Decode(&c); err != nil {
+		return "", fmt.Errorf("invalid %s: %v", containerPath, err)
+	}
+	if len(c.Rootfiles) == 0 {
+		return "", fmt.Errorf("missing rootfile in %s", containerPath)
+	}
+	return c.Rootfiles[0].FullPath, nil
+}
+
+// isClear reports whether the file is left unencrypted, so the reading apps can find and list the publication.
+func isClear(name, packagePath string) bool {
+	return path.Dir(name) == "META-INF" || name == packagePath
+}
+
+// encryptionDocument lists the encrypted resources and points to the content key of the license to decrypt them.
+func encryptionDocument(names []string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.WriteString(`<encryption xmlns="urn:oasis:names:tc:opendocument:xmlns:container" xmlns:enc="http://www.w3.org/2001/04/xmlenc#" xmlns:ds="http://www.w3.org/2000/09/xmldsig#">` + "\n")
+	for _, name := range names {
+		buf.WriteString("  <enc:EncryptedData>\n")
+		fmt.Fprintf(&buf, "    <enc:EncryptionMethod Algorithm=%q/>\n", AlgorithmAES256CBC)
+		fmt.Fprintf(&buf, "    <ds:KeyInfo><ds:RetrievalMethod URI=%q Type=%q/></ds:KeyInfo>\n", contentKeyReference, EncryptedContentKeyType)
+		buf.WriteString("    <enc:CipherData><enc:CipherReference URI=\"")
+		_ = xml.EscapeText(&buf, []byte(name))
+		buf.WriteString("\"/></enc:CipherData>\n")
+		buf.WriteString("  </enc:EncryptedData>\n")
+	}
+	buf.WriteString("</encryption>\n")
+	return buf.Bytes()
+}
+
+// encrypt encrypts the data with AES-256-CBC and PKCS#7 padding, prepending the random IV.
+func encrypt(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	padding := aes.BlockSize - len(data)%aes.BlockSize
+	ciphertext := make([]byte, aes.BlockSize+len(data)+padding)
+	iv := ciphertext[:aes.BlockSize]
+	if _, err = rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	plaintext := append(append(make([]byte, 0, len(data)+padding), data...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext[aes.BlockSize:], plaintext)
+	return ciphertext, nil
+}
+
+// encryptTo encrypts the content of r to w like encrypt, a chunk at a time.
+func encryptTo(w io.Writer, key []byte, r io.Reader) error {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err = rand.Read(iv); err != nil {
+		return err
+	}
+	if _, err = w.Write(iv); err != nil {
+		return err
+	}
+
+	mode := cipher.NewCBCEncrypter(block, iv)
+	buf := make([]byte, 64*aes.BlockSize*16)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		// the chunks are a multiple of the block size, so only the last one, shorter, is padded
+		last := err != nil
+		if last {
+			padding := aes.BlockSize - n%aes.BlockSize
+			buf = append(buf[:n], bytes.Repeat([]byte{byte(padding)}, padding)...)
+			n += padding
+		}
+
+		mode.CryptBlocks(buf[:n], buf[:n])
+		if _, err = w.Write(buf[:n]); err != nil {
+			return err
+		}
+		if last {
+			return nil
+		}
+	}
+}
+
+// seal encrypts the content key with AES-GCM under the secret, prepending the nonce.
+func seal(secret Secret, key []byte) ([]byte, error) {
+	aead, err := secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+// open decrypts a content key sealed with the secret.
+func open(secret Secret, sealed []byte) ([]byte, error) {
+	aead, err := secretAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("the sealed key is too short")
+	}
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+}
+
+func secretAEAD(secret Secret) (cipher.AEAD, error) {
+	key := sha256.Sum256(secret)
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,151 @@
+package lcp
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = Secret("test-secret")
+
+func testEPUB(t *testing.T, extra ...string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	files := [][2]string{
+		{mimetypePath, "application/epub+zip"},
+		{containerPath, `<?xml version="1.0"?><container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container"><rootfiles><rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/></rootfiles></container>`},
+		{"OEBPS/content.opf", `<package version="3.0"></package>`},
+		{"OEBPS/chapter1.xhtml", "<html><body>Chapter one</body></html>"},
+	}
+	for _, name := range extra {
+		files = append(files, [2]string{name, "extra"})
+	}
+
+	for _, file := range files {
+		f, err := w.Create(file[0])
+		require.NoError(t, err)
+		_, err = f.Write([]byte(file[1]))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+// decrypt reverses encrypt, like the reading apps do.
+func decrypt(t *testing.T, key, data []byte) []byte {
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	require.Zero(t, len(data)%aes.BlockSize)
+
+	plaintext := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(plaintext, data[aes.BlockSize:])
+	padding := int(plaintext[len(plaintext)-1])
+	return plaintext[:len(plaintext)-padding]
+}
+
+func readFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return ioutil.ReadAll(r)
+}
+
+func readEntry(t *testing.T, archive *zip.Reader, name string) (*zip.File, []byte) {
+	for _, f := range archive.File {
+		if f.Name == name {
+			data, err := readFile(f)
+			require.NoError(t, err)
+			return f, data
+		}
+	}
+	require.Failf(t, "missing entry", "%s not found", name)
+	return nil, nil
+}
+
+func TestProtector_Protect(t *testing.T) {
+	var protected bytes.Buffer
+	sealed, err := NewProtector(testSecret).Protect(bytes.NewReader(testEPUB(t)), &protected)
+	require.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(protected.Bytes()), int64(protected.Len()))
+	require.NoError(t, err)
+	require.Equal(t, mimetypePath, archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	_, opf := readEntry(t, archive, "OEBPS/content.opf")
+	assert.Equal(t, `<package version="3.0"></package>`, string(opf))
+
+	chapter, encrypted := readEntry(t, archive, "OEBPS/chapter1.xhtml")
+	assert.Equal(t, zip.Store, chapter.Method)
+	assert.NotContains(t, string(encrypted), "Chapter one")
+
+	key, err := open(testSecret, sealed)
+	require.NoError(t, err)
+	assert.Len(t, key, contentKeySize)
+	assert.Equal(t, "<html><body>Chapter one</body></html>", string(decrypt(t, key, encrypted)))
+
+	_, encryption := readEntry(t, archive, encryptionPath)
+	assert.Contains(t, string(encryption), `<enc:CipherReference URI="OEBPS/chapter1.xhtml"/>`)
+	assert.NotContains(t, string(encryption), "content.opf")
+	assert.Contains(t, string(encryption), contentKeyReference)
+}
+
+func TestProtector_ProtectGeneratesKeys(t *testing.T) {
+	protector := NewProtector(testSecret)
+	first, err := protector.Protect(bytes.NewReader(testEPUB(t)), ioutil.Discard)
+	require.NoError(t, err)
+	second, err := protector.Protect(bytes.NewReader(testEPUB(t)), ioutil.Discard)
+	require.NoError(t, err)
+
+	firstKey, err := open(testSecret, first)
+	require.NoError(t, err)
+	secondKey, err := open(testSecret, second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstKey, secondKey)
+
+	_, err = open(Secret("other-secret"), first)
+	assert.Error(t, err)
+}
+
+func TestProtector_ProtectInvalidEPUB(t *testing.T) {
+	_, err := NewProtector(testSecret).Protect(strings.NewReader("not an epub"), ioutil.Discard)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidProtectionSource)
+}
+
+func TestProtector_ProtectEncryptedEPUB(t *testing.T) {
+	_, err := NewProtector(testSecret).Protect(bytes.NewReader(testEPUB(t, encryptionPath)), ioutil.Discard)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidProtectionSource)
+}
+
+func TestEncryptTo(t *testing.T) {
+	key := make([]byte, contentKeySize)
+	for _, size := range []int{0, 15, aes.BlockSize, 16384, 16385, 40000} {
+		data := bytes.Repeat([]byte("x"), size)
+
+		var encrypted bytes.Buffer
+		require.NoError(t, encryptTo(&encrypted, key, bytes.NewReader(data)))
+
+		assert.Equal(t, data, decrypt(t, key, encrypted.Bytes()), "size %d", size)
+	}
+}
+
+func TestEncryptionDocument(t *testing.T) {
+	document := string(encryptionDocument([]string{"OEBPS/a&b.xhtml"}))
+
+	assert.Contains(t, document, `URI="OEBPS/a&amp;b.xhtml"`)
+	assert.Contains(t, document, AlgorithmAES256CBC)
+}
@@ -0,0 +1,426 @@
+// Package pdf reads and rewrites PDF documents at the level of their objects. It doesn't render or interpret the
+// content of the pages, it's only meant to select the pages of a document and add content on top of them.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+var (
+	ErrMalformed   = fmt.Errorf("the document is not a valid PDF")
+	ErrUnsupported = fmt.Errorf("the document uses PDF features that are not supported")
+	ErrEncrypted   = fmt.Errorf("the document is encrypted")
+)
+
+// header is the marker every PDF must start with, within its first kilobyte.
+var header = regexp.MustCompile(`%PDF-(\d\.\d)`)
+
+// definition matches the start of an indirect object or a trailer dictionary.
+var definition = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b|trailer\b`)
+
+// inheritableKeys are the attributes a page takes from its ancestors in the page tree when it doesn't have its own.
+var inheritableKeys = []Name{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// Document is a PDF loaded in memory. Its page tree is flattened into a single node on load,
+// so the pages can be selected and written back in order.
+type Document struct {
+	version string
+	objects map[int]Object
+	catalog Dict
+	root    Ref
+	info    Object
+	pages   Ref
+	kids    []Ref
+	next    int
+}
+
+// definitionAt is an indirect object and the offset it was defined at. Incremental updates append newer versions
+// of the objects, so the last definition is the one in use.
+type definitionAt struct {
+	object Object
+	offset int
+}
+
+// Read loads the document. The objects are found by scanning the file instead of following the cross-reference
+// tables, which makes it tolerant to the broken offsets that are common in the wild.
+func Read(data []byte) (*Document, error) {
+	prefix := data
+	if len(prefix) > 1024 {
+		prefix = prefix[:1024]
+	}
+	version := header.FindSubmatch(prefix)
+	if version == nil {
+		return nil, fmt.Errorf("(Read) failed finding the PDF header: %w", ErrMalformed)
+	}
+
+	definitions, trailer, err := scan(data)
+	if err != nil {
+		return nil, fmt.Errorf("(Read) failed scanning objects: %w", err)
+	}
+	if _, ok := trailer["Encrypt"]; ok {
+		return nil, fmt.Errorf("(Read) failed reading document: %w", ErrEncrypted)
+	}
+
+	d := &Document{version: string(version[1]), objects: make(map[int]Object, len(definitions)), info: trailer["Info"]}
+	for num, def := range definitions {
+		d.objects[num] = def.object
+		if num >= d.next {
+			d.next = num + 1
+		}
+	}
+
+	root, ok := trailer["Root"].(Ref)
+	if !ok {
+		return nil, fmt.Errorf("(Read) failed finding the document catalog: %w", ErrMalformed)
+	}
+	if d.catalog, ok = d.Resolve(root).(Dict); !ok {
+		return nil, fmt.Errorf("(Read) failed reading the document catalog %d: %w", root.Num, ErrMalformed)
+	}
+	d.root = root
+
+	if err = d.flattenPages(); err != nil {
+		return nil, fmt.Errorf("(Read) failed reading pages: %w", err)
+	}
+
+	return d, nil
+}
+
+// scan reads every object definition of the data, including the ones compressed in object streams,
+// and merges the trailer dictionaries and cross-reference streams into a single trailer.
+func scan(data []byte) (map[int]definitionAt, Dict, error) {
+	definitions := make(map[int]definitionAt)
+	trailers := make(map[int]Dict)
+	p := &parser{data: data}
+
+	for p.pos < len(data) {
+		base := p.pos
+		match := definition.FindSubmatchIndex(data[base:])
+		if match == nil {
+			break
+		}
+		offset := base + match[0]
+		p.pos = base + match[1]
+
+		if match[2] < 0 {
+			if trailer, err := p.parseObject(0); err == nil {
+				if dict, ok := trailer.(Dict); ok {
+					trailers[offset] = dict
+				}
+			}
+			continue
+		}
+
+		start := p.pos
+		o, err := p.parseObject(0)
+		if err != nil {
+			p.pos = start
+			continue
+		}
+		p.keyword("endobj")
+
+		num, _ := strconv.Atoi(string(data[base+match[2] : base+match[3]]))
+		if previous, ok := definitions[num]; !ok || previous.offset < offset {
+			definitions[num] = definitionAt{object: o, offset: offset}
+		}
+	}
+
+	for num, def := range definitions {
+		stream, ok := def.object.(Stream)
+		if !ok {
+			continue
+		}
+
+		switch stream.Dict.Name("Type") {
+		case "XRef":
+			trailers[def.offset] = stream.Dict
+			delete(definitions, num)
+		case "ObjStm":
+			objects, err := readObjectStream(stream)
+			if err != nil {
+				return nil, nil, fmt.Errorf("(scan) failed reading object stream %d: %w", num, err)
+			}
+			for n, o := range objects {
+				if previous, ok := definitions[n]; !ok || previous.offset < def.offset {
+					definitions[n] = definitionAt{object: o, offset: def.offset}
+				}
+			}
+			delete(definitions, num)
+		}
+	}
+
+	offsets := make([]int, 0, len(trailers))
+	for offset := range trailers {
+		offsets = append(offsets, offset)
+	}
+	sort.Ints(offsets)
+
+	trailer := Dict{}
+	for _, offset := range offsets {
+		for _, key := range []Name{"Root", "Info", "Encrypt"} {
+			if value, ok := trailers[offset][key]; ok {
+				trailer[key] = value
+			}
+		}
+	}
+
+	return definitions, trailer, nil
+}
+
+// readObjectStream returns the objects compressed in an object stream by their number.
+func readObjectStream(stream Stream) (map[int]Object, error) {
+	data, err := stream.Decode()
+	if err != nil {
+		return nil, fmt.Errorf("(readObjectStream) failed decoding stream: %w", err)
+	}
+
+	n, _ := stream.Dict["N"].(Integer)
+	first, _ := stream.Dict["First"].(Integer)
+	if first < 0 || int(first) > len(data) {
+		return nil, fmt.Errorf("(readObjectStream) failed finding the first object at %d: %w", first, ErrMalformed)
+	}
+
+	index := &parser{data: data[:first]}
+	objects := make(map[int]Object, n)
+	for i := 0; i < int(n); i++ {
+		num, err := index.parseObject(0)
+		if err != nil {
+			return nil, fmt.Errorf("(readObjectStream) failed reading object number: %w", err)
+		}
+		offset, err := index.parseObject(0)
+		if err != nil {
+			return nil, fmt.Errorf("(readObjectStream) failed reading object offset: %w", err)
+		}
+
+		numInt, okNum := num.(Integer)
+		offsetInt, okOffset := offset.(Integer)
+		if !okNum || !okOffset || int(first)+int(offsetInt) > len(data) {
+			return nil, fmt.Errorf("(readObjectStream) failed reading header entry %d: %w", i, ErrMalformed)
+		}
+
+		p := &parser{data: data, pos: int(first) + int(offsetInt)}
+		if objects[int(numInt)], err = p.parseObject(0); err != nil {
+			return nil, fmt.Errorf("(readObjectStream) failed reading object %d: %w", numInt, err)
+		}
+	}
+
+	return objects, nil
+}
+
+// flattenPages collects the pages of the page tree in order and puts them under a new single node.
+func (d *Document) flattenPages() error {
+	pages, ok := d.catalog["Pages"].(Ref)
+	if !ok {
+		return fmt.Errorf("(flattenPages) failed finding the page tree: %w", ErrMalformed)
+	}
+
+	visited := make(map[int]bool)
+	if err := d.collectPages(pages, Dict{}, visited, 0); err != nil {
+		return fmt.Errorf("(flattenPages) failed collecting pages: %w", err)
+	}
+	if len(d.kids) == 0 {
+		return fmt.Errorf("(flattenPages) failed finding any page: %w", ErrMalformed)
+	}
+
+	d.pages = d.Add(Dict{"Type": Name("Pages")})
+	d.catalog["Pages"] = d.pages
+	d.updatePages()
+
+	return nil
+}
+
+func (d *Document) collectPages(ref Ref, inherited Dict, visited map[int]bool, depth int) error {
+	if visited[ref.Num] || depth > maxDepth {
+		return fmt.Errorf("(collectPages) failed walking page tree with a cycle at %d: %w", ref.Num, ErrMalformed)
+	}
+	visited[ref.Num] = true
+
+	node, ok := d.Resolve(ref).(Dict)
+	if !ok {
+		return fmt.Errorf("(collectPages) failed reading page tree node %d: %w", ref.Num, ErrMalformed)
+	}
+
+	if node.Name("Type") == "Page" || node["Kids"] == nil {
+		for _, key := range inheritableKeys {
+			if _, ok := node[key]; !ok && inherited[key] != nil {
+				node[key] = inherited[key]
+			}
+		}
+		d.kids = append(d.kids, ref)
+		return nil
+	}
+
+	attributes := make(Dict, len(inheritableKeys))
+	for _, key := range inheritableKeys {
+		if value, ok := node[key]; ok {
+			attributes[key] = value
+		} else if value, ok := inherited[key]; ok {
+			attributes[key] = value
+		}
+	}
+
+	kids, _ := d.Resolve(node["Kids"]).(Array)
+	for _, kid := range kids {
+		if kidRef, ok := kid.(Ref); ok {
+			if err := d.collectPages(kidRef, attributes, visited, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *Document) updatePages() {
+	kids := make(Array, 0, len(d.kids))
+	for _, kid := range d.kids {
+		kids = append(kids, kid)
+		d.objects[kid.Num].(Dict)["Parent"] = d.pages
+	}
+
+	node := d.objects[d.pages.Num].(Dict)
+	node["Kids"] = kids
+	node["Count"] = Integer(len(kids))
+}
+
+// Resolve returns the object a reference points to, or the object itself when it's not a reference.
+func (d *Document) Resolve(o Object) Object {
+	for i := 0; i < maxDepth; i++ {
+		ref, ok := o.(Ref)
+		if !ok {
+			return o
+		}
+		o = d.objects[ref.Num]
+	}
+	return nil
+}
+
+// Add stores the object as a new indirect object and returns the reference to it.
+func (d *Document) Add(o Object) Ref {
+	ref := Ref{Num: d.next}
+	d.objects[ref.Num] = o
+	d.next++
+	return ref
+}
+
+// NumPages returns the number of pages of the document.
+func (d *Document) NumPages() int {
+	return len(d.kids)
+}
+
+// Page returns the dictionary of the page at the index, starting at 0.
+func (d *Document) Page(i int) Dict {
+	return d.objects[d.kids[i].Num].(Dict)
+}
+
+// KeepPages removes the pages after the first n. The parts of the document that may point to the removed pages,
+// like the outlines, the named destinations, the forms and the links between pages, are removed as well.
+func (d *Document) KeepPages(n int) {
+	if n >= len(d.kids) {
+		return
+	}
+
+	d.kids = d.kids[:n]
+	d.updatePages()
+
+	for _, key := range []Name{"Outlines", "Dests", "Names", "StructTreeRoot", "OpenAction", "AcroForm", "Threads"} {
+		delete(d.catalog, key)
+	}
+	for i := range d.kids {
+		page := d.Page(i)
+		delete(page, "B")
+		delete(page, "StructParents")
+
+		annotations, _ := d.Resolve(page["Annots"]).(Array)
+		kept := make(Array, 0, len(annotations))
+		for _, annotation := range annotations {
+			if d.isSelfContained(annotation) {
+				kept = append(kept, annotation)
+			}
+		}
+		page["Annots"] = kept
+	}
+}
+
+// isSelfContained reports whether the annotation doesn't point to other pages or to the removed forms.
+func (d *Document) isSelfContained(annotation Object) bool {
+	dict, ok := d.Resolve(annotation).(Dict)
+	if !ok {
+		return false
+	}
+
+	switch dict.Name("Subtype") {
+	case "Widget":
+		return false
+	case "Link":
+		action, _ := d.Resolve(dict["A"]).(Dict)
+		return dict["Dest"] == nil && action.Name("S") == "URI"
+	default:
+		return true
+	}
+}
+
+// WriteTo writes the objects reachable from the document catalog as a new PDF with a single cross-reference table.
+// The pages that are not in the document anymore are written as null, in case something still points to them.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	kept := make(map[int]bool, len(d.kids))
+	for _, kid := range d.kids {
+		kept[kid.Num] = true
+	}
+	kept[d.pages.Num] = true
+
+	numbers := make(map[int]int)
+	queue := make([]int, 0, len(d.objects))
+	ref := func(r Ref) Object {
+		o, ok := d.objects[r.Num]
+		if !ok {
+			return nil
+		}
+		if dict, isDict := o.(Dict); isDict && !kept[r.Num] && (dict.Name("Type") == "Page" || dict.Name("Type") == "Pages") {
+			return nil
+		}
+
+		if _, ok := numbers[r.Num]; !ok {
+			numbers[r.Num] = len(numbers) + 1
+			queue = append(queue, r.Num)
+		}
+		return reference(numbers[r.Num])
+	}
+
+	trailer := Dict{"Root": ref(d.root)}
+	if info, ok := d.info.(Ref); ok {
+		trailer["Info"] = ref(info)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%%PDF-%s\n%%\xe2\xe3\xcf\xd3\n", d.version)
+
+	offsets := make([]int, 0, len(d.objects))
+	for i := 0; i < len(queue); i++ {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n", i+1)
+		writeObject(&buf, d.objects[queue[i]], ref)
+		buf.WriteString("\nendobj\n")
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+
+	trailer["Size"] = Integer(len(offsets) + 1)
+	buf.WriteString("trailer\n")
+	writeObject(&buf, trailer, ref)
+	fmt.Fprintf(&buf, "\nstartxref\n%d\n%%%%EOF\n", xref)
+
+	n, err := buf.WriteTo(w)
+	if err != nil {
+		return n, fmt.Errorf("(WriteTo) failed writing document: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,174 @@
+package pdf_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"testing"
+
+	"github.com/ebookstore/internal/platform/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// buildPDF writes a document with the given objects, numbered from 1, and a cross-reference table.
+func buildPDF(trailer string, objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n%\xe2\xe3\xcf\xd3\n")
+
+	offsets := make([]int, 0, len(objects))
+	for i, o := range objects {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n%s\nstartxref\n%d\n%%%%EOF\n", trailer, xref)
+	return buf.Bytes()
+}
+
+func contentStream(text string) string {
+	content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+}
+
+// threePages is a document with a nested page tree, inherited attributes, outlines and a link to the last page.
+func threePages() []byte {
+	return buildPDF("<< /Size 12 /Root 1 0 R /Info 11 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R /Outlines 10 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 3 /MediaBox [0 0 612 792] /Resources << /Font << /F1 9 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 6 0 R /Annots [<< /Type /Annot /Subtype /Link /Dest [5 0 R /Fit] >> << /Type /Annot /Subtype /Link /A << /S /URI /URI (https://ebookstore.com) >> >>] >>",
+		"<< /Type /Pages /Parent 2 0 R /Kids [12 0 R 5 0 R] /Count 2 >>",
+		"<< /Type /Page /Parent 4 0 R /Contents 8 0 R >>",
+		contentStream("Chapter one"),
+		contentStream("Chapter two"),
+		contentStream("Chapter three"),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Outlines /First 5 0 R >>",
+		"<< /Title (Clean Code) /Author (Robert C. Martin) >>",
+		"<< /Type /Page /Parent 4 0 R /Contents 7 0 R /MediaBox [0 0 300 300] >>",
+	)
+}
+
+func TestRead_FlattensPageTree(t *testing.T) {
+	document, err := pdf.Read(threePages())
+	require.NoError(t, err)
+
+	require.Equal(t, 3, document.NumPages())
+	assert.Equal(t, pdf.Array{pdf.Integer(0), pdf.Integer(0), pdf.Integer(612), pdf.Integer(792)}, document.Page(0)["MediaBox"])
+	assert.Equal(t, pdf.Array{pdf.Integer(0), pdf.Integer(0), pdf.Integer(300), pdf.Integer(300)}, document.Page(1)["MediaBox"])
+	assert.NotNil(t, document.Page(2)["Resources"])
+}
+
+func TestKeepPages(t *testing.T) {
+	document, err := pdf.Read(threePages())
+	require.NoError(t, err)
+
+	document.KeepPages(2)
+
+	var buf bytes.Buffer
+	_, err = document.WriteTo(&buf)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "Chapter one")
+	assert.Contains(t, buf.String(), "Chapter two")
+	assert.NotContains(t, buf.String(), "Chapter three")
+	assert.NotContains(t, buf.String(), "/Outlines")
+	assert.Contains(t, buf.String(), "https://ebookstore.com")
+	assert.Contains(t, buf.String(), "Robert C. Martin")
+
+	sample, err := pdf.Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 2, sample.NumPages())
+	assert.Len(t, sample.Resolve(sample.Page(0)["Annots"]), 1)
+}
+
+func TestKeepPages_MorePagesThanDocument(t *testing.T) {
+	document, err := pdf.Read(threePages())
+	require.NoError(t, err)
+
+	document.KeepPages(10)
+
+	var buf bytes.Buffer
+	_, err = document.WriteTo(&buf)
+	require.NoError(t, err)
+
+	sample, err := pdf.Read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, 3, sample.NumPages())
+	assert.Contains(t, buf.String(), "Chapter three")
+}
+
+func TestRead_ObjectStream(t *testing.T) {
+	objects := "<< /Type /Catalog /Pages 6 0 R >> << /Type /Pages /Kids [1 0 R] /Count 1 >>"
+	index := "5 0 6 34 "
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	_, _ = w.Write([]byte(index + objects))
+	_ = w.Close()
+
+	data := buildPDF("<< /Size 7 >>",
+		"<< /Type /Page /Parent 6 0 R /MediaBox [0 0 612 792] /Contents 2 0 R >>",
+		contentStream("Compressed"),
+		"<< /Type /XRef /Root 5 0 R /Length 0 >>\nstream\n\nendstream",
+		fmt.Sprintf("<< /Type /ObjStm /N 2 /First %d /Filter /FlateDecode /Length %d >>\nstream\n%s\nendstream", len(index), compressed.Len(), compressed.Bytes()),
+	)
+
+	document, err := pdf.Read(data)
+	require.NoError(t, err)
+	assert.Equal(t, 1, document.NumPages())
+
+	var buf bytes.Buffer
+	_, err = document.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.NotContains(t, buf.String(), "/ObjStm")
+	assert.Contains(t, buf.String(), "Compressed")
+}
+
+func TestRead_StreamLengthOverflow(t *testing.T) {
+	content := "BT /F1 12 Tf 72 720 Td (Overflow) Tj ET"
+	data := buildPDF("<< /Size 5 /Root 1 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 612 792] >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+		fmt.Sprintf("<< /Length 9223372036854775807 >>\nstream\n%s\nendstream", content),
+	)
+
+	document, err := pdf.Read(data)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, document.NumPages())
+}
+
+func TestRead_Encrypted(t *testing.T) {
+	data := buildPDF("<< /Size 4 /Root 1 0 R /Encrypt 3 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [] /Count 0 >>",
+		"<< /Filter /Standard /V 2 >>",
+	)
+
+	_, err := pdf.Read(data)
+
+	assert.ErrorIs(t, err, pdf.ErrEncrypted)
+}
+
+func TestRead_NotPDF(t *testing.T) {
+	_, err := pdf.Read([]byte("PK\x03\x04 not a pdf"))
+
+	assert.ErrorIs(t, err, pdf.ErrMalformed)
+}
+
+func TestRead_WithoutPages(t *testing.T) {
+	data := buildPDF("<< /Size 3 /Root 1 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [] /Count 0 >>",
+	)
+
+	_, err := pdf.Read(data)
+
+	assert.ErrorIs(t, err, pdf.ErrMalformed)
+}
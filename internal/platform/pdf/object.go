@@ -0,0 +1,194 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+)
+
+// maxDecodedSize bounds the decompressed size of a stream, so a small stream crafted to inflate cannot exhaust the memory.
+const maxDecodedSize = 256 << 20
+
+// Object is any of the PDF object types: nil (null), bool, Integer, Real, String, Name, Array, Dict, Stream or Ref.
+type Object interface{}
+
+type Integer int64
+
+type Real float64
+
+// String is the content of a literal or hexadecimal string.
+type String []byte
+
+type Name string
+
+type Array []Object
+
+type Dict map[Name]Object
+
+// Stream keeps its data encoded with the filters of its dictionary.
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+// Ref points to an indirect object of the document by its number.
+type Ref struct {
+	Num int
+	Gen int
+}
+
+// Name returns the value of the key when it's a name.
+func (d Dict) Name(key Name) Name {
+	n, _ := d[key].(Name)
+	return n
+}
+
+// Decode returns the data of the stream without its filters. Only the FlateDecode filter without predictors is supported,
+// which is the one used by the object streams and most content streams.
+func (s Stream) Decode() ([]byte, error) {
+	var filters Array
+	switch f := s.Dict["Filter"].(type) {
+	case nil:
+		return s.Data, nil
+	case Name:
+		filters = Array{f}
+	case Array:
+		filters = f
+	}
+
+	if len(filters) == 0 {
+		return s.Data, nil
+	}
+	if len(filters) > 1 || filters[0] != Name("FlateDecode") {
+		return nil, fmt.Errorf("(Decode) failed decoding stream with filters %v: %w", filters, ErrUnsupported)
+	}
+	if params, ok := s.Dict["DecodeParms"].(Dict); ok {
+		if predictor, ok := params["Predictor"].(Integer); ok && predictor > 1 {
+			return nil, fmt.Errorf("(Decode) failed decoding stream with predictor %d: %w", predictor, ErrUnsupported)
+		}
+	}
+
+	r, err := zlib.NewReader(bytes.NewReader(s.Data))
+	if err != nil {
+		return nil, fmt.Errorf("(Decode) failed reading compressed stream: %v: %w", err, ErrMalformed)
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxDecodedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("(Decode) failed decompressing stream: %v: %w", err, ErrMalformed)
+	}
+	if len(data) > maxDecodedSize {
+		return nil, fmt.Errorf("(Decode) failed decompressing stream larger than %d bytes: %w", maxDecodedSize, ErrMalformed)
+	}
+
+	return data, nil
+}
+
+// NewStream returns a stream of the data compressed with the FlateDecode filter.
+func NewStream(dict Dict, data []byte) Stream {
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	_, _ = w.Write(data)
+	_ = w.Close()
+
+	if dict == nil {
+		dict = Dict{}
+	}
+	dict["Filter"] = Name("FlateDecode")
+	return Stream{Dict: dict, Data: buf.Bytes()}
+}
+
+// writeObject serializes the object, renumbering the references with ref.
+func writeObject(buf *bytes.Buffer, o Object, ref func(Ref) Object) {
+	switch v := o.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case Integer:
+		buf.WriteString(strconv.FormatInt(int64(v), 10))
+	case Real:
+		buf.WriteString(strconv.FormatFloat(float64(v), 'f', -1, 64))
+	case String:
+		writeString(buf, v)
+	case Name:
+		writeName(buf, v)
+	case Array:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			writeObject(buf, item, ref)
+		}
+		buf.WriteByte(']')
+	case Dict:
+		writeDict(buf, v, ref)
+	case Stream:
+		dict := make(Dict, len(v.Dict))
+		for k, item := range v.Dict {
+			dict[k] = item
+		}
+		dict["Length"] = Integer(len(v.Data))
+		writeDict(buf, dict, ref)
+		buf.WriteString("\nstream\n")
+		buf.Write(v.Data)
+		buf.WriteString("\nendstream")
+	case Ref:
+		writeObject(buf, ref(v), ref)
+	case reference:
+		fmt.Fprintf(buf, "%d 0 R", int(v))
+	}
+}
+
+// reference is a renumbered Ref ready to be written.
+type reference int
+
+func writeDict(buf *bytes.Buffer, d Dict, ref func(Ref) Object) {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, string(k))
+	}
+	sort.Strings(keys)
+
+	buf.WriteString("<<")
+	for _, k := range keys {
+		writeName(buf, Name(k))
+		buf.WriteByte(' ')
+		writeObject(buf, d[Name(k)], ref)
+	}
+	buf.WriteString(">>")
+}
+
+func writeName(buf *bytes.Buffer, n Name) {
+	buf.WriteByte('/')
+	for i := 0; i < len(n); i++ {
+		c := n[i]
+		if c < '!' || c > '~' || c == '#' || isDelimiter(c) {
+			fmt.Fprintf(buf, "#%02X", c)
+			continue
+		}
+		buf.WriteByte(c)
+	}
+}
+
+func writeString(buf *bytes.Buffer, s String) {
+	buf.WriteByte('(')
+	for _, c := range s {
+		switch c {
+		case '(', ')', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(')')
+}
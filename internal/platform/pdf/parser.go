@@ -0,0 +1,310 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDepth limits the nesting of arrays and dictionaries, so malformed files cannot exhaust the stack.
+const maxDepth = 64
+
+// parser reads the objects of the PDF syntax from data, starting at pos.
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func isWhitespace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (p *parser) skipWhitespace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case isWhitespace(c):
+			p.pos++
+		case c == '%':
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+// token reads the regular characters of a number or a keyword.
+func (p *parser) token() string {
+	start := p.pos
+	for p.pos < len(p.data) && !isWhitespace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// keyword reports whether the next token is the given keyword and consumes it in that case.
+func (p *parser) keyword(k string) bool {
+	p.skipWhitespace()
+	start := p.pos
+	if p.token() == k {
+		return true
+	}
+	p.pos = start
+	return false
+}
+
+func (p *parser) parseObject(depth int) (Object, error) {
+	if depth > maxDepth {
+		return nil, fmt.Errorf("(parseObject) failed parsing object nested too deep at %d: %w", p.pos, ErrMalformed)
+	}
+
+	p.skipWhitespace()
+	if p.pos >= len(p.data) {
+		return nil, fmt.Errorf("(parseObject) failed parsing object at the end of the data: %w", ErrMalformed)
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '/':
+		p.pos++
+		return p.parseName()
+	case c == '(':
+		p.pos++
+		return p.parseLiteralString()
+	case c == '<' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '<':
+		p.pos += 2
+		return p.parseDict(depth)
+	case c == '<':
+		p.pos++
+		return p.parseHexString()
+	case c == '[':
+		p.pos++
+		return p.parseArray(depth)
+	case isDelimiter(c):
+		return nil, fmt.Errorf("(parseObject) failed parsing unexpected %q at %d: %w", c, p.pos, ErrMalformed)
+	}
+
+	start := p.pos
+	switch t := p.token(); t {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	default:
+		if i, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return p.parseRef(Integer(i)), nil
+		}
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return Real(f), nil
+		}
+		return nil, fmt.Errorf("(parseObject) failed parsing unexpected token %q at %d: %w", t, start, ErrMalformed)
+	}
+}
+
+// parseRef reads the "gen R" following an integer when it is the number of a reference.
+func (p *parser) parseRef(num Integer) Object {
+	start := p.pos
+	p.skipWhitespace()
+	gen, err := strconv.Atoi(p.token())
+	if err == nil && p.keyword("R") {
+		return Ref{Num: int(num), Gen: gen}
+	}
+	p.pos = start
+	return num
+}
+
+func (p *parser) parseName() (Object, error) {
+	t := p.token()
+	if !strings.Contains(t, "#") {
+		return Name(t), nil
+	}
+
+	name := make([]byte, 0, len(t))
+	for i := 0; i < len(t); i++ {
+		if t[i] == '#' && i+2 < len(t) {
+			if c, err := strconv.ParseUint(t[i+1:i+3], 16, 8); err == nil {
+				name = append(name, byte(c))
+				i += 2
+				continue
+			}
+		}
+		name = append(name, t[i])
+	}
+	return Name(name), nil
+}
+
+func (p *parser) parseLiteralString() (Object, error) {
+	var s []byte
+	for nesting := 0; p.pos < len(p.data); p.pos++ {
+		c := p.data[p.pos]
+		switch c {
+		case '(':
+			nesting++
+		case ')':
+			if nesting == 0 {
+				p.pos++
+				return String(s), nil
+			}
+			nesting--
+		case '\\':
+			p.pos++
+			if p.pos >= len(p.data) {
+				break
+			}
+			c = p.data[p.pos]
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if p.pos+1 < len(p.data) && p.data[p.pos+1] == '\n' {
+					p.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					octal := 0
+					for n := 0; n < 3 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; n++ {
+						octal = octal*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					p.pos--
+					c = byte(octal)
+				}
+			}
+		}
+		s = append(s, c)
+	}
+	return nil, fmt.Errorf("(parseLiteralString) failed finding the end of the string: %w", ErrMalformed)
+}
+
+func (p *parser) parseHexString() (Object, error) {
+	end := bytes.IndexByte(p.data[p.pos:], '>')
+	if end < 0 {
+		return nil, fmt.Errorf("(parseHexString) failed finding the end of the string: %w", ErrMalformed)
+	}
+
+	digits := make([]byte, 0, end)
+	for _, c := range p.data[p.pos : p.pos+end] {
+		if !isWhitespace(c) {
+			digits = append(digits, c)
+		}
+	}
+	p.pos += end + 1
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	s := make([]byte, len(digits)/2)
+	for i := range s {
+		c, err := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		if err != nil {
+			return nil, fmt.Errorf("(parseHexString) failed decoding %q: %w", digits[2*i:2*i+2], ErrMalformed)
+		}
+		s[i] = byte(c)
+	}
+	return String(s), nil
+}
+
+func (p *parser) parseArray(depth int) (Object, error) {
+	var a Array
+	for {
+		p.skipWhitespace()
+		if p.pos < len(p.data) && p.data[p.pos] == ']' {
+			p.pos++
+			return a, nil
+		}
+
+		o, err := p.parseObject(depth + 1)
+		if err != nil {
+			return nil, fmt.Errorf("(parseArray) failed parsing item: %w", err)
+		}
+		a = append(a, o)
+	}
+}
+
+func (p *parser) parseDict(depth int) (Object, error) {
+	d := Dict{}
+	for {
+		p.skipWhitespace()
+		if p.pos+1 < len(p.data) && p.data[p.pos] == '>' && p.data[p.pos+1] == '>' {
+			p.pos += 2
+			break
+		}
+
+		key, err := p.parseObject(depth + 1)
+		if err != nil {
+			return nil, fmt.Errorf("(parseDict) failed parsing key: %w", err)
+		}
+		name, ok := key.(Name)
+		if !ok {
+			return nil, fmt.Errorf("(parseDict) failed parsing key %v that is not a name: %w", key, ErrMalformed)
+		}
+
+		value, err := p.parseObject(depth + 1)
+		if err != nil {
+			return nil, fmt.Errorf("(parseDict) failed parsing value of %s: %w", name, err)
+		}
+		d[name] = value
+	}
+
+	if !p.keyword("stream") {
+		return d, nil
+	}
+	return p.parseStream(d)
+}
+
+// parseStream reads the data of the stream that follows its dictionary. The length is checked against the endstream
+// keyword because it's often an indirect object, which may not have been read yet, or just wrong.
+func (p *parser) parseStream(d Dict) (Object, error) {
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+		p.pos++
+	}
+	start := p.pos
+
+	if length, ok := d["Length"].(Integer); ok && length >= 0 && int64(length) <= int64(len(p.data)-start) {
+		p.pos = start + int(length)
+		if p.keyword("endstream") {
+			return Stream{Dict: d, Data: p.data[start : start+int(length)]}, nil
+		}
+	}
+
+	end := bytes.Index(p.data[start:], []byte("endstream"))
+	if end < 0 {
+		return nil, fmt.Errorf("(parseStream) failed finding the end of the stream at %d: %w", start, ErrMalformed)
+	}
+	p.pos = start + end + len("endstream")
+
+	data := p.data[start : start+end]
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	data = bytes.TrimSuffix(data, []byte("\r"))
+	return Stream{Dict: d, Data: data}, nil
+}
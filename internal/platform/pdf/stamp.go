@@ -0,0 +1,208 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"unicode/utf16"
+)
+
+// stampFont is the name the font of the stamps gets in the resources of every page.
+const stampFont Name = "FStamp0"
+
+const (
+	stampFontSize = 7
+	stampMargin   = 12
+)
+
+// letter is the page size readers assume when a page has no valid media box.
+var letter = [4]float64{0, 0, 612, 792}
+
+// winAnsi maps the characters of the WinAnsiEncoding that are not at their Latin-1 position.
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A,
+	'‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+	'˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// Stamp writes the footer at the bottom of every page, and the hidden text as invisible text over the page, which
+// is still found by text extraction when the footer is cropped out. The text is set in Helvetica, which every reader
+// provides, so the characters out of its encoding are replaced with question marks.
+func (d *Document) Stamp(footer, hidden string) {
+	font := d.Add(Dict{
+		"Type":     Name("Font"),
+		"Subtype":  Name("Type1"),
+		"BaseFont": Name("Helvetica"),
+		"Encoding": Name("WinAnsiEncoding"),
+	})
+	// The content of the page is wrapped in a saved graphics state, so the stamp is drawn in the default one
+	// whatever the content leaves behind
+	save := d.Add(NewStream(nil, []byte("q\n")))
+
+	for i := range d.kids {
+		page := d.Page(i)
+		stamp := d.Add(NewStream(nil, stampContent(d.pageBox(page), rotation(d.Resolve(page["Rotate"])), footer, hidden)))
+
+		page["Contents"] = d.wrapContents(page["Contents"], save, stamp)
+		page["Resources"] = d.withFont(page["Resources"], font)
+	}
+}
+
+// SetInfo sets entries of the document information dictionary, the metadata shown by readers in the properties of
+// the document. The other entries are kept.
+func (d *Document) SetInfo(entries map[Name]string) {
+	info := copyDict(d.Resolve(d.info))
+	for key, value := range entries {
+		info[key] = textString(value)
+	}
+	d.info = d.Add(info)
+}
+
+// wrapContents returns the content streams of the page between the given ones.
+func (d *Document) wrapContents(contents Object, before, after Ref) Array {
+	wrapped := Array{before}
+	switch c := d.Resolve(contents).(type) {
+	case Stream:
+		ref, ok := contents.(Ref)
+		if !ok {
+			ref = d.Add(c)
+		}
+		wrapped = append(wrapped, ref)
+	case Array:
+		wrapped = append(wrapped, c...)
+	}
+	return append(wrapped, after)
+}
+
+// withFont returns a copy of the resources with the font of the stamps, since the resources may be shared with other
+// pages or forms.
+func (d *Document) withFont(resources Object, font Ref) Dict {
+	copied := copyDict(d.Resolve(resources))
+	fonts := copyDict(d.Resolve(copied["Font"]))
+	fonts[stampFont] = font
+	copied["Font"] = fonts
+	return copied
+}
+
+// pageBox returns the visible area of the page as its lower left and upper right corners.
+func (d *Document) pageBox(page Dict) [4]float64 {
+	for _, key := range []Name{"CropBox", "MediaBox"} {
+		box, ok := d.Resolve(page[key]).(Array)
+		if !ok || len(box) != 4 {
+			continue
+		}
+
+		var corners [4]float64
+		valid := true
+		for i, value := range box {
+			if corners[i], ok = number(d.Resolve(value)); !ok {
+				valid = false
+			}
+		}
+		if valid && corners[0] != corners[2] && corners[1] != corners[3] {
+			return [4]float64{
+				math.Min(corners[0], corners[2]), math.Min(corners[1], corners[3]),
+				math.Max(corners[0], corners[2]), math.Max(corners[1], corners[3]),
+			}
+		}
+	}
+	return letter
+}
+
+// stampContent draws the footer along the bottom edge of the page as it is displayed, which is another edge of the
+// box when the page is rotated, with the hidden text invisible right above it.
+func stampContent(box [4]float64, rotate int, footer, hidden string) []byte {
+	var matrix [6]float64
+	switch rotate {
+	case 90:
+		matrix = [6]float64{0, 1, -1, 0, box[2] - stampMargin, box[1] + stampMargin}
+	case 180:
+		matrix = [6]float64{-1, 0, 0, -1, box[2] - stampMargin, box[3] - stampMargin}
+	case 270:
+		matrix = [6]float64{0, -1, 1, 0, box[0] + stampMargin, box[3] - stampMargin}
+	default:
+		matrix = [6]float64{1, 0, 0, 1, box[0] + stampMargin, box[1] + stampMargin}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\nQ\nq\nBT\n")
+	fmt.Fprintf(&buf, "/%s %d Tf\n0.5 g\n", stampFont, stampFontSize)
+	for _, v := range matrix {
+		buf.WriteString(formatNumber(v))
+		buf.WriteByte(' ')
+	}
+	buf.WriteString("Tm\n")
+	writeString(&buf, encodeWinAnsi(footer))
+	fmt.Fprintf(&buf, " Tj\n3 Tr\n0 %d Td\n", stampFontSize*2)
+	writeString(&buf, encodeWinAnsi(hidden))
+	buf.WriteString(" Tj\nET\nQ\n")
+
+	return buf.Bytes()
+}
+
+// rotation returns the rotation of the page as one of 0, 90, 180 or 270 degrees.
+func rotation(o Object) int {
+	rotate, _ := o.(Integer)
+	return ((int(rotate)%360 + 360) % 360) / 90 * 90
+}
+
+func number(o Object) (float64, bool) {
+	switch n := o.(type) {
+	case Integer:
+		return float64(n), true
+	case Real:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func formatNumber(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+// encodeWinAnsi encodes the text for a font with the WinAnsiEncoding.
+func encodeWinAnsi(text string) String {
+	encoded := make(String, 0, len(text))
+	for _, r := range text {
+		switch {
+		case r < ' ':
+			encoded = append(encoded, ' ')
+		case r < 0x7F || (r >= 0xA0 && r <= 0xFF):
+			encoded = append(encoded, byte(r))
+		case winAnsi[r] != 0:
+			encoded = append(encoded, winAnsi[r])
+		default:
+			encoded = append(encoded, '?')
+		}
+	}
+	return encoded
+}
+
+// textString encodes a text string of the document metadata, in UTF-16 when it's not plain ASCII.
+func textString(text string) String {
+	ascii := true
+	for _, r := range text {
+		if r >= 0x7F {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return String(text)
+	}
+
+	encoded := String{0xFE, 0xFF}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		encoded = append(encoded, byte(unit>>8), byte(unit))
+	}
+	return encoded
+}
+
+func copyDict(o Object) Dict {
+	dict, _ := o.(Dict)
+	copied := make(Dict, len(dict)+1)
+	for key, value := range dict {
+		copied[key] = value
+	}
+	return copied
+}
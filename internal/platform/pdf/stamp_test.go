@@ -0,0 +1,98 @@
+package pdf_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ebookstore/internal/platform/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rewrite writes the document and reads it back, so the assertions run on what readers get.
+func rewrite(t *testing.T, document *pdf.Document) *pdf.Document {
+	var buf bytes.Buffer
+	_, err := document.WriteTo(&buf)
+	require.NoError(t, err)
+
+	document, err = pdf.Read(buf.Bytes())
+	require.NoError(t, err)
+	return document
+}
+
+// pageStamp returns the decoded content of the last content stream of the page, which is the stamp.
+func pageStamp(t *testing.T, document *pdf.Document, i int) string {
+	contents, ok := document.Resolve(document.Page(i)["Contents"]).(pdf.Array)
+	require.True(t, ok)
+	require.Len(t, contents, 3)
+
+	stamp, ok := document.Resolve(contents[2]).(pdf.Stream)
+	require.True(t, ok)
+	data, err := stamp.Decode()
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestStamp(t *testing.T) {
+	document, err := pdf.Read(threePages())
+	require.NoError(t, err)
+
+	document.Stamp("Licensed to Zoë Müller", "order 1234")
+	document = rewrite(t, document)
+
+	require.Equal(t, 3, document.NumPages())
+	for i := 0; i < document.NumPages(); i++ {
+		stamp := pageStamp(t, document, i)
+		assert.Contains(t, stamp, "(Licensed to Zo\xeb M\xfcller) Tj")
+		assert.Contains(t, stamp, "3 Tr")
+		assert.Contains(t, stamp, "(order 1234) Tj")
+
+		resources, ok := document.Resolve(document.Page(i)["Resources"]).(pdf.Dict)
+		require.True(t, ok)
+		fonts, ok := document.Resolve(resources["Font"]).(pdf.Dict)
+		require.True(t, ok)
+		assert.Contains(t, fonts, pdf.Name("F1"))
+		assert.Contains(t, fonts, pdf.Name("FStamp0"))
+	}
+
+	assert.Contains(t, pageStamp(t, document, 0), "1.00 0.00 0.00 1.00 12.00 12.00 Tm")
+}
+
+func TestStamp_RotatedPage(t *testing.T) {
+	data := buildPDF("<< /Size 4 /Root 1 0 R >>",
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Rotate 90 >>",
+	)
+	document, err := pdf.Read(data)
+	require.NoError(t, err)
+
+	document.Stamp("footer", "hidden")
+	document = rewrite(t, document)
+
+	contents, ok := document.Resolve(document.Page(0)["Contents"]).(pdf.Array)
+	require.True(t, ok)
+	require.Len(t, contents, 2)
+
+	stamp, ok := document.Resolve(contents[1]).(pdf.Stream)
+	require.True(t, ok)
+	content, err := stamp.Decode()
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "0.00 1.00 -1.00 0.00 600.00 12.00 Tm")
+}
+
+func TestSetInfo(t *testing.T) {
+	document, err := pdf.Read(threePages())
+	require.NoError(t, err)
+
+	document.SetInfo(map[pdf.Name]string{"Buyer": "Zoë", "OrderID": "1234"})
+	document = rewrite(t, document)
+
+	var buf bytes.Buffer
+	_, err = document.WriteTo(&buf)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "/Title (Clean Code)")
+	assert.Contains(t, buf.String(), "/OrderID (1234)")
+	assert.Contains(t, buf.String(), "/Buyer (\xfe\xff\x00Z\x00o\x00\xeb)")
+}
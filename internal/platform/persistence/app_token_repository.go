@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"gorm.io/gorm"
+)
+
+type AppTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewAppTokenRepository(db *gorm.DB) *AppTokenRepository {
+	return &AppTokenRepository{db: db}
+}
+
+func (r *AppTokenRepository) Create(ctx context.Context, appToken *auth.AppToken) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(appToken)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AppTokenRepository) FindByUserID(ctx context.Context, userID string) ([]auth.AppToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var appTokens []auth.AppToken
+	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&appTokens)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindByUserID) failed running select query: %w", err)
+	}
+
+	return appTokens, nil
+}
+
+func (r *AppTokenRepository) FindByHash(ctx context.Context, tokenHash string) (auth.AppToken, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	appToken := auth.AppToken{}
+	result := r.db.WithContext(ctx).First(&appToken, "token_hash = ?", tokenHash)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = &ErrEntityNotFound{entity: "app token"}
+		}
+
+		return auth.AppToken{}, fmt.Errorf("(FindByHash) failed running select query: %w", err)
+	}
+
+	return appToken, nil
+}
+
+func (r *AppTokenRepository) Delete(ctx context.Context, userID, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&auth.AppToken{}, "user_id = ? AND id = ?", userID, id)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", &ErrEntityNotFound{entity: "app token"})
+	}
+
+	return nil
+}
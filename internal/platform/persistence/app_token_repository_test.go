@@ -0,0 +1,82 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AppTokenRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo *persistence.AppTokenRepository
+}
+
+func (s *AppTokenRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewAppTokenRepository(s.db)
+}
+
+func TestAppTokenRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(AppTokenRepositoryTestSuite))
+}
+
+func (s *AppTokenRepositoryTestSuite) SetupTest() {
+	user := auth.User{ID: "user-id", FirstName: "Raphael", LastName: "Collin", Email: "raphael@test.com", Role: auth.Customer, Password: "password"}
+	require.Nil(s.T(), s.db.Create(&user).Error)
+}
+
+func (s *AppTokenRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&auth.AppToken{}, "1 = 1")
+	s.db.Delete(&auth.User{}, "1 = 1")
+}
+
+func (s *AppTokenRepositoryTestSuite) TestCreateAndFindByHash() {
+	ctx := context.TODO()
+
+	appToken := auth.AppToken{ID: "token-id", UserID: "user-id", Name: "My e-reader", TokenHash: auth.HashAppToken("secret"), CreatedAt: time.Now()}
+	require.Nil(s.T(), s.repo.Create(ctx, &appToken))
+
+	found, err := s.repo.FindByHash(ctx, auth.HashAppToken("secret"))
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), "token-id", found.ID)
+	assert.Equal(s.T(), "user-id", found.UserID)
+
+	_, err = s.repo.FindByHash(ctx, auth.HashAppToken("other"))
+	var notFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &notFound))
+}
+
+func (s *AppTokenRepositoryTestSuite) TestFindByUserID() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.Create(ctx, &auth.AppToken{ID: "token-1", UserID: "user-id", Name: "Phone", TokenHash: "hash-1"}))
+	require.Nil(s.T(), s.repo.Create(ctx, &auth.AppToken{ID: "token-2", UserID: "user-id", Name: "Tablet", TokenHash: "hash-2"}))
+
+	appTokens, err := s.repo.FindByUserID(ctx, "user-id")
+
+	require.Nil(s.T(), err)
+	assert.Len(s.T(), appTokens, 2)
+}
+
+func (s *AppTokenRepositoryTestSuite) TestDelete() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.Create(ctx, &auth.AppToken{ID: "token-id", UserID: "user-id", Name: "Phone", TokenHash: "hash"}))
+
+	err := s.repo.Delete(ctx, "other-user", "token-id")
+	var notFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &notFound))
+
+	assert.Nil(s.T(), s.repo.Delete(ctx, "user-id", "token-id"))
+}
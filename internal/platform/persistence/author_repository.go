@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"gorm.io/gorm"
+)
+
+type AuthorRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(db *gorm.DB) *AuthorRepository {
+	return &AuthorRepository{db: db}
+}
+
+func (r *AuthorRepository) FindByID(ctx context.Context, id string) (catalog.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	author := catalog.Author{}
+	result := r.db.WithContext(ctx).Preload("Books").Preload("Books.Book").First(&author, "id = ?", id)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = &ErrEntityNotFound{entity: "author"}
+		}
+
+		return catalog.Author{}, fmt.Errorf("(FindByID) failed running select query: %w", err)
+	}
+
+	return author, nil
+}
+
+func (r *AuthorRepository) FindByIDs(ctx context.Context, ids []string) ([]catalog.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var authors []catalog.Author
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&authors).Error; err != nil {
+		return nil, fmt.Errorf("(FindByIDs) failed running select query: %w", err)
+	}
+
+	return authors, nil
+}
+
+func (r *AuthorRepository) FindBySlugs(ctx context.Context, slugs []string) ([]catalog.Author, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var authors []catalog.Author
+	if err := r.db.WithContext(ctx).Where("slug IN ?", slugs).Find(&authors).Error; err != nil {
+		return nil, fmt.Errorf("(FindBySlugs) failed running select query: %w", err)
+	}
+
+	return authors, nil
+}
+
+func (r *AuthorRepository) Create(ctx context.Context, author *catalog.Author) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Omit("Books").Create(author)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "slug"}
+		}
+
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuthorRepository) Update(ctx context.Context, author *catalog.Author) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	// The books of an author are managed through the BookRepository
+	result := r.db.WithContext(ctx).Omit("Books").Save(author)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "slug"}
+		}
+
+		return fmt.Errorf("(Update) failed running update statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AuthorRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&catalog.Author{}, "id = ?", id)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(Delete) no rows affected: %w", &ErrEntityNotFound{entity: "author"})
+	}
+
+	return nil
+}
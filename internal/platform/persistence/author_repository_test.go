@@ -0,0 +1,133 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthorRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.AuthorRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *AuthorRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewAuthorRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestAuthorRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(AuthorRepositoryTestSuite))
+}
+
+func (s *AuthorRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+	s.db.Delete(&catalog.Author{}, "1 = 1")
+}
+
+func (s *AuthorRepositoryTestSuite) TestCreate_WithDuplicateSlug() {
+	ctx := context.TODO()
+
+	err := s.repo.Create(ctx, &catalog.Author{ID: "some-id", Name: "Eric Evans", Slug: "eric-evans"})
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &catalog.Author{ID: "other-id", Name: "Eric Evans", Slug: "eric-evans"})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+}
+
+func (s *AuthorRepositoryTestSuite) TestFindByID_WithBooks() {
+	ctx := context.TODO()
+
+	author := catalog.Author{ID: "some-id", Name: "Eric Evans", Slug: "eric-evans", Bio: "Domain-Driven Design"}
+	require.Nil(s.T(), s.repo.Create(ctx, &author))
+
+	book := catalog.Book{
+		ID:          "book-id",
+		Title:       "Domain Driver Design",
+		Authors:     []catalog.BookAuthor{{AuthorID: author.ID, Role: catalog.RoleAuthor}},
+		Price:       8000,
+		ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC),
+	}
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &book))
+
+	actual, err := s.repo.FindByID(ctx, author.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), author.Bio, actual.Bio)
+	require.Len(s.T(), actual.Books, 1)
+	assert.Equal(s.T(), book.Title, actual.Books[0].Book.Title)
+	assert.Equal(s.T(), catalog.RoleAuthor, actual.Books[0].Role)
+}
+
+func (s *AuthorRepositoryTestSuite) TestFindByID_WhenAuthorIsNotFound() {
+	_, err := s.repo.FindByID(context.TODO(), "unknown-id")
+
+	var errEntityNotFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &errEntityNotFound))
+}
+
+func (s *AuthorRepositoryTestSuite) TestFindByIDs() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.Create(ctx, &catalog.Author{ID: "some-id1", Name: "Eric Evans", Slug: "eric-evans"}))
+	require.Nil(s.T(), s.repo.Create(ctx, &catalog.Author{ID: "some-id2", Name: "Martin Fowler", Slug: "martin-fowler"}))
+
+	actual, err := s.repo.FindByIDs(ctx, []string{"some-id1", "unknown-id"})
+
+	assert.Nil(s.T(), err)
+	require.Len(s.T(), actual, 1)
+	assert.Equal(s.T(), "some-id1", actual[0].ID)
+}
+
+func (s *AuthorRepositoryTestSuite) TestFindBySlugs() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.Create(ctx, &catalog.Author{ID: "some-id1", Name: "Eric Evans", Slug: "eric-evans"}))
+	require.Nil(s.T(), s.repo.Create(ctx, &catalog.Author{ID: "some-id2", Name: "Martin Fowler", Slug: "martin-fowler"}))
+
+	actual, err := s.repo.FindBySlugs(ctx, []string{"martin-fowler", "unknown-slug"})
+
+	assert.Nil(s.T(), err)
+	require.Len(s.T(), actual, 1)
+	assert.Equal(s.T(), "some-id2", actual[0].ID)
+}
+
+func (s *AuthorRepositoryTestSuite) TestUpdate_Successfully() {
+	ctx := context.TODO()
+
+	author := catalog.Author{ID: "some-id", Name: "Eric Evans", Slug: "eric-evans"}
+	require.Nil(s.T(), s.repo.Create(ctx, &author))
+
+	photoID := "photo-id"
+	author.Bio = "new bio"
+	author.PhotoID = &photoID
+	require.Nil(s.T(), s.repo.Update(ctx, &author))
+
+	actual, err := s.repo.FindByID(ctx, author.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), "new bio", actual.Bio)
+	assert.Equal(s.T(), &photoID, actual.PhotoID)
+}
+
+func (s *AuthorRepositoryTestSuite) TestDelete_WithInvalidID() {
+	err := s.repo.Delete(context.TODO(), "some-id")
+
+	var errEntityNotFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &errEntityNotFound))
+}
@@ -9,8 +9,24 @@ import (
 	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/core/query"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// bookColumns maps the query fields that are not columns of the books table to SQL expressions.
+var bookColumns = map[string]string{
+	"category_id":           "id IN (SELECT book_id FROM books_categories WHERE category_id %s ?)",
+	"author_id":             "id IN (SELECT book_id FROM books_authors WHERE author_id %s ?)",
+	"author_name":           "id IN (SELECT ba.book_id FROM books_authors ba INNER JOIN authors a ON a.id = ba.author_id WHERE a.name %s ?)",
+	"accessibility_feature": "id IN (SELECT book_id FROM books_accessibility_features WHERE feature %s ?)",
+	"archived":              "(archived_at IS NOT NULL) %s ?",
+}
+
+// bookUniqueKeys maps the unique constraints of the books table to the key reported as duplicated.
+var bookUniqueKeys = map[string]string{
+	"books_isbn_unique":        "isbn",
+	"books_external_id_unique": "external_id",
+}
+
 type BookRepository struct {
 	db *gorm.DB
 }
@@ -25,10 +41,14 @@ func (r *BookRepository) FindByQuery(ctx context.Context, query query.Query, pag
 
 	db := r.db.WithContext(ctx)
 
-	conditions, values := parseQuery(query)
+	conditions, values := parseQueryWithColumns(query, bookColumns)
+
+	if page.Keyset {
+		return r.findByKeyset(db, conditions, values, page)
+	}
 
 	paginated := catalog.PaginatedBooks{}
-	result := db.WithContext(ctx).Preload("Images").
+	result := preloadBookAssociations(db).
 		Limit(page.Size).
 		Offset(page.Offset()).
 		Where(conditions, values...).Find(&paginated.Books)
@@ -36,8 +56,8 @@ func (r *BookRepository) FindByQuery(ctx context.Context, query query.Query, pag
 		return catalog.PaginatedBooks{}, fmt.Errorf("(FindByQuery) failed running select query: %w", err)
 	}
 
-	var count int64
-	if err := db.WithContext(ctx).Model(&catalog.Book{}).Where(conditions, values...).Count(&count).Error; err != nil {
+	count, err := r.count(db, conditions, values)
+	if err != nil {
 		return catalog.PaginatedBooks{}, fmt.Errorf("(FindByQuery) failed running count query: %w", err)
 	}
 
@@ -47,12 +67,61 @@ func (r *BookRepository) FindByQuery(ctx context.Context, query query.Query, pag
 	return paginated, nil
 }
 
+func (r *BookRepository) findByKeyset(db *gorm.DB, conditions string, values []interface{}, page query.Page) (catalog.PaginatedBooks, error) {
+	statement, cursor, err := paginateByKeyset(preloadBookAssociations(db).Where(conditions, values...), page)
+	if err != nil {
+		return catalog.PaginatedBooks{}, fmt.Errorf("(findByKeyset) failed paginating query: %w", err)
+	}
+
+	var books []catalog.Book
+	if err = statement.Find(&books).Error; err != nil {
+		return catalog.PaginatedBooks{}, fmt.Errorf("(findByKeyset) failed running select query: %w", err)
+	}
+
+	hasMore := len(books) > page.Size
+	if hasMore {
+		books = books[:page.Size]
+	}
+
+	if cursor.Backward {
+		for i, j := 0, len(books)-1; i < j; i, j = i+1, j-1 {
+			books[i], books[j] = books[j], books[i]
+		}
+	}
+
+	paginated := catalog.PaginatedBooks{Books: books, Limit: page.Size, Keyset: true}
+	if len(books) > 0 {
+		first, last := books[0], books[len(books)-1]
+		paginated.NextCursor, paginated.PrevCursor = keysetCursors(
+			cursor,
+			hasMore,
+			query.NewCursor(first.CreatedAt, first.ID, true),
+			query.NewCursor(last.CreatedAt, last.ID, false),
+		)
+	}
+
+	if page.Counted() {
+		paginated.IncludeTotal = true
+		if paginated.TotalBooks, err = r.count(db, conditions, values); err != nil {
+			return catalog.PaginatedBooks{}, fmt.Errorf("(findByKeyset) failed running count query: %w", err)
+		}
+	}
+
+	return paginated, nil
+}
+
+func (r *BookRepository) count(db *gorm.DB, conditions string, values []interface{}) (int64, error) {
+	var count int64
+	err := db.Model(&catalog.Book{}).Where(conditions, values...).Count(&count).Error
+	return count, err
+}
+
 func (r *BookRepository) FindByID(ctx context.Context, id string) (catalog.Book, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
 	book := catalog.Book{}
-	result := r.db.WithContext(ctx).Preload("Images").First(&book, "id = ?", id)
+	result := preloadBookAssociations(r.db.WithContext(ctx)).First(&book, "id = ?", id)
 	if err := result.Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			err = &ErrEntityNotFound{entity: "book"}
@@ -68,8 +137,31 @@ func (r *BookRepository) Create(ctx context.Context, book *catalog.Book) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	result := r.db.WithContext(ctx).Create(book)
-	if err := result.Error; err != nil {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Categories and authors are managed by their own repositories, so only the links are created here
+		if err := tx.Omit("Categories.*", "Authors", "AccessibilityFeatures", "Images", "Files", "Sample").Create(book).Error; err != nil {
+			return err
+		}
+
+		if err := saveBookImages(tx, book.ID, book.Images); err != nil {
+			return err
+		}
+
+		if err := createBookAuthors(tx, book); err != nil {
+			return err
+		}
+
+		if err := createBookFiles(tx, book); err != nil {
+			return err
+		}
+
+		return createAccessibilityFeatures(tx, book)
+	})
+	if err != nil {
+		if key, ok := bookUniqueKeys[violatedConstraint(err)]; ok {
+			return &ErrDuplicateKey{key: key}
+		}
+
 		return fmt.Errorf("(Create) failed running insert statement: %w", err)
 	}
 
@@ -80,12 +172,41 @@ func (r *BookRepository) Update(ctx context.Context, book *catalog.Book) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	if err := r.db.Model(&book).Association("Images").Replace(book.Images); err != nil {
-		return fmt.Errorf("(Update) failed running update statement: %w", err)
-	}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(book).Omit("Categories.*").Association("Categories").Replace(book.Categories); err != nil {
+			return err
+		}
+
+		if err := tx.Where("book_id = ?", book.ID).Delete(&catalog.BookAuthor{}).Error; err != nil {
+			return err
+		}
+
+		if err := createBookAuthors(tx, book); err != nil {
+			return err
+		}
+
+		if err := tx.Where("book_id = ?", book.ID).Delete(&catalog.AccessibilityFeature{}).Error; err != nil {
+			return err
+		}
+
+		if err := createAccessibilityFeatures(tx, book); err != nil {
+			return err
+		}
+
+		if err := saveBookImages(tx, book.ID, book.Images); err != nil {
+			return err
+		}
+
+		// The rating is maintained by the reviews, the files and samples by their own methods and the publication status and
+		// archiving by UpdateStatus, PublishScheduled and Archive, so they are never overwritten here with stale values
+		return tx.Omit("Categories.*", "Authors", "AccessibilityFeatures", "Images", "Files", "Sample", "RatingAverage", "RatingCount",
+			"Status", "PublishAt", "ArchivedAt").Save(book).Error
+	})
+	if err != nil {
+		if key, ok := bookUniqueKeys[violatedConstraint(err)]; ok {
+			return &ErrDuplicateKey{key: key}
+		}
 
-	result := r.db.WithContext(ctx).Save(book)
-	if err := result.Error; err != nil {
 		return fmt.Errorf("(Update) failed running update statement: %w", err)
 	}
 
@@ -107,3 +228,237 @@ func (r *BookRepository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (r *BookRepository) Archive(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&catalog.Book{}).
+		Where("id = ?", id).
+		UpdateColumn("archived_at", gorm.Expr("COALESCE(archived_at, ?)", time.Now()))
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Archive) failed running update statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(Archive) no rows affected: %w", &ErrEntityNotFound{entity: "book"})
+	}
+
+	return nil
+}
+
+func (r *BookRepository) Restore(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&catalog.Book{}).
+		Where("id = ?", id).
+		UpdateColumn("archived_at", nil)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Restore) failed running update statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(Restore) no rows affected: %w", &ErrEntityNotFound{entity: "book"})
+	}
+
+	return nil
+}
+
+func (r *BookRepository) UpdateStatus(ctx context.Context, id string, status catalog.PublicationStatus, publishAt *time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&catalog.Book{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{"status": status, "publish_at": publishAt})
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(UpdateStatus) failed running update statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(UpdateStatus) no rows affected: %w", &ErrEntityNotFound{entity: "book"})
+	}
+
+	return nil
+}
+
+func (r *BookRepository) PublishScheduled(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&catalog.Book{}).
+		Where("status = ? AND publish_at <= ?", catalog.StatusScheduled, now).
+		UpdateColumn("status", catalog.StatusPublished)
+	if err := result.Error; err != nil {
+		return 0, fmt.Errorf("(PublishScheduled) failed running update statement: %w", err)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *BookRepository) SaveFile(ctx context.Context, file *catalog.BookFile) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "book_id"}, {Name: "format"}},
+			DoUpdates: clause.AssignmentColumns([]string{"content_id", "media_type", "size", "checksum", "updated_at"}),
+		}).
+		Create(file)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(SaveFile) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BookRepository) SaveSample(ctx context.Context, sample *catalog.BookSample) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "book_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"format", "content_id", "media_type", "size", "generated", "updated_at"}),
+		}).
+		Create(sample)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(SaveSample) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BookRepository) SaveImages(ctx context.Context, bookID string, images []catalog.Image) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return saveBookImages(tx, bookID, images)
+	})
+	if err != nil {
+		return fmt.Errorf("(SaveImages) failed saving images: %w", err)
+	}
+
+	return nil
+}
+
+// saveBookImages deletes the images no longer listed and upserts the rest, so their variants are kept. The images
+// of other books are rejected, as the upsert would move them to this book.
+func saveBookImages(tx *gorm.DB, bookID string, images []catalog.Image) error {
+	ids := make([]string, 0, len(images))
+	for _, image := range images {
+		ids = append(ids, image.ID)
+	}
+
+	if len(ids) > 0 {
+		var taken int64
+		if err := tx.Model(&catalog.Image{}).Where("id IN ? AND book_id <> ?", ids, bookID).Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return &ErrDuplicateKey{key: "image id"}
+		}
+	}
+
+	removed := tx.Where("book_id = ?", bookID)
+	if len(ids) > 0 {
+		removed = removed.Where("id NOT IN ?", ids)
+	}
+	if err := removed.Delete(&catalog.Image{}).Error; err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		return nil
+	}
+
+	for i := range images {
+		images[i].BookID = bookID
+	}
+
+	return tx.Omit("Variants", "VariantsGenerated").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"description", "book_id", "position", "is_main"}),
+		}).
+		Create(&images).Error
+}
+
+func (r *BookRepository) DeleteFile(ctx context.Context, bookID string, format catalog.BookFormat) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&catalog.BookFile{}, "book_id = ? AND format = ?", bookID, format)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(DeleteFile) failed running delete statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(DeleteFile) no rows affected: %w", &ErrEntityNotFound{entity: "book file"})
+	}
+
+	return nil
+}
+
+func preloadBookAssociations(db *gorm.DB) *gorm.DB {
+	return db.Preload("Images", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Images.Variants").
+		Preload("Files").
+		Preload("Sample").
+		Preload("Categories").
+		Preload("AccessibilityFeatures").
+		Preload("Authors", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Preload("Authors.Author")
+}
+
+// createBookAuthors links the authors to the book. The linked authors that don't exist yet are created first,
+// so they are saved in the same transaction as the book.
+func createBookAuthors(tx *gorm.DB, book *catalog.Book) error {
+	if len(book.Authors) == 0 {
+		return nil
+	}
+
+	authors := make([]catalog.Author, 0, len(book.Authors))
+	for i := range book.Authors {
+		book.Authors[i].BookID = book.ID
+		if book.Authors[i].Author.ID != "" {
+			authors = append(authors, book.Authors[i].Author)
+		}
+	}
+
+	if len(authors) > 0 {
+		err := tx.Omit("Books").Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "id"}}, DoNothing: true}).Create(&authors).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Omit("Book", "Author").Create(&book.Authors).Error
+}
+
+func createBookFiles(tx *gorm.DB, book *catalog.Book) error {
+	if len(book.Files) == 0 {
+		return nil
+	}
+
+	for i := range book.Files {
+		book.Files[i].BookID = book.ID
+	}
+
+	return tx.Create(&book.Files).Error
+}
+
+func createAccessibilityFeatures(tx *gorm.DB, book *catalog.Book) error {
+	if len(book.AccessibilityFeatures) == 0 {
+		return nil
+	}
+
+	for i := range book.AccessibilityFeatures {
+		book.AccessibilityFeatures[i].BookID = book.ID
+	}
+
+	return tx.Create(&book.AccessibilityFeatures).Error
+}
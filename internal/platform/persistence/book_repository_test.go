@@ -3,6 +3,7 @@ package persistence_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -34,6 +35,17 @@ func TestBookRepositoryRun(t *testing.T) {
 
 func (s *BookRepositoryTestSuite) TearDownTest() {
 	s.db.Delete(&catalog.Book{}, "1 = 1")
+	s.db.Delete(&catalog.Author{}, "1 = 1")
+}
+
+func (s *BookRepositoryTestSuite) createAuthors() (catalog.Author, catalog.Author) {
+	robert := catalog.Author{ID: "author-id1", Name: "Robert C. Martin", Slug: "robert-c-martin"}
+	eric := catalog.Author{ID: "author-id2", Name: "Eric Evans", Slug: "eric-evans"}
+
+	require.Nil(s.T(), s.db.Create(&robert).Error)
+	require.Nil(s.T(), s.db.Create(&eric).Error)
+
+	return robert, eric
 }
 
 func (s *BookRepositoryTestSuite) TestFindByQuery_WithEmptyQuery() {
@@ -42,14 +54,12 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithEmptyQuery() {
 	book1 := catalog.Book{
 		ID:          "some-id1",
 		Title:       "Clean Code",
-		AuthorName:  "Robert c. Martin",
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
-		ID:         "some-id2",
-		Title:      "Clean Coder",
-		AuthorName: "Robert c. Martin",
+		ID:    "some-id2",
+		Title: "Clean Coder",
 		Images: []catalog.Image{
 			{
 				ID:          "some-id2",
@@ -63,7 +73,6 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithEmptyQuery() {
 	book3 := catalog.Book{
 		ID:          "some-id3",
 		Title:       "DOmain Driver Design",
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -88,14 +97,12 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithTitle() {
 	book1 := catalog.Book{
 		ID:          "some-id1",
 		Title:       "Clean Code",
-		AuthorName:  "Robert c. Martin",
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
 		ID:          "some-id2",
 		Title:       "Clean Coder",
-		AuthorName:  "Robert c. Martin",
 		Price:       7000,
 		ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -109,7 +116,6 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithTitle() {
 				BookID:      "some-id3",
 			},
 		},
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -144,21 +150,18 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithDescription() {
 				BookID:      "some-id1",
 			},
 		},
-		AuthorName:  "Robert c. Martin",
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
 		ID:          "some-id2",
 		Title:       "Clean Coder",
-		AuthorName:  "Robert c. Martin",
 		Price:       7000,
 		ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.UTC),
 	}
 	book3 := catalog.Book{
 		ID:          "some-id3",
 		Title:       "Domain Driver Design",
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -181,6 +184,7 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithDescription() {
 
 func (s *BookRepositoryTestSuite) TestFindByQuery_WithAuthorName() {
 	ctx := context.TODO()
+	robert, eric := s.createAuthors()
 
 	book1 := catalog.Book{
 		ID:    "some-id1",
@@ -193,21 +197,21 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithAuthorName() {
 			},
 		},
 		Description: "Craftsman",
-		AuthorName:  "Robert c. Martin",
+		Authors:     []catalog.BookAuthor{{AuthorID: robert.ID, Role: catalog.RoleAuthor}},
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
 		ID:          "some-id2",
 		Title:       "Clean Coder",
-		AuthorName:  "Robert c. Martin",
+		Authors:     []catalog.BookAuthor{{AuthorID: robert.ID, Role: catalog.RoleAuthor}},
 		Price:       7000,
 		ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.UTC),
 	}
 	book3 := catalog.Book{
 		ID:          "some-id3",
 		Title:       "Domain Driver Design",
-		AuthorName:  "Eric Evans",
+		Authors:     []catalog.BookAuthor{{AuthorID: eric.ID, Role: catalog.RoleAuthor}},
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -226,6 +230,13 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithAuthorName() {
 	assert.Equal(s.T(), 1, len(paginatedBooks.Books))
 	assert.Equal(s.T(), int64(1), paginatedBooks.TotalBooks)
 	assert.Equal(s.T(), book3.ID, paginatedBooks.Books[0].ID)
+	assert.Equal(s.T(), []string{"Eric Evans"}, paginatedBooks.Books[0].AuthorNames(catalog.RoleAuthor))
+
+	q = *query.New().And(query.Condition{Field: "author_id", Operator: query.Equal, Value: robert.ID})
+	paginatedBooks, err = s.repo.FindByQuery(ctx, q, query.DefaultPage)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(2), paginatedBooks.TotalBooks)
 }
 
 func (s *BookRepositoryTestSuite) TestFindByQuery_WithPageSize() {
@@ -235,21 +246,18 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithPageSize() {
 		ID:          "some-id1",
 		Title:       "Clean Code",
 		Description: "Craftsman",
-		AuthorName:  "Robert c. Martin",
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
 		ID:          "some-id2",
 		Title:       "Clean Coder",
-		AuthorName:  "Robert c. Martin",
 		Price:       7000,
 		ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.UTC),
 	}
 	book3 := catalog.Book{
 		ID:          "some-id3",
 		Title:       "Domain Driver Design",
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -281,21 +289,18 @@ func (s *BookRepositoryTestSuite) TestFindByQuery_WithPageNumber() {
 		ID:          "some-id1",
 		Title:       "Clean Code",
 		Description: "Craftsman",
-		AuthorName:  "Robert c. Martin",
 		Price:       5500,
 		ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
 	}
 	book2 := catalog.Book{
 		ID:          "some-id2",
 		Title:       "Clean Coder",
-		AuthorName:  "Robert c. Martin",
 		Price:       7000,
 		ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.UTC),
 	}
 	book3 := catalog.Book{
 		ID:          "some-id3",
 		Title:       "Domain Driver Design",
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.UTC),
 	}
@@ -342,7 +347,6 @@ func (s *BookRepositoryTestSuite) TestFindByID_WithValidID() {
 				BookID:      "some-id",
 			},
 		},
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
 	}
@@ -372,7 +376,6 @@ func (s *BookRepositoryTestSuite) TestCreate_Successfully() {
 				BookID:      "some-id",
 			},
 		},
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
 	}
@@ -386,13 +389,185 @@ func (s *BookRepositoryTestSuite) TestCreate_Successfully() {
 	assert.Equal(s.T(), book.ID, persisted.ID)
 }
 
+func (s *BookRepositoryTestSuite) TestSaveFile_ReplacesFormat() {
+	ctx := context.TODO()
+
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Files:       []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", MediaType: "application/pdf", Size: 100}},
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.repo.Create(ctx, &book))
+
+	require.Nil(s.T(), s.repo.SaveFile(ctx, &catalog.BookFile{BookID: book.ID, Format: catalog.FormatEPUB, ContentID: "epub-key", MediaType: "application/epub+zip", Size: 200}))
+	require.Nil(s.T(), s.repo.SaveFile(ctx, &catalog.BookFile{BookID: book.ID, Format: catalog.FormatPDF, ContentID: "new-pdf-key", MediaType: "application/pdf", Size: 300}))
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+
+	file, ok := persisted.File(catalog.FormatPDF)
+	require.True(s.T(), ok)
+	assert.Equal(s.T(), "new-pdf-key", file.ContentID)
+	assert.Equal(s.T(), int64(300), file.Size)
+	assert.Len(s.T(), persisted.Files, 2)
+
+	require.Nil(s.T(), s.repo.DeleteFile(ctx, book.ID, catalog.FormatEPUB))
+	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(s.repo.DeleteFile(ctx, book.ID, catalog.FormatEPUB)))
+}
+
+func (s *BookRepositoryTestSuite) TestSaveSample_ReplacesSample() {
+	ctx := context.TODO()
+
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Files:       []catalog.BookFile{{Format: catalog.FormatPDF, ContentID: "pdf-key", MediaType: "application/pdf", Size: 100}},
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.repo.Create(ctx, &book))
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+	assert.Nil(s.T(), persisted.Sample)
+
+	require.Nil(s.T(), s.repo.SaveSample(ctx, &catalog.BookSample{BookID: book.ID, Format: catalog.FormatPDF, ContentID: "sample-key", MediaType: "application/pdf", Size: 10, Generated: true}))
+	require.Nil(s.T(), s.repo.SaveSample(ctx, &catalog.BookSample{BookID: book.ID, Format: catalog.FormatEPUB, ContentID: "new-sample-key", MediaType: "application/epub+zip", Size: 20}))
+
+	persisted, err = s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+	require.NotNil(s.T(), persisted.Sample)
+	assert.Equal(s.T(), catalog.FormatEPUB, persisted.Sample.Format)
+	assert.Equal(s.T(), "new-sample-key", persisted.Sample.ContentID)
+	assert.False(s.T(), persisted.Sample.Generated)
+}
+
+func (s *BookRepositoryTestSuite) TestArchive_HidesBookFromQueries() {
+	ctx := context.TODO()
+
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.repo.Create(ctx, &book))
+	require.Nil(s.T(), s.repo.Archive(ctx, book.ID))
+
+	onSale := *query.New().And(query.Condition{Field: "archived", Operator: query.Equal, Value: false})
+	paginated, err := s.repo.FindByQuery(ctx, onSale, query.DefaultPage)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), paginated.Books)
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+	assert.NotNil(s.T(), persisted.ArchivedAt)
+
+	require.Nil(s.T(), s.repo.Restore(ctx, book.ID))
+
+	paginated, err = s.repo.FindByQuery(ctx, onSale, query.DefaultPage)
+	require.Nil(s.T(), err)
+	assert.Len(s.T(), paginated.Books, 1)
+}
+
+func (s *BookRepositoryTestSuite) TestArchive_NotFound() {
+	err := s.repo.Archive(context.TODO(), "unknown-id")
+
+	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(err))
+}
+
+func (s *BookRepositoryTestSuite) TestPublishScheduled_PublishesDueBooks() {
+	ctx := context.TODO()
+
+	due := catalog.Book{ID: "some-id1", Title: "Clean Code", Price: 5500, ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.Local)}
+	later := catalog.Book{ID: "some-id2", Title: "Clean Coder", Price: 7000, ReleaseDate: time.Date(2017, time.February, 12, 0, 0, 0, 0, time.Local)}
+	require.Nil(s.T(), s.repo.Create(ctx, &due))
+	require.Nil(s.T(), s.repo.Create(ctx, &later))
+	assert.Equal(s.T(), catalog.StatusDraft, due.Status)
+
+	now := time.Now()
+	dueAt, laterAt := now.Add(-time.Minute), now.Add(time.Hour)
+	require.Nil(s.T(), s.repo.UpdateStatus(ctx, due.ID, catalog.StatusScheduled, &dueAt))
+	require.Nil(s.T(), s.repo.UpdateStatus(ctx, later.ID, catalog.StatusScheduled, &laterAt))
+
+	published, err := s.repo.PublishScheduled(ctx, now)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(1), published)
+
+	listed := *query.New().And(query.Condition{Field: "status", Operator: query.Equal, Value: catalog.StatusPublished})
+	paginated, err := s.repo.FindByQuery(ctx, listed, query.DefaultPage)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), paginated.Books, 1)
+	assert.Equal(s.T(), due.ID, paginated.Books[0].ID)
+	assert.NotNil(s.T(), paginated.Books[0].PublishAt)
+}
+
+func (s *BookRepositoryTestSuite) TestUpdateStatus_NotFound() {
+	err := s.repo.UpdateStatus(context.TODO(), "unknown-id", catalog.StatusPublished, nil)
+
+	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(err))
+}
+
+func (s *BookRepositoryTestSuite) TestSaveImages_KeepsOrderAndMainImage() {
+	ctx := context.TODO()
+
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "Domain Driver Design",
+		Images: []catalog.Image{
+			{ID: "image-id1", Description: "cover", BookID: "some-id", Position: 0, IsMain: true},
+			{ID: "image-id2", Description: "back", BookID: "some-id", Position: 1},
+		},
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.repo.Create(ctx, &book))
+
+	images := []catalog.Image{
+		{ID: "image-id3", Description: "spine", Position: 0},
+		{ID: "image-id2", Description: "back", Position: 1, IsMain: true},
+	}
+	require.Nil(s.T(), s.repo.SaveImages(ctx, book.ID, images))
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), persisted.Images, 2)
+	assert.Equal(s.T(), "image-id3", persisted.Images[0].ID)
+	assert.Equal(s.T(), "image-id2", persisted.Images[1].ID)
+	assert.Equal(s.T(), "image-id2", persisted.MainImageID())
+}
+
+func (s *BookRepositoryTestSuite) TestSaveImages_WithImageOfAnotherBook() {
+	ctx := context.TODO()
+
+	book1 := catalog.Book{ID: "some-id1", Title: "Domain Driver Design", Images: []catalog.Image{{ID: "image-id1"}}, Price: 8000}
+	book2 := catalog.Book{ID: "some-id2", Title: "Clean Code", Price: 4000}
+	require.Nil(s.T(), s.repo.Create(ctx, &book1))
+	require.Nil(s.T(), s.repo.Create(ctx, &book2))
+
+	err := s.repo.SaveImages(ctx, book2.ID, []catalog.Image{{ID: "image-id1"}})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+
+	book3 := catalog.Book{ID: "some-id3", Title: "Refactoring", Images: []catalog.Image{{ID: "image-id1"}}, Price: 5000}
+	err = s.repo.Create(ctx, &book3)
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+
+	persisted, err := s.repo.FindByID(ctx, book1.ID)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), persisted.Images, 1)
+	assert.Equal(s.T(), "image-id1", persisted.Images[0].ID)
+}
+
 func (s *BookRepositoryTestSuite) TestUpdate_Successfully() {
 	ctx := context.TODO()
 
 	book := catalog.Book{
-		ID:         "some-id",
-		Title:      "Domain Driver Design",
-		AuthorName: "Eric Evans",
+		ID:    "some-id",
+		Title: "Domain Driver Design",
 		Images: []catalog.Image{
 			{
 				ID:          "some-id2",
@@ -420,6 +595,166 @@ func (s *BookRepositoryTestSuite) TestUpdate_Successfully() {
 	assert.Empty(s.T(), book.Images)
 }
 
+func (s *BookRepositoryTestSuite) TestUpdate_KeepsStatusAndArchiving() {
+	ctx := context.TODO()
+
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.repo.Create(ctx, &book))
+
+	require.Nil(s.T(), s.repo.UpdateStatus(ctx, book.ID, catalog.StatusPublished, nil))
+	require.Nil(s.T(), s.repo.Archive(ctx, book.ID))
+
+	book.Title = "new title"
+	require.Nil(s.T(), s.repo.Update(ctx, &book))
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), "new title", persisted.Title)
+	assert.Equal(s.T(), catalog.StatusPublished, persisted.Status)
+	assert.NotNil(s.T(), persisted.ArchivedAt)
+}
+
+func (s *BookRepositoryTestSuite) TestUpdate_ReplacesAuthors() {
+	ctx := context.TODO()
+	robert, eric := s.createAuthors()
+
+	book := catalog.Book{
+		ID:      "some-id",
+		Title:   "Domain Driver Design",
+		Authors: []catalog.BookAuthor{{AuthorID: robert.ID, Role: catalog.RoleAuthor}},
+		Price:   8000,
+	}
+
+	err := s.repo.Create(ctx, &book)
+	require.Nil(s.T(), err)
+
+	book.Authors = []catalog.BookAuthor{
+		{BookID: book.ID, AuthorID: eric.ID, Role: catalog.RoleAuthor, Position: 0},
+		{BookID: book.ID, AuthorID: robert.ID, Role: catalog.RoleTranslator, Position: 1},
+	}
+	err = s.repo.Update(ctx, &book)
+	require.Nil(s.T(), err)
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	assert.Nil(s.T(), err)
+
+	require.Len(s.T(), persisted.Authors, 2)
+	assert.Equal(s.T(), eric.Name, persisted.Authors[0].Author.Name)
+	assert.Equal(s.T(), catalog.RoleTranslator, persisted.Authors[1].Role)
+}
+
+func (s *BookRepositoryTestSuite) TestCreate_WithNewAuthors() {
+	ctx := context.TODO()
+	robert, _ := s.createAuthors()
+	jane := catalog.Author{ID: "jane-id", Name: "Jane Doe", Slug: "jane-doe"}
+
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "Domain Driver Design",
+		Authors: []catalog.BookAuthor{
+			{AuthorID: robert.ID, Role: catalog.RoleAuthor, Author: robert},
+			{AuthorID: jane.ID, Role: catalog.RoleTranslator, Position: 1, Author: jane},
+		},
+		Price: 8000,
+	}
+
+	err := s.repo.Create(ctx, &book)
+	require.Nil(s.T(), err)
+
+	persisted, err := s.repo.FindByID(ctx, book.ID)
+	assert.Nil(s.T(), err)
+
+	require.Len(s.T(), persisted.Authors, 2)
+	assert.Equal(s.T(), robert.Name, persisted.Authors[0].Author.Name)
+	assert.Equal(s.T(), jane.Name, persisted.Authors[1].Author.Name)
+}
+
+func (s *BookRepositoryTestSuite) TestCreate_WithDuplicateISBN() {
+	ctx := context.TODO()
+	isbn := "9780321125217"
+
+	book1 := catalog.Book{ID: "some-id1", Title: "Domain Driver Design", ISBN: &isbn, Price: 8000}
+	book2 := catalog.Book{ID: "some-id2", Title: "Domain Driver Design", ISBN: &isbn, Price: 8000}
+
+	err := s.repo.Create(ctx, &book1)
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &book2)
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+	assert.EqualError(s.T(), err, "the provided isbn is already being used")
+}
+
+func (s *BookRepositoryTestSuite) TestCreate_WithDuplicateExternalID() {
+	ctx := context.TODO()
+	externalID := "vendor-123"
+
+	book1 := catalog.Book{ID: "some-id1", Title: "Domain Driver Design", ExternalID: &externalID, Price: 8000}
+	book2 := catalog.Book{ID: "some-id2", Title: "Domain Driver Design", ExternalID: &externalID, Price: 8000}
+
+	err := s.repo.Create(ctx, &book1)
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &book2)
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+	assert.EqualError(s.T(), err, "the provided external_id is already being used")
+}
+
+func (s *BookRepositoryTestSuite) TestFindByQuery_WithBibliographicMetadata() {
+	ctx := context.TODO()
+	isbn := "9780321125217"
+
+	book1 := catalog.Book{
+		ID:        "some-id1",
+		Title:     "Domain Driver Design",
+		ISBN:      &isbn,
+		Publisher: "Addison-Wesley",
+		Language:  "en",
+		PageCount: 560,
+		AccessibilityFeatures: []catalog.AccessibilityFeature{
+			{Feature: "tableOfContents"},
+			{Feature: "index"},
+		},
+		Price: 8000,
+	}
+	book2 := catalog.Book{
+		ID:        "some-id2",
+		Title:     "Clean Code",
+		Publisher: "Prentice Hall",
+		Language:  "en",
+		PageCount: 464,
+		Price:     5500,
+	}
+
+	require.Nil(s.T(), s.repo.Create(ctx, &book1))
+	require.Nil(s.T(), s.repo.Create(ctx, &book2))
+
+	q := *query.New().And(query.Condition{Field: "accessibility_feature", Operator: query.Equal, Value: "index"}).
+		And(query.Condition{Field: "page_count", Operator: query.GreaterOrEqual, Value: 500})
+	paginatedBooks, err := s.repo.FindByQuery(ctx, q, query.DefaultPage)
+
+	assert.Nil(s.T(), err)
+	require.Len(s.T(), paginatedBooks.Books, 1)
+	assert.Equal(s.T(), book1.ID, paginatedBooks.Books[0].ID)
+	assert.Equal(s.T(), &isbn, paginatedBooks.Books[0].ISBN)
+	assert.ElementsMatch(s.T(), []string{"tableOfContents", "index"}, paginatedBooks.Books[0].Features())
+
+	q = *query.New().And(query.Condition{Field: "publisher", Operator: query.Match, Value: "prentice"})
+	paginatedBooks, err = s.repo.FindByQuery(ctx, q, query.DefaultPage)
+
+	assert.Nil(s.T(), err)
+	require.Len(s.T(), paginatedBooks.Books, 1)
+	assert.Equal(s.T(), book2.ID, paginatedBooks.Books[0].ID)
+}
+
 func (s *BookRepositoryTestSuite) TestDelete_WithInvalidID() {
 	ctx := context.TODO()
 
@@ -436,7 +771,6 @@ func (s *BookRepositoryTestSuite) TestDelete_WithValidID() {
 	book := catalog.Book{
 		ID:          "some-id",
 		Title:       "Domain Driver Design",
-		AuthorName:  "Eric Evans",
 		Price:       8000,
 		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
 	}
@@ -448,3 +782,60 @@ func (s *BookRepositoryTestSuite) TestDelete_WithValidID() {
 
 	assert.Nil(s.T(), err)
 }
+
+func (s *BookRepositoryTestSuite) TestFindByQuery_WithKeyset() {
+	ctx := context.TODO()
+
+	for i := 1; i <= 3; i++ {
+		book := catalog.Book{
+			ID:          fmt.Sprintf("some-id%d", i),
+			Title:       fmt.Sprintf("Book %d", i),
+			Price:       5500,
+			ReleaseDate: time.Date(2017, time.January, 20, 0, 0, 0, 0, time.UTC),
+			CreatedAt:   time.Date(2022, time.January, i, 0, 0, 0, 0, time.UTC),
+		}
+		require.Nil(s.T(), s.repo.Create(ctx, &book))
+	}
+
+	page := query.Page{Size: 2, Keyset: true}
+	firstPage, err := s.repo.FindByQuery(ctx, *query.New(), page)
+
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"some-id3", "some-id2"}, bookIDs(firstPage.Books))
+	assert.Equal(s.T(), int64(0), firstPage.TotalBooks)
+	assert.False(s.T(), firstPage.IncludeTotal)
+	assert.NotEmpty(s.T(), firstPage.NextCursor)
+	assert.Empty(s.T(), firstPage.PrevCursor)
+
+	page.Cursor = firstPage.NextCursor
+	page.IncludeTotal = true
+	secondPage, err := s.repo.FindByQuery(ctx, *query.New(), page)
+
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"some-id1"}, bookIDs(secondPage.Books))
+	assert.Equal(s.T(), int64(3), secondPage.TotalBooks)
+	assert.True(s.T(), secondPage.IncludeTotal)
+	assert.Empty(s.T(), secondPage.NextCursor)
+	assert.NotEmpty(s.T(), secondPage.PrevCursor)
+
+	page.Cursor = secondPage.PrevCursor
+	previousPage, err := s.repo.FindByQuery(ctx, *query.New(), page)
+
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []string{"some-id3", "some-id2"}, bookIDs(previousPage.Books))
+	assert.Empty(s.T(), previousPage.PrevCursor)
+}
+
+func (s *BookRepositoryTestSuite) TestFindByQuery_WithInvalidCursor() {
+	_, err := s.repo.FindByQuery(context.TODO(), *query.New(), query.Page{Size: 2, Keyset: true, Cursor: "invalid"})
+
+	assert.ErrorIs(s.T(), err, query.ErrInvalidCursor)
+}
+
+func bookIDs(books []catalog.Book) []string {
+	ids := make([]string, 0, len(books))
+	for _, b := range books {
+		ids = append(ids, b.ID)
+	}
+	return ids
+}
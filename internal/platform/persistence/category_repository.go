@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]catalog.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var categories []catalog.Category
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("(FindAll) failed running select query: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id string) (catalog.Category, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *CategoryRepository) FindBySlug(ctx context.Context, slug string) (catalog.Category, error) {
+	return r.findOne(ctx, "slug = ?", slug)
+}
+
+func (r *CategoryRepository) findOne(ctx context.Context, condition string, value string) (catalog.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	category := catalog.Category{}
+	result := r.db.WithContext(ctx).First(&category, condition, value)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = &ErrEntityNotFound{entity: "category"}
+		}
+
+		return catalog.Category{}, fmt.Errorf("(findOne) failed running select query: %w", err)
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) FindByIDs(ctx context.Context, ids []string) ([]catalog.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var categories []catalog.Category
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("(FindByIDs) failed running select query: %w", err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindSubtreeIDs(ctx context.Context, id string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var ids []string
+	result := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = ?
+			UNION
+			SELECT c.id FROM categories c INNER JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT id FROM subtree`, id).Scan(&ids)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindSubtreeIDs) failed running select query: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *CategoryRepository) CountBooks(ctx context.Context) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var rows []struct {
+		CategoryID string
+		BookCount  int64
+	}
+
+	// Every category is paired with itself and all its descendants, so books are counted for the whole subtree
+	result := r.db.WithContext(ctx).Raw(`
+		WITH RECURSIVE subtree AS (
+			SELECT id AS root_id, id FROM categories
+			UNION
+			SELECT s.root_id, c.id FROM categories c INNER JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT s.root_id AS category_id, COUNT(DISTINCT bc.book_id) AS book_count
+		FROM subtree s INNER JOIN books_categories bc ON bc.category_id = s.id
+		GROUP BY s.root_id`).Scan(&rows)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(CountBooks) failed running select query: %w", err)
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.BookCount
+	}
+
+	return counts, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *catalog.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(category)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "slug"}
+		}
+
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *catalog.Category) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Save(category)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "slug"}
+		}
+
+		return fmt.Errorf("(Update) failed running update statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&catalog.Category{}, "id = ?", id)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(Delete) no rows affected: %w", &ErrEntityNotFound{entity: "category"})
+	}
+
+	return nil
+}
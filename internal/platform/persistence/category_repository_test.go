@@ -0,0 +1,122 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CategoryRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.CategoryRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *CategoryRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewCategoryRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestCategoryRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(CategoryRepositoryTestSuite))
+}
+
+func (s *CategoryRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+	s.db.Delete(&catalog.Category{}, "1 = 1")
+}
+
+func (s *CategoryRepositoryTestSuite) createTree() (catalog.Category, catalog.Category, catalog.Category) {
+	ctx := context.TODO()
+
+	fiction := catalog.Category{ID: "fiction-id", Name: "Fiction", Slug: "fiction"}
+	fantasy := catalog.Category{ID: "fantasy-id", Name: "Fantasy", Slug: "fantasy", ParentID: &fiction.ID}
+	epic := catalog.Category{ID: "epic-id", Name: "Epic Fantasy", Slug: "epic-fantasy", ParentID: &fantasy.ID}
+
+	require.Nil(s.T(), s.repo.Create(ctx, &fiction))
+	require.Nil(s.T(), s.repo.Create(ctx, &fantasy))
+	require.Nil(s.T(), s.repo.Create(ctx, &epic))
+
+	return fiction, fantasy, epic
+}
+
+func (s *CategoryRepositoryTestSuite) TestCreate_WithDuplicateSlug() {
+	ctx := context.TODO()
+
+	err := s.repo.Create(ctx, &catalog.Category{ID: "some-id", Name: "Fiction", Slug: "fiction"})
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &catalog.Category{ID: "other-id", Name: "Fiction", Slug: "fiction"})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+}
+
+func (s *CategoryRepositoryTestSuite) TestFindBySlug() {
+	_, fantasy, _ := s.createTree()
+
+	actual, err := s.repo.FindBySlug(context.TODO(), "fantasy")
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), fantasy.ID, actual.ID)
+	assert.Equal(s.T(), fantasy.ParentID, actual.ParentID)
+}
+
+func (s *CategoryRepositoryTestSuite) TestFindByID_WhenCategoryIsNotFound() {
+	_, err := s.repo.FindByID(context.TODO(), "unknown-id")
+
+	var errEntityNotFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &errEntityNotFound))
+}
+
+func (s *CategoryRepositoryTestSuite) TestFindSubtreeIDs() {
+	fiction, fantasy, epic := s.createTree()
+
+	actual, err := s.repo.FindSubtreeIDs(context.TODO(), fiction.ID)
+
+	assert.Nil(s.T(), err)
+	assert.ElementsMatch(s.T(), []string{fiction.ID, fantasy.ID, epic.ID}, actual)
+}
+
+func (s *CategoryRepositoryTestSuite) TestCountBooks() {
+	fiction, fantasy, epic := s.createTree()
+	ctx := context.TODO()
+
+	book1 := catalog.Book{ID: "book-1", Title: "The Hobbit", Price: 1000}
+	book2 := catalog.Book{ID: "book-2", Title: "Dune", Price: 1000}
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &book1))
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &book2))
+
+	book1.Categories = []catalog.Category{fantasy, epic}
+	book2.Categories = []catalog.Category{fiction}
+	require.Nil(s.T(), s.bookRepo.Update(ctx, &book1))
+	require.Nil(s.T(), s.bookRepo.Update(ctx, &book2))
+
+	actual, err := s.repo.CountBooks(ctx)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), map[string]int64{fiction.ID: 2, fantasy.ID: 1, epic.ID: 1}, actual)
+}
+
+func (s *CategoryRepositoryTestSuite) TestDelete_DetachesChildren() {
+	fiction, fantasy, _ := s.createTree()
+	ctx := context.TODO()
+
+	err := s.repo.Delete(ctx, fiction.ID)
+	require.Nil(s.T(), err)
+
+	actual, err := s.repo.FindByID(ctx, fantasy.ID)
+	assert.Nil(s.T(), err)
+	assert.Nil(s.T(), actual.ParentID)
+}
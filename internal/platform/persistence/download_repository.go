@@ -0,0 +1,30 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/shop"
+	"gorm.io/gorm"
+)
+
+type DownloadRepository struct {
+	db *gorm.DB
+}
+
+func NewDownloadRepository(db *gorm.DB) *DownloadRepository {
+	return &DownloadRepository{db: db}
+}
+
+func (r *DownloadRepository) Save(ctx context.Context, download *shop.Download) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(download)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Save) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DownloadRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo      *persistence.DownloadRepository
+	orderRepo *persistence.OrderRepository
+}
+
+func (s *DownloadRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewDownloadRepository(s.db)
+	s.orderRepo = persistence.NewOrderRepository(s.db)
+}
+
+func TestDownloadRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(DownloadRepositoryTestSuite))
+}
+
+func (s *DownloadRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&shop.Download{}, "1 = 1")
+	s.db.Delete(&shop.Order{}, "1 = 1")
+}
+
+func (s *DownloadRepositoryTestSuite) TestSave() {
+	ctx := context.TODO()
+	order := shop.Order{ID: "order-id", Status: shop.Paid, Items: []shop.Item{{ID: "book-id", Price: 5000}}, UserID: "user-id"}
+	require.Nil(s.T(), s.orderRepo.Create(ctx, &order))
+
+	download := shop.Download{
+		ID:        "download-id",
+		UserID:    "user-id",
+		OrderID:   order.ID,
+		ItemID:    "book-id",
+		Format:    catalog.FormatEPUB,
+		Offset:    1024,
+		Bytes:     2048,
+		Size:      3072,
+		Completed: true,
+	}
+	require.Nil(s.T(), s.repo.Save(ctx, &download))
+
+	var saved shop.Download
+	require.Nil(s.T(), s.db.First(&saved, "id = ?", download.ID).Error)
+	assert.Equal(s.T(), download.Offset, saved.Offset)
+	assert.Equal(s.T(), download.Bytes, saved.Bytes)
+	assert.True(s.T(), saved.Completed)
+	assert.False(s.T(), saved.CreatedAt.IsZero())
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const feedCacheKey = "feed:catalog"
+
+// FeedCache keeps the public catalog feed in redis. The ttl must be shorter than the expiration of the
+// image links written to the feed.
+type FeedCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewFeedCache(client *redis.Client, ttl time.Duration) *FeedCache {
+	return &FeedCache{client: client, ttl: ttl}
+}
+
+func (c *FeedCache) Get(ctx context.Context) ([]byte, bool, error) {
+	feed, err := c.client.Get(ctx, feedCacheKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("(Get) failed retrieving feed from redis: %w", err)
+	}
+
+	return feed, true, nil
+}
+
+func (c *FeedCache) Save(ctx context.Context, feed []byte) error {
+	if err := c.client.Set(ctx, feedCacheKey, feed, c.ttl).Err(); err != nil {
+		return fmt.Errorf("(Save) failed saving feed to redis: %w", err)
+	}
+
+	return nil
+}
+
+func (c *FeedCache) Delete(ctx context.Context) error {
+	if err := c.client.Del(ctx, feedCacheKey).Err(); err != nil {
+		return fmt.Errorf("(Delete) failed deleting feed from redis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/test"
+	redisclient "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeedCacheTestSuite struct {
+	suite.Suite
+	cache     *FeedCache
+	client    *redisclient.Client
+	container *test.RedisContainer
+}
+
+func (s *FeedCacheTestSuite) SetupSuite() {
+	ctx := context.TODO()
+
+	var err error
+	s.container, err = test.NewRedisContainer(ctx)
+	s.Require().NoError(err)
+
+	s.client = redisclient.NewClient(&redisclient.Options{
+		Addr: s.container.Endpoint,
+	})
+	s.cache = NewFeedCache(s.client, time.Second*10)
+}
+
+func (s *FeedCacheTestSuite) TearDownSuite() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.container.Terminate(ctx))
+}
+
+func (s *FeedCacheTestSuite) TearDownTest() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.client.FlushDB(ctx).Err())
+}
+
+func (s *FeedCacheTestSuite) TestGet_NotFound() {
+	feed, found, err := s.cache.Get(context.TODO())
+
+	s.NoError(err)
+	s.False(found)
+	s.Nil(feed)
+}
+
+func (s *FeedCacheTestSuite) TestSave() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.cache.Save(ctx, []byte("<rss/>")))
+
+	feed, found, err := s.cache.Get(ctx)
+	s.NoError(err)
+	s.True(found)
+	s.Equal([]byte("<rss/>"), feed)
+}
+
+func (s *FeedCacheTestSuite) TestDelete() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.cache.Save(ctx, []byte("<rss/>")))
+	s.Require().NoError(s.cache.Delete(ctx))
+
+	_, found, err := s.cache.Get(ctx)
+	s.NoError(err)
+	s.False(found)
+}
+
+func TestFeedCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(FeedCacheTestSuite))
+}
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"gorm.io/gorm"
+)
+
+type ImageRepository struct {
+	db *gorm.DB
+}
+
+func NewImageRepository(db *gorm.DB) *ImageRepository {
+	return &ImageRepository{db: db}
+}
+
+func (r *ImageRepository) FindWithoutVariants(ctx context.Context, limit int) ([]catalog.Image, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var images []catalog.Image
+	result := r.db.WithContext(ctx).
+		Where("variants_generated = ? AND book_id IS NOT NULL", false).
+		Order("variants_failed_at NULLS FIRST, id").
+		Limit(limit).
+		Find(&images)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindWithoutVariants) failed running select query: %w", err)
+	}
+
+	return images, nil
+}
+
+func (r *ImageRepository) SaveVariants(ctx context.Context, imageID string, variants []catalog.ImageVariant) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("image_id = ?", imageID).Delete(&catalog.ImageVariant{}).Error; err != nil {
+			return err
+		}
+
+		if len(variants) > 0 {
+			if err := tx.Create(&variants).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&catalog.Image{}).Where("id = ?", imageID).Update("variants_generated", true)
+		if err := result.Error; err != nil {
+			return err
+		}
+		if result.RowsAffected <= 0 {
+			return &ErrEntityNotFound{entity: "image"}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("(SaveVariants) failed saving variants of image %s: %w", imageID, err)
+	}
+
+	return nil
+}
+
+func (r *ImageRepository) MarkVariantsFailed(ctx context.Context, imageID string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Model(&catalog.Image{}).Where("id = ?", imageID).Update("variants_failed_at", time.Now())
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(MarkVariantsFailed) failed running update statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(MarkVariantsFailed) no rows affected: %w", &ErrEntityNotFound{entity: "image"})
+	}
+
+	return nil
+}
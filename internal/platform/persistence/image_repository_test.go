@@ -0,0 +1,121 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ImageRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.ImageRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *ImageRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewImageRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestImageRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(ImageRepositoryTestSuite))
+}
+
+func (s *ImageRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+}
+
+func (s *ImageRepositoryTestSuite) createBook() catalog.Book {
+	book := catalog.Book{
+		ID:    "some-id",
+		Title: "Domain Driver Design",
+		Images: []catalog.Image{
+			{ID: "image-id1", Description: "cover", BookID: "some-id"},
+			{ID: "image-id2", Description: "back", BookID: "some-id"},
+		},
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.bookRepo.Create(context.TODO(), &book))
+
+	return book
+}
+
+func (s *ImageRepositoryTestSuite) TestFindWithoutVariants() {
+	ctx := context.TODO()
+	s.createBook()
+
+	images, err := s.repo.FindWithoutVariants(ctx, 1)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), images, 1)
+	assert.Equal(s.T(), "image-id1", images[0].ID)
+
+	require.Nil(s.T(), s.repo.SaveVariants(ctx, "image-id1", nil))
+
+	images, err = s.repo.FindWithoutVariants(ctx, 10)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), images, 1)
+	assert.Equal(s.T(), "image-id2", images[0].ID)
+}
+
+func (s *ImageRepositoryTestSuite) TestFindWithoutVariants_FailedImagesLast() {
+	ctx := context.TODO()
+	s.createBook()
+
+	require.Nil(s.T(), s.repo.MarkVariantsFailed(ctx, "image-id1"))
+
+	images, err := s.repo.FindWithoutVariants(ctx, 10)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), images, 2)
+	assert.Equal(s.T(), "image-id2", images[0].ID)
+	assert.Equal(s.T(), "image-id1", images[1].ID)
+}
+
+func (s *ImageRepositoryTestSuite) TestMarkVariantsFailed_NotFound() {
+	err := s.repo.MarkVariantsFailed(context.TODO(), "unknown-id")
+
+	var notFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &notFound))
+}
+
+func (s *ImageRepositoryTestSuite) TestSaveVariants_ReplacesVariants() {
+	ctx := context.TODO()
+	book := s.createBook()
+
+	thumbnail := catalog.ImageVariant{ImageID: "image-id1", Name: catalog.VariantThumbnail, ContentID: "variants/image-id1/thumbnail", MediaType: "image/jpeg", Width: 200, Height: 100}
+	medium := catalog.ImageVariant{ImageID: "image-id1", Name: catalog.VariantMedium, ContentID: "variants/image-id1/medium", MediaType: "image/png", Width: 600, Height: 300}
+
+	require.Nil(s.T(), s.repo.SaveVariants(ctx, "image-id1", []catalog.ImageVariant{thumbnail}))
+	require.Nil(s.T(), s.repo.SaveVariants(ctx, "image-id1", []catalog.ImageVariant{medium}))
+
+	persisted, err := s.bookRepo.FindByID(ctx, book.ID)
+	require.Nil(s.T(), err)
+
+	image := persisted.Images[0]
+	if image.ID != "image-id1" {
+		image = persisted.Images[1]
+	}
+	assert.True(s.T(), image.VariantsGenerated)
+	require.Len(s.T(), image.Variants, 1)
+	assert.Equal(s.T(), medium.ContentID, image.Variants[0].ContentID)
+	assert.Equal(s.T(), "image/png", image.Variants[0].MediaType)
+}
+
+func (s *ImageRepositoryTestSuite) TestSaveVariants_NotFound() {
+	err := s.repo.SaveVariants(context.TODO(), "unknown-id", nil)
+
+	var notFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &notFound))
+}
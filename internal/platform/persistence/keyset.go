@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"fmt"
+
+	"github.com/ebookstore/internal/core/query"
+	"gorm.io/gorm"
+)
+
+// paginateByKeyset restricts the statement to the rows after the page cursor using the (created_at, id) key.
+// It fetches one extra row, so the caller can tell if there are more rows in the cursor direction.
+func paginateByKeyset(db *gorm.DB, page query.Page) (*gorm.DB, query.Cursor, error) {
+	cursor, err := query.ParseCursor(page.Cursor)
+	if err != nil {
+		return nil, query.Cursor{}, fmt.Errorf("(paginateByKeyset) failed parsing cursor: %w", err)
+	}
+
+	comparison, order := "<", "created_at DESC, id DESC"
+	if cursor.Backward {
+		// Rows before the cursor are read in ascending order and reversed by the caller
+		comparison, order = ">", "created_at ASC, id ASC"
+	}
+
+	if !cursor.Start() {
+		db = db.Where(fmt.Sprintf("(created_at, id) %s (?, ?)", comparison), cursor.SortKey, cursor.ID)
+	}
+
+	return db.Order(order).Limit(page.Size + 1), cursor, nil
+}
+
+// keysetCursors returns the encoded cursors pointing to the pages around the current one.
+// first and last are the cursors built from the boundaries of the current page.
+func keysetCursors(cursor query.Cursor, hasMore bool, first, last query.Cursor) (next string, prev string) {
+	if cursor.Backward {
+		next = last.Encode()
+		if hasMore {
+			prev = first.Encode()
+		}
+		return next, prev
+	}
+
+	if hasMore {
+		next = last.Encode()
+	}
+	if !cursor.Start() {
+		prev = first.Encode()
+	}
+
+	return next, prev
+}
@@ -0,0 +1,68 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeysetCursors(t *testing.T) {
+	createdAt := time.Date(2022, time.September, 23, 0, 0, 0, 0, time.UTC)
+	first := query.NewCursor(createdAt, "first-id", true)
+	last := query.NewCursor(createdAt, "last-id", false)
+	middle := query.NewCursor(createdAt, "middle-id", false)
+
+	tests := []struct {
+		name         string
+		cursor       query.Cursor
+		hasMore      bool
+		expectedNext string
+		expectedPrev string
+	}{
+		{
+			name:         "when it is the first page and there are more rows, then it should return only the next cursor",
+			cursor:       query.Cursor{},
+			hasMore:      true,
+			expectedNext: last.Encode(),
+		},
+		{
+			name:   "when it is the first page and there are no more rows, then it should return no cursors",
+			cursor: query.Cursor{},
+		},
+		{
+			name:         "when moving forward and there are more rows, then it should return both cursors",
+			cursor:       middle,
+			hasMore:      true,
+			expectedNext: last.Encode(),
+			expectedPrev: first.Encode(),
+		},
+		{
+			name:         "when moving forward and there are no more rows, then it should return only the previous cursor",
+			cursor:       middle,
+			expectedPrev: first.Encode(),
+		},
+		{
+			name:         "when moving backward and there are more rows, then it should return both cursors",
+			cursor:       query.NewCursor(createdAt, "middle-id", true),
+			hasMore:      true,
+			expectedNext: last.Encode(),
+			expectedPrev: first.Encode(),
+		},
+		{
+			name:         "when moving backward and there are no more rows, then it should return only the next cursor",
+			cursor:       query.NewCursor(createdAt, "middle-id", true),
+			expectedNext: last.Encode(),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			next, prev := keysetCursors(tc.cursor, tc.hasMore, first, last)
+
+			assert.Equal(t, tc.expectedNext, next)
+			assert.Equal(t, tc.expectedPrev, prev)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/shop"
+	"gorm.io/gorm"
+)
+
+type LicenseRepository struct {
+	db *gorm.DB
+}
+
+func NewLicenseRepository(db *gorm.DB) *LicenseRepository {
+	return &LicenseRepository{db: db}
+}
+
+func (r *LicenseRepository) Create(ctx context.Context, license *shop.License) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(license)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "order_id, book_id"}
+		}
+
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LicenseRepository) FindByOrderItem(ctx context.Context, orderID, bookID string) (*shop.License, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var license shop.License
+	result := r.db.WithContext(ctx).First(&license, "order_id = ? AND book_id = ?", orderID, bookID)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("(FindByOrderItem) failed running select query: %w", err)
+	}
+
+	return &license, nil
+}
@@ -0,0 +1,67 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type LicenseRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo      *persistence.LicenseRepository
+	orderRepo *persistence.OrderRepository
+}
+
+func (s *LicenseRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewLicenseRepository(s.db)
+	s.orderRepo = persistence.NewOrderRepository(s.db)
+}
+
+func TestLicenseRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(LicenseRepositoryTestSuite))
+}
+
+func (s *LicenseRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&shop.License{}, "1 = 1")
+	s.db.Delete(&shop.Order{}, "1 = 1")
+}
+
+func (s *LicenseRepositoryTestSuite) TestCreateAndFindByOrderItem() {
+	ctx := context.TODO()
+	order := shop.Order{ID: "order-id", Status: shop.Paid, Items: []shop.Item{{ID: "book-id", Price: 5000}}, UserID: "user-id"}
+	require.Nil(s.T(), s.orderRepo.Create(ctx, &order))
+
+	printLimit := 10
+	end := time.Now().Add(time.Hour).Truncate(time.Second)
+	license := shop.License{ID: "license-id", UserID: "user-id", OrderID: order.ID, BookID: "book-id", Print: &printLimit, Start: time.Now(), End: &end}
+	require.Nil(s.T(), s.repo.Create(ctx, &license))
+
+	found, err := s.repo.FindByOrderItem(ctx, order.ID, "book-id")
+	require.Nil(s.T(), err)
+	require.NotNil(s.T(), found)
+	assert.Equal(s.T(), license.ID, found.ID)
+	assert.Equal(s.T(), 10, *found.Print)
+	assert.Nil(s.T(), found.Copy)
+	assert.True(s.T(), end.Equal(*found.End))
+
+	err = s.repo.Create(ctx, &shop.License{ID: "other-id", UserID: "user-id", OrderID: order.ID, BookID: "book-id", Start: time.Now()})
+	assert.IsType(s.T(), &persistence.ErrDuplicateKey{}, err)
+}
+
+func (s *LicenseRepositoryTestSuite) TestFindByOrderItem_NotIssued() {
+	license, err := s.repo.FindByOrderItem(context.TODO(), "order-id", "book-id")
+
+	require.Nil(s.T(), err)
+	assert.Nil(s.T(), license)
+}
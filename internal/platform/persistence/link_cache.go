@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const linkCacheKeyPrefix = "link:"
+
+// LinkCache keeps the presigned links of the images in redis, keyed by the key of the object. The ttl must be
+// at most half the expiration of the links, so they are never served close to expire.
+type LinkCache struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewLinkCache(client *redis.Client, ttl time.Duration) *LinkCache {
+	return &LinkCache{client: client, ttl: ttl}
+}
+
+func (c *LinkCache) GetLinks(ctx context.Context, keys []string) (map[string]string, error) {
+	cacheKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, linkCacheKeyPrefix+key)
+	}
+
+	values, err := c.client.MGet(ctx, cacheKeys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("(GetLinks) failed retrieving links from redis: %w", err)
+	}
+
+	links := make(map[string]string, len(keys))
+	for i, value := range values {
+		if link, ok := value.(string); ok {
+			links[keys[i]] = link
+		}
+	}
+
+	return links, nil
+}
+
+func (c *LinkCache) SaveLinks(ctx context.Context, links map[string]string) error {
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, link := range links {
+			pipe.Set(ctx, linkCacheKeyPrefix+key, link, c.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("(SaveLinks) failed saving links to redis: %w", err)
+	}
+
+	return nil
+}
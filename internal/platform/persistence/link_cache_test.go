@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/test"
+	redisclient "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type LinkCacheTestSuite struct {
+	suite.Suite
+	cache     *LinkCache
+	client    *redisclient.Client
+	container *test.RedisContainer
+}
+
+func (s *LinkCacheTestSuite) SetupSuite() {
+	ctx := context.TODO()
+
+	var err error
+	s.container, err = test.NewRedisContainer(ctx)
+	s.Require().NoError(err)
+
+	s.client = redisclient.NewClient(&redisclient.Options{
+		Addr: s.container.Endpoint,
+	})
+	s.cache = NewLinkCache(s.client, time.Second*10)
+}
+
+func (s *LinkCacheTestSuite) TearDownSuite() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.container.Terminate(ctx))
+}
+
+func (s *LinkCacheTestSuite) TearDownTest() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.client.FlushDB(ctx).Err())
+}
+
+func (s *LinkCacheTestSuite) TestGetLinks_NotFound() {
+	links, err := s.cache.GetLinks(context.TODO(), []string{"some-key"})
+
+	s.NoError(err)
+	s.Empty(links)
+}
+
+func (s *LinkCacheTestSuite) TestSaveLinks() {
+	ctx := context.TODO()
+
+	s.Require().NoError(s.cache.SaveLinks(ctx, map[string]string{"some-key": "some-link", "other-key": "other-link"}))
+
+	links, err := s.cache.GetLinks(ctx, []string{"some-key", "missing-key", "other-key"})
+	s.NoError(err)
+	s.Equal(map[string]string{"some-key": "some-link", "other-key": "other-link"}, links)
+
+	ttl, err := s.client.TTL(ctx, "link:some-key").Result()
+	s.NoError(err)
+	s.InDelta(time.Second*10, ttl, float64(time.Second))
+}
+
+func TestLinkCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(LinkCacheTestSuite))
+}
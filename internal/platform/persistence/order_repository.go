@@ -26,6 +26,10 @@ func (r *OrderRepository) FindByQuery(ctx context.Context, q query.Query, p quer
 	db := r.db.WithContext(ctx)
 	conditions, values := parseQuery(q)
 
+	if p.Keyset {
+		return r.findByKeyset(db, conditions, values, p)
+	}
+
 	paginated := shop.PaginatedOrders{}
 	result := db.Limit(p.Size).Offset(p.Offset()).
 		Preload("Items").
@@ -36,8 +40,8 @@ func (r *OrderRepository) FindByQuery(ctx context.Context, q query.Query, p quer
 		return shop.PaginatedOrders{}, fmt.Errorf("(FindByQuery) failed running select query: %w", err)
 	}
 
-	var count int64
-	if err := db.Model(&shop.Order{}).Where(conditions, values...).Count(&count).Error; err != nil {
+	count, err := r.count(db, conditions, values)
+	if err != nil {
 		return shop.PaginatedOrders{}, fmt.Errorf("(FindByQuery) failed running count query: %w", err)
 	}
 
@@ -48,6 +52,55 @@ func (r *OrderRepository) FindByQuery(ctx context.Context, q query.Query, p quer
 	return paginated, nil
 }
 
+func (r *OrderRepository) findByKeyset(db *gorm.DB, conditions string, values []interface{}, p query.Page) (shop.PaginatedOrders, error) {
+	statement, cursor, err := paginateByKeyset(db.Preload("Items").Where(conditions, values...), p)
+	if err != nil {
+		return shop.PaginatedOrders{}, fmt.Errorf("(findByKeyset) failed paginating query: %w", err)
+	}
+
+	var orders []shop.Order
+	if err = statement.Find(&orders).Error; err != nil {
+		return shop.PaginatedOrders{}, fmt.Errorf("(findByKeyset) failed running select query: %w", err)
+	}
+
+	hasMore := len(orders) > p.Size
+	if hasMore {
+		orders = orders[:p.Size]
+	}
+
+	if cursor.Backward {
+		for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+			orders[i], orders[j] = orders[j], orders[i]
+		}
+	}
+
+	paginated := shop.PaginatedOrders{Orders: orders, Limit: p.Size, Keyset: true}
+	if len(orders) > 0 {
+		first, last := orders[0], orders[len(orders)-1]
+		paginated.NextCursor, paginated.PrevCursor = keysetCursors(
+			cursor,
+			hasMore,
+			query.NewCursor(first.CreatedAt, first.ID, true),
+			query.NewCursor(last.CreatedAt, last.ID, false),
+		)
+	}
+
+	if p.Counted() {
+		paginated.IncludeTotal = true
+		if paginated.TotalOrders, err = r.count(db, conditions, values); err != nil {
+			return shop.PaginatedOrders{}, fmt.Errorf("(findByKeyset) failed running count query: %w", err)
+		}
+	}
+
+	return paginated, nil
+}
+
+func (r *OrderRepository) count(db *gorm.DB, conditions string, values []interface{}) (int64, error) {
+	var count int64
+	err := db.Model(&shop.Order{}).Where(conditions, values...).Count(&count).Error
+	return count, err
+}
+
 func (r *OrderRepository) FindByID(ctx context.Context, id string) (shop.Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
@@ -88,3 +141,78 @@ func (r *OrderRepository) Update(ctx context.Context, order *shop.Order) error {
 
 	return nil
 }
+
+func (r *OrderRepository) HasPaidItem(ctx context.Context, userID, itemID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var count int64
+	result := r.db.WithContext(ctx).
+		Model(&shop.Order{}).
+		Joins("INNER JOIN items ON items.order_id = orders.id").
+		Where("orders.user_id = ? AND orders.status = ? AND items.id = ?", userID, shop.Paid, itemID).
+		Count(&count)
+	if err := result.Error; err != nil {
+		return false, fmt.Errorf("(HasPaidItem) failed running select query: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+func (r *OrderRepository) FindReleases(ctx context.Context, now time.Time) ([]shop.Release, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var releases []shop.Release
+	result := r.db.WithContext(ctx).
+		Model(&shop.Item{}).
+		Select(`items.order_id, orders.user_id, users.email, users.first_name, items.id AS book_id, books.title AS name`).
+		Joins("INNER JOIN orders ON orders.id = items.order_id").
+		Joins("INNER JOIN books ON books.id = items.id").
+		Joins("INNER JOIN users ON users.id = orders.user_id").
+		Where("items.pre_order AND NOT items.release_notified AND orders.status = ? AND books.release_date <= ?", shop.Paid, now).
+		Scan(&releases)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindReleases) failed running select query: %w", err)
+	}
+
+	return releases, nil
+}
+
+func (r *OrderRepository) MarkReleaseNotified(ctx context.Context, orderID, bookID string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Model(&shop.Item{}).
+		Where("order_id = ? AND id = ?", orderID, bookID).
+		Update("release_notified", true)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(MarkReleaseNotified) failed running update statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OrderRepository) FindBuyer(ctx context.Context, orderID string) (shop.Buyer, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var buyer shop.Buyer
+	result := r.db.WithContext(ctx).
+		Table("orders").
+		Select("users.id AS user_id, users.email, users.first_name, users.last_name, users.license_key, users.license_hint").
+		Joins("INNER JOIN users ON users.id = orders.user_id").
+		Where("orders.id = ?", orderID).
+		Limit(1).
+		Scan(&buyer)
+	if err := result.Error; err != nil {
+		return shop.Buyer{}, fmt.Errorf("(FindBuyer) failed running select query: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return shop.Buyer{}, fmt.Errorf("(FindBuyer) no rows affected: %w", &ErrEntityNotFound{entity: "order"})
+	}
+
+	return buyer, nil
+}
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/core/query"
 	"github.com/ebookstore/internal/core/shop"
 	"github.com/ebookstore/internal/platform/persistence"
@@ -309,6 +312,101 @@ func (s *OrderRepositoryTestSuite) TestFindByID_WithError() {
 	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(err))
 }
 
+func (s *OrderRepositoryTestSuite) TestHasPaidItem() {
+	ctx := context.TODO()
+
+	paid := shop.Order{ID: "some-id1", Status: shop.Paid, Items: []shop.Item{{ID: "book-id1", Price: 5000}}, UserID: "user-id"}
+	pending := shop.Order{ID: "some-id2", Status: shop.Pending, Items: []shop.Item{{ID: "book-id2", Price: 4000}}, UserID: "user-id"}
+	require.Nil(s.T(), s.repo.Create(ctx, &paid))
+	require.Nil(s.T(), s.repo.Create(ctx, &pending))
+
+	tests := []struct {
+		name     string
+		userID   string
+		itemID   string
+		expected bool
+	}{
+		{name: "paid item", userID: "user-id", itemID: "book-id1", expected: true},
+		{name: "pending item", userID: "user-id", itemID: "book-id2", expected: false},
+		{name: "other user", userID: "other-user-id", itemID: "book-id1", expected: false},
+	}
+
+	for _, tc := range tests {
+		s.Run(tc.name, func() {
+			actual, err := s.repo.HasPaidItem(ctx, tc.userID, tc.itemID)
+
+			assert.Nil(s.T(), err)
+			assert.Equal(s.T(), tc.expected, actual)
+		})
+	}
+}
+
+func (s *OrderRepositoryTestSuite) TestFindReleases() {
+	ctx := context.TODO()
+	now := time.Now()
+
+	user := auth.User{ID: "user-id", FirstName: "Raphael", LastName: "Collin", Email: "raphael@test.com", Role: auth.Customer, Password: "password"}
+	released := catalog.Book{ID: "book-id1", Title: "Clean Code", Price: 5000, ReleaseDate: now.Add(-time.Hour)}
+	upcoming := catalog.Book{ID: "book-id2", Title: "Clean Coder", Price: 4000, ReleaseDate: now.Add(time.Hour)}
+	require.Nil(s.T(), s.db.Create(&user).Error)
+	require.Nil(s.T(), s.db.Create(&released).Error)
+	require.Nil(s.T(), s.db.Create(&upcoming).Error)
+	defer func() {
+		s.db.Delete(&shop.Order{}, "1 = 1")
+		s.db.Delete(&catalog.Book{}, "1 = 1")
+		s.db.Delete(&auth.User{}, "1 = 1")
+	}()
+
+	paid := shop.Order{ID: "some-id1", Status: shop.Paid, UserID: user.ID, Items: []shop.Item{
+		{ID: released.ID, Price: 5000, PreOrder: true},
+		{ID: upcoming.ID, Price: 4000, PreOrder: true},
+	}}
+	pending := shop.Order{ID: "some-id2", Status: shop.Pending, UserID: user.ID, Items: []shop.Item{{ID: released.ID, Price: 5000, PreOrder: true}}}
+	require.Nil(s.T(), s.repo.Create(ctx, &paid))
+	require.Nil(s.T(), s.repo.Create(ctx, &pending))
+
+	releases, err := s.repo.FindReleases(ctx, now)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), []shop.Release{{
+		OrderID:   paid.ID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		BookID:    released.ID,
+		Name:      released.Title,
+	}}, releases)
+
+	require.Nil(s.T(), s.repo.MarkReleaseNotified(ctx, paid.ID, released.ID))
+
+	releases, err = s.repo.FindReleases(ctx, now)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), releases)
+}
+
+func (s *OrderRepositoryTestSuite) TestFindBuyer() {
+	ctx := context.TODO()
+
+	user := auth.User{ID: "user-id", FirstName: "Raphael", LastName: "Collin", Email: "raphael@test.com", Role: auth.Customer, Password: "password",
+		LicenseKey: auth.LicenseKey("passphrase"), LicenseHint: "hint"}
+	require.Nil(s.T(), s.db.Create(&user).Error)
+	defer func() {
+		s.db.Delete(&shop.Order{}, "1 = 1")
+		s.db.Delete(&auth.User{}, "1 = 1")
+	}()
+
+	order := shop.Order{ID: "some-id1", Status: shop.Paid, UserID: user.ID}
+	require.Nil(s.T(), s.repo.Create(ctx, &order))
+
+	buyer, err := s.repo.FindBuyer(ctx, order.ID)
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), shop.Buyer{UserID: user.ID, Email: user.Email, FirstName: user.FirstName, LastName: user.LastName,
+		LicenseKey: user.LicenseKey, LicenseHint: user.LicenseHint}, buyer)
+
+	_, err = s.repo.FindBuyer(ctx, "missing-id")
+	var errNotFound *persistence.ErrEntityNotFound
+	assert.ErrorAs(s.T(), err, &errNotFound)
+}
+
 func (s *OrderRepositoryTestSuite) TestCreate_Successfully() {
 	order := shop.Order{
 		ID:     "some-id1",
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProtectedContentRepository struct {
+	db *gorm.DB
+}
+
+func NewProtectedContentRepository(db *gorm.DB) *ProtectedContentRepository {
+	return &ProtectedContentRepository{db: db}
+}
+
+func (r *ProtectedContentRepository) FindByContentID(ctx context.Context, contentID string) (*catalog.ProtectedContent, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var content catalog.ProtectedContent
+	result := r.db.WithContext(ctx).First(&content, "content_id = ?", contentID)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("(FindByContentID) failed running select query: %w", err)
+	}
+
+	return &content, nil
+}
+
+func (r *ProtectedContentRepository) Create(ctx context.Context, content *catalog.ProtectedContent) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "content_id"}}, DoNothing: true}).
+		Create(content)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProtectedContentRepository) Delete(ctx context.Context, contentIDs []string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Where("content_id IN ?", contentIDs).Delete(&catalog.ProtectedContent{}).Error; err != nil {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", err)
+	}
+
+	return nil
+}
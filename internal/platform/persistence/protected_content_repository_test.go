@@ -0,0 +1,104 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProtectedContentRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.ProtectedContentRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *ProtectedContentRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewProtectedContentRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestProtectedContentRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(ProtectedContentRepositoryTestSuite))
+}
+
+func (s *ProtectedContentRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&catalog.ProtectedContent{}, "1 = 1")
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+}
+
+func (s *ProtectedContentRepositoryTestSuite) createBook() catalog.Book {
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Files:       []catalog.BookFile{{BookID: "some-id", Format: catalog.FormatEPUB, ContentID: "epub-key", MediaType: "application/epub+zip", Size: 1024}},
+		Price:       8000,
+		Protected:   true,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.bookRepo.Create(context.TODO(), &book))
+	return book
+}
+
+func (s *ProtectedContentRepositoryTestSuite) TestCreateAndFindByContentID() {
+	ctx := context.TODO()
+	book := s.createBook()
+
+	content := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/package-id", Key: []byte("sealed-key"), Size: 2048, Checksum: "checksum"}
+	require.Nil(s.T(), s.repo.Create(ctx, &content))
+
+	found, err := s.repo.FindByContentID(ctx, "epub-key")
+	require.Nil(s.T(), err)
+	require.NotNil(s.T(), found)
+	assert.Equal(s.T(), content.PackageKey, found.PackageKey)
+	assert.Equal(s.T(), content.Key, found.Key)
+	assert.Equal(s.T(), content.Size, found.Size)
+	assert.Equal(s.T(), content.Checksum, found.Checksum)
+}
+
+func (s *ProtectedContentRepositoryTestSuite) TestCreate_KeepsFirstContent() {
+	ctx := context.TODO()
+	book := s.createBook()
+
+	first := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/first-id", Key: []byte("first-key"), Size: 2048, Checksum: "checksum"}
+	require.Nil(s.T(), s.repo.Create(ctx, &first))
+	second := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/second-id", Key: []byte("second-key"), Size: 2048, Checksum: "checksum"}
+	require.Nil(s.T(), s.repo.Create(ctx, &second))
+
+	found, err := s.repo.FindByContentID(ctx, "epub-key")
+	require.Nil(s.T(), err)
+	require.NotNil(s.T(), found)
+	assert.Equal(s.T(), first.PackageKey, found.PackageKey)
+	assert.Equal(s.T(), first.Key, found.Key)
+}
+
+func (s *ProtectedContentRepositoryTestSuite) TestFindByContentID_NotProtected() {
+	content, err := s.repo.FindByContentID(context.TODO(), "epub-key")
+
+	require.Nil(s.T(), err)
+	assert.Nil(s.T(), content)
+}
+
+func (s *ProtectedContentRepositoryTestSuite) TestDelete() {
+	ctx := context.TODO()
+	book := s.createBook()
+
+	content := catalog.ProtectedContent{ContentID: "epub-key", BookID: book.ID, PackageKey: "protected/epub-key/package-id", Key: []byte("sealed-key"), Size: 2048, Checksum: "checksum"}
+	require.Nil(s.T(), s.repo.Create(ctx, &content))
+
+	require.Nil(s.T(), s.repo.Delete(ctx, []string{"epub-key", "other-key"}))
+
+	found, err := s.repo.FindByContentID(ctx, "epub-key")
+	require.Nil(s.T(), err)
+	assert.Nil(s.T(), found)
+}
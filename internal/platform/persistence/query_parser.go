@@ -11,10 +11,19 @@ var operatorMapping = map[query.ComparisonOperator]string{
 	query.Equal: "=",
 	query.Match: "ILIKE",
 	query.NotEqual: "!=",
+	query.In: "IN",
+	query.GreaterOrEqual: ">=",
+	query.LessOrEqual: "<=",
 }
 
 // parseQuery function responsible for parsing a query into a SQL string
 func parseQuery(query query.Query) (string, []interface{}) {
+	return parseQueryWithColumns(query, nil)
+}
+
+// parseQueryWithColumns works like parseQuery, but fields found in columns are replaced by their SQL expression.
+// The expression must contain a %s verb for the operator and a placeholder for the value.
+func parseQueryWithColumns(query query.Query, columns map[string]string) (string, []interface{}) {
 	if query.Empty() {
 		return "", nil
 	}
@@ -32,7 +41,11 @@ func parseQuery(query query.Query) (string, []interface{}) {
 		op := parseCondition(condition)
 		value := parseValue(condition)
 
-		result.WriteString(fmt.Sprintf("%s %s ?", field, op))
+		if expression, ok := columns[field]; ok {
+			result.WriteString(fmt.Sprintf(expression, op))
+		} else {
+			result.WriteString(fmt.Sprintf("%s %s ?", field, op))
+		}
 		values = append(values, value)
 	}
 
@@ -47,6 +47,19 @@ func TestParseQuery(t *testing.T) {
 			expectedQuery: "title IS NOT ? AND author = ? AND price = ?",
 			expectedValues: []interface{}{nil, "author", 10},
 		},
+		{
+			name: "when query has range conditions, then it should return a string with >= and <=",
+			query: *query.New().And(query.Condition{Field: "page_count", Operator: query.GreaterOrEqual, Value: 100}).
+				And(query.Condition{Field: "page_count", Operator: query.LessOrEqual, Value: 500}),
+			expectedQuery: "page_count >= ? AND page_count <= ?",
+			expectedValues: []interface{}{100, 500},
+		},
+		{
+			name: "when query has IN condition, then it should return a string with IN and the values",
+			query: *query.New().And(query.Condition{Field: "id", Operator: query.In, Value: []string{"1", "2"}}),
+			expectedQuery: "id IN ?",
+			expectedValues: []interface{}{[]string{"1", "2"}},
+		},
 	}
 
 	for _, tc := range tests {
@@ -58,6 +71,17 @@ func TestParseQuery(t *testing.T) {
 	}
 }
 
+func TestParseQueryWithColumns(t *testing.T) {
+	columns := map[string]string{"category_id": "id IN (SELECT book_id FROM books_categories WHERE category_id %s ?)"}
+	q := *query.New().And(query.Condition{Field: "title", Operator: query.Match, Value: "value"}).
+		And(query.Condition{Field: "category_id", Operator: query.In, Value: []string{"1"}})
+
+	actualQuery, actualValues := parseQueryWithColumns(q, columns)
+
+	assert.Equal(t, "title ILIKE ? AND id IN (SELECT book_id FROM books_categories WHERE category_id IN ?)", actualQuery)
+	assert.Equal(t, []interface{}{"%value%", []string{"1"}}, actualValues)
+}
+
 func TestParseCondition(t *testing.T) {
 	tests := []struct {
 		name     string
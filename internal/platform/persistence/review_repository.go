@@ -0,0 +1,146 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/core/reviews"
+	"gorm.io/gorm"
+)
+
+// reviewUniqueConstraint allows a single review of a book per customer.
+const reviewUniqueConstraint = "reviews_book_id_user_id_unique"
+
+type ReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+func (r *ReviewRepository) FindByQuery(ctx context.Context, q query.Query, p query.Page) (reviews.PaginatedReviews, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	db := r.db.WithContext(ctx)
+	conditions, values := parseQuery(q)
+
+	paginated := reviews.PaginatedReviews{}
+	result := db.Limit(p.Size).
+		Offset(p.Offset()).
+		Where(conditions, values...).
+		Order("created_at DESC").
+		Find(&paginated.Reviews)
+	if err := result.Error; err != nil {
+		return reviews.PaginatedReviews{}, fmt.Errorf("(FindByQuery) failed running select query: %w", err)
+	}
+
+	var count int64
+	if err := db.Model(&reviews.Review{}).Where(conditions, values...).Count(&count).Error; err != nil {
+		return reviews.PaginatedReviews{}, fmt.Errorf("(FindByQuery) failed running count query: %w", err)
+	}
+
+	paginated.Limit = p.Size
+	paginated.Offset = p.Offset()
+	paginated.TotalReviews = count
+
+	return paginated, nil
+}
+
+func (r *ReviewRepository) FindByID(ctx context.Context, id string) (reviews.Review, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	review := reviews.Review{}
+	result := r.db.WithContext(ctx).First(&review, "id = ?", id)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = &ErrEntityNotFound{entity: "review"}
+		}
+
+		return reviews.Review{}, fmt.Errorf("(FindByID) failed running select query: %w", err)
+	}
+
+	return review, nil
+}
+
+func (r *ReviewRepository) Create(ctx context.Context, review *reviews.Review) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+
+		return refreshBookRating(tx, review.BookID)
+	})
+	if err != nil {
+		if violatedConstraint(err) == reviewUniqueConstraint {
+			return &ErrDuplicateKey{key: "review"}
+		}
+
+		return fmt.Errorf("(Create) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) Update(ctx context.Context, review *reviews.Review) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(review).Error; err != nil {
+			return err
+		}
+
+		return refreshBookRating(tx, review.BookID)
+	})
+	if err != nil {
+		return fmt.Errorf("(Update) failed running update statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReviewRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		review := reviews.Review{}
+		if err := tx.First(&review, "id = ?", id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return &ErrEntityNotFound{entity: "review"}
+			}
+
+			return err
+		}
+
+		if err := tx.Delete(&review).Error; err != nil {
+			return err
+		}
+
+		return refreshBookRating(tx, review.BookID)
+	})
+	if err != nil {
+		return fmt.Errorf("(Delete) failed running delete statement: %w", err)
+	}
+
+	return nil
+}
+
+// refreshBookRating recalculates the rating of the book from its approved reviews.
+func refreshBookRating(tx *gorm.DB, bookID string) error {
+	return tx.Exec(`UPDATE books SET
+		rating_average = COALESCE((SELECT AVG(rating) FROM reviews WHERE book_id = @book AND status = @status), 0),
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE book_id = @book AND status = @status)
+		WHERE id = @book`,
+		map[string]interface{}{"book": bookID, "status": reviews.Approved},
+	).Error
+}
@@ -0,0 +1,162 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/core/reviews"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.ReviewRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *ReviewRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewReviewRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestReviewRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(ReviewRepositoryTestSuite))
+}
+
+func (s *ReviewRepositoryTestSuite) SetupTest() {
+	users := []auth.User{
+		{ID: "user-id1", FirstName: "Raphael", LastName: "Collin", Email: "raphael@test.com", Role: auth.Customer, Password: "password"},
+		{ID: "user-id2", FirstName: "Eric", LastName: "Evans", Email: "eric@test.com", Role: auth.Customer, Password: "password"},
+	}
+	require.Nil(s.T(), s.db.Create(&users).Error)
+
+	book := catalog.Book{
+		ID:          "book-id",
+		Title:       "Domain Driven Design",
+		Price:       8000,
+		ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC),
+	}
+	require.Nil(s.T(), s.bookRepo.Create(context.TODO(), &book))
+}
+
+func (s *ReviewRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&reviews.Review{}, "1 = 1")
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+	s.db.Delete(&auth.User{}, "1 = 1")
+}
+
+func (s *ReviewRepositoryTestSuite) TestCreate_WithDuplicateReview() {
+	ctx := context.TODO()
+
+	err := s.repo.Create(ctx, &reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 5, Status: reviews.Pending})
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &reviews.Review{ID: "review-id2", BookID: "book-id", UserID: "user-id1", Rating: 3, Status: reviews.Pending})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.True(s.T(), errors.As(err, &errDuplicateKey))
+}
+
+func (s *ReviewRepositoryTestSuite) TestCreate_WithInvalidRating() {
+	err := s.repo.Create(context.TODO(), &reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 6, Status: reviews.Pending})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.Error(s.T(), err)
+	assert.False(s.T(), errors.As(err, &errDuplicateKey))
+}
+
+func (s *ReviewRepositoryTestSuite) TestCreate_WithDuplicateID() {
+	ctx := context.TODO()
+
+	err := s.repo.Create(ctx, &reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 5, Status: reviews.Pending})
+	require.Nil(s.T(), err)
+
+	err = s.repo.Create(ctx, &reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id2", Rating: 3, Status: reviews.Pending})
+
+	var errDuplicateKey *persistence.ErrDuplicateKey
+	assert.Error(s.T(), err)
+	assert.False(s.T(), errors.As(err, &errDuplicateKey))
+}
+
+func (s *ReviewRepositoryTestSuite) TestFindByQuery_WithStatus() {
+	ctx := context.TODO()
+
+	pending := reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 5, Status: reviews.Pending}
+	approved := reviews.Review{ID: "review-id2", BookID: "book-id", UserID: "user-id2", Rating: 3, Status: reviews.Approved}
+	require.Nil(s.T(), s.repo.Create(ctx, &pending))
+	require.Nil(s.T(), s.repo.Create(ctx, &approved))
+
+	q := *query.New().And(query.Condition{Field: "status", Operator: query.Equal, Value: reviews.Pending})
+	paginated, err := s.repo.FindByQuery(ctx, q, query.DefaultPage)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(1), paginated.TotalReviews)
+	require.Len(s.T(), paginated.Reviews, 1)
+	assert.Equal(s.T(), pending.ID, paginated.Reviews[0].ID)
+}
+
+func (s *ReviewRepositoryTestSuite) TestUpdate_RefreshesBookRating() {
+	ctx := context.TODO()
+
+	review1 := reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 5, Status: reviews.Pending}
+	review2 := reviews.Review{ID: "review-id2", BookID: "book-id", UserID: "user-id2", Rating: 2, Status: reviews.Pending}
+	require.Nil(s.T(), s.repo.Create(ctx, &review1))
+	require.Nil(s.T(), s.repo.Create(ctx, &review2))
+
+	book, err := s.bookRepo.FindByID(ctx, "book-id")
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, book.RatingCount)
+
+	review1.Moderate(reviews.Approve)
+	review2.Moderate(reviews.Approve)
+	require.Nil(s.T(), s.repo.Update(ctx, &review1))
+	require.Nil(s.T(), s.repo.Update(ctx, &review2))
+
+	book, err = s.bookRepo.FindByID(ctx, "book-id")
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), 2, book.RatingCount)
+	assert.Equal(s.T(), 3.5, book.RatingAverage)
+
+	review2.Moderate(reviews.Hide)
+	require.Nil(s.T(), s.repo.Update(ctx, &review2))
+
+	book, err = s.bookRepo.FindByID(ctx, "book-id")
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), 1, book.RatingCount)
+	assert.Equal(s.T(), 5.0, book.RatingAverage)
+}
+
+func (s *ReviewRepositoryTestSuite) TestDelete_RefreshesBookRating() {
+	ctx := context.TODO()
+
+	review := reviews.Review{ID: "review-id1", BookID: "book-id", UserID: "user-id1", Rating: 4, Status: reviews.Approved}
+	require.Nil(s.T(), s.repo.Create(ctx, &review))
+
+	err := s.repo.Delete(ctx, review.ID)
+	require.Nil(s.T(), err)
+
+	book, err := s.bookRepo.FindByID(ctx, "book-id")
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), 0, book.RatingCount)
+	assert.Equal(s.T(), 0.0, book.RatingAverage)
+}
+
+func (s *ReviewRepositoryTestSuite) TestDelete_WhenReviewIsNotFound() {
+	err := s.repo.Delete(context.TODO(), "unknown-id")
+
+	var errEntityNotFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &errEntityNotFound))
+}
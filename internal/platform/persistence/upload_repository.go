@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// uploadReferenced matches the uploads referenced by a book file, sample, image or author photo.
+const uploadReferenced = `(EXISTS (SELECT 1 FROM book_files WHERE book_files.content_id = uploads.key)
+	OR EXISTS (SELECT 1 FROM book_samples WHERE book_samples.content_id = uploads.key)
+	OR EXISTS (SELECT 1 FROM images WHERE images.id = uploads.key)
+	OR EXISTS (SELECT 1 FROM authors WHERE authors.photo_id = uploads.key))`
+
+type UploadRepository struct {
+	db *gorm.DB
+}
+
+func NewUploadRepository(db *gorm.DB) *UploadRepository {
+	return &UploadRepository{db: db}
+}
+
+func (r *UploadRepository) Track(ctx context.Context, keys []string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	now := time.Now()
+	uploads := make([]catalog.Upload, 0, len(keys))
+	for _, key := range keys {
+		uploads = append(uploads, catalog.Upload{Key: key, CreatedAt: now})
+	}
+
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"created_at"}),
+		}).
+		Create(&uploads)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(Track) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *UploadRepository) FindOrphans(ctx context.Context, before time.Time, limit int) ([]catalog.Upload, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var uploads []catalog.Upload
+	result := r.db.WithContext(ctx).
+		Where("created_at < ? AND NOT "+uploadReferenced, before).
+		Order("created_at").
+		Limit(limit).
+		Find(&uploads)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindOrphans) failed running select query: %w", err)
+	}
+
+	return uploads, nil
+}
+
+func (r *UploadRepository) UntrackReferenced(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Where("created_at < ? AND "+uploadReferenced, before).
+		Delete(&catalog.Upload{})
+	if err := result.Error; err != nil {
+		return 0, fmt.Errorf("(UntrackReferenced) failed running delete statement: %w", err)
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *UploadRepository) Untrack(ctx context.Context, keys []string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	if err := r.db.WithContext(ctx).Where("key IN ?", keys).Delete(&catalog.Upload{}).Error; err != nil {
+		return fmt.Errorf("(Untrack) failed running delete statement: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package persistence_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UploadRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.UploadRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *UploadRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewUploadRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestUploadRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(UploadRepositoryTestSuite))
+}
+
+func (s *UploadRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&catalog.Upload{}, "1 = 1")
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+}
+
+func (s *UploadRepositoryTestSuite) TestFindOrphans_SkipsReferencedUploads() {
+	ctx := context.TODO()
+	book := catalog.Book{
+		ID:          "some-id",
+		Title:       "Domain Driver Design",
+		Images:      []catalog.Image{{ID: "image-key", Description: "cover", BookID: "some-id"}},
+		Files:       []catalog.BookFile{{BookID: "some-id", Format: catalog.FormatPDF, ContentID: "pdf-key", MediaType: "application/pdf", Size: 1024}},
+		Price:       8000,
+		ReleaseDate: time.Date(2008, time.December, 12, 0, 0, 0, 0, time.Local),
+	}
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &book))
+	require.Nil(s.T(), s.repo.Track(ctx, []string{"image-key", "pdf-key", "orphan-key"}))
+
+	orphans, err := s.repo.FindOrphans(ctx, time.Now().Add(time.Minute), 10)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), orphans, 1)
+	assert.Equal(s.T(), "orphan-key", orphans[0].Key)
+
+	untracked, err := s.repo.UntrackReferenced(ctx, time.Now().Add(time.Minute))
+	require.Nil(s.T(), err)
+	assert.Equal(s.T(), int64(2), untracked)
+}
+
+func (s *UploadRepositoryTestSuite) TestFindOrphans_WithinGracePeriod() {
+	ctx := context.TODO()
+	require.Nil(s.T(), s.repo.Track(ctx, []string{"orphan-key"}))
+
+	orphans, err := s.repo.FindOrphans(ctx, time.Now().Add(-time.Hour), 10)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), orphans)
+}
+
+func (s *UploadRepositoryTestSuite) TestUntrack() {
+	ctx := context.TODO()
+	require.Nil(s.T(), s.repo.Track(ctx, []string{"orphan-key", "other-key"}))
+	require.Nil(s.T(), s.repo.Untrack(ctx, []string{"orphan-key"}))
+
+	orphans, err := s.repo.FindOrphans(ctx, time.Now().Add(time.Minute), 10)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), orphans, 1)
+	assert.Equal(s.T(), "other-key", orphans[0].Key)
+}
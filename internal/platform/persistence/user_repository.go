@@ -52,6 +52,23 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (auth.Us
 	return user, nil
 }
 
+func (r *UserRepository) FindByID(ctx context.Context, id string) (auth.User, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	user := auth.User{}
+	result := r.db.WithContext(ctx).First(&user, "id = ?", id)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = &ErrEntityNotFound{entity: "User"}
+		}
+
+		return auth.User{}, fmt.Errorf("(FindByID) failed executing select query: %w", err)
+	}
+
+	return user, nil
+}
+
 func (r *UserRepository) Update(ctx context.Context, user *auth.User) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
@@ -68,3 +85,12 @@ func isConstraintViolationError(err error) bool {
 	parsed, ok := err.(*pq.Error)
 	return ok && parsed.Code == "23505"
 }
+
+// violatedConstraint returns the name of the unique constraint violated by err, or "" if err is not a unique violation.
+func violatedConstraint(err error) string {
+	if !isConstraintViolationError(err) {
+		return ""
+	}
+
+	return err.(*pq.Error).Constraint
+}
@@ -104,6 +104,36 @@ func (s *UserRepositoryTestSuite) TestUserRepository_FindByEmailNotFound() {
 	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(err))
 }
 
+func (s *UserRepositoryTestSuite) TestUserRepository_FindByIDSuccessfully() {
+	ctx := context.TODO()
+
+	expected := auth.User{
+		ID:        "some-id",
+		FirstName: "Raphael",
+		LastName:  "Collin",
+		Email:     "raphael@test.com",
+		Role:      auth.Customer,
+		Password:  "password",
+		CreatedAt: time.Now().Unix(),
+	}
+
+	err := s.repo.Save(ctx, &expected)
+	require.Nil(s.T(), err)
+
+	actual, err := s.repo.FindByID(ctx, expected.ID)
+
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), expected, actual)
+}
+
+func (s *UserRepositoryTestSuite) TestUserRepository_FindByIDNotFound() {
+	ctx := context.TODO()
+
+	_, err := s.repo.FindByID(ctx, "some-id")
+
+	assert.IsType(s.T(), &persistence.ErrEntityNotFound{}, errors.Unwrap(err))
+}
+
 func (s *UserRepositoryTestSuite) TestUserRepository_UpdateSuccessfully() {
 	ctx := context.TODO()
 
@@ -0,0 +1,123 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"gorm.io/gorm"
+)
+
+type WishlistRepository struct {
+	db *gorm.DB
+}
+
+func NewWishlistRepository(db *gorm.DB) *WishlistRepository {
+	return &WishlistRepository{db: db}
+}
+
+// FindByUserID returns the wishlist of the user. Users without wishlisted books have an empty wishlist.
+func (r *WishlistRepository) FindByUserID(ctx context.Context, userID string) (shop.Wishlist, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	wishlist := shop.Wishlist{UserID: userID}
+	result := r.db.WithContext(ctx).
+		Select("wishlist_items.*, books.price AS current_price").
+		Joins("INNER JOIN books ON books.id = wishlist_items.book_id").
+		Where("wishlist_items.user_id = ?", userID).
+		Order("wishlist_items.created_at DESC").
+		Find(&wishlist.Items)
+	if err := result.Error; err != nil {
+		return shop.Wishlist{}, fmt.Errorf("(FindByUserID) failed running select query: %w", err)
+	}
+
+	return wishlist, nil
+}
+
+func (r *WishlistRepository) AddItem(ctx context.Context, item *shop.WishlistItem) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Create(item)
+	if err := result.Error; err != nil {
+		if isConstraintViolationError(err) {
+			return &ErrDuplicateKey{key: "book"}
+		}
+
+		return fmt.Errorf("(AddItem) failed running insert statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WishlistRepository) RemoveItem(ctx context.Context, userID, bookID string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).Delete(&shop.WishlistItem{}, "user_id = ? AND book_id = ?", userID, bookID)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(RemoveItem) failed running delete statement: %w", err)
+	}
+
+	if result.RowsAffected <= 0 {
+		return fmt.Errorf("(RemoveItem) failed running delete statement: %w", &ErrEntityNotFound{entity: "wishlist item"})
+	}
+
+	return nil
+}
+
+func (r *WishlistRepository) FindPriceDrops(ctx context.Context) ([]shop.PriceDrop, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	var drops []shop.PriceDrop
+	result := r.db.WithContext(ctx).
+		Model(&shop.WishlistItem{}).
+		Select(`wishlist_items.user_id, users.email, users.first_name, wishlist_items.book_id, books.title AS name,
+			COALESCE(wishlist_items.notified_price, wishlist_items.price) AS old_price, books.price AS new_price`).
+		Joins("INNER JOIN books ON books.id = wishlist_items.book_id").
+		Joins("INNER JOIN users ON users.id = wishlist_items.user_id").
+		Where("books.price < COALESCE(wishlist_items.notified_price, wishlist_items.price)").
+		Where("books.archived_at IS NULL AND books.status = ?", catalog.StatusPublished).
+		Scan(&drops)
+	if err := result.Error; err != nil {
+		return nil, fmt.Errorf("(FindPriceDrops) failed running select query: %w", err)
+	}
+
+	return drops, nil
+}
+
+func (r *WishlistRepository) MarkNotified(ctx context.Context, userID, bookID string, price int64) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	result := r.db.WithContext(ctx).
+		Model(&shop.WishlistItem{}).
+		Where("user_id = ? AND book_id = ?", userID, bookID).
+		Update("notified_price", price)
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(MarkNotified) failed running update statement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WishlistRepository) ResetNotifiedPrices(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	// Back at the wishlisted price the item starts over, below it the next drop is measured from the raised price
+	result := r.db.WithContext(ctx).
+		Model(&shop.WishlistItem{}).
+		Where("notified_price < (SELECT price FROM books WHERE books.id = wishlist_items.book_id)").
+		Update("notified_price", gorm.Expr(`(SELECT CASE WHEN books.price >= wishlist_items.price THEN NULL ELSE books.price END
+			FROM books WHERE books.id = wishlist_items.book_id)`))
+	if err := result.Error; err != nil {
+		return fmt.Errorf("(ResetNotifiedPrices) failed running update statement: %w", err)
+	}
+
+	return nil
+}
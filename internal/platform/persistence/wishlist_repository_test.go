@@ -0,0 +1,185 @@
+package persistence_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type WishlistRepositoryTestSuite struct {
+	PostgresRepositoryTestSuite
+	repo     *persistence.WishlistRepository
+	bookRepo *persistence.BookRepository
+}
+
+func (s *WishlistRepositoryTestSuite) SetupSuite() {
+	s.PostgresRepositoryTestSuite.SetupSuite()
+
+	s.repo = persistence.NewWishlistRepository(s.db)
+	s.bookRepo = persistence.NewBookRepository(s.db)
+}
+
+func TestWishlistRepositoryRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+	suite.Run(t, new(WishlistRepositoryTestSuite))
+}
+
+func (s *WishlistRepositoryTestSuite) SetupTest() {
+	user := auth.User{ID: "user-id", FirstName: "Raphael", LastName: "Collin", Email: "raphael@test.com", Role: auth.Customer, Password: "password"}
+	require.Nil(s.T(), s.db.Create(&user).Error)
+
+	book := catalog.Book{
+		ID:          "book-id",
+		Title:       "Clean Code",
+		Price:       5000,
+		ReleaseDate: time.Date(2008, time.August, 1, 0, 0, 0, 0, time.UTC),
+		Status:      catalog.StatusPublished,
+	}
+	require.Nil(s.T(), s.bookRepo.Create(context.TODO(), &book))
+}
+
+func (s *WishlistRepositoryTestSuite) TearDownTest() {
+	s.db.Delete(&shop.WishlistItem{}, "1 = 1")
+	s.db.Delete(&catalog.Book{}, "1 = 1")
+	s.db.Delete(&auth.User{}, "1 = 1")
+}
+
+func (s *WishlistRepositoryTestSuite) TestFindByUserID_WithCurrentPrice() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "book-id", Name: "Clean Code", Price: 5000}))
+	require.Nil(s.T(), s.db.Model(&catalog.Book{}).Where("id = ?", "book-id").Update("price", 4000).Error)
+
+	wishlist, err := s.repo.FindByUserID(ctx, "user-id")
+
+	assert.Nil(s.T(), err)
+	require.Len(s.T(), wishlist.Items, 1)
+	assert.Equal(s.T(), int64(5000), wishlist.Items[0].Price)
+	assert.Equal(s.T(), int64(4000), wishlist.Items[0].CurrentPrice)
+}
+
+func (s *WishlistRepositoryTestSuite) TestRemoveItem_WhenItemIsNotFound() {
+	err := s.repo.RemoveItem(context.TODO(), "user-id", "book-id")
+
+	var errEntityNotFound *persistence.ErrEntityNotFound
+	assert.True(s.T(), errors.As(err, &errEntityNotFound))
+}
+
+func (s *WishlistRepositoryTestSuite) TestFindPriceDrops() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "book-id", Name: "Clean Code", Price: 5000}))
+
+	drops, err := s.repo.FindPriceDrops(ctx)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), drops)
+
+	require.Nil(s.T(), s.db.Model(&catalog.Book{}).Where("id = ?", "book-id").Update("price", 4000).Error)
+
+	drops, err = s.repo.FindPriceDrops(ctx)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), drops, 1)
+	assert.Equal(s.T(), shop.PriceDrop{
+		UserID:    "user-id",
+		Email:     "raphael@test.com",
+		FirstName: "Raphael",
+		BookID:    "book-id",
+		Name:      "Clean Code",
+		OldPrice:  5000,
+		NewPrice:  4000,
+	}, drops[0])
+
+	require.Nil(s.T(), s.repo.MarkNotified(ctx, "user-id", "book-id", 4000))
+
+	drops, err = s.repo.FindPriceDrops(ctx)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), drops)
+}
+
+func (s *WishlistRepositoryTestSuite) TestFindPriceDrops_AfterBookUpdate() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "book-id", Name: "Clean Code", Price: 5000}))
+
+	book, err := s.bookRepo.FindByID(ctx, "book-id")
+	require.Nil(s.T(), err)
+	price := 4000
+	updated := catalog.UpdateBook{ID: "book-id", Price: &price}.Update(book)
+	require.Nil(s.T(), s.bookRepo.Update(ctx, &updated))
+
+	drops, err := s.repo.FindPriceDrops(ctx)
+
+	require.Nil(s.T(), err)
+	require.Len(s.T(), drops, 1)
+	assert.Equal(s.T(), 5000, int(drops[0].OldPrice))
+	assert.Equal(s.T(), 4000, int(drops[0].NewPrice))
+}
+
+func (s *WishlistRepositoryTestSuite) TestFindPriceDrops_WithArchivedAndDraftBooks() {
+	ctx := context.TODO()
+	archivedAt := time.Now()
+	archived := catalog.Book{
+		ID:          "archived-book-id",
+		Title:       "Refactoring",
+		Price:       4000,
+		ReleaseDate: time.Date(1999, time.July, 8, 0, 0, 0, 0, time.UTC),
+		Status:      catalog.StatusPublished,
+		ArchivedAt:  &archivedAt,
+	}
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &archived))
+	draft := catalog.Book{
+		ID:          "draft-book-id",
+		Title:       "Working Effectively with Legacy Code",
+		Price:       4000,
+		ReleaseDate: time.Date(2004, time.September, 22, 0, 0, 0, 0, time.UTC),
+		Status:      catalog.StatusDraft,
+	}
+	require.Nil(s.T(), s.bookRepo.Create(ctx, &draft))
+
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "archived-book-id", Name: "Refactoring", Price: 5000}))
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "draft-book-id", Name: "Working Effectively with Legacy Code", Price: 5000}))
+
+	drops, err := s.repo.FindPriceDrops(ctx)
+
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), drops)
+}
+
+func (s *WishlistRepositoryTestSuite) TestResetNotifiedPrices() {
+	ctx := context.TODO()
+
+	require.Nil(s.T(), s.repo.AddItem(ctx, &shop.WishlistItem{UserID: "user-id", BookID: "book-id", Name: "Clean Code", Price: 5000}))
+	require.Nil(s.T(), s.repo.MarkNotified(ctx, "user-id", "book-id", 4000))
+
+	require.Nil(s.T(), s.db.Model(&catalog.Book{}).Where("id = ?", "book-id").Update("price", 4500).Error)
+	require.Nil(s.T(), s.repo.ResetNotifiedPrices(ctx))
+
+	drops, err := s.repo.FindPriceDrops(ctx)
+	require.Nil(s.T(), err)
+	assert.Empty(s.T(), drops)
+
+	require.Nil(s.T(), s.db.Model(&catalog.Book{}).Where("id = ?", "book-id").Update("price", 4200).Error)
+
+	drops, err = s.repo.FindPriceDrops(ctx)
+	require.Nil(s.T(), err)
+	require.Len(s.T(), drops, 1)
+	assert.Equal(s.T(), int64(4500), drops[0].OldPrice)
+
+	require.Nil(s.T(), s.db.Model(&catalog.Book{}).Where("id = ?", "book-id").Update("price", 6000).Error)
+	require.Nil(s.T(), s.repo.ResetNotifiedPrices(ctx))
+
+	wishlist, err := s.repo.FindByUserID(ctx, "user-id")
+	require.Nil(s.T(), err)
+	assert.Nil(s.T(), wishlist.Items[0].NotifiedPrice)
+}
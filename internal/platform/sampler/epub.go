@@ -0,0 +1,198 @@
+package sampler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	epubContainerPath = "META-INF/container.xml"
+	epubMimetypePath  = "mimetype"
+)
+
+// The package document is rewritten with regular expressions instead of being encoded back,
+// so everything the sample doesn't change is kept byte for byte.
+var (
+	epubItemref   = regexp.MustCompile(`<(?:[\w-]+:)?itemref\b[^>]*>(?:\s*</(?:[\w-]+:)?itemref>)?`)
+	epubItem      = regexp.MustCompile(`<(?:[\w-]+:)?item\b[^>]*>(?:\s*</(?:[\w-]+:)?item>)?`)
+	epubIDAttr    = regexp.MustCompile(`\sid\s*=\s*["']([^"']*)["']`)
+	epubIDRefAttr = regexp.MustCompile(`\sidref\s*=\s*["']([^"']*)["']`)
+)
+
+type epubContainer struct {
+	Rootfiles []struct {
+		FullPath string `xml:"full-path,attr"`
+	} `xml:"rootfiles>rootfile"`
+}
+
+type epubPackage struct {
+	Manifest []struct {
+		ID         string `xml:"id,attr"`
+		Href       string `xml:"href,attr"`
+		Properties string `xml:"properties,attr"`
+	} `xml:"manifest>item"`
+	Spine []struct {
+		IDRef string `xml:"idref,attr"`
+	} `xml:"spine>itemref"`
+}
+
+// sampleEPUB keeps the first items of the spine, the reading order of the book, and removes the documents
+// of the rest. The navigation document and the resources like styles and images are kept.
+func sampleEPUB(content []byte, items int) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+
+	var container epubContainer
+	if err = readXML(archive, epubContainerPath, &container); err != nil {
+		return nil, err
+	}
+	if len(container.Rootfiles) == 0 {
+		return nil, fmt.Errorf("missing rootfile in %s", epubContainerPath)
+	}
+	packagePath := container.Rootfiles[0].FullPath
+
+	packageDocument, err := readFile(archive, packagePath)
+	if err != nil {
+		return nil, err
+	}
+	var pkg epubPackage
+	if err = xml.Unmarshal(packageDocument, &pkg); err != nil {
+		return nil, fmt.Errorf("invalid package document %s: %v", packagePath, err)
+	}
+	if len(pkg.Spine) <= items {
+		return content, nil
+	}
+
+	kept := make(map[string]bool, items)
+	for _, itemref := range pkg.Spine[:items] {
+		kept[itemref.IDRef] = true
+	}
+
+	removedItems := make(map[string]bool)
+	removedFiles := make(map[string]bool)
+	for _, item := range pkg.Manifest {
+		if !kept[item.ID] && inSpine(pkg, item.ID) && !strings.Contains(item.Properties, "nav") {
+			removedItems[item.ID] = true
+			removedFiles[resolveHref(packagePath, item.Href)] = true
+		}
+	}
+
+	spineIndex := 0
+	packageDocument = epubItemref.ReplaceAllFunc(packageDocument, func(itemref []byte) []byte {
+		spineIndex++
+		if spineIndex > items {
+			return nil
+		}
+		return itemref
+	})
+	packageDocument = epubItem.ReplaceAllFunc(packageDocument, func(item []byte) []byte {
+		if id := epubIDAttr.FindSubmatch(item); id != nil && removedItems[string(id[1])] {
+			return nil
+		}
+		return item
+	})
+
+	return writeEPUB(archive, packagePath, packageDocument, removedFiles)
+}
+
+func inSpine(pkg epubPackage, id string) bool {
+	for _, itemref := range pkg.Spine {
+		if itemref.IDRef == id {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveHref returns the path in the archive of a manifest href, which is relative to the package document.
+func resolveHref(packagePath, href string) string {
+	if i := strings.IndexByte(href, '#'); i >= 0 {
+		href = href[:i]
+	}
+	if unescaped, err := url.PathUnescape(href); err == nil {
+		href = unescaped
+	}
+	return path.Join(path.Dir(packagePath), href)
+}
+
+// writeEPUB copies the archive without the removed files. The mimetype file must be the first one and stored
+// without compression, so the format can be detected by its first bytes.
+func writeEPUB(archive *zip.Reader, packagePath string, packageDocument []byte, removedFiles map[string]bool) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	mimetype, err := w.CreateHeader(&zip.FileHeader{Name: epubMimetypePath, Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err = mimetype.Write([]byte("application/epub+zip")); err != nil {
+		return nil, err
+	}
+
+	for _, f := range archive.File {
+		if f.Name == epubMimetypePath || removedFiles[f.Name] {
+			continue
+		}
+
+		entry, err := w.CreateHeader(&zip.FileHeader{Name: f.Name, Method: f.Method, Modified: f.Modified})
+		if err != nil {
+			return nil, err
+		}
+		if f.Name == packagePath {
+			if _, err = entry.Write(packageDocument); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err = copyFile(entry, f); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func copyFile(w io.Writer, f *zip.File) error {
+	r, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	_, err = io.Copy(w, r)
+	return err
+}
+
+func readFile(archive *zip.Reader, name string) ([]byte, error) {
+	f, err := archive.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("missing %s: %v", name, err)
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
+
+func readXML(archive *zip.Reader, name string, v interface{}) error {
+	data, err := readFile(archive, name)
+	if err != nil {
+		return err
+	}
+	if err = xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return nil
+}
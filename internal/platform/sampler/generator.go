@@ -0,0 +1,40 @@
+package sampler
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// Generator cuts the beginning of the book contents to give it away as a free sample.
+type Generator struct{}
+
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+// Generate returns a sample with the first length pages of a PDF or the first length spine items of an EPUB.
+func (g *Generator) Generate(format catalog.BookFormat, r io.Reader, length int) ([]byte, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("(Generate) failed reading content: %w", err)
+	}
+
+	var sample []byte
+	switch format {
+	case catalog.FormatPDF:
+		sample, err = samplePDF(content, length)
+	case catalog.FormatEPUB:
+		sample, err = sampleEPUB(content, length)
+	default:
+		return nil, fmt.Errorf("(Generate) unsupported format %s: %w", format, catalog.ErrInvalidSampleSource)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("(Generate) failed cutting %s content: %v: %w", format, err, catalog.ErrInvalidSampleSource)
+	}
+
+	return sample, nil
+}
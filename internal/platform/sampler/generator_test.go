@@ -0,0 +1,146 @@
+package sampler
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackageDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="uid">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Dune</dc:title></metadata>
+  <manifest>
+    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>
+    <item id="css" href="styles/book.css" media-type="text/css"/>
+    <item id="chapter-1" href="text/chapter%201.xhtml" media-type="application/xhtml+xml"/>
+    <item id="chapter-2" href="text/chapter-2.xhtml" media-type="application/xhtml+xml"/>
+    <item id="chapter-3" href="text/chapter-3.xhtml" media-type="application/xhtml+xml"></item>
+  </manifest>
+  <spine>
+    <itemref idref="chapter-1"/>
+    <itemref idref="chapter-2"/>
+    <itemref idref="chapter-3"></itemref>
+  </spine>
+</package>`
+
+func testEPUB(t *testing.T) []byte {
+	files := []struct{ name, content string }{
+		{"mimetype", "application/epub+zip"},
+		{"META-INF/container.xml", `<container><rootfiles><rootfile full-path="OEBPS/content.opf"/></rootfiles></container>`},
+		{"OEBPS/content.opf", testPackageDocument},
+		{"OEBPS/nav.xhtml", "<nav>contents</nav>"},
+		{"OEBPS/styles/book.css", "body {}"},
+		{"OEBPS/text/chapter 1.xhtml", "<p>Chapter one</p>"},
+		{"OEBPS/text/chapter-2.xhtml", "<p>Chapter two</p>"},
+		{"OEBPS/text/chapter-3.xhtml", "<p>Chapter three</p>"},
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		entry, err := w.Create(f.name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(f.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func testPDF(pages int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, 0, pages)
+	for i := 0; i < pages; i++ {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 3+2*i))
+	}
+	fmt.Fprintf(&buf, "1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	fmt.Fprintf(&buf, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d /MediaBox [0 0 612 792] >>\nendobj\n", strings.Join(kids, " "), pages)
+	for i := 0; i < pages; i++ {
+		content := fmt.Sprintf("BT (Page %d) Tj ET", i+1)
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Type /Page /Parent 2 0 R /Contents %d 0 R >>\nendobj\n", 3+2*i, 4+2*i)
+		fmt.Fprintf(&buf, "%d 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", 4+2*i, len(content), content)
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return buf.Bytes()
+}
+
+func readEPUB(t *testing.T, content []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	require.NoError(t, err)
+
+	require.NotEmpty(t, archive.File)
+	assert.Equal(t, "mimetype", archive.File[0].Name)
+	assert.Equal(t, zip.Store, archive.File[0].Method)
+
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := ioutil.ReadAll(r)
+		require.NoError(t, err)
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+func TestGenerator_GenerateEPUB(t *testing.T) {
+	sample, err := NewGenerator().Generate(catalog.FormatEPUB, bytes.NewReader(testEPUB(t)), 1)
+	require.NoError(t, err)
+
+	files := readEPUB(t, sample)
+	assert.Contains(t, files, "OEBPS/text/chapter 1.xhtml")
+	assert.Contains(t, files, "OEBPS/nav.xhtml")
+	assert.Contains(t, files, "OEBPS/styles/book.css")
+	assert.NotContains(t, files, "OEBPS/text/chapter-2.xhtml")
+	assert.NotContains(t, files, "OEBPS/text/chapter-3.xhtml")
+
+	packageDocument := files["OEBPS/content.opf"]
+	assert.Contains(t, packageDocument, `<itemref idref="chapter-1"/>`)
+	assert.NotContains(t, packageDocument, "chapter-2")
+	assert.NotContains(t, packageDocument, "chapter-3")
+	assert.Contains(t, packageDocument, `<dc:title>Dune</dc:title>`)
+}
+
+func TestGenerator_GenerateEPUBShorterThanSample(t *testing.T) {
+	content := testEPUB(t)
+
+	sample, err := NewGenerator().Generate(catalog.FormatEPUB, bytes.NewReader(content), 5)
+	require.NoError(t, err)
+
+	assert.Equal(t, content, sample)
+}
+
+func TestGenerator_GenerateInvalidEPUB(t *testing.T) {
+	_, err := NewGenerator().Generate(catalog.FormatEPUB, strings.NewReader("not a zip"), 1)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidSampleSource)
+}
+
+func TestGenerator_GeneratePDF(t *testing.T) {
+	sample, err := NewGenerator().Generate(catalog.FormatPDF, bytes.NewReader(testPDF(5)), 2)
+	require.NoError(t, err)
+
+	document, err := pdf.Read(sample)
+	require.NoError(t, err)
+	assert.Equal(t, 2, document.NumPages())
+	assert.Contains(t, string(sample), "Page 2")
+	assert.NotContains(t, string(sample), "Page 3")
+}
+
+func TestGenerator_GenerateUnsupportedFormat(t *testing.T) {
+	_, err := NewGenerator().Generate(catalog.FormatMOBI, strings.NewReader("BOOKMOBI"), 1)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidSampleSource)
+}
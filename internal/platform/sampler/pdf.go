@@ -0,0 +1,23 @@
+package sampler
+
+import (
+	"bytes"
+
+	"github.com/ebookstore/internal/platform/pdf"
+)
+
+func samplePDF(content []byte, pages int) ([]byte, error) {
+	document, err := pdf.Read(content)
+	if err != nil {
+		return nil, err
+	}
+
+	document.KeepPages(pages)
+
+	var buf bytes.Buffer
+	if _, err = document.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ebookstore/internal/log"
+)
+
+// Job is a task that runs periodically in the background.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Scheduler runs each job in its own goroutine every time its interval elapses.
+type Scheduler struct {
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+// Start starts running the jobs. Jobs without a positive interval are disabled.
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			log.Infof(ctx, "job %s is disabled", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.run(ctx, job)
+	}
+}
+
+// Stop stops the jobs and waits for the running ones to finish.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			log.Debugf(ctx, "running job %s", job.Name)
+			if err := job.Run(ctx); err != nil {
+				log.Warnf(ctx, "job %s has failed: %v", job.Name, err)
+			}
+		}
+	}
+}
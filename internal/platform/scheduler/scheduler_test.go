@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduler_RunsJobsUntilStopped(t *testing.T) {
+	var runs int32
+	s := New(Job{
+		Name:     "counter",
+		Interval: time.Millisecond * 5,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return fmt.Errorf("failures don't stop the job")
+		},
+	})
+
+	s.Start(context.Background())
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) >= 2 }, time.Second, time.Millisecond)
+	s.Stop()
+
+	stopped := atomic.LoadInt32(&runs)
+	time.Sleep(time.Millisecond * 20)
+	assert.Equal(t, stopped, atomic.LoadInt32(&runs))
+}
+
+func TestScheduler_SkipsDisabledJobs(t *testing.T) {
+	s := New(Job{
+		Name: "disabled",
+		Run: func(ctx context.Context) error {
+			t.Fatal("disabled job must not run")
+			return nil
+		},
+	})
+
+	s.Start(context.Background())
+	s.Stop()
+}
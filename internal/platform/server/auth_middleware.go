@@ -54,3 +54,19 @@ func (m *AuthenticationMiddleware) Handler() gin.HandlerFunc {
 		context.Next()
 	}
 }
+
+// OptionalHandler identifies the user of public routes when a valid Bearer token is provided, so the responses
+// can depend on the user. Requests without one, or with an invalid one, go on anonymously.
+func (m *AuthenticationMiddleware) OptionalHandler() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		header := context.GetHeader("Authorization")
+		if match, err := regexp.MatchString("Bearer .+", header); match && err == nil {
+			if user, err := m.token.ExtractUserFromToken(header[7:]); err == nil {
+				context.Set("userId", user.ID)
+				context.Set("admin", user.IsAdmin())
+			}
+		}
+
+		context.Next()
+	}
+}
@@ -87,3 +87,36 @@ func (s *AuthMiddlewareTestSuite) TestHandler_WithValidToken() {
 
 	s.token.AssertNumberOfCalls(s.T(), extractUserFromTokenMethod, 1)
 }
+
+func (s *AuthMiddlewareTestSuite) TestOptionalHandler_WithoutHeader() {
+	s.middleware.OptionalHandler()(s.context)
+
+	assert.False(s.T(), s.context.IsAborted())
+	assert.Nil(s.T(), s.context.Value("admin"))
+
+	s.token.AssertNotCalled(s.T(), extractUserFromTokenMethod)
+}
+
+func (s *AuthMiddlewareTestSuite) TestOptionalHandler_WithInvalidToken() {
+	s.context.Request.Header.Set("Authorization", "Bearer token")
+
+	s.token.On(extractUserFromTokenMethod, "token").Return(auth.User{}, fmt.Errorf("some error"))
+
+	s.middleware.OptionalHandler()(s.context)
+
+	assert.False(s.T(), s.context.IsAborted())
+	assert.Nil(s.T(), s.context.Value("admin"))
+}
+
+func (s *AuthMiddlewareTestSuite) TestOptionalHandler_WithValidToken() {
+	s.context.Request.Header.Set("Authorization", "Bearer token")
+
+	user := auth.User{ID: "some-id", Role: auth.Admin}
+	s.token.On(extractUserFromTokenMethod, "token").Return(user, nil)
+
+	s.middleware.OptionalHandler()(s.context)
+
+	assert.False(s.T(), s.context.IsAborted())
+	assert.Equal(s.T(), user.ID, s.context.Value("userId").(string))
+	assert.True(s.T(), s.context.Value("admin").(bool))
+}
@@ -13,6 +13,10 @@ type Authenticator interface {
 	Register(context.Context, auth.RegisterRequest) (auth.CredentialsResponse, error)
 	Login(context.Context, auth.LoginRequest) (auth.CredentialsResponse, error)
 	ResetPassword(context.Context, auth.PasswordResetRequest) error
+	CreateAppToken(context.Context, auth.CreateAppTokenRequest) (auth.AppTokenResponse, error)
+	FindAppTokens(context.Context) ([]auth.AppTokenResponse, error)
+	DeleteAppToken(context.Context, string) error
+	SetLicensePassphrase(context.Context, auth.SetLicensePassphraseRequest) error
 }
 
 type AuthenticationHandler struct {
@@ -30,6 +34,10 @@ func (h *AuthenticationHandler) Routes() []Route {
 		{Method: http.MethodPost, Path: "/register", Handler: h.register, Public: true},
 		{Method: http.MethodPost, Path: "/login", Handler: h.login, Public: true},
 		{Method: http.MethodPost, Path: "/password-reset", Handler: h.resetPassword, Public: true},
+		{Method: http.MethodGet, Path: "/me/app-tokens", Handler: h.getAppTokens, Public: false},
+		{Method: http.MethodPost, Path: "/me/app-tokens", Handler: h.createAppToken, Public: false},
+		{Method: http.MethodDelete, Path: "/me/app-tokens/:id", Handler: h.deleteAppToken, Public: false},
+		{Method: http.MethodPut, Path: "/me/license-passphrase", Handler: h.setLicensePassphrase, Public: false},
 	}
 }
 
@@ -111,3 +119,89 @@ func (h *AuthenticationHandler) resetPassword(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// getAppTokens godoc
+// @Summary Fetch the app tokens of the current user
+// @Tags Auth
+// @Produce  json
+// @Success 200 {array} auth.AppTokenResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/app-tokens [get]
+func (h *AuthenticationHandler) getAppTokens(c *gin.Context) {
+	response, err := h.authenticator.FindAppTokens(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getAppTokens) failed handling find request: %w ", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// createAppToken godoc
+// @Summary Create an app token, so reading apps can access the library without the password
+// @Tags Auth
+// @Accept json
+// @Produce  json
+// @Param payload body auth.CreateAppTokenRequest true "App Token Payload"
+// @Success 201 {object} auth.AppTokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/app-tokens [post]
+func (h *AuthenticationHandler) createAppToken(c *gin.Context) {
+	var request auth.CreateAppTokenRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(createAppToken) failed binding request body: %w", err)})
+		return
+	}
+
+	response, err := h.authenticator.CreateAppToken(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(createAppToken) failed handling create request: %w ", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// deleteAppToken godoc
+// @Summary Revoke an app token of the current user
+// @Tags Auth
+// @Produce  json
+// @Param id path string true "App Token ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/app-tokens/{id} [delete]
+func (h *AuthenticationHandler) deleteAppToken(c *gin.Context) {
+	if err := h.authenticator.DeleteAppToken(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(deleteAppToken) failed handling delete request: %w ", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// setLicensePassphrase godoc
+// @Summary Set the passphrase that opens the licenses of the protected books in the reading apps
+// @Tags Auth
+// @Accept json
+// @Produce  json
+// @Param payload body auth.SetLicensePassphraseRequest true "License Passphrase Payload"
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/license-passphrase [put]
+func (h *AuthenticationHandler) setLicensePassphrase(c *gin.Context) {
+	var request auth.SetLicensePassphraseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(setLicensePassphrase) failed binding request body: %w", err)})
+		return
+	}
+
+	if err := h.authenticator.SetLicensePassphrase(c, request); err != nil {
+		_ = c.Error(fmt.Errorf("(setLicensePassphrase) failed handling set request: %w ", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -104,3 +104,70 @@ func (s *ServerSuiteTest) TestResetPassword_Success() {
 		Status(http.StatusNoContent).
 		End()
 }
+
+func (s *ServerSuiteTest) TestCreateAppToken_Success() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/me/app-tokens").
+		Header("Authorization", "Bearer "+token).
+		JSON(auth.CreateAppTokenRequest{Name: "My e-reader"}).
+		Expect(s.T()).
+		Status(http.StatusCreated).
+		Assert(jsonpath.Equal("$.name", "My e-reader")).
+		Assert(jsonpath.Present("$.token")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/me/app-tokens").
+		Header("Authorization", "Bearer "+token).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$", 1)).
+		Assert(jsonpath.NotPresent("$[0].token")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSetLicensePassphrase_Success() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/me/license-passphrase").
+		Header("Authorization", "Bearer "+token).
+		JSON(auth.SetLicensePassphraseRequest{Passphrase: "my license passphrase", Hint: "the usual one"}).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSetLicensePassphrase_WithInvalidData() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/me/license-passphrase").
+		Header("Authorization", "Bearer "+token).
+		JSON(auth.SetLicensePassphraseRequest{Passphrase: "short"}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) createAppToken(authToken string) auth.AppTokenResponse {
+	var response auth.AppTokenResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/me/app-tokens").
+		Header("Authorization", "Bearer "+authToken).
+		JSON(auth.CreateAppTokenRequest{Name: "My e-reader"}).
+		Expect(s.T()).
+		Status(http.StatusCreated).
+		End().
+		JSON(&response)
+
+	return response
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// basicAuthRealm is sent in the challenge, so the reading apps prompt for the credentials.
+const basicAuthRealm = `Basic realm="Ebook Store", charset="UTF-8"`
+
+type CredentialsAuthenticator interface {
+	AuthenticateBasic(ctx context.Context, email, secret string) (auth.User, error)
+	AuthenticateAppToken(ctx context.Context, token string) (auth.User, error)
+}
+
+// BasicAuthenticationMiddleware authenticates the clients that can't log in to get a JWT token, like e-readers.
+// They send the email and the password (or an app token) using HTTP Basic, or an app token as a Bearer token.
+type BasicAuthenticationMiddleware struct {
+	authenticator CredentialsAuthenticator
+}
+
+func NewBasicAuthenticationMiddleware(authenticator CredentialsAuthenticator) *BasicAuthenticationMiddleware {
+	return &BasicAuthenticationMiddleware{authenticator}
+}
+
+func (m *BasicAuthenticationMiddleware) Handler() gin.HandlerFunc {
+	return func(context *gin.Context) {
+		var user auth.User
+		var err error
+
+		header := context.GetHeader("Authorization")
+		if email, secret, ok := context.Request.BasicAuth(); ok {
+			user, err = m.authenticator.AuthenticateBasic(context, email, secret)
+		} else if strings.HasPrefix(header, "Bearer ") && len(header) > 7 {
+			user, err = m.authenticator.AuthenticateAppToken(context, header[7:])
+		} else {
+			m.abort(context, "The 'Authorization' header must be in the format 'Basic credentials' or 'Bearer token'")
+			return
+		}
+
+		if err != nil {
+			m.abort(context, "The provided credentials are not valid")
+			return
+		}
+
+		context.Set("userId", user.ID)
+		context.Set("admin", user.IsAdmin())
+		context.Next()
+	}
+}
+
+func (m *BasicAuthenticationMiddleware) abort(context *gin.Context, details string) {
+	context.Header("WWW-Authenticate", basicAuthRealm)
+	context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+		"message": "You are not authorized.",
+		"details": details,
+	})
+}
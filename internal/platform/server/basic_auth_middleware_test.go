@@ -0,0 +1,83 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/platform/server"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	authenticateBasicMethod    = "AuthenticateBasic"
+	authenticateAppTokenMethod = "AuthenticateAppToken"
+)
+
+type BasicAuthMiddlewareTestSuite struct {
+	suite.Suite
+	recorder      *httptest.ResponseRecorder
+	context       *gin.Context
+	authenticator *server.MockCredentialsAuthenticator
+	middleware    *server.BasicAuthenticationMiddleware
+}
+
+func (s *BasicAuthMiddlewareTestSuite) SetupTest() {
+	s.recorder = httptest.NewRecorder()
+	s.context, _ = gin.CreateTestContext(s.recorder)
+	s.context.Request = httptest.NewRequest("GET", "/opds/library", nil)
+
+	s.authenticator = new(server.MockCredentialsAuthenticator)
+	s.middleware = server.NewBasicAuthenticationMiddleware(s.authenticator)
+}
+
+func TestBasicAuthMiddlewareRun(t *testing.T) {
+	suite.Run(t, new(BasicAuthMiddlewareTestSuite))
+}
+
+func (s *BasicAuthMiddlewareTestSuite) TestHandler_WithoutHeader() {
+	s.middleware.Handler()(s.context)
+
+	assert.True(s.T(), s.context.IsAborted())
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+	assert.Contains(s.T(), s.recorder.Header().Get("WWW-Authenticate"), "Basic")
+}
+
+func (s *BasicAuthMiddlewareTestSuite) TestHandler_WithInvalidCredentials() {
+	s.context.Request.SetBasicAuth("raphael@test.com", "password")
+	s.authenticator.On(authenticateBasicMethod, mock.Anything, "raphael@test.com", "password").Return(auth.User{}, fmt.Errorf("some error"))
+
+	s.middleware.Handler()(s.context)
+
+	assert.True(s.T(), s.context.IsAborted())
+	assert.Equal(s.T(), http.StatusUnauthorized, s.recorder.Code)
+}
+
+func (s *BasicAuthMiddlewareTestSuite) TestHandler_WithValidCredentials() {
+	s.context.Request.SetBasicAuth("raphael@test.com", "password")
+	s.authenticator.On(authenticateBasicMethod, mock.Anything, "raphael@test.com", "password").Return(auth.User{ID: "some-id", Role: auth.Admin}, nil)
+
+	s.middleware.Handler()(s.context)
+
+	assert.False(s.T(), s.context.IsAborted())
+	assert.Equal(s.T(), "some-id", s.context.GetString("userId"))
+	assert.True(s.T(), s.context.GetBool("admin"))
+	s.authenticator.AssertNotCalled(s.T(), authenticateAppTokenMethod)
+}
+
+func (s *BasicAuthMiddlewareTestSuite) TestHandler_WithValidAppToken() {
+	s.context.Request.Header.Set("Authorization", "Bearer app-token")
+	s.authenticator.On(authenticateAppTokenMethod, mock.Anything, "app-token").Return(auth.User{ID: "some-id", Role: auth.Customer}, nil)
+
+	s.middleware.Handler()(s.context)
+
+	assert.False(s.T(), s.context.IsAborted())
+	assert.Equal(s.T(), "some-id", s.context.GetString("userId"))
+	assert.False(s.T(), s.context.GetBool("admin"))
+	s.authenticator.AssertNotCalled(s.T(), authenticateBasicMethod)
+}
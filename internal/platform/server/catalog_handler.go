@@ -3,9 +3,11 @@ package server
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/log"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,7 +17,39 @@ type Catalog interface {
 	CreateBook(context.Context, catalog.CreateBook) (catalog.BookResponse, error)
 	UpdateBook(context.Context, catalog.UpdateBook) error
 	DeleteBook(context.Context, string) error
-	GeneratePutPreSignedUrl(context.Context) (catalog.PresignURLResponse, error)
+	RestoreBook(context.Context, string) error
+	PurgeBook(context.Context, string) error
+	PublishBook(context.Context, catalog.PublishBook) (catalog.BookResponse, error)
+	UnpublishBook(context.Context, string) error
+	SaveBookFile(context.Context, catalog.SaveBookFile) (catalog.BookResponse, error)
+	DeleteBookFile(context.Context, string, catalog.BookFormat) error
+	AddBookImage(context.Context, catalog.AddBookImage) (catalog.BookResponse, error)
+	RemoveBookImage(context.Context, string, string) error
+	ReorderBookImages(context.Context, catalog.ReorderBookImages) (catalog.BookResponse, error)
+	SetMainBookImage(context.Context, string, string) (catalog.BookResponse, error)
+	AttachBookSample(context.Context, catalog.AttachBookSample) (catalog.BookResponse, error)
+	GenerateBookSample(context.Context, catalog.GenerateBookSample) (catalog.BookResponse, error)
+	GetBookSampleURL(context.Context, string) (catalog.SampleResponse, error)
+	GeneratePutPreSignedUrl(context.Context, catalog.PresignUpload) (catalog.PresignURLResponse, error)
+	FindCategories(context.Context) ([]catalog.CategoryResponse, error)
+	FindCategoryByID(context.Context, string) (catalog.CategoryResponse, error)
+	CreateCategory(context.Context, catalog.CreateCategory) (catalog.CategoryResponse, error)
+	UpdateCategory(context.Context, catalog.UpdateCategory) error
+	DeleteCategory(context.Context, string) error
+	FindAuthorByID(context.Context, string) (catalog.AuthorResponse, error)
+	CreateAuthor(context.Context, catalog.CreateAuthor) (catalog.AuthorResponse, error)
+	UpdateAuthor(context.Context, catalog.UpdateAuthor) error
+	DeleteAuthor(context.Context, string) error
+	ImportBooks(context.Context, catalog.ImportBooks) (catalog.ImportReportResponse, error)
+	ExportBooks(context.Context, catalog.ExportBooks, io.Writer) error
+	FindPublicFeed(context.Context) ([]byte, error)
+}
+
+// exportContentTypes are the content types and file extensions of the export formats.
+var exportContentTypes = map[string][2]string{
+	catalog.ExportFormatCSV:            {"text/csv; charset=utf-8", "csv"},
+	catalog.ExportFormatJSONL:          {"application/x-ndjson", "jsonl"},
+	catalog.ExportFormatGoogleMerchant: {"application/xml; charset=utf-8", "xml"},
 }
 
 type CatalogHandler struct {
@@ -28,14 +62,42 @@ func NewCatalogHandler(catalog Catalog) *CatalogHandler {
 	}
 }
 
+// importMaxBodySize limits the import requests, since catalog files are larger than the other request bodies.
+const importMaxBodySize = 50 << 20 // 50MB
+
 func (h *CatalogHandler) Routes() []Route {
 	return []Route{
 		{Method: http.MethodGet, Path: "/books", Handler: h.getBooks, Public: true},
 		{Method: http.MethodGet, Path: "/books/:id", Handler: h.getBook, Public: true},
 		{Method: http.MethodPost, Path: "/books", Handler: h.createBook, Public: false},
+		{Method: http.MethodPost, Path: "/books/import", Handler: h.importBooks, Public: false, MaxBodySize: importMaxBodySize},
+		{Method: http.MethodGet, Path: "/books/export", Handler: h.exportBooks, Public: false},
+		{Method: http.MethodGet, Path: "/feeds/google-merchant.xml", Handler: h.getPublicFeed, Public: true},
 		{Method: http.MethodPatch, Path: "/books/:id", Handler: h.updateBook, Public: false},
 		{Method: http.MethodDelete, Path: "/books/:id", Handler: h.deleteBook, Public: false},
+		{Method: http.MethodPost, Path: "/books/:id/restore", Handler: h.restoreBook, Public: false},
+		{Method: http.MethodDelete, Path: "/books/:id/purge", Handler: h.purgeBook, Public: false},
+		{Method: http.MethodPost, Path: "/books/:id/publish", Handler: h.publishBook, Public: false},
+		{Method: http.MethodPost, Path: "/books/:id/unpublish", Handler: h.unpublishBook, Public: false},
+		{Method: http.MethodPut, Path: "/books/:id/files/:format", Handler: h.saveBookFile, Public: false},
+		{Method: http.MethodDelete, Path: "/books/:id/files/:format", Handler: h.deleteBookFile, Public: false},
+		{Method: http.MethodPost, Path: "/books/:id/images", Handler: h.addBookImage, Public: false},
+		{Method: http.MethodPut, Path: "/books/:id/images/order", Handler: h.reorderBookImages, Public: false},
+		{Method: http.MethodPut, Path: "/books/:id/images/:imageId/main", Handler: h.setMainBookImage, Public: false},
+		{Method: http.MethodDelete, Path: "/books/:id/images/:imageId", Handler: h.removeBookImage, Public: false},
+		{Method: http.MethodGet, Path: "/books/:id/sample", Handler: h.getBookSample, Public: true},
+		{Method: http.MethodPut, Path: "/books/:id/sample", Handler: h.attachBookSample, Public: false},
+		{Method: http.MethodPost, Path: "/books/:id/sample/generate", Handler: h.generateBookSample, Public: false},
 		{Method: http.MethodPost, Path: "/presign-url", Handler: h.generatePutPreSignedUrl, Public: false},
+		{Method: http.MethodGet, Path: "/categories", Handler: h.getCategories, Public: true},
+		{Method: http.MethodGet, Path: "/categories/:id", Handler: h.getCategory, Public: true},
+		{Method: http.MethodPost, Path: "/categories", Handler: h.createCategory, Public: false},
+		{Method: http.MethodPatch, Path: "/categories/:id", Handler: h.updateCategory, Public: false},
+		{Method: http.MethodDelete, Path: "/categories/:id", Handler: h.deleteCategory, Public: false},
+		{Method: http.MethodGet, Path: "/authors/:id", Handler: h.getAuthor, Public: true},
+		{Method: http.MethodPost, Path: "/authors", Handler: h.createAuthor, Public: false},
+		{Method: http.MethodPatch, Path: "/authors/:id", Handler: h.updateAuthor, Public: false},
+		{Method: http.MethodDelete, Path: "/authors/:id", Handler: h.deleteAuthor, Public: false},
 	}
 }
 
@@ -110,6 +172,104 @@ func (h *CatalogHandler) createBook(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
+// importBooks godoc
+// @Summary Create or update Books from a CSV or ONIX catalog file
+// @Tags Catalog
+// @Accept mpfd
+// @Produce  json
+// @Param format formData string true "File format" Enums(csv, onix)
+// @Param dryRun formData bool false "Validate the file without importing it"
+// @Param file formData file true "Catalog file"
+// @Success 200 {object} catalog.ImportReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/import [post]
+func (h *CatalogHandler) importBooks(c *gin.Context) {
+	var request catalog.ImportBooks
+	if err := c.ShouldBind(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(importBooks) failed binding request body: %w", err)})
+		return
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(importBooks) failed reading file: %w", err)})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(importBooks) failed opening file: %w", err))
+		return
+	}
+	defer file.Close()
+
+	request.File = file
+	response, err := h.catalog.ImportBooks(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(importBooks) failed handling import request: %w ", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// exportBooks godoc
+// @Summary Export the whole catalog as a file
+// @Tags Catalog
+// @Produce  text/csv,application/x-ndjson,application/xml
+// @Param params query catalog.ExportBooks true "Export format"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/export [get]
+func (h *CatalogHandler) exportBooks(c *gin.Context) {
+	var request catalog.ExportBooks
+	if err := c.ShouldBindQuery(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(exportBooks) failed binding query: %w", err)})
+		return
+	}
+
+	contentType := exportContentTypes[request.Format]
+	writer := &attachmentWriter{
+		context:     c,
+		contentType: contentType[0],
+		filename:    "books." + contentType[1],
+	}
+
+	if err := h.catalog.ExportBooks(c, request, writer); err != nil {
+		// Once the file started streaming the status cannot be changed, so the export is just cut
+		if writer.started {
+			log.Warnf(c, "failed exporting books after streaming started: %v", err)
+			return
+		}
+
+		_ = c.Error(fmt.Errorf("(exportBooks) failed handling export request: %w ", err))
+		return
+	}
+
+	writer.start()
+}
+
+// getPublicFeed godoc
+// @Summary Fetch the product feed of the catalog in the Google Merchant format
+// @Tags Catalog
+// @Produce  application/xml
+// @Success 200 {file} file
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/feeds/google-merchant.xml [get]
+func (h *CatalogHandler) getPublicFeed(c *gin.Context) {
+	feed, err := h.catalog.FindPublicFeed(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getPublicFeed) failed handling feed request: %w ", err))
+		return
+	}
+
+	c.Data(http.StatusOK, exportContentTypes[catalog.PublicFeedFormat][0], feed)
+}
+
 // updateBook godoc
 // @Summary Update the provided Book
 // @Tags Catalog
@@ -139,7 +299,7 @@ func (h *CatalogHandler) updateBook(c *gin.Context) {
 }
 
 // deleteBook godoc
-// @Summary Delete a Book
+// @Summary Archive a Book, withdrawing it from sale
 // @Tags Catalog
 // @Produce  json
 // @Param id path string true "Book ID"
@@ -156,15 +316,338 @@ func (h *CatalogHandler) deleteBook(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// restoreBook godoc
+// @Summary Restore an archived Book
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/restore [post]
+func (h *CatalogHandler) restoreBook(c *gin.Context) {
+	if err := h.catalog.RestoreBook(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(restoreBook) failed handling restore request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// purgeBook godoc
+// @Summary Delete an archived Book permanently
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/purge [delete]
+func (h *CatalogHandler) purgeBook(c *gin.Context) {
+	if err := h.catalog.PurgeBook(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(purgeBook) failed handling purge request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// publishBook godoc
+// @Summary Publish a Book now or schedule it for later
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.PublishBook false "Publish Payload"
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/publish [post]
+func (h *CatalogHandler) publishBook(c *gin.Context) {
+	var request catalog.PublishBook
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			_ = c.Error(&BindingErr{Err: fmt.Errorf("(publishBook) failed binding request body: %w", err)})
+			return
+		}
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.catalog.PublishBook(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(publishBook) failed handling publish request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// unpublishBook godoc
+// @Summary Withdraw a Book from the catalog
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/unpublish [post]
+func (h *CatalogHandler) unpublishBook(c *gin.Context) {
+	if err := h.catalog.UnpublishBook(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(unpublishBook) failed handling unpublish request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// saveBookFile godoc
+// @Summary Add or replace a downloadable format of a Book
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.BookFileRequest true "Book File Payload"
+// @Param id path string true "Book ID"
+// @Param format path string true "Format" Enums(pdf, epub, mobi)
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/files/{format} [put]
+func (h *CatalogHandler) saveBookFile(c *gin.Context) {
+	var request catalog.SaveBookFile
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(saveBookFile) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	request.Format = catalog.BookFormat(c.Param("format"))
+	response, err := h.catalog.SaveBookFile(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(saveBookFile) failed handling save request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// deleteBookFile godoc
+// @Summary Remove a downloadable format of a Book
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param format path string true "Format" Enums(pdf, epub, mobi)
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/files/{format} [delete]
+func (h *CatalogHandler) deleteBookFile(c *gin.Context) {
+	if err := h.catalog.DeleteBookFile(c, c.Param("id"), catalog.BookFormat(c.Param("format"))); err != nil {
+		_ = c.Error(fmt.Errorf("(deleteBookFile) failed handling delete request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// addBookImage godoc
+// @Summary Add an image after the existing images of a Book
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.ImageRequest true "Image Payload"
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/images [post]
+func (h *CatalogHandler) addBookImage(c *gin.Context) {
+	var request catalog.AddBookImage
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(addBookImage) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.catalog.AddBookImage(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(addBookImage) failed handling add request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// reorderBookImages godoc
+// @Summary Change the order of the images of a Book
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.ReorderBookImages true "Image Order Payload"
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/images/order [put]
+func (h *CatalogHandler) reorderBookImages(c *gin.Context) {
+	var request catalog.ReorderBookImages
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(reorderBookImages) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.catalog.ReorderBookImages(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(reorderBookImages) failed handling reorder request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// setMainBookImage godoc
+// @Summary Choose the image used as the cover of a Book
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param imageId path string true "Image ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/images/{imageId}/main [put]
+func (h *CatalogHandler) setMainBookImage(c *gin.Context) {
+	response, err := h.catalog.SetMainBookImage(c, c.Param("id"), c.Param("imageId"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(setMainBookImage) failed handling update request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// removeBookImage godoc
+// @Summary Remove an image of a Book
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param imageId path string true "Image ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/images/{imageId} [delete]
+func (h *CatalogHandler) removeBookImage(c *gin.Context) {
+	if err := h.catalog.RemoveBookImage(c, c.Param("id"), c.Param("imageId")); err != nil {
+		_ = c.Error(fmt.Errorf("(removeBookImage) failed handling delete request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getBookSample godoc
+// @Summary Get a link to the free sample of a Book
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.SampleResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/sample [get]
+func (h *CatalogHandler) getBookSample(c *gin.Context) {
+	response, err := h.catalog.GetBookSampleURL(c, c.Param("id"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getBookSample) failed handling get request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// attachBookSample godoc
+// @Summary Set an uploaded file as the free sample of a Book
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.AttachBookSample true "Sample Payload"
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/sample [put]
+func (h *CatalogHandler) attachBookSample(c *gin.Context) {
+	var request catalog.AttachBookSample
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(attachBookSample) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.catalog.AttachBookSample(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(attachBookSample) failed handling attach request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// generateBookSample godoc
+// @Summary Generate the free sample of a Book from its content
+// @Description The sample has the first pages of the PDF or the first spine items of the EPUB.
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.GenerateBookSample true "Sample Payload"
+// @Param id path string true "Book ID"
+// @Success 200 {object} catalog.BookResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/sample/generate [post]
+func (h *CatalogHandler) generateBookSample(c *gin.Context) {
+	var request catalog.GenerateBookSample
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(generateBookSample) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.catalog.GenerateBookSample(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(generateBookSample) failed handling generate request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // generatePutPreSignedUrl godoc
-// @Summary Generate a Presigned URL for saving an image poster to S3
+// @Summary Generate a Presigned URL for uploading an image or a book file to S3
+// @Description The upload must have the requested content type and size, and the checksum when one is requested.
 // @Tags Catalog
+// @Accept json
 // @Produce  json
+// @Param payload body catalog.PresignUpload true "Upload Payload"
 // @Success 200 {object} catalog.PresignURLResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/presign-url [post]
 func (h *CatalogHandler) generatePutPreSignedUrl(c *gin.Context) {
-	response, err := h.catalog.GeneratePutPreSignedUrl(c)
+	var request catalog.PresignUpload
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(generatePutPreSignedUrl) failed binding request body: %w", err)})
+		return
+	}
+
+	response, err := h.catalog.GeneratePutPreSignedUrl(c, request)
 	if err != nil {
 		_ = c.Error(fmt.Errorf("(generatePutPreSignedUrl) failed handling generate request: %w ", err))
 		return
@@ -172,3 +655,232 @@ func (h *CatalogHandler) generatePutPreSignedUrl(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// getCategories godoc
+// @Summary Fetch the Category tree with the number of books of each category
+// @Tags Catalog
+// @Produce  json
+// @Success 200 {array} catalog.CategoryResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/categories [get]
+func (h *CatalogHandler) getCategories(c *gin.Context) {
+	response, err := h.catalog.FindCategories(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getCategories) failed handling find request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// getCategory godoc
+// @Summary Fetch Category by ID
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Category ID"
+// @Success 200 {object} catalog.CategoryResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/categories/{id} [get]
+func (h *CatalogHandler) getCategory(c *gin.Context) {
+	response, err := h.catalog.FindCategoryByID(c, c.Param("id"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getCategory) failed handling get request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// createCategory godoc
+// @Summary Create a new Category
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.CreateCategory true "Category Payload"
+// @Success 201 {object} catalog.CategoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/categories [post]
+func (h *CatalogHandler) createCategory(c *gin.Context) {
+	var request catalog.CreateCategory
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(createCategory) failed binding request body: %w", err)})
+		return
+	}
+
+	response, err := h.catalog.CreateCategory(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(createCategory) failed handling create request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// updateCategory godoc
+// @Summary Update the provided Category
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.UpdateCategory true "Category Payload"
+// @Param id path string true "Category ID"
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/categories/{id} [patch]
+func (h *CatalogHandler) updateCategory(c *gin.Context) {
+	var request catalog.UpdateCategory
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(updateCategory) failed binding request body: %w", err)})
+		return
+	}
+
+	request.ID = c.Param("id")
+	if err := h.catalog.UpdateCategory(c, request); err != nil {
+		_ = c.Error(fmt.Errorf("(updateCategory) failed handling update request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteCategory godoc
+// @Summary Delete a Category
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Category ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/categories/{id} [delete]
+func (h *CatalogHandler) deleteCategory(c *gin.Context) {
+	if err := h.catalog.DeleteCategory(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(deleteCategory) failed handling delete request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// getAuthor godoc
+// @Summary Fetch Author by ID with the books it is credited in
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Author ID"
+// @Success 200 {object} catalog.AuthorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/authors/{id} [get]
+func (h *CatalogHandler) getAuthor(c *gin.Context) {
+	response, err := h.catalog.FindAuthorByID(c, c.Param("id"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getAuthor) failed handling get request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// createAuthor godoc
+// @Summary Create a new Author
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.CreateAuthor true "Author Payload"
+// @Success 201 {object} catalog.AuthorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/authors [post]
+func (h *CatalogHandler) createAuthor(c *gin.Context) {
+	var request catalog.CreateAuthor
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(createAuthor) failed binding request body: %w", err)})
+		return
+	}
+
+	response, err := h.catalog.CreateAuthor(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(createAuthor) failed handling create request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// updateAuthor godoc
+// @Summary Update the provided Author
+// @Tags Catalog
+// @Accept json
+// @Produce  json
+// @Param payload body catalog.UpdateAuthor true "Author Payload"
+// @Param id path string true "Author ID"
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/authors/{id} [patch]
+func (h *CatalogHandler) updateAuthor(c *gin.Context) {
+	var request catalog.UpdateAuthor
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(updateAuthor) failed binding request body: %w", err)})
+		return
+	}
+
+	request.ID = c.Param("id")
+	if err := h.catalog.UpdateAuthor(c, request); err != nil {
+		_ = c.Error(fmt.Errorf("(updateAuthor) failed handling update request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteAuthor godoc
+// @Summary Delete an Author
+// @Tags Catalog
+// @Produce  json
+// @Param id path string true "Author ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/authors/{id} [delete]
+func (h *CatalogHandler) deleteAuthor(c *gin.Context) {
+	if err := h.catalog.DeleteAuthor(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(deleteAuthor) failed handling delete request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// attachmentWriter streams a file download. The headers are only written with the first bytes,
+// so an error found before anything is exported still gets an error response.
+type attachmentWriter struct {
+	context     *gin.Context
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *attachmentWriter) start() {
+	if w.started {
+		return
+	}
+
+	w.started = true
+	w.context.Header("Content-Type", w.contentType)
+	w.context.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+	w.context.Status(http.StatusOK)
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	w.start()
+	return w.context.Writer.Write(p)
+}
@@ -2,9 +2,15 @@
 package server_test
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
-	"os"
+	"strings"
 	"time"
 
 	"github.com/ebookstore/internal/core/catalog"
@@ -13,14 +19,17 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// testFileChecksum is the SHA-256 checksum given to the files of the books created by the tests.
+const testFileChecksum = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
 func (s *ServerSuiteTest) TestCreateBook_Unauthorized() {
 	token := s.createDefaultCustomer()
 
 	req := catalog.CreateBook{
 		Title:       "Domain Design Driven",
 		Description: "Complexity",
-		AuthorName:  "Eric Evans",
-		ContentID:   "123",
+		Authors:     []catalog.BookAuthorRequest{{AuthorID: "author-1"}},
+		Files:       []catalog.BookFileRequest{{Format: catalog.FormatPDF, ContentID: "123", Size: 1024, Checksum: testFileChecksum}},
 		Images: []catalog.ImageRequest{
 			{ID: "image-1", Description: "The first image"},
 		},
@@ -45,8 +54,8 @@ func (s *ServerSuiteTest) TestCreateBook_InvalidPayload() {
 	req := catalog.CreateBook{
 		Title:       "",
 		Description: "Complexity",
-		AuthorName:  "Eric Evans",
-		ContentID:   "123",
+		Authors:     []catalog.BookAuthorRequest{{AuthorID: "author-1"}},
+		Files:       []catalog.BookFileRequest{{Format: catalog.FormatPDF, ContentID: "123", Size: 1024, Checksum: testFileChecksum}},
 		Images: []catalog.ImageRequest{
 			{ID: "image-1", Description: "The first image"},
 		},
@@ -71,16 +80,336 @@ func (s *ServerSuiteTest) TestCreateBook_Success() {
 	s.T().Log(response)
 }
 
+func (s *ServerSuiteTest) TestSaveBookFile_Success() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+	contentID, content := s.uploadFile(token, "testdata/book1_content.pdf", catalog.FormatEPUB.MediaType())
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/files/epub").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.BookFileRequest{ContentID: contentID, Size: content.Size, Checksum: content.Checksum}).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.formats", 2)).
+		Assert(jsonpath.Equal("$.formats[1].format", "epub")).
+		Assert(jsonpath.Equal("$.formats[1].mediaType", "application/epub+zip")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSaveBookFile_UploadNotFound() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/files/epub").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.BookFileRequest{ContentID: "missing-content", Size: 512, Checksum: testFileChecksum}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		Assert(jsonpath.Equal("$.message", "the uploaded content does not exist")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSaveBookFile_WrongContentType() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+	contentID, content := s.uploadFile(token, "testdata/book1_content.pdf", catalog.FormatPDF.MediaType())
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/files/epub").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.BookFileRequest{ContentID: contentID, Size: content.Size, Checksum: content.Checksum}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGeneratePutPreSignedUrl_ContentTypeNotAllowed() {
+	token := s.createDefaultAdmin()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/presign-url").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.PresignUpload{ContentType: "application/x-msdownload", Size: 1024}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSaveBookFile_InvalidFormat() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/files/doc").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.BookFileRequest{ContentID: "doc-content", Size: 512, Checksum: testFileChecksum}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) TestDeleteBookFile_LastFile() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Delete(s.baseURL+"/api/v1/books/"+book.ID+"/files/pdf").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) TestDeleteBookFile_NotAvailable() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Delete(s.baseURL+"/api/v1/books/"+book.ID+"/files/mobi").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (s *ServerSuiteTest) TestAddBookImage_ChoosesCover() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+	imageID, _ := s.uploadFile(token, "testdata/book1_image.jpg", "image/jpeg")
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/images").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.ImageRequest{ID: imageID, Description: "Cover", IsMain: true}).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.images", 2)).
+		Assert(jsonpath.Equal("$.images[1].id", imageID)).
+		Assert(jsonpath.Equal("$.mainImageId", imageID)).
+		End()
+}
+
+func (s *ServerSuiteTest) TestReorderBookImages_InvalidOrder() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/images/order").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.ReorderBookImages{ImageIDs: []string{"unknown-image"}}).
+		Expect(s.T()).
+		Status(http.StatusBadRequest).
+		End()
+}
+
+func (s *ServerSuiteTest) TestSetMainBookImage_NotFound() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Put(s.baseURL+"/api/v1/books/"+book.ID+"/images/unknown-image/main").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNotFound).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGetBookSample_NotAvailable() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID + "/sample").
+		Expect(s.T()).
+		Status(http.StatusNotFound).
+		Assert(jsonpath.Equal("$.message", "the book has no sample")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGenerateBookSample_Success() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/sample/generate").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.GenerateBookSample{Length: 1}).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.sample.format", "pdf")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID + "/sample").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Present("$.url")).
+		Assert(jsonpath.Equal("$.mediaType", "application/pdf")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGenerateBookSample_Unauthorized() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/some-id/sample/generate").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.GenerateBookSample{Length: 1}).
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (s *ServerSuiteTest) TestImportBooks_Unauthorized() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/import").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		MultipartFormData("format", catalog.ImportFormatCSV).
+		MultipartFile("file", "testdata/catalog_import.csv").
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (s *ServerSuiteTest) TestImportBooks_DryRun() {
+	token := s.createDefaultAdmin()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/import").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		MultipartFormData("format", catalog.ImportFormatCSV).
+		MultipartFormData("dryRun", "true").
+		MultipartFile("file", "testdata/catalog_import.csv").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.dryRun", true)).
+		Assert(jsonpath.Equal("$.created", float64(1))).
+		Assert(jsonpath.Equal("$.failed", float64(1))).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/books").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.results", 0)).
+		End()
+}
+
+func (s *ServerSuiteTest) TestImportBooks_Success() {
+	token := s.createDefaultAdmin()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/import").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		MultipartFormData("format", catalog.ImportFormatCSV).
+		MultipartFile("file", "testdata/catalog_import.csv").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.created", float64(1))).
+		Assert(jsonpath.Equal("$.rows[0].status", "created")).
+		Assert(jsonpath.Equal("$.rows[1].status", "failed")).
+		End()
+
+	// Importing the same file again updates the book found by ISBN
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/import").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		MultipartFormData("format", catalog.ImportFormatCSV).
+		MultipartFile("file", "testdata/catalog_import.csv").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.updated", float64(1))).
+		Assert(jsonpath.Equal("$.rows[0].externalId", "DDD-1")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestExportBooks_Unauthorized() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/books/export").
+		Query("format", catalog.ExportFormatCSV).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (s *ServerSuiteTest) TestExportBooks_Success() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/books/export").
+		Query("format", catalog.ExportFormatJSONL).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Header("Content-Type", "application/x-ndjson").
+		Header("Content-Disposition", `attachment; filename="books.jsonl"`).
+		Assert(jsonpath.Equal("$.id", book.ID)).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGetPublicFeed_Success() {
+	book := s.createBook(s.createDefaultAdmin())
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/feeds/google-merchant.xml").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Header("Content-Type", "application/xml; charset=utf-8").
+		Assert(func(response *http.Response, request *http.Request) error {
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				return err
+			}
+			if !strings.Contains(string(body), "<g:id>"+book.ID+"</g:id>") {
+				return fmt.Errorf("book %s not found in feed", book.ID)
+			}
+			return nil
+		}).
+		End()
+}
+
 func (s *ServerSuiteTest) TestGetBooks_Success() {
 	book := s.createBook(s.createDefaultAdmin())
 	token := s.createDefaultCustomer()
 
+	currentPage, totalPages, totalItems := 1, 1, int64(1)
 	expected := catalog.PaginatedBooksResponse{
 		Results:     []catalog.BookResponse{book},
-		CurrentPage: 1,
+		CurrentPage: &currentPage,
 		PerPage:     15,
-		TotalPages:  1,
-		TotalItems:  1,
+		TotalPages:  &totalPages,
+		TotalItems:  &totalItems,
 	}
 	var actual catalog.PaginatedBooksResponse
 
@@ -130,7 +459,7 @@ func (s *ServerSuiteTest) TestGetBook_Success() {
 	s.Equal(book.ID, actual.ID)
 	s.Equal(book.Title, actual.Title)
 	s.Equal(book.Description, actual.Description)
-	s.Equal(book.AuthorName, actual.AuthorName)
+	s.Equal(book.Authors, actual.Authors)
 }
 
 func (s *ServerSuiteTest) TestUpdateBook_Unauthorized() {
@@ -141,7 +470,6 @@ func (s *ServerSuiteTest) TestUpdateBook_Unauthorized() {
 		ID:          book.ID,
 		Title:       &book.Title,
 		Description: &book.Description,
-		AuthorName:  &book.AuthorName,
 	}
 
 	apitest.New().
@@ -163,7 +491,6 @@ func (s *ServerSuiteTest) TestUpdateBook_Success() {
 		ID:          book.ID,
 		Title:       &book.Title,
 		Description: &book.Description,
-		AuthorName:  &book.AuthorName,
 	}
 
 	apitest.New().
@@ -216,32 +543,168 @@ func (s *ServerSuiteTest) TestDeleteBook_Success() {
 		End()
 }
 
-func (s *ServerSuiteTest) createBook(authToken string) catalog.BookResponse {
-	var presignResponse catalog.PresignURLResponse
+func (s *ServerSuiteTest) TestDeleteBook_KeepsBookAvailableToRestore() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
 
 	apitest.New().
 		EnableNetworking().
-		Post(s.baseURL+"/api/v1/presign-url").
-		Header("Authorization", fmt.Sprintf("Bearer %v", authToken)).
+		Delete(s.baseURL+"/api/v1/books/"+book.ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Present("$.archivedAt")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/restore").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+}
+
+func (s *ServerSuiteTest) TestPurgeBook_NotArchived() {
+	token := s.createDefaultAdmin()
+	book := s.createBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Delete(s.baseURL+"/api/v1/books/"+book.ID+"/purge").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusConflict).
+		Assert(jsonpath.Equal("$.message", "only archived books can be purged")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestCreateBook_HiddenUntilPublished() {
+	token := s.createDefaultAdmin()
+	book := s.createDraftBook(token)
+	s.Equal(catalog.StatusDraft, book.Status)
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID).
+		Expect(s.T()).
+		Status(http.StatusNotFound).
+		Assert(jsonpath.Equal("$.message", "the book is not published")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/publish").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.status", "published")).
+		Assert(jsonpath.Present("$.publishAt")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		End()
+}
+
+func (s *ServerSuiteTest) TestPublishBook_Scheduled() {
+	token := s.createDefaultAdmin()
+	book := s.createDraftBook(token)
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/publish").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(map[string]interface{}{"publishAt": time.Now().Add(24 * time.Hour)}).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.status", "scheduled")).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/unpublish").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/books/"+book.ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.status", "draft")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestCreateAuthor_Unauthorized() {
+	token := s.createDefaultCustomer()
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/authors").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(catalog.CreateAuthor{Name: "Eric Evans"}).
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		Assert(jsonpath.Equal("$.message", "the access to this action is restricted to allowed users")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGetAuthor_Success() {
+	book := s.createBook(s.createDefaultAdmin())
+	token := s.createDefaultCustomer()
+
+	var actual catalog.AuthorResponse
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/authors/"+book.Authors[0].ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
 		Expect(s.T()).
 		Status(http.StatusOK).
 		End().
-		JSON(&presignResponse)
+		JSON(&actual)
 
-	s.putFile(presignResponse.URL, "testdata/book1_image.jpg")
-	imageID := presignResponse.ID
+	s.Equal("Eric Evans", actual.Name)
+	s.Equal("eric-evans", actual.Slug)
+	s.Require().Len(actual.Books, 1)
+	s.Equal(book.ID, actual.Books[0].ID)
+	s.Equal(catalog.RoleAuthor, actual.Books[0].Role)
+}
+
+// createBook creates a book and publishes it, so customers can find and buy it.
+func (s *ServerSuiteTest) createBook(authToken string) catalog.BookResponse {
+	book := s.createDraftBook(authToken)
 
 	apitest.New().
 		EnableNetworking().
-		Post(s.baseURL+"/api/v1/presign-url").
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/publish").
 		Header("Authorization", fmt.Sprintf("Bearer %v", authToken)).
 		Expect(s.T()).
 		Status(http.StatusOK).
 		End().
-		JSON(&presignResponse)
+		JSON(&book)
+
+	return book
+}
+
+func (s *ServerSuiteTest) createDraftBook(authToken string) catalog.BookResponse {
+	imageID, _ := s.uploadFile(authToken, "testdata/book1_image.jpg", "image/jpeg")
+	contentID, content := s.uploadFile(authToken, "testdata/book1_content.pdf", catalog.FormatPDF.MediaType())
 
-	s.putFile(presignResponse.URL, "testdata/book1_content.pdf")
-	contentID := presignResponse.ID
+	author := s.createAuthor(authToken)
 
 	var bookResponse catalog.BookResponse
 
@@ -252,8 +715,8 @@ func (s *ServerSuiteTest) createBook(authToken string) catalog.BookResponse {
 		JSON(catalog.CreateBook{
 			Title:       "Domain Driven Design",
 			Description: "Complexity",
-			AuthorName:  "Eric Evans",
-			ContentID:   contentID,
+			Authors:     []catalog.BookAuthorRequest{{AuthorID: author.ID, Role: catalog.RoleAuthor}},
+			Files:       []catalog.BookFileRequest{{Format: catalog.FormatPDF, ContentID: contentID, Size: content.Size, Checksum: content.Checksum}},
 			Images: []catalog.ImageRequest{
 				{ID: imageID, Description: "The first image"},
 			},
@@ -268,14 +731,59 @@ func (s *ServerSuiteTest) createBook(authToken string) catalog.BookResponse {
 	return bookResponse
 }
 
-func (s *ServerSuiteTest) putFile(url string, filepath string) {
-	file, err := os.Open(filepath)
+func (s *ServerSuiteTest) createAuthor(authToken string) catalog.AuthorResponse {
+	var authorResponse catalog.AuthorResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/authors").
+		Header("Authorization", fmt.Sprintf("Bearer %v", authToken)).
+		JSON(catalog.CreateAuthor{
+			Name: "Eric Evans",
+			Bio:  "Domain-Driven Design",
+		}).
+		Expect(s.T()).
+		Status(http.StatusCreated).
+		End().
+		JSON(&authorResponse)
+
+	return authorResponse
+}
+
+// uploadFile uploads the file with a presigned URL and returns its id along with the object it was uploaded as.
+func (s *ServerSuiteTest) uploadFile(authToken string, filepath string, contentType string) (string, catalog.ObjectInfo) {
+	content, err := ioutil.ReadFile(filepath)
 	require.NoError(s.T(), err)
 
-	defer file.Close()
+	checksum := sha256.Sum256(content)
+	upload := catalog.PresignUpload{ContentType: contentType, Size: int64(len(content)), Checksum: hex.EncodeToString(checksum[:])}
+
+	var presignResponse catalog.PresignURLResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/presign-url").
+		Header("Authorization", fmt.Sprintf("Bearer %v", authToken)).
+		JSON(upload).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		End().
+		JSON(&presignResponse)
+
+	s.putFile(presignResponse.URL, content, upload)
+
+	return presignResponse.ID, upload.ObjectInfo()
+}
+
+// putFile sends the content with the headers signed in the presigned URL.
+func (s *ServerSuiteTest) putFile(url string, content []byte, upload catalog.PresignUpload) {
+	checksum, err := hex.DecodeString(upload.Checksum)
+	require.NoError(s.T(), err)
 
-	request, err := http.NewRequest(http.MethodPut, url, file)
+	request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(content))
 	require.NoError(s.T(), err)
+	request.Header.Set("Content-Type", upload.ContentType)
+	request.Header.Set("x-amz-checksum-sha256", base64.StdEncoding.EncodeToString(checksum))
 
 	response, err := http.DefaultClient.Do(request)
 	require.NoError(s.T(), err)
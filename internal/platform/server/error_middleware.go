@@ -8,9 +8,12 @@ import (
 
 	"github.com/ebookstore/internal/core/auth"
 	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/query"
+	"github.com/ebookstore/internal/core/reviews"
 	"github.com/ebookstore/internal/core/shop"
 	"github.com/ebookstore/internal/log"
 	"github.com/ebookstore/internal/platform/persistence"
+	"github.com/ebookstore/internal/platform/storage"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 )
@@ -55,15 +58,28 @@ func (e *ErrorMiddleware) Handler() gin.HandlerFunc {
 			response = newErrorResponse(http.StatusNotFound, entityNotFoundErr)
 		case errors.As(err, &duplicateKeyErr):
 			response = newErrorResponse(http.StatusConflict, duplicateKeyErr)
+		case errors.Is(err, query.ErrInvalidCursor), errors.Is(err, catalog.ErrCategoryCycle), errors.Is(err, catalog.ErrUnknownCategory),
+			errors.Is(err, catalog.ErrUnknownAuthor), errors.Is(err, catalog.ErrDuplicateBookAuthor), errors.Is(err, catalog.ErrInvalidImportFile),
+			errors.Is(err, catalog.ErrLastBookFile), errors.Is(err, catalog.ErrInvalidSampleSource), errors.Is(err, catalog.ErrInvalidWatermarkSource),
+			errors.Is(err, catalog.ErrInvalidImageOrder), errors.Is(err, catalog.ErrUploadNotFound), errors.Is(err, catalog.ErrInvalidUpload),
+			errors.Is(err, catalog.ErrProtectedBookWithoutEPUB), errors.Is(err, catalog.ErrContentTooLarge), errors.Is(err, storage.ErrInvalidKey):
+			response = newErrorResponse(http.StatusBadRequest, err)
 		case errors.Is(err, auth.ErrWrongPassword):
 			response = newErrorResponse(http.StatusUnauthorized, err)
-		case errors.Is(err, catalog.ErrForbiddenCatalogAccess), errors.Is(err, shop.ErrForbiddenOrderAccess):
+		case errors.Is(err, catalog.ErrForbiddenCatalogAccess), errors.Is(err, shop.ErrForbiddenOrderAccess),
+			errors.Is(err, reviews.ErrForbiddenReviewAccess), errors.Is(err, reviews.ErrReviewRequiresPurchase),
+			errors.Is(err, storage.ErrInvalidSignature), errors.Is(err, storage.ErrLinkExpired), errors.Is(err, shop.ErrLicenseExpired):
 			response = newErrorResponse(http.StatusForbidden, err)
 		case errors.Is(err, shop.ErrOrderNotCompleted):
 			response = newErrorResponse(http.StatusPaymentRequired, err)
-		case errors.Is(err, shop.ErrItemAlreadyInCart):
+		case errors.Is(err, shop.ErrItemAlreadyInCart), errors.Is(err, shop.ErrItemAlreadyInWishlist), errors.Is(err, catalog.ErrDuplicateImage),
+			errors.Is(err, catalog.ErrBookNotArchived), errors.Is(err, shop.ErrItemNotAvailable), errors.Is(err, shop.ErrItemNotReleased),
+			errors.Is(err, catalog.ErrBookProtected), errors.Is(err, shop.ErrLicensePassphraseRequired):
 			response = newErrorResponse(http.StatusConflict, err)
-		case errors.Is(err, shop.ErrItemNotFoundInCart), errors.Is(err, shop.ErrItemNotFoundInOrder):
+		case errors.Is(err, shop.ErrItemNotFoundInCart), errors.Is(err, shop.ErrItemNotFoundInOrder),
+			errors.Is(err, shop.ErrItemNotFoundInWishlist), errors.Is(err, catalog.ErrBookFormatNotAvailable),
+			errors.Is(err, catalog.ErrSampleNotAvailable), errors.Is(err, catalog.ErrImageNotFound), errors.Is(err, catalog.ErrBookNotPublished),
+			errors.Is(err, storage.ErrObjectNotFound):
 			response = newErrorResponse(http.StatusNotFound, err)
 		default:
 			response = newGenericErrorResponse(err)
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/storage"
+	"github.com/gin-gonic/gin"
+)
+
+type LocalStorage interface {
+	Upload(ctx context.Context, key string, params url.Values, contentType string, size int64, body io.Reader) error
+	Download(ctx context.Context, key string, params url.Values) (*os.File, catalog.ObjectInfo, error)
+}
+
+// LocalStorageHandler serves the links of the local storage, taking the place of the bucket when the app runs
+// without S3. The links are not part of the API and are authorized by their signature.
+type LocalStorageHandler struct {
+	storage LocalStorage
+}
+
+func NewLocalStorageHandler(storage LocalStorage) *LocalStorageHandler {
+	return &LocalStorageHandler{storage: storage}
+}
+
+func (h *LocalStorageHandler) Routes() []Route {
+	return []Route{
+		{Method: http.MethodPut, Path: storage.LocalStoragePath + "/*key", Handler: h.upload, Public: true},
+		{Method: http.MethodGet, Path: storage.LocalStoragePath + "/*key", Handler: h.download, Public: true},
+	}
+}
+
+func (h *LocalStorageHandler) upload(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	err := h.storage.Upload(c, key, c.Request.URL.Query(), c.GetHeader("Content-Type"), c.Request.ContentLength, c.Request.Body)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(upload) failed handling upload request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// download serves the object with support for range and conditional requests.
+func (h *LocalStorageHandler) download(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	file, object, err := h.storage.Download(c, key, c.Request.URL.Query())
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(download) failed handling download request: %w", err))
+		return
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(download) failed inspecting object: %w", err))
+		return
+	}
+
+	c.Header("Content-Type", object.ContentType)
+	http.ServeContent(c.Writer, c.Request, key, stat.ModTime(), file)
+}
@@ -0,0 +1,88 @@
+package server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/server"
+	"github.com/ebookstore/internal/platform/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorageHandler(t *testing.T) {
+	router := gin.New()
+	router.Use(server.NewErrorMiddleware().Handler())
+	httpServer := httptest.NewServer(router)
+	defer httpServer.Close()
+
+	localStorage := storage.NewLocalStorage(storage.LocalConfig{
+		Dir:    storage.LocalStorageDir(t.TempDir()),
+		URL:    storage.LocalStorageURL(httpServer.URL),
+		Secret: []byte("secret"),
+	})
+	for _, r := range server.NewLocalStorageHandler(localStorage).Routes() {
+		router.Handle(r.Method, r.Path, r.Handlers()...)
+	}
+
+	ctx := context.TODO()
+	content := "%PDF-1.4 some content"
+	object := catalog.ObjectInfo{Size: int64(len(content)), ContentType: "application/pdf"}
+
+	link, err := localStorage.GeneratePutPreSignedUrl(ctx, "files/book", object)
+	require.NoError(t, err)
+
+	response := putLocalObject(t, link, "image/png", content)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+	response = putLocalObject(t, link, object.ContentType, content)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+
+	link, err = localStorage.GenerateGetPreSignedUrl(ctx, "files/book", catalog.AssetBookFile)
+	require.NoError(t, err)
+
+	response, err = http.Get(link)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, object.ContentType, response.Header.Get("Content-Type"))
+	assert.Equal(t, content, string(body))
+
+	request, err := http.NewRequest(http.MethodGet, link, nil)
+	require.NoError(t, err)
+	request.Header.Set("Range", "bytes=0-7")
+	response, err = http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err = ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusPartialContent, response.StatusCode)
+	assert.Equal(t, "%PDF-1.4", string(body))
+
+	response, err = http.Get(strings.Replace(link, "signature=", "signature=0", 1))
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusForbidden, response.StatusCode)
+}
+
+func putLocalObject(t *testing.T, link, contentType, content string) *http.Response {
+	request, err := http.NewRequest(http.MethodPut, link, strings.NewReader(content))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", contentType)
+	request.Header.Set("Content-Length", strconv.Itoa(len(content)))
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+
+	return response
+}
@@ -15,6 +15,82 @@ type MockAuthenticator struct {
 	mock.Mock
 }
 
+// CreateAppToken provides a mock function with given fields: _a0, _a1
+func (_m *MockAuthenticator) CreateAppToken(_a0 context.Context, _a1 auth.CreateAppTokenRequest) (auth.AppTokenResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAppToken")
+	}
+
+	var r0 auth.AppTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.CreateAppTokenRequest) (auth.AppTokenResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, auth.CreateAppTokenRequest) auth.AppTokenResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(auth.AppTokenResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, auth.CreateAppTokenRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAppToken provides a mock function with given fields: _a0, _a1
+func (_m *MockAuthenticator) DeleteAppToken(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAppToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAppTokens provides a mock function with given fields: _a0
+func (_m *MockAuthenticator) FindAppTokens(_a0 context.Context) ([]auth.AppTokenResponse, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAppTokens")
+	}
+
+	var r0 []auth.AppTokenResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]auth.AppTokenResponse, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []auth.AppTokenResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]auth.AppTokenResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: _a0, _a1
 func (_m *MockAuthenticator) Login(_a0 context.Context, _a1 auth.LoginRequest) (auth.CredentialsResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -89,6 +165,24 @@ func (_m *MockAuthenticator) ResetPassword(_a0 context.Context, _a1 auth.Passwor
 	return r0
 }
 
+// SetLicensePassphrase provides a mock function with given fields: _a0, _a1
+func (_m *MockAuthenticator) SetLicensePassphrase(_a0 context.Context, _a1 auth.SetLicensePassphraseRequest) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLicensePassphrase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, auth.SetLicensePassphraseRequest) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockAuthenticator creates a new instance of MockAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAuthenticator(t interface {
@@ -4,6 +4,7 @@ package server
 
 import (
 	context "context"
+	io "io"
 
 	catalog "github.com/ebookstore/internal/core/catalog"
 
@@ -15,6 +16,90 @@ type MockCatalog struct {
 	mock.Mock
 }
 
+// AddBookImage provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) AddBookImage(_a0 context.Context, _a1 catalog.AddBookImage) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddBookImage")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.AddBookImage) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.AddBookImage) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.AddBookImage) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AttachBookSample provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) AttachBookSample(_a0 context.Context, _a1 catalog.AttachBookSample) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttachBookSample")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.AttachBookSample) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.AttachBookSample) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.AttachBookSample) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAuthor provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) CreateAuthor(_a0 context.Context, _a1 catalog.CreateAuthor) (catalog.AuthorResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAuthor")
+	}
+
+	var r0 catalog.AuthorResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.CreateAuthor) (catalog.AuthorResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.CreateAuthor) catalog.AuthorResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.AuthorResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.CreateAuthor) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateBook provides a mock function with given fields: _a0, _a1
 func (_m *MockCatalog) CreateBook(_a0 context.Context, _a1 catalog.CreateBook) (catalog.BookResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -43,6 +128,52 @@ func (_m *MockCatalog) CreateBook(_a0 context.Context, _a1 catalog.CreateBook) (
 	return r0, r1
 }
 
+// CreateCategory provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) CreateCategory(_a0 context.Context, _a1 catalog.CreateCategory) (catalog.CategoryResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCategory")
+	}
+
+	var r0 catalog.CategoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.CreateCategory) (catalog.CategoryResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.CreateCategory) catalog.CategoryResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.CategoryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.CreateCategory) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAuthor provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) DeleteAuthor(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteBook provides a mock function with given fields: _a0, _a1
 func (_m *MockCatalog) DeleteBook(_a0 context.Context, _a1 string) error {
 	ret := _m.Called(_a0, _a1)
@@ -61,6 +192,88 @@ func (_m *MockCatalog) DeleteBook(_a0 context.Context, _a1 string) error {
 	return r0
 }
 
+// DeleteBookFile provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCatalog) DeleteBookFile(_a0 context.Context, _a1 string, _a2 catalog.BookFormat) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBookFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, catalog.BookFormat) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCategory provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) DeleteCategory(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportBooks provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCatalog) ExportBooks(_a0 context.Context, _a1 catalog.ExportBooks, _a2 io.Writer) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportBooks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.ExportBooks, io.Writer) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAuthorByID provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) FindAuthorByID(_a0 context.Context, _a1 string) (catalog.AuthorResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindAuthorByID")
+	}
+
+	var r0 catalog.AuthorResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (catalog.AuthorResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) catalog.AuthorResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.AuthorResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindBookByID provides a mock function with given fields: _a0, _a1
 func (_m *MockCatalog) FindBookByID(_a0 context.Context, _a1 string) (catalog.BookResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -117,23 +330,25 @@ func (_m *MockCatalog) FindBooks(_a0 context.Context, _a1 catalog.SearchBooks) (
 	return r0, r1
 }
 
-// GeneratePutPreSignedUrl provides a mock function with given fields: _a0
-func (_m *MockCatalog) GeneratePutPreSignedUrl(_a0 context.Context) (catalog.PresignURLResponse, error) {
+// FindCategories provides a mock function with given fields: _a0
+func (_m *MockCatalog) FindCategories(_a0 context.Context) ([]catalog.CategoryResponse, error) {
 	ret := _m.Called(_a0)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GeneratePutPreSignedUrl")
+		panic("no return value specified for FindCategories")
 	}
 
-	var r0 catalog.PresignURLResponse
+	var r0 []catalog.CategoryResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (catalog.PresignURLResponse, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]catalog.CategoryResponse, error)); ok {
 		return rf(_a0)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) catalog.PresignURLResponse); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []catalog.CategoryResponse); ok {
 		r0 = rf(_a0)
 	} else {
-		r0 = ret.Get(0).(catalog.PresignURLResponse)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]catalog.CategoryResponse)
+		}
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -145,6 +360,378 @@ func (_m *MockCatalog) GeneratePutPreSignedUrl(_a0 context.Context) (catalog.Pre
 	return r0, r1
 }
 
+// FindCategoryByID provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) FindCategoryByID(_a0 context.Context, _a1 string) (catalog.CategoryResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCategoryByID")
+	}
+
+	var r0 catalog.CategoryResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (catalog.CategoryResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) catalog.CategoryResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.CategoryResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindPublicFeed provides a mock function with given fields: _a0
+func (_m *MockCatalog) FindPublicFeed(_a0 context.Context) ([]byte, error) {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPublicFeed")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]byte, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateBookSample provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) GenerateBookSample(_a0 context.Context, _a1 catalog.GenerateBookSample) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateBookSample")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.GenerateBookSample) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.GenerateBookSample) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.GenerateBookSample) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GeneratePutPreSignedUrl provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) GeneratePutPreSignedUrl(_a0 context.Context, _a1 catalog.PresignUpload) (catalog.PresignURLResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GeneratePutPreSignedUrl")
+	}
+
+	var r0 catalog.PresignURLResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.PresignUpload) (catalog.PresignURLResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.PresignUpload) catalog.PresignURLResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.PresignURLResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.PresignUpload) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBookSampleURL provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) GetBookSampleURL(_a0 context.Context, _a1 string) (catalog.SampleResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBookSampleURL")
+	}
+
+	var r0 catalog.SampleResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (catalog.SampleResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) catalog.SampleResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.SampleResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportBooks provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) ImportBooks(_a0 context.Context, _a1 catalog.ImportBooks) (catalog.ImportReportResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportBooks")
+	}
+
+	var r0 catalog.ImportReportResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.ImportBooks) (catalog.ImportReportResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.ImportBooks) catalog.ImportReportResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.ImportReportResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.ImportBooks) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishBook provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) PublishBook(_a0 context.Context, _a1 catalog.PublishBook) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishBook")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.PublishBook) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.PublishBook) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.PublishBook) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeBook provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) PurgeBook(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveBookImage provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCatalog) RemoveBookImage(_a0 context.Context, _a1 string, _a2 string) error {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveBookImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderBookImages provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) ReorderBookImages(_a0 context.Context, _a1 catalog.ReorderBookImages) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderBookImages")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.ReorderBookImages) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.ReorderBookImages) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.ReorderBookImages) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreBook provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) RestoreBook(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveBookFile provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) SaveBookFile(_a0 context.Context, _a1 catalog.SaveBookFile) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveBookFile")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.SaveBookFile) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.SaveBookFile) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, catalog.SaveBookFile) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetMainBookImage provides a mock function with given fields: _a0, _a1, _a2
+func (_m *MockCatalog) SetMainBookImage(_a0 context.Context, _a1 string, _a2 string) (catalog.BookResponse, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMainBookImage")
+	}
+
+	var r0 catalog.BookResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (catalog.BookResponse, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) catalog.BookResponse); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		r0 = ret.Get(0).(catalog.BookResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UnpublishBook provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) UnpublishBook(_a0 context.Context, _a1 string) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnpublishBook")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateAuthor provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) UpdateAuthor(_a0 context.Context, _a1 catalog.UpdateAuthor) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAuthor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.UpdateAuthor) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateBook provides a mock function with given fields: _a0, _a1
 func (_m *MockCatalog) UpdateBook(_a0 context.Context, _a1 catalog.UpdateBook) error {
 	ret := _m.Called(_a0, _a1)
@@ -163,6 +750,24 @@ func (_m *MockCatalog) UpdateBook(_a0 context.Context, _a1 catalog.UpdateBook) e
 	return r0
 }
 
+// UpdateCategory provides a mock function with given fields: _a0, _a1
+func (_m *MockCatalog) UpdateCategory(_a0 context.Context, _a1 catalog.UpdateCategory) error {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCategory")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, catalog.UpdateCategory) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockCatalog creates a new instance of MockCatalog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockCatalog(t interface {
@@ -0,0 +1,318 @@
+package server
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// Media types of the OPDS 1.2 and OPDS 2.0 feeds.
+const (
+	opdsNavigationType  = "application/atom+xml;profile=opds-catalog;kind=navigation"
+	opdsAcquisitionType = "application/atom+xml;profile=opds-catalog;kind=acquisition"
+	openSearchType      = "application/opensearchdescription+xml"
+	opds2Type           = "application/opds+json"
+)
+
+// Link relations defined by the OPDS specifications.
+const (
+	opdsRelBuy        = "http://opds-spec.org/acquisition/buy"
+	opdsRelOpenAccess = "http://opds-spec.org/acquisition/open-access"
+	opdsRelImage      = "http://opds-spec.org/image"
+	opdsRelThumbnail  = "http://opds-spec.org/image/thumbnail"
+	opdsRelSubsection = "subsection"
+	opdsRelShelf      = "http://opds-spec.org/shelf"
+)
+
+// opdsCurrency is the currency of the book prices, which are stored in cents.
+const opdsCurrency = "USD"
+
+// opdsAcquisition is a link to get a book, written in the format of each OPDS version.
+type opdsAcquisition struct {
+	Rel  string
+	Href string
+	Type string
+	// Price is the price in cents for the acquisitions that must be paid
+	Price int
+}
+
+type opdsFeed struct {
+	XMLName         xml.Name    `xml:"feed"`
+	Xmlns           string      `xml:"xmlns,attr"`
+	XmlnsDC         string      `xml:"xmlns:dc,attr"`
+	XmlnsOPDS       string      `xml:"xmlns:opds,attr"`
+	XmlnsOpenSearch string      `xml:"xmlns:opensearch,attr"`
+	ID              string      `xml:"id"`
+	Title           string      `xml:"title"`
+	Updated         time.Time   `xml:"updated"`
+	TotalResults    int64       `xml:"opensearch:totalResults,omitempty"`
+	ItemsPerPage    int         `xml:"opensearch:itemsPerPage,omitempty"`
+	Links           []opdsLink  `xml:"link"`
+	Entries         []opdsEntry `xml:"entry"`
+}
+
+type opdsLink struct {
+	Rel   string     `xml:"rel,attr,omitempty"`
+	Href  string     `xml:"href,attr"`
+	Type  string     `xml:"type,attr,omitempty"`
+	Title string     `xml:"title,attr,omitempty"`
+	Price *opdsPrice `xml:"opds:price,omitempty"`
+}
+
+type opdsPrice struct {
+	CurrencyCode string `xml:"currencycode,attr"`
+	Value        string `xml:",chardata"`
+}
+
+type opdsEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Updated    time.Time      `xml:"updated"`
+	Authors    []opdsAuthor   `xml:"author"`
+	Identifier string         `xml:"dc:identifier,omitempty"`
+	Language   string         `xml:"dc:language,omitempty"`
+	Publisher  string         `xml:"dc:publisher,omitempty"`
+	Issued     string         `xml:"dc:issued,omitempty"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    *opdsContent   `xml:"content,omitempty"`
+	Categories []opdsCategory `xml:"category"`
+	Links      []opdsLink     `xml:"link"`
+}
+
+type opdsAuthor struct {
+	Name string `xml:"name"`
+}
+
+type opdsContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type opdsCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+func newOPDSFeed(id, title string, updated time.Time, links []opdsLink) opdsFeed {
+	return opdsFeed{
+		Xmlns:           "http://www.w3.org/2005/Atom",
+		XmlnsDC:         "http://purl.org/dc/terms/",
+		XmlnsOPDS:       "http://opds-spec.org/2010/catalog",
+		XmlnsOpenSearch: "http://a9.com/-/spec/opensearch/1.1/",
+		ID:              id,
+		Title:           title,
+		Updated:         updated,
+		Links:           links,
+		Entries:         []opdsEntry{},
+	}
+}
+
+func newOPDSNavigationEntry(id, title, content, href string, updated time.Time) opdsEntry {
+	return opdsEntry{
+		Title:   title,
+		ID:      id,
+		Updated: updated,
+		Content: &opdsContent{Type: "text", Value: content},
+		Links:   []opdsLink{{Rel: opdsRelSubsection, Href: href, Type: opdsAcquisitionType}},
+	}
+}
+
+func newOPDSBookEntry(book catalog.BookResponse, acquisitions []opdsAcquisition) opdsEntry {
+	entry := opdsEntry{
+		Title:      book.Title,
+		ID:         "urn:uuid:" + book.ID,
+		Updated:    book.UpdatedAt,
+		Identifier: bookIdentifier(book),
+		Language:   book.Language,
+		Publisher:  book.Publisher,
+		Issued:     book.ReleaseDate.Format("2006-01-02"),
+		Summary:    book.Description,
+	}
+
+	for _, author := range book.Authors {
+		if author.Role == catalog.RoleAuthor {
+			entry.Authors = append(entry.Authors, opdsAuthor{Name: author.Name})
+		}
+	}
+
+	for _, category := range book.Categories {
+		entry.Categories = append(entry.Categories, opdsCategory{Term: category.Slug, Label: category.Name})
+	}
+
+	if cover := bookCoverLink(book); cover != "" {
+		entry.Links = append(entry.Links,
+			opdsLink{Rel: opdsRelImage, Href: cover, Type: "image/jpeg"},
+			opdsLink{Rel: opdsRelThumbnail, Href: cover, Type: "image/jpeg"},
+		)
+	}
+
+	for _, acquisition := range acquisitions {
+		link := opdsLink{Rel: acquisition.Rel, Href: acquisition.Href, Type: acquisition.Type}
+		if acquisition.Price > 0 {
+			link.Price = &opdsPrice{CurrencyCode: opdsCurrency, Value: formatOPDSPrice(acquisition.Price)}
+		}
+		entry.Links = append(entry.Links, link)
+	}
+
+	return entry
+}
+
+type openSearchDescription struct {
+	XMLName        xml.Name      `xml:"OpenSearchDescription"`
+	Xmlns          string        `xml:"xmlns,attr"`
+	ShortName      string        `xml:"ShortName"`
+	Description    string        `xml:"Description"`
+	InputEncoding  string        `xml:"InputEncoding"`
+	OutputEncoding string        `xml:"OutputEncoding"`
+	URL            openSearchURL `xml:"Url"`
+}
+
+type openSearchURL struct {
+	Type     string `xml:"type,attr"`
+	Template string `xml:"template,attr"`
+}
+
+func newOpenSearchDescription(template string) openSearchDescription {
+	return openSearchDescription{
+		Xmlns:          "http://a9.com/-/spec/opensearch/1.1/",
+		ShortName:      "Ebook Store",
+		Description:    "Search the books of the store",
+		InputEncoding:  "UTF-8",
+		OutputEncoding: "UTF-8",
+		URL:            openSearchURL{Type: opdsAcquisitionType, Template: template},
+	}
+}
+
+type opds2NavigationFeed struct {
+	Metadata   opds2Metadata `json:"metadata"`
+	Links      []opds2Link   `json:"links"`
+	Navigation []opds2Link   `json:"navigation"`
+}
+
+type opds2PublicationsFeed struct {
+	Metadata     opds2Metadata      `json:"metadata"`
+	Links        []opds2Link        `json:"links"`
+	Publications []opds2Publication `json:"publications"`
+}
+
+type opds2Metadata struct {
+	Title         string `json:"title"`
+	NumberOfItems int64  `json:"numberOfItems,omitempty"`
+	ItemsPerPage  int    `json:"itemsPerPage,omitempty"`
+	CurrentPage   int    `json:"currentPage,omitempty"`
+}
+
+type opds2Link struct {
+	Rel        string           `json:"rel,omitempty"`
+	Href       string           `json:"href"`
+	Type       string           `json:"type,omitempty"`
+	Title      string           `json:"title,omitempty"`
+	Templated  bool             `json:"templated,omitempty"`
+	Properties *opds2Properties `json:"properties,omitempty"`
+}
+
+type opds2Properties struct {
+	Price *opds2Price `json:"price,omitempty"`
+}
+
+type opds2Price struct {
+	Currency string  `json:"currency"`
+	Value    float64 `json:"value"`
+}
+
+type opds2Publication struct {
+	Metadata opds2PublicationMetadata `json:"metadata"`
+	Links    []opds2Link              `json:"links"`
+	Images   []opds2Link              `json:"images,omitempty"`
+}
+
+type opds2PublicationMetadata struct {
+	Type          string         `json:"@type"`
+	Identifier    string         `json:"identifier"`
+	Title         string         `json:"title"`
+	Subtitle      string         `json:"subtitle,omitempty"`
+	Author        []string       `json:"author,omitempty"`
+	Translator    []string       `json:"translator,omitempty"`
+	Illustrator   []string       `json:"illustrator,omitempty"`
+	Language      string         `json:"language,omitempty"`
+	Publisher     string         `json:"publisher,omitempty"`
+	Published     string         `json:"published,omitempty"`
+	Modified      time.Time      `json:"modified"`
+	Description   string         `json:"description,omitempty"`
+	NumberOfPages int            `json:"numberOfPages,omitempty"`
+	Subject       []opds2Subject `json:"subject,omitempty"`
+}
+
+type opds2Subject struct {
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+func newOPDS2Publication(book catalog.BookResponse, acquisitions []opdsAcquisition) opds2Publication {
+	metadata := opds2PublicationMetadata{
+		Type:          "http://schema.org/EBook",
+		Identifier:    bookIdentifier(book),
+		Title:         book.Title,
+		Subtitle:      book.Subtitle,
+		Language:      book.Language,
+		Publisher:     book.Publisher,
+		Published:     book.ReleaseDate.Format("2006-01-02"),
+		Modified:      book.UpdatedAt,
+		Description:   book.Description,
+		NumberOfPages: book.PageCount,
+	}
+
+	for _, author := range book.Authors {
+		switch author.Role {
+		case catalog.RoleAuthor:
+			metadata.Author = append(metadata.Author, author.Name)
+		case catalog.RoleTranslator:
+			metadata.Translator = append(metadata.Translator, author.Name)
+		case catalog.RoleIllustrator:
+			metadata.Illustrator = append(metadata.Illustrator, author.Name)
+		}
+	}
+
+	for _, category := range book.Categories {
+		metadata.Subject = append(metadata.Subject, opds2Subject{Name: category.Name, Code: category.Slug})
+	}
+
+	publication := opds2Publication{Metadata: metadata, Links: make([]opds2Link, 0, len(acquisitions))}
+	for _, acquisition := range acquisitions {
+		link := opds2Link{Rel: acquisition.Rel, Href: acquisition.Href, Type: acquisition.Type}
+		if acquisition.Price > 0 {
+			link.Properties = &opds2Properties{Price: &opds2Price{Currency: opdsCurrency, Value: float64(acquisition.Price) / 100}}
+		}
+		publication.Links = append(publication.Links, link)
+	}
+
+	if cover := bookCoverLink(book); cover != "" {
+		publication.Images = []opds2Link{{Href: cover, Type: "image/jpeg"}}
+	}
+
+	return publication
+}
+
+// bookIdentifier returns the URN of the book, preferring its ISBN.
+func bookIdentifier(book catalog.BookResponse) string {
+	if book.ISBN != "" {
+		return "urn:isbn:" + book.ISBN
+	}
+	return "urn:uuid:" + book.ID
+}
+
+// bookCoverLink returns the link of the main image of the book.
+func bookCoverLink(book catalog.BookResponse) string {
+	for _, image := range book.Images {
+		if image.ID == book.MainImageID {
+			return image.Link
+		}
+	}
+	return ""
+}
+
+func formatOPDSPrice(cents int) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
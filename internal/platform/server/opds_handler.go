@@ -0,0 +1,464 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/gin-gonic/gin"
+)
+
+// StoreURL is the base URL of the store front, where the books are purchased.
+type StoreURL string
+
+// opdsBasePath is the path the OPDS routes are served from. The feeds link to each other with absolute paths.
+const opdsBasePath = "/api/v1/opds"
+
+// opdsPageSize is the number of books of each page of an acquisition feed.
+const opdsPageSize = 20
+
+// Library gives access to the books purchased by the current user.
+type Library interface {
+	FindLibrary(ctx context.Context) (shop.LibraryResponse, error)
+	DownloadOrderItemContent(ctx context.Context, request shop.DownloadOrderContentRequest) (shop.DownloadResponse, error)
+	StreamOrderItemContent(ctx context.Context, request shop.DownloadOrderContentRequest) (catalog.BookContent, error)
+}
+
+type OPDSHandler struct {
+	catalog        Catalog
+	library        Library
+	authentication *BasicAuthenticationMiddleware
+	storeURL       StoreURL
+	streaming      DownloadStreaming
+}
+
+func NewOPDSHandler(catalog Catalog, library Library, authentication *BasicAuthenticationMiddleware, storeURL StoreURL, streaming DownloadStreaming) *OPDSHandler {
+	return &OPDSHandler{
+		catalog:        catalog,
+		library:        library,
+		authentication: authentication,
+		storeURL:       storeURL,
+		streaming:      streaming,
+	}
+}
+
+func (h *OPDSHandler) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Path: "/opds", Handler: h.getNavigation, Public: true},
+		{Method: http.MethodGet, Path: "/opds/books", Handler: h.getBooks, Public: true},
+		{Method: http.MethodGet, Path: "/opds/search.xml", Handler: h.getSearchDescription, Public: true},
+		{Method: http.MethodGet, Path: "/opds/v2", Handler: h.getNavigationV2, Public: true},
+		{Method: http.MethodGet, Path: "/opds/v2/books", Handler: h.getBooksV2, Public: true},
+		// The library is read by e-readers that can't send JWT tokens, so it uses its own authentication
+		{Method: http.MethodGet, Path: "/opds/library", Handler: h.getLibrary, Public: true, Middlewares: h.libraryMiddlewares()},
+		{Method: http.MethodGet, Path: "/opds/library/orders/:id/items/:itemId/content", Handler: h.downloadLibraryBook, Public: true, Middlewares: h.libraryMiddlewares()},
+		{Method: http.MethodGet, Path: "/opds/v2/library", Handler: h.getLibraryV2, Public: true, Middlewares: h.libraryMiddlewares()},
+	}
+}
+
+func (h *OPDSHandler) libraryMiddlewares() []gin.HandlerFunc {
+	return []gin.HandlerFunc{h.authentication.Handler()}
+}
+
+// OPDSBooksRequest are the filters of an acquisition feed. Query is the search terms of OpenSearch.
+type OPDSBooksRequest struct {
+	Query    string `form:"q"`
+	Category string `form:"category"`
+	Page     int    `form:"page"`
+}
+
+// getNavigation godoc
+// @Summary Fetch the OPDS 1.2 navigation feed of the catalog
+// @Tags OPDS
+// @Produce  application/atom+xml
+// @Success 200 {file} file
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds [get]
+func (h *OPDSHandler) getNavigation(c *gin.Context) {
+	categories, err := h.catalog.FindCategories(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getNavigation) failed finding categories: %w ", err))
+		return
+	}
+
+	now := time.Now()
+	feed := newOPDSFeed("urn:ebookstore:opds", "Ebook Store", now, []opdsLink{
+		{Rel: "self", Href: opdsBasePath, Type: opdsNavigationType},
+		{Rel: "start", Href: opdsBasePath, Type: opdsNavigationType},
+		{Rel: "search", Href: opdsBasePath + "/search.xml", Type: openSearchType},
+		{Rel: opdsRelShelf, Href: opdsBasePath + "/library", Type: opdsAcquisitionType},
+	})
+
+	feed.Entries = append(feed.Entries, newOPDSNavigationEntry("urn:ebookstore:opds:books", "All books",
+		"Every book of the store", opdsBasePath+"/books", now))
+	for _, category := range categories {
+		feed.Entries = append(feed.Entries, newOPDSNavigationEntry("urn:ebookstore:opds:category:"+category.Slug, category.Name,
+			fmt.Sprintf("%d books", category.BookCount), opdsBasePath+"/books?category="+url.QueryEscape(category.Slug), now))
+	}
+
+	renderOPDS(c, opdsNavigationType, feed)
+}
+
+// getBooks godoc
+// @Summary Fetch an OPDS 1.2 acquisition feed of the catalog
+// @Tags OPDS
+// @Produce  application/atom+xml
+// @Param params query OPDSBooksRequest false "Filters"
+// @Success 200 {file} file
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/books [get]
+func (h *OPDSHandler) getBooks(c *gin.Context) {
+	request, response, err := h.findBooks(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getBooks) failed finding books: %w ", err))
+		return
+	}
+
+	feed := newOPDSFeed("urn:ebookstore:opds:books", opdsFeedTitle(request), time.Now(), []opdsLink{
+		{Rel: "self", Href: opdsPageLink(c, request.Page), Type: opdsAcquisitionType},
+		{Rel: "start", Href: opdsBasePath, Type: opdsNavigationType},
+		{Rel: "search", Href: opdsBasePath + "/search.xml", Type: openSearchType},
+	})
+	feed.ItemsPerPage = response.PerPage
+	if response.TotalItems != nil {
+		feed.TotalResults = *response.TotalItems
+	}
+
+	for _, link := range opdsPaginationLinks(c, response) {
+		feed.Links = append(feed.Links, opdsLink{Rel: link.Rel, Href: link.Href, Type: opdsAcquisitionType})
+	}
+
+	for _, book := range response.Results {
+		feed.Entries = append(feed.Entries, newOPDSBookEntry(book, h.acquisitions(book)))
+	}
+
+	renderOPDS(c, opdsAcquisitionType, feed)
+}
+
+// getSearchDescription godoc
+// @Summary Fetch the OpenSearch description used by the OPDS feeds
+// @Tags OPDS
+// @Produce  application/opensearchdescription+xml
+// @Success 200 {file} file
+// @Router /api/v1/opds/search.xml [get]
+func (h *OPDSHandler) getSearchDescription(c *gin.Context) {
+	renderOPDS(c, openSearchType, newOpenSearchDescription(opdsBasePath+"/books?q={searchTerms}"))
+}
+
+// getNavigationV2 godoc
+// @Summary Fetch the OPDS 2.0 navigation feed of the catalog
+// @Tags OPDS
+// @Produce  application/opds+json
+// @Success 200 {file} file
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/v2 [get]
+func (h *OPDSHandler) getNavigationV2(c *gin.Context) {
+	categories, err := h.catalog.FindCategories(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getNavigationV2) failed finding categories: %w ", err))
+		return
+	}
+
+	feed := opds2NavigationFeed{
+		Metadata: opds2Metadata{Title: "Ebook Store"},
+		Links: []opds2Link{
+			{Rel: "self", Href: opdsBasePath + "/v2", Type: opds2Type},
+			{Rel: "search", Href: opdsBasePath + "/v2/books{?q}", Type: opds2Type, Templated: true},
+			{Rel: opdsRelShelf, Href: opdsBasePath + "/v2/library", Type: opds2Type},
+		},
+		Navigation: []opds2Link{{Href: opdsBasePath + "/v2/books", Type: opds2Type, Title: "All books"}},
+	}
+
+	for _, category := range categories {
+		feed.Navigation = append(feed.Navigation, opds2Link{
+			Href:  opdsBasePath + "/v2/books?category=" + url.QueryEscape(category.Slug),
+			Type:  opds2Type,
+			Title: category.Name,
+		})
+	}
+
+	renderOPDS2(c, feed)
+}
+
+// getBooksV2 godoc
+// @Summary Fetch an OPDS 2.0 feed of the publications of the catalog
+// @Tags OPDS
+// @Produce  application/opds+json
+// @Param params query OPDSBooksRequest false "Filters"
+// @Success 200 {file} file
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/v2/books [get]
+func (h *OPDSHandler) getBooksV2(c *gin.Context) {
+	request, response, err := h.findBooks(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getBooksV2) failed finding books: %w ", err))
+		return
+	}
+
+	feed := opds2PublicationsFeed{
+		Metadata: opds2Metadata{
+			Title:        opdsFeedTitle(request),
+			ItemsPerPage: response.PerPage,
+		},
+		Links: []opds2Link{
+			{Rel: "self", Href: opdsPageLink(c, request.Page), Type: opds2Type},
+			{Rel: "start", Href: opdsBasePath + "/v2", Type: opds2Type},
+			{Rel: "search", Href: opdsBasePath + "/v2/books{?q}", Type: opds2Type, Templated: true},
+		},
+		Publications: make([]opds2Publication, 0, len(response.Results)),
+	}
+
+	if response.TotalItems != nil {
+		feed.Metadata.NumberOfItems = *response.TotalItems
+	}
+
+	if response.CurrentPage != nil {
+		feed.Metadata.CurrentPage = *response.CurrentPage
+	}
+
+	for _, link := range opdsPaginationLinks(c, response) {
+		feed.Links = append(feed.Links, opds2Link{Rel: link.Rel, Href: link.Href, Type: opds2Type})
+	}
+
+	for _, book := range response.Results {
+		feed.Publications = append(feed.Publications, newOPDS2Publication(book, h.acquisitions(book)))
+	}
+
+	renderOPDS2(c, feed)
+}
+
+// getLibrary godoc
+// @Summary Fetch an OPDS 1.2 acquisition feed of the books purchased by the user
+// @Description The feed is authenticated with HTTP Basic, using the email and the password or an app token, or with an app token as Bearer token.
+// @Tags OPDS
+// @Produce  application/atom+xml
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/library [get]
+func (h *OPDSHandler) getLibrary(c *gin.Context) {
+	response, err := h.library.FindLibrary(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getLibrary) failed finding library: %w ", err))
+		return
+	}
+
+	feed := newOPDSFeed("urn:ebookstore:opds:library", "My library", time.Now(), []opdsLink{
+		{Rel: "self", Href: opdsBasePath + "/library", Type: opdsAcquisitionType},
+		{Rel: "start", Href: opdsBasePath, Type: opdsNavigationType},
+	})
+	feed.TotalResults = int64(len(response.Books))
+	feed.ItemsPerPage = len(response.Books)
+
+	for _, libraryBook := range response.Books {
+		feed.Entries = append(feed.Entries, newOPDSBookEntry(libraryBook.Book, libraryAcquisitions(libraryBook)))
+	}
+
+	renderOPDS(c, opdsAcquisitionType, feed)
+}
+
+// getLibraryV2 godoc
+// @Summary Fetch an OPDS 2.0 feed of the books purchased by the user
+// @Description The feed is authenticated with HTTP Basic, using the email and the password or an app token, or with an app token as Bearer token.
+// @Tags OPDS
+// @Produce  application/opds+json
+// @Success 200 {file} file
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/v2/library [get]
+func (h *OPDSHandler) getLibraryV2(c *gin.Context) {
+	response, err := h.library.FindLibrary(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getLibraryV2) failed finding library: %w ", err))
+		return
+	}
+
+	feed := opds2PublicationsFeed{
+		Metadata: opds2Metadata{
+			Title:         "My library",
+			NumberOfItems: int64(len(response.Books)),
+			ItemsPerPage:  len(response.Books),
+		},
+		Links: []opds2Link{
+			{Rel: "self", Href: opdsBasePath + "/v2/library", Type: opds2Type},
+			{Rel: "start", Href: opdsBasePath + "/v2", Type: opds2Type},
+		},
+		Publications: make([]opds2Publication, 0, len(response.Books)),
+	}
+
+	for _, libraryBook := range response.Books {
+		feed.Publications = append(feed.Publications, newOPDS2Publication(libraryBook.Book, libraryAcquisitions(libraryBook)))
+	}
+
+	renderOPDS2(c, feed)
+}
+
+// downloadLibraryBook godoc
+// @Summary Redirect to a freshly signed link of the content of a purchased book
+// @Description The acquisition links of the library point here, so they don't expire while the feed is cached by the e-readers. The content is served directly when downloads are streamed. The license document is served for the protected books, the e-readers download the encrypted content from it.
+// @Tags OPDS
+// @Param id path string true "Order ID"
+// @Param itemId path string true "Item ID"
+// @Param format query string false "Format of the content, the preferred one when empty" Enums(pdf, epub, mobi)
+// @Success 200 {file} file
+// @Success 302 "Redirect to the content"
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/opds/library/orders/{id}/items/{itemId}/content [get]
+func (h *OPDSHandler) downloadLibraryBook(c *gin.Context) {
+	request := shop.DownloadOrderContentRequest{
+		OrderID: c.Param("id"),
+		ItemID:  c.Param("itemId"),
+		Format:  c.Query("format"),
+	}
+
+	if h.streaming {
+		content, err := h.library.StreamOrderItemContent(c, request)
+		if err == nil {
+			serveBookContent(c, content)
+			return
+		}
+		if !errors.Is(err, catalog.ErrBookProtected) {
+			_ = c.Error(fmt.Errorf("(downloadLibraryBook) failed handling stream request: %w ", err))
+			return
+		}
+	}
+
+	response, err := h.library.DownloadOrderItemContent(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(downloadLibraryBook) failed handling download request: %w ", err))
+		return
+	}
+
+	if response.License != nil {
+		c.Data(http.StatusOK, shop.LicenseMediaType, response.License)
+		return
+	}
+
+	c.Redirect(http.StatusFound, response.URL)
+}
+
+func (h *OPDSHandler) findBooks(c *gin.Context) (OPDSBooksRequest, catalog.PaginatedBooksResponse, error) {
+	var request OPDSBooksRequest
+	if err := c.ShouldBindQuery(&request); err != nil {
+		return request, catalog.PaginatedBooksResponse{}, &BindingErr{Err: fmt.Errorf("(findBooks) failed binding query: %w", err)}
+	}
+
+	if request.Page <= 0 {
+		request.Page = 1
+	}
+
+	response, err := h.catalog.FindBooks(c, catalog.SearchBooks{
+		Title:    request.Query,
+		Category: request.Category,
+		Page:     request.Page,
+		PerPage:  opdsPageSize,
+	})
+
+	return request, response, err
+}
+
+// acquisitions links the books to their page in the store front, where they can be purchased.
+func (h *OPDSHandler) acquisitions(book catalog.BookResponse) []opdsAcquisition {
+	return []opdsAcquisition{{
+		Rel:   opdsRelBuy,
+		Href:  strings.TrimSuffix(string(h.storeURL), "/") + "/books/" + book.ID,
+		Type:  "text/html",
+		Price: book.Price,
+	}}
+}
+
+// libraryAcquisitions links the purchased books to the content of each of their formats. The links resolve to signed URLs only when they are followed.
+// The protected books only link to the license of their EPUB.
+func libraryAcquisitions(libraryBook shop.LibraryBookResponse) []opdsAcquisition {
+	if libraryBook.Book.Protected {
+		return []opdsAcquisition{{
+			Rel:  opdsRelOpenAccess,
+			Href: fmt.Sprintf("%s/library/orders/%s/items/%s/content?format=%s", opdsBasePath, libraryBook.OrderID, libraryBook.Book.ID, catalog.FormatEPUB),
+			Type: shop.LicenseMediaType,
+		}}
+	}
+
+	acquisitions := make([]opdsAcquisition, 0, len(libraryBook.Book.Formats))
+	for _, file := range libraryBook.Book.Formats {
+		acquisitions = append(acquisitions, opdsAcquisition{
+			Rel:  opdsRelOpenAccess,
+			Href: fmt.Sprintf("%s/library/orders/%s/items/%s/content?format=%s", opdsBasePath, libraryBook.OrderID, libraryBook.Book.ID, file.Format),
+			Type: file.MediaType,
+		})
+	}
+	return acquisitions
+}
+
+func opdsFeedTitle(request OPDSBooksRequest) string {
+	switch {
+	case request.Query != "":
+		return fmt.Sprintf("Search results for %q", request.Query)
+	case request.Category != "":
+		return "Books in " + request.Category
+	default:
+		return "All books"
+	}
+}
+
+// opdsPaginationLinks returns the links to the pages around the current one.
+func opdsPaginationLinks(c *gin.Context, response catalog.PaginatedBooksResponse) []opds2Link {
+	if response.CurrentPage == nil || response.TotalPages == nil || *response.TotalPages <= 1 {
+		return nil
+	}
+
+	currentPage, totalPages := *response.CurrentPage, *response.TotalPages
+	links := []opds2Link{
+		{Rel: "first", Href: opdsPageLink(c, 1)},
+		{Rel: "last", Href: opdsPageLink(c, totalPages)},
+	}
+
+	if currentPage > 1 {
+		links = append(links, opds2Link{Rel: "previous", Href: opdsPageLink(c, currentPage-1)})
+	}
+
+	if currentPage < totalPages {
+		links = append(links, opds2Link{Rel: "next", Href: opdsPageLink(c, currentPage+1)})
+	}
+
+	return links
+}
+
+// opdsPageLink returns the link to the given page of the current feed, keeping its filters.
+func opdsPageLink(c *gin.Context, page int) string {
+	values := c.Request.URL.Query()
+	values.Set("page", strconv.Itoa(page))
+
+	return c.Request.URL.Path + "?" + values.Encode()
+}
+
+func renderOPDS(c *gin.Context, contentType string, v interface{}) {
+	body, err := xml.Marshal(v)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(renderOPDS) failed marshalling feed: %w", err))
+		return
+	}
+
+	c.Data(http.StatusOK, contentType, append([]byte(xml.Header), body...))
+}
+
+func renderOPDS2(c *gin.Context, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(renderOPDS2) failed marshalling feed: %w", err))
+		return
+	}
+
+	c.Data(http.StatusOK, opds2Type, body)
+}
@@ -0,0 +1,340 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/auth"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newOPDSTestRouter(c Catalog) *gin.Engine {
+	return newOPDSLibraryTestRouter(c, new(MockLibrary), new(MockCredentialsAuthenticator))
+}
+
+func newOPDSLibraryTestRouter(c Catalog, library Library, authenticator CredentialsAuthenticator) *gin.Engine {
+	return newOPDSHandlerTestRouter(NewOPDSHandler(c, library, NewBasicAuthenticationMiddleware(authenticator), "https://store.com", false))
+}
+
+func newOPDSHandlerTestRouter(handler *OPDSHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewErrorMiddleware().Handler())
+	group := router.Group("/api/v1")
+	for _, r := range handler.Routes() {
+		group.Handle(r.Method, r.Path, r.Handlers()...)
+	}
+	return router
+}
+
+func opdsTestLibrary() shop.LibraryResponse {
+	return shop.LibraryResponse{Books: []shop.LibraryBookResponse{{
+		OrderID: "order-1",
+		Book:    opdsTestBooks().Results[0],
+	}}}
+}
+
+func opdsTestBooks() catalog.PaginatedBooksResponse {
+	currentPage, totalPages, totalItems := 2, 3, int64(41)
+	return catalog.PaginatedBooksResponse{
+		Results: []catalog.BookResponse{{
+			ID:          "book-1",
+			Title:       "Domain-Driven Design",
+			ISBN:        "9780321125217",
+			Language:    "en",
+			Price:       5499,
+			MainImageID: "image-1",
+			Images:      []catalog.ImageResponse{{ID: "image-1", Link: "https://images/1"}},
+			Authors: []catalog.BookAuthorResponse{
+				{Name: "Eric Evans", Role: catalog.RoleAuthor},
+				{Name: "Jane Doe", Role: catalog.RoleTranslator},
+			},
+			ReleaseDate: time.Date(2003, time.August, 20, 0, 0, 0, 0, time.UTC),
+			Formats: []catalog.BookFileResponse{
+				{Format: catalog.FormatPDF, MediaType: "application/pdf", Size: 1024},
+				{Format: catalog.FormatEPUB, MediaType: "application/epub+zip", Size: 512},
+			},
+		}},
+		CurrentPage: &currentPage,
+		PerPage:     opdsPageSize,
+		TotalPages:  &totalPages,
+		TotalItems:  &totalItems,
+	}
+}
+
+func TestOPDSHandler_GetNavigation(t *testing.T) {
+	c := new(MockCatalog)
+	c.On("FindCategories", mock.Anything).Return([]catalog.CategoryResponse{{Name: "Software", Slug: "software", BookCount: 3}}, nil)
+
+	recorder := httptest.NewRecorder()
+	newOPDSTestRouter(c).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/opds", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, opdsNavigationType, recorder.Header().Get("Content-Type"))
+
+	var feed opdsFeed
+	require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+	require.Len(t, feed.Entries, 2)
+	assert.Equal(t, "/api/v1/opds/books", feed.Entries[0].Links[0].Href)
+	assert.Equal(t, "/api/v1/opds/books?category=software", feed.Entries[1].Links[0].Href)
+}
+
+func TestOPDSHandler_GetBooks(t *testing.T) {
+	c := new(MockCatalog)
+	c.On("FindBooks", mock.Anything, catalog.SearchBooks{Title: "design", Page: 2, PerPage: opdsPageSize}).Return(opdsTestBooks(), nil)
+
+	recorder := httptest.NewRecorder()
+	newOPDSTestRouter(c).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/opds/books?q=design&page=2", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, opdsAcquisitionType, recorder.Header().Get("Content-Type"))
+
+	var feed struct {
+		TotalResults int64 `xml:"totalResults"`
+		Links        []struct {
+			Rel  string `xml:"rel,attr"`
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+		Entries []struct {
+			Identifier string `xml:"identifier"`
+			Authors    []struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+			Links []struct {
+				Rel   string `xml:"rel,attr"`
+				Href  string `xml:"href,attr"`
+				Price struct {
+					CurrencyCode string `xml:"currencycode,attr"`
+					Value        string `xml:",chardata"`
+				} `xml:"price"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+
+	assert.Equal(t, int64(41), feed.TotalResults)
+	links := make(map[string]string)
+	for _, link := range feed.Links {
+		links[link.Rel] = link.Href
+	}
+	assert.Equal(t, "/api/v1/opds/books?page=3&q=design", links["next"])
+	assert.Equal(t, "/api/v1/opds/books?page=1&q=design", links["previous"])
+	assert.Equal(t, "/api/v1/opds/search.xml", links["search"])
+
+	require.Len(t, feed.Entries, 1)
+	entry := feed.Entries[0]
+	assert.Equal(t, "urn:isbn:9780321125217", entry.Identifier)
+	require.Len(t, entry.Authors, 1)
+	assert.Equal(t, "Eric Evans", entry.Authors[0].Name)
+	require.Len(t, entry.Links, 3)
+	assert.Equal(t, opdsRelImage, entry.Links[0].Rel)
+	assert.Equal(t, "https://images/1", entry.Links[0].Href)
+	assert.Equal(t, opdsRelBuy, entry.Links[2].Rel)
+	assert.Equal(t, "https://store.com/books/book-1", entry.Links[2].Href)
+	assert.Equal(t, "54.99", entry.Links[2].Price.Value)
+	assert.Equal(t, "USD", entry.Links[2].Price.CurrencyCode)
+}
+
+func TestOPDSHandler_GetSearchDescription(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newOPDSTestRouter(new(MockCatalog)).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/opds/search.xml", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var description openSearchDescription
+	require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &description))
+	assert.Equal(t, "/api/v1/opds/books?q={searchTerms}", description.URL.Template)
+}
+
+func TestOPDSHandler_GetBooksV2(t *testing.T) {
+	c := new(MockCatalog)
+	c.On("FindBooks", mock.Anything, catalog.SearchBooks{Category: "software", Page: 1, PerPage: opdsPageSize}).Return(opdsTestBooks(), nil)
+
+	recorder := httptest.NewRecorder()
+	newOPDSTestRouter(c).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/opds/v2/books?category=software", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, opds2Type, recorder.Header().Get("Content-Type"))
+
+	var feed opds2PublicationsFeed
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+	assert.Equal(t, int64(41), feed.Metadata.NumberOfItems)
+	require.Len(t, feed.Publications, 1)
+
+	publication := feed.Publications[0]
+	assert.Equal(t, []string{"Eric Evans"}, publication.Metadata.Author)
+	assert.Equal(t, []string{"Jane Doe"}, publication.Metadata.Translator)
+	assert.Equal(t, "https://images/1", publication.Images[0].Href)
+	assert.Equal(t, opdsRelBuy, publication.Links[0].Rel)
+	assert.Equal(t, 54.99, publication.Links[0].Properties.Price.Value)
+}
+
+func TestOPDSHandler_GetLibrary_WithoutCredentials(t *testing.T) {
+	library := new(MockLibrary)
+
+	recorder := httptest.NewRecorder()
+	newOPDSLibraryTestRouter(new(MockCatalog), library, new(MockCredentialsAuthenticator)).
+		ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/opds/library", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	assert.Equal(t, basicAuthRealm, recorder.Header().Get("WWW-Authenticate"))
+	library.AssertNotCalled(t, "FindLibrary", mock.Anything)
+}
+
+func TestOPDSHandler_GetLibrary(t *testing.T) {
+	authenticator := new(MockCredentialsAuthenticator)
+	authenticator.On("AuthenticateBasic", mock.Anything, "raphael@test.com", "app-token").Return(auth.User{ID: "user-id", Role: auth.Customer}, nil)
+
+	library := new(MockLibrary)
+	library.On("FindLibrary", mock.MatchedBy(func(c *gin.Context) bool { return c.GetString("userId") == "user-id" })).Return(opdsTestLibrary(), nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/opds/library", nil)
+	request.SetBasicAuth("raphael@test.com", "app-token")
+
+	recorder := httptest.NewRecorder()
+	newOPDSLibraryTestRouter(new(MockCatalog), library, authenticator).ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, opdsAcquisitionType, recorder.Header().Get("Content-Type"))
+
+	var feed struct {
+		Entries []struct {
+			Links []struct {
+				Rel  string `xml:"rel,attr"`
+				Href string `xml:"href,attr"`
+				Type string `xml:"type,attr"`
+			} `xml:"link"`
+		} `xml:"entry"`
+	}
+	require.NoError(t, xml.Unmarshal(recorder.Body.Bytes(), &feed))
+
+	require.Len(t, feed.Entries, 1)
+	require.Len(t, feed.Entries[0].Links, 4)
+	pdf, epub := feed.Entries[0].Links[2], feed.Entries[0].Links[3]
+	assert.Equal(t, opdsRelOpenAccess, pdf.Rel)
+	assert.Equal(t, "/api/v1/opds/library/orders/order-1/items/book-1/content?format=pdf", pdf.Href)
+	assert.Equal(t, "application/pdf", pdf.Type)
+	assert.Equal(t, opdsRelOpenAccess, epub.Rel)
+	assert.Equal(t, "/api/v1/opds/library/orders/order-1/items/book-1/content?format=epub", epub.Href)
+	assert.Equal(t, "application/epub+zip", epub.Type)
+}
+
+func TestOPDSHandler_GetLibraryV2_WithAppToken(t *testing.T) {
+	authenticator := new(MockCredentialsAuthenticator)
+	authenticator.On("AuthenticateAppToken", mock.Anything, "app-token").Return(auth.User{ID: "user-id", Role: auth.Customer}, nil)
+
+	library := new(MockLibrary)
+	library.On("FindLibrary", mock.Anything).Return(opdsTestLibrary(), nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/opds/v2/library", nil)
+	request.Header.Set("Authorization", "Bearer app-token")
+
+	recorder := httptest.NewRecorder()
+	newOPDSLibraryTestRouter(new(MockCatalog), library, authenticator).ServeHTTP(recorder, request)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var feed opds2PublicationsFeed
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+	require.Len(t, feed.Publications, 1)
+	assert.Equal(t, opdsRelOpenAccess, feed.Publications[0].Links[0].Rel)
+	assert.Nil(t, feed.Publications[0].Links[0].Properties)
+}
+
+func TestOPDSHandler_DownloadLibraryBook(t *testing.T) {
+	authenticator := new(MockCredentialsAuthenticator)
+	authenticator.On("AuthenticateBasic", mock.Anything, "raphael@test.com", "password").Return(auth.User{ID: "user-id", Role: auth.Customer}, nil)
+
+	library := new(MockLibrary)
+	library.On("DownloadOrderItemContent", mock.Anything, shop.DownloadOrderContentRequest{OrderID: "order-1", ItemID: "book-1", Format: "epub"}).
+		Return(shop.DownloadResponse{URL: "https://content/book-1?signature=abc"}, nil)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/opds/library/orders/order-1/items/book-1/content?format=epub", nil)
+	request.SetBasicAuth("raphael@test.com", "password")
+
+	recorder := httptest.NewRecorder()
+	newOPDSLibraryTestRouter(new(MockCatalog), library, authenticator).ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusFound, recorder.Code)
+	assert.Equal(t, "https://content/book-1?signature=abc", recorder.Header().Get("Location"))
+}
+
+func TestOPDSHandler_DownloadLibraryBook_Streaming(t *testing.T) {
+	authenticator := new(MockCredentialsAuthenticator)
+	authenticator.On("AuthenticateBasic", mock.Anything, "raphael@test.com", "password").Return(auth.User{ID: "user-id", Role: auth.Customer}, nil)
+
+	content := func() catalog.BookContent {
+		return catalog.BookContent{
+			ReadSeekCloser: nopReadSeekCloser{strings.NewReader("0123456789")},
+			Filename:       "Café Crème.epub",
+			ContentType:    "application/epub+zip",
+			Size:           10,
+			ETag:           `"abc"`,
+			UpdatedAt:      time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+		}
+	}
+	library := new(MockLibrary)
+	library.On("StreamOrderItemContent", mock.Anything, shop.DownloadOrderContentRequest{OrderID: "order-1", ItemID: "book-1"}).
+		Return(func(context.Context, shop.DownloadOrderContentRequest) catalog.BookContent { return content() }, nil)
+	router := newOPDSHandlerTestRouter(NewOPDSHandler(new(MockCatalog), library, NewBasicAuthenticationMiddleware(authenticator), "https://store.com", true))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/opds/library/orders/order-1/items/book-1/content", nil)
+	request.SetBasicAuth("raphael@test.com", "password")
+	request.Header.Set("Range", "bytes=4-")
+	request.Header.Set("If-Range", `"abc"`)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusPartialContent, recorder.Code)
+	assert.Equal(t, "456789", recorder.Body.String())
+	assert.Equal(t, "bytes 4-9/10", recorder.Header().Get("Content-Range"))
+	assert.Equal(t, "application/epub+zip", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename*=utf-8''Caf%C3%A9%20Cr%C3%A8me.epub", recorder.Header().Get("Content-Disposition"))
+
+	// The file changed since the download started, so it's sent again from the start
+	request.Header.Set("If-Range", `"old"`)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "0123456789", recorder.Body.String())
+	assert.Equal(t, "bytes", recorder.Header().Get("Accept-Ranges"))
+}
+
+type nopReadSeekCloser struct {
+	io.ReadSeeker
+}
+
+func (nopReadSeekCloser) Close() error {
+	return nil
+}
+
+func TestOPDSHandler_DownloadLibraryBook_OfAnotherUser(t *testing.T) {
+	authenticator := new(MockCredentialsAuthenticator)
+	authenticator.On("AuthenticateBasic", mock.Anything, "raphael@test.com", "password").Return(auth.User{ID: "user-id", Role: auth.Customer}, nil)
+
+	library := new(MockLibrary)
+	library.On("DownloadOrderItemContent", mock.Anything, mock.Anything).Return(shop.DownloadResponse{}, shop.ErrForbiddenOrderAccess)
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/opds/library/orders/order-1/items/book-1/content", nil)
+	request.SetBasicAuth("raphael@test.com", "password")
+
+	recorder := httptest.NewRecorder()
+	newOPDSLibraryTestRouter(new(MockCatalog), library, authenticator).ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Location"))
+}
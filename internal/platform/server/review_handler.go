@@ -0,0 +1,188 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ebookstore/internal/core/reviews"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewHandler struct {
+	reviews *reviews.Reviews
+}
+
+func NewReviewHandler(reviews *reviews.Reviews) *ReviewHandler {
+	return &ReviewHandler{
+		reviews: reviews,
+	}
+}
+
+func (h *ReviewHandler) Routes() []Route {
+	return []Route{
+		{Method: http.MethodGet, Path: "/books/:id/reviews", Handler: h.getBookReviews, Public: true},
+		{Method: http.MethodPost, Path: "/books/:id/reviews", Handler: h.createReview, Public: false},
+		{Method: http.MethodGet, Path: "/reviews", Handler: h.getReviews, Public: false},
+		{Method: http.MethodPatch, Path: "/reviews/:id", Handler: h.updateReview, Public: false},
+		{Method: http.MethodDelete, Path: "/reviews/:id", Handler: h.deleteReview, Public: false},
+		{Method: http.MethodPost, Path: "/reviews/:id/moderation", Handler: h.moderateReview, Public: false},
+	}
+}
+
+// getBookReviews godoc
+// @Summary Fetch the approved Reviews of a Book
+// @Tags Reviews
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param params query reviews.SearchReviews true "Filters"
+// @Success 200 {object} reviews.PaginatedReviewsResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/reviews [get]
+func (h *ReviewHandler) getBookReviews(c *gin.Context) {
+	var request reviews.SearchReviews
+	if err := c.ShouldBindQuery(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(getBookReviews) failed binding query: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.reviews.FindBookReviews(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getBookReviews) failed handling find request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// createReview godoc
+// @Summary Review a purchased Book
+// @Tags Reviews
+// @Accept json
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Param payload body reviews.CreateReview true "Review Payload"
+// @Success 201 {object} reviews.ReviewResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/books/{id}/reviews [post]
+func (h *ReviewHandler) createReview(c *gin.Context) {
+	var request reviews.CreateReview
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(createReview) failed binding request body: %w", err)})
+		return
+	}
+
+	request.BookID = c.Param("id")
+	response, err := h.reviews.CreateReview(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(createReview) failed handling create request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// getReviews godoc
+// @Summary Fetch Reviews of any status. Admins use it as moderation queue
+// @Tags Reviews
+// @Produce  json
+// @Param params query reviews.SearchReviews true "Filters"
+// @Success 200 {object} reviews.PaginatedReviewsResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews [get]
+func (h *ReviewHandler) getReviews(c *gin.Context) {
+	var request reviews.SearchReviews
+	if err := c.ShouldBindQuery(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(getReviews) failed binding query: %w", err)})
+		return
+	}
+
+	response, err := h.reviews.FindReviews(c, request)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getReviews) failed handling find request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// updateReview godoc
+// @Summary Update a Review of the current user
+// @Tags Reviews
+// @Accept json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param payload body reviews.UpdateReview true "Review Payload"
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews/{id} [patch]
+func (h *ReviewHandler) updateReview(c *gin.Context) {
+	var request reviews.UpdateReview
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(updateReview) failed binding request body: %w", err)})
+		return
+	}
+
+	request.ID = c.Param("id")
+	if err := h.reviews.UpdateReview(c, request); err != nil {
+		_ = c.Error(fmt.Errorf("(updateReview) failed handling update request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// deleteReview godoc
+// @Summary Delete a Review
+// @Tags Reviews
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Success 204 "Success"
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews/{id} [delete]
+func (h *ReviewHandler) deleteReview(c *gin.Context) {
+	if err := h.reviews.DeleteReview(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(deleteReview) failed handling delete request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// moderateReview godoc
+// @Summary Approve, reject or hide a Review
+// @Tags Reviews
+// @Accept json
+// @Produce  json
+// @Param id path string true "Review ID"
+// @Param payload body reviews.ModerateReview true "Moderation Payload"
+// @Success 204 "Success"
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/reviews/{id}/moderation [post]
+func (h *ReviewHandler) moderateReview(c *gin.Context) {
+	var request reviews.ModerateReview
+	if err := c.ShouldBindJSON(&request); err != nil {
+		_ = c.Error(&BindingErr{Err: fmt.Errorf("(moderateReview) failed binding request body: %w", err)})
+		return
+	}
+
+	request.ID = c.Param("id")
+	if err := h.reviews.ModerateReview(c, request); err != nil {
+		_ = c.Error(fmt.Errorf("(moderateReview) failed handling moderation request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
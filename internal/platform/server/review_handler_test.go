@@ -0,0 +1,98 @@
+package server_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/core/reviews"
+	"github.com/ebookstore/internal/core/shop"
+	"github.com/steinfletcher/apitest"
+	jsonpath "github.com/steinfletcher/apitest-jsonpath"
+	"github.com/stretchr/testify/require"
+)
+
+func (s *ServerSuiteTest) TestCreateReview_WithoutPurchase() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/reviews").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(reviews.CreateReview{Rating: 5}).
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		Assert(jsonpath.Equal("$.message", "only buyers of a book can review it")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestModerateReview_Unauthorized() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+	review := s.createReview(token, book)
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/reviews/"+review.ID+"/moderation").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		JSON(reviews.ModerateReview{Action: reviews.Approve}).
+		Expect(s.T()).
+		Status(http.StatusForbidden).
+		End()
+}
+
+func (s *ServerSuiteTest) TestModerateReview_Success() {
+	adminToken := s.createDefaultAdmin()
+	token := s.createDefaultCustomer()
+	book := s.createBook(adminToken)
+	review := s.createReview(token, book)
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/reviews/"+review.ID+"/moderation").
+		Header("Authorization", fmt.Sprintf("Bearer %v", adminToken)).
+		JSON(reviews.ModerateReview{Action: reviews.Approve}).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID + "/reviews").
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.results[0].id", review.ID)).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL + "/api/v1/books/" + book.ID).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Equal("$.ratingAverage", float64(review.Rating))).
+		Assert(jsonpath.Equal("$.ratingCount", float64(1))).
+		End()
+}
+
+// createReview buys the book for the customer and reviews it.
+func (s *ServerSuiteTest) createReview(customerToken string, book catalog.BookResponse) reviews.ReviewResponse {
+	s.createCart(customerToken, book)
+	order := s.createOrder(customerToken)
+	result := s.container.DB().Model(&shop.Order{}).Where("id = ?", order.ID).Update("status", shop.Paid)
+	require.NoError(s.T(), result.Error)
+
+	var response reviews.ReviewResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/books/"+book.ID+"/reviews").
+		Header("Authorization", fmt.Sprintf("Bearer %v", customerToken)).
+		JSON(reviews.CreateReview{Rating: 4, Title: "Great", Body: "A must read"}).
+		Expect(s.T()).
+		Status(http.StatusCreated).
+		End().
+		JSON(&response)
+
+	return response
+}
@@ -4,13 +4,36 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// maxRequestBodySize limits the request bodies of the API, unless a route sets its own limit.
+const maxRequestBodySize = 2 << 20 // 2MB
+
 type Route struct {
 	Method  string
 	Path    string
 	Handler gin.HandlerFunc
 	Public  bool
+	// Middlewares run before the handler, after the ones of the router
+	Middlewares []gin.HandlerFunc
+	// MaxBodySize replaces the size limit of the request bodies of the API when it's set
+	MaxBodySize int64
 }
 
 func (r Route) IsPublic() bool {
 	return r.Public
 }
+
+// Handlers returns the middlewares of the route followed by its handler.
+func (r Route) Handlers() []gin.HandlerFunc {
+	handlers := make([]gin.HandlerFunc, 0, len(r.Middlewares)+1)
+	handlers = append(handlers, r.Middlewares...)
+
+	return append(handlers, r.Handler)
+}
+
+// BodySizeLimit returns the size limit of the request bodies of the route.
+func (r Route) BodySizeLimit() int64 {
+	if r.MaxBodySize > 0 {
+		return r.MaxBodySize
+	}
+	return maxRequestBodySize
+}
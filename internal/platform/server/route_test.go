@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -19,3 +20,21 @@ func TestRoute_IsPublic(t *testing.T) {
 		assert.Equal(t, tc.Expected, route.IsPublic())
 	}
 }
+
+func TestRoute_Handlers(t *testing.T) {
+	var calls []string
+	middleware := func(c *gin.Context) { calls = append(calls, "middleware") }
+	handler := func(c *gin.Context) { calls = append(calls, "handler") }
+
+	route := Route{Handler: handler, Middlewares: []gin.HandlerFunc{middleware}}
+	for _, h := range route.Handlers() {
+		h(nil)
+	}
+
+	assert.Equal(t, []string{"middleware", "handler"}, calls)
+}
+
+func TestRoute_BodySizeLimit(t *testing.T) {
+	assert.Equal(t, int64(2<<20), Route{}.BodySizeLimit())
+	assert.Equal(t, int64(50<<20), Route{MaxBodySize: 50 << 20}.BodySizeLimit())
+}
@@ -42,6 +42,9 @@ type Config struct {
 	AuthenticationHandler    *AuthenticationHandler
 	CatalogHandler           *CatalogHandler
 	ShopHandler              *ShopHandler
+	ReviewHandler            *ReviewHandler
+	OPDSHandler              *OPDSHandler
+	LocalStorageHandler      *LocalStorageHandler
 	Addr                     Addr
 	Timeout                  Timeout
 }
@@ -60,7 +63,6 @@ func (s *Server) Start() error {
 
 	router.Use(s.CorrelationIDMiddleware.Handler())
 	router.Use(gin.Recovery())
-	router.Use(limits.RequestSizeLimiter(2 << 20)) // 10MB
 
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
@@ -80,15 +82,28 @@ func (s *Server) Start() error {
 	routes = append(routes, s.HealthcheckHandler.Routes()...)
 	routes = append(routes, s.CatalogHandler.Routes()...)
 	routes = append(routes, s.ShopHandler.Routes()...)
+	routes = append(routes, s.ReviewHandler.Routes()...)
+	routes = append(routes, s.OPDSHandler.Routes()...)
+
+	// The local storage is only served when it's used instead of the bucket. It checks the size of the uploads
+	// against the one signed in their link instead of the size limit of the API
+	if s.LocalStorageHandler != nil {
+		for _, r := range s.LocalStorageHandler.Routes() {
+			router.Handle(r.Method, r.Path, r.Handlers()...)
+		}
+	}
 
 	versionedRouter := router.Group("/api/v1")
+	publicRouter := versionedRouter.Group("/", s.AuthenticationMiddleware.OptionalHandler())
 	authorizedRouter := versionedRouter.Group("/", s.AuthenticationMiddleware.Handler())
 
 	for _, r := range routes {
+		handlers := append([]gin.HandlerFunc{limits.RequestSizeLimiter(r.BodySizeLimit())}, r.Handlers()...)
+
 		if r.IsPublic() {
-			versionedRouter.Handle(r.Method, r.Path, r.Handler)
+			publicRouter.Handle(r.Method, r.Path, handlers...)
 		} else {
-			authorizedRouter.Handle(r.Method, r.Path, r.Handler)
+			authorizedRouter.Handle(r.Method, r.Path, handlers...)
 		}
 	}
 
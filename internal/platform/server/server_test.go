@@ -67,6 +67,7 @@ func (s *ServerSuiteTest) SetupSuite() {
 func (s *ServerSuiteTest) TearDownTest() {
 	s.container.DB().Exec("DELETE FROM users")
 	s.container.DB().Exec("DELETE FROM books")
+	s.container.DB().Exec("DELETE FROM authors")
 	s.container.DB().Exec("DELETE FROM orders")
 	s.container.Cache().FlushAll(context.Background())
 }
@@ -1,21 +1,30 @@
 package server
 
 import (
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 
+	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/core/shop"
 	"github.com/ebookstore/internal/log"
 	"github.com/gin-gonic/gin"
 )
 
+// DownloadStreaming makes the API stream the purchased books itself instead of handing out links to the storage,
+// which can be shared until they expire.
+type DownloadStreaming bool
+
 type ShopHandler struct {
-	shop *shop.Shop
+	shop      *shop.Shop
+	streaming DownloadStreaming
 }
 
-func NewShopHandler(shop *shop.Shop) *ShopHandler {
+func NewShopHandler(shop *shop.Shop, streaming DownloadStreaming) *ShopHandler {
 	return &ShopHandler{
-		shop: shop,
+		shop:      shop,
+		streaming: streaming,
 	}
 }
 
@@ -28,6 +37,10 @@ func (h *ShopHandler) Routes() []Route {
 		{Method: http.MethodGet, Path: "/active-cart", Handler: h.getActiveCart, Public: false},
 		{Method: http.MethodPost, Path: "/cart/items/:id", Handler: h.addItemToCart, Public: false},
 		{Method: http.MethodDelete, Path: "/cart/items/:id", Handler: h.removeItemFromCart, Public: false},
+		{Method: http.MethodGet, Path: "/me/wishlist", Handler: h.getWishlist, Public: false},
+		{Method: http.MethodPost, Path: "/me/wishlist/items/:id", Handler: h.addItemToWishlist, Public: false},
+		{Method: http.MethodDelete, Path: "/me/wishlist/items/:id", Handler: h.removeItemFromWishlist, Public: false},
+		{Method: http.MethodPost, Path: "/me/wishlist/items/:id/cart", Handler: h.addWishlistItemToCart, Public: false},
 		{Method: http.MethodPost, Path: "/stripe/webhook", Handler: h.handleStripeWebhook, Public: true},
 	}
 }
@@ -96,11 +109,14 @@ func (h *ShopHandler) createOrder(c *gin.Context) {
 
 // downloadOrder godoc
 // @Summary Download the book for the given Order
+// @Description Returns a link to the content, or the content itself when downloads are streamed. Streamed downloads support range requests, so they can be resumed. The PDFs of watermarked books are stamped with the buyer. Protected books are always delivered as a link to their encrypted EPUB along with the license document of the buyer, who must have set a license passphrase.
 // @Tags Shop
-// @Produce  json
+// @Produce  json,application/pdf,application/epub+zip,application/x-mobipocket-ebook
 // @Param id path string true "Order ID"
 // @Param itemId path string true "Item ID to be downloaded"
+// @Param format query string false "Format to be downloaded, the preferred one when empty" Enums(pdf, epub, mobi)
 // @Success 200 {object} shop.DownloadResponse
+// @Success 206 {file} file
 // @Failure 402 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -109,6 +125,20 @@ func (h *ShopHandler) downloadOrder(c *gin.Context) {
 	req := shop.DownloadOrderContentRequest{
 		OrderID: c.Param("id"),
 		ItemID:  c.Param("itemId"),
+		Format:  c.Query("format"),
+	}
+
+	if h.streaming {
+		content, err := h.shop.StreamOrderItemContent(c, req)
+		if err == nil {
+			serveBookContent(c, content)
+			return
+		}
+		// The protected books are delivered with their license, which links to the encrypted content
+		if !errors.Is(err, catalog.ErrBookProtected) {
+			_ = c.Error(fmt.Errorf("(downloadOrder) failed handling stream book content: %w", err))
+			return
+		}
 	}
 
 	response, err := h.shop.DownloadOrderItemContent(c, req)
@@ -176,6 +206,81 @@ func (h *ShopHandler) removeItemFromCart(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// getWishlist godoc
+// @Summary Fetch the wishlist of the current user
+// @Tags Shop
+// @Produce  json
+// @Success 200 {object} shop.WishlistResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/wishlist [get]
+func (h *ShopHandler) getWishlist(c *gin.Context) {
+	response, err := h.shop.GetWishlist(c)
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(getWishlist) failed handling get request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// addItemToWishlist godoc
+// @Summary Add a book to the wishlist of the current user
+// @Tags Shop
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 200 {object} shop.WishlistResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/wishlist/items/{id} [post]
+func (h *ShopHandler) addItemToWishlist(c *gin.Context) {
+	response, err := h.shop.AddItemToWishlist(c, c.Param("id"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(addItemToWishlist) failed handling add item request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// removeItemFromWishlist godoc
+// @Summary Remove a book from the wishlist of the current user
+// @Tags Shop
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 204 "Success"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/wishlist/items/{id} [delete]
+func (h *ShopHandler) removeItemFromWishlist(c *gin.Context) {
+	if err := h.shop.RemoveItemFromWishlist(c, c.Param("id")); err != nil {
+		_ = c.Error(fmt.Errorf("(removeItemFromWishlist) failed handling remove item request: %w", err))
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// addWishlistItemToCart godoc
+// @Summary Add a book of the wishlist to the active cart
+// @Tags Shop
+// @Produce  json
+// @Param id path string true "Book ID"
+// @Success 200 {object} shop.CartResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/me/wishlist/items/{id}/cart [post]
+func (h *ShopHandler) addWishlistItemToCart(c *gin.Context) {
+	response, err := h.shop.AddWishlistItemToCart(c, c.Param("id"))
+	if err != nil {
+		_ = c.Error(fmt.Errorf("(addWishlistItemToCart) failed handling add item request: %w", err))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // handleStripeWebhook godoc
 // @Summary Handle stripe webhooks
 // @Tags Shop
@@ -203,3 +308,14 @@ func (h *ShopHandler) handleStripeWebhook(c *gin.Context) {
 
 	c.Status(http.StatusOK)
 }
+
+// serveBookContent streams the content as an attachment named after the book. Range and conditional requests are
+// answered with the part of the content requested, so interrupted downloads are resumed where they stopped.
+func serveBookContent(c *gin.Context, content catalog.BookContent) {
+	defer content.Close()
+
+	c.Header("Content-Type", content.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": content.Filename}))
+	c.Header("ETag", content.ETag)
+	http.ServeContent(c.Writer, c.Request, content.Filename, content.UpdatedAt, content)
+}
@@ -156,10 +156,10 @@ func (s *ServerSuiteTest) TestGetOrders_Success() {
 		End().
 		JSON(&response)
 
-	s.Equal(1, response.CurrentPage)
+	s.Equal(1, *response.CurrentPage)
 	s.Equal(15, response.PerPage)
-	s.Equal(int64(1), response.TotalItems)
-	s.Equal(1, response.TotalPages)
+	s.Equal(int64(1), *response.TotalItems)
+	s.Equal(1, *response.TotalPages)
 	s.Equal(order.Items, response.Results[0].Items)
 	s.Equal(order.Status, response.Results[0].Status)
 	s.Equal(order.TotalPrice, response.Results[0].TotalPrice)
@@ -247,3 +247,119 @@ func (s *ServerSuiteTest) createOrder(customerToken string) shop.OrderResponse {
 
 	return response
 }
+
+func (s *ServerSuiteTest) TestAddItemToWishlist_Duplicated() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+	s.addItemToWishlist(token, book)
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/me/wishlist/items/"+book.ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusConflict).
+		Assert(jsonpath.Equal("$.message", "item already in wishlist")).
+		End()
+}
+
+func (s *ServerSuiteTest) TestAddWishlistItemToCart_Success() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+	s.addItemToWishlist(token, book)
+
+	var response shop.CartResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/me/wishlist/items/"+book.ID+"/cart").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		End().
+		JSON(&response)
+
+	s.Equal(book.ID, response.Items[0].ID)
+}
+
+func (s *ServerSuiteTest) TestRemoveItemFromWishlist_Success() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+	s.addItemToWishlist(token, book)
+
+	apitest.New().
+		EnableNetworking().
+		Delete(s.baseURL+"/api/v1/me/wishlist/items/"+book.ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusNoContent).
+		End()
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/me/wishlist").
+		Header("Authorization", fmt.Sprintf("Bearer %v", token)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.items", 0)).
+		End()
+}
+
+func (s *ServerSuiteTest) addItemToWishlist(customerToken string, book catalog.BookResponse) shop.WishlistResponse {
+	var response shop.WishlistResponse
+
+	apitest.New().
+		EnableNetworking().
+		Post(s.baseURL+"/api/v1/me/wishlist/items/"+book.ID).
+		Header("Authorization", fmt.Sprintf("Bearer %v", customerToken)).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		End().
+		JSON(&response)
+
+	return response
+}
+
+func (s *ServerSuiteTest) TestGetOPDSLibrary_WithoutCredentials() {
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/opds/library").
+		Expect(s.T()).
+		Status(http.StatusUnauthorized).
+		Header("WWW-Authenticate", `Basic realm="Ebook Store", charset="UTF-8"`).
+		End()
+}
+
+func (s *ServerSuiteTest) TestGetOPDSLibrary_Success() {
+	token := s.createDefaultCustomer()
+	book := s.createBook(s.createDefaultAdmin())
+	s.createCart(token, book)
+	order := s.createOrder(token)
+	result := s.container.DB().Model(&shop.Order{}).Where("id = ?", order.ID).Update("status", shop.Paid)
+	require.NoError(s.T(), result.Error)
+
+	appToken := s.createAppToken(token)
+	contentPath := "/api/v1/opds/library/orders/" + order.ID + "/items/" + book.ID + "/content"
+
+	apitest.New().
+		EnableNetworking().
+		Get(s.baseURL+"/api/v1/opds/v2/library").
+		BasicAuth("raphael@test.com", appToken.Token).
+		Expect(s.T()).
+		Status(http.StatusOK).
+		Assert(jsonpath.Len("$.publications", 1)).
+		Assert(jsonpath.Equal("$.publications[0].links[0].href", contentPath)).
+		End()
+
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	apitest.New().
+		EnableNetworking(noRedirects).
+		Get(s.baseURL+contentPath).
+		Header("Authorization", "Bearer "+appToken.Token).
+		Expect(s.T()).
+		Status(http.StatusFound).
+		End()
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudfront/sign"
+	"github.com/ebookstore/internal/core/catalog"
+)
+
+// LinkMode is how the links to an asset are generated.
+type LinkMode string
+
+const (
+	// LinkPresigned links to the bucket with an S3 presigned URL.
+	LinkPresigned LinkMode = "presigned"
+	// LinkPublic links to the CDN without a signature, so the CDN must serve the asset to anyone.
+	LinkPublic LinkMode = "public"
+	// LinkSigned links to the CDN with a URL signed with a canned policy, which only sets the expiration.
+	LinkSigned LinkMode = "signed"
+	// LinkSignedPolicy links to the CDN with a URL signed with a custom policy, which also sets the time the link
+	// starts to be valid and the IP range allowed to use it when there is one.
+	LinkSignedPolicy LinkMode = "signed-policy"
+)
+
+// clockSkew is how long before it's signed a link with a custom policy is valid, so clocks behind are not rejected.
+const clockSkew = time.Minute
+
+type CDNDomain string
+
+type CDNLinkExpiration time.Duration
+
+// CDNSourceIP is the IP range in CIDR notation allowed to use the links signed with a custom policy. Any IP is
+// allowed when it's empty.
+type CDNSourceIP string
+
+// CDNLinks is the link mode of each asset. Assets without a mode are linked with S3 presigned URLs.
+type CDNLinks map[catalog.Asset]LinkMode
+
+type CDNConfig struct {
+	// Storage stores, inspects and presigns the uploads of the objects, which stay in the bucket behind the CDN.
+	*Storage
+	Signer     *sign.URLSigner
+	Domain     CDNDomain
+	Expiration CDNLinkExpiration
+	SourceIP   CDNSourceIP
+	Links      CDNLinks
+}
+
+// CDN serves the objects of the bucket through a CloudFront distribution. The links are generated for each asset
+// according to its link mode.
+type CDN struct {
+	CDNConfig
+}
+
+func NewCDN(c CDNConfig) *CDN {
+	return &CDN{CDNConfig: c}
+}
+
+// Validate checks the link modes are known and the signed ones can be generated. The signed links must expire at
+// least twice as late as linkCacheTTL, the time a generated link is cached, so a cached link can still be used when
+// it's served. The presigned links are checked by the Config of the storage.
+func (c CDNConfig) Validate(linkCacheTTL time.Duration) error {
+	signed := false
+	for asset, mode := range c.Links {
+		switch mode {
+		case "", LinkPresigned, LinkPublic:
+		case LinkSigned, LinkSignedPolicy:
+			signed = true
+		default:
+			return fmt.Errorf("unknown link mode %q for %s", mode, asset)
+		}
+	}
+	if !signed {
+		return nil
+	}
+
+	if c.Signer == nil {
+		return fmt.Errorf("signed links need the private key of the CDN key pair")
+	}
+	return validateLinkExpiration("signed links", time.Duration(c.Expiration), linkCacheTTL)
+}
+
+func (c *CDN) GenerateGetPreSignedUrl(ctx context.Context, key string, asset catalog.Asset) (string, error) {
+	mode := c.Links[asset]
+	if mode == "" || mode == LinkPresigned {
+		return c.Storage.GenerateGetPreSignedUrl(ctx, key, asset)
+	}
+
+	link := c.link(key)
+	now := time.Now()
+	expires := now.Add(time.Duration(c.Expiration))
+
+	var (
+		signed string
+		err    error
+	)
+	switch mode {
+	case LinkPublic:
+		return link, nil
+	case LinkSigned:
+		signed, err = c.Signer.Sign(link, expires)
+	case LinkSignedPolicy:
+		signed, err = c.Signer.SignWithPolicy(link, c.policy(link, now, expires))
+	default:
+		err = fmt.Errorf("unknown link mode %q", mode)
+	}
+	if err != nil {
+		return "", fmt.Errorf("(GenerateGetPreSignedUrl) failed signing %s link for key: %s: %w", asset, key, err)
+	}
+
+	return signed, nil
+}
+
+// link is the unsigned URL of the object in the CDN.
+func (c *CDN) link(key string) string {
+	return fmt.Sprintf("https://%s/%s", c.Domain, escapeKey(key))
+}
+
+func (c *CDN) policy(link string, now, expires time.Time) *sign.Policy {
+	condition := sign.Condition{
+		DateGreaterThan: sign.NewAWSEpochTime(now.Add(-clockSkew)),
+		DateLessThan:    sign.NewAWSEpochTime(expires),
+	}
+	if c.SourceIP != "" {
+		condition.IPAddress = &sign.IPAddress{SourceIP: string(c.SourceIP)}
+	}
+
+	return &sign.Policy{Statements: []sign.Statement{{Resource: link, Condition: condition}}}
+}
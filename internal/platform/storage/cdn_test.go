@@ -0,0 +1,123 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cloudfront/sign"
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCDN(t *testing.T, sourceIP storage.CDNSourceIP) *storage.CDN {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	return storage.NewCDN(storage.CDNConfig{
+		Signer:     sign.NewURLSigner("KEYPAIRID", key),
+		Domain:     "cdn.example.com",
+		Expiration: storage.CDNLinkExpiration(time.Hour),
+		SourceIP:   sourceIP,
+		Links: storage.CDNLinks{
+			catalog.AssetImage:    storage.LinkPublic,
+			catalog.AssetBookFile: storage.LinkSigned,
+			catalog.AssetSample:   storage.LinkSignedPolicy,
+		},
+	})
+}
+
+func TestCDN_GenerateGetPreSignedUrl_Public(t *testing.T) {
+	link, err := newTestCDN(t, "").GenerateGetPreSignedUrl(context.TODO(), "variants/an image/thumbnail", catalog.AssetImage)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/variants/an%20image/thumbnail", link)
+}
+
+func TestCDN_GenerateGetPreSignedUrl_Signed(t *testing.T) {
+	link, err := newTestCDN(t, "").GenerateGetPreSignedUrl(context.TODO(), "file-id", catalog.AssetBookFile)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.Equal(t, "cdn.example.com", parsed.Host)
+	assert.Equal(t, "/file-id", parsed.Path)
+
+	query := parsed.Query()
+	assert.NotEmpty(t, query.Get("Expires"))
+	assert.NotEmpty(t, query.Get("Signature"))
+	assert.Equal(t, "KEYPAIRID", query.Get("Key-Pair-Id"))
+	assert.Empty(t, query.Get("Policy"))
+}
+
+func TestCDN_GenerateGetPreSignedUrl_SignedPolicy(t *testing.T) {
+	link, err := newTestCDN(t, "10.0.0.0/8").GenerateGetPreSignedUrl(context.TODO(), "sample-id", catalog.AssetSample)
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+
+	query := parsed.Query()
+	assert.NotEmpty(t, query.Get("Signature"))
+	assert.Equal(t, "KEYPAIRID", query.Get("Key-Pair-Id"))
+	assert.Empty(t, query.Get("Expires"))
+
+	// CloudFront replaces the characters of base64 that are not safe in URLs
+	encoded := strings.NewReplacer("-", "+", "_", "=", "~", "/").Replace(query.Get("Policy"))
+	policy, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	assert.Contains(t, string(policy), `"Resource":"https://cdn.example.com/sample-id"`)
+	assert.Contains(t, string(policy), `"DateGreaterThan"`)
+	assert.Contains(t, string(policy), `"AWS:SourceIp":"10.0.0.0/8"`)
+}
+
+func TestCDNConfig_Validate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer := sign.NewURLSigner("KEYPAIRID", key)
+
+	tests := map[string]struct {
+		config storage.CDNConfig
+		valid  bool
+	}{
+		"signed links": {
+			config: storage.CDNConfig{Signer: signer, Expiration: storage.CDNLinkExpiration(time.Hour), Links: storage.CDNLinks{catalog.AssetBookFile: storage.LinkSigned}},
+			valid:  true,
+		},
+		"unsigned links without signer": {
+			config: storage.CDNConfig{Links: storage.CDNLinks{catalog.AssetImage: storage.LinkPublic, catalog.AssetSample: storage.LinkPresigned}},
+			valid:  true,
+		},
+		"signed links without signer": {
+			config: storage.CDNConfig{Expiration: storage.CDNLinkExpiration(time.Hour), Links: storage.CDNLinks{catalog.AssetSample: storage.LinkSignedPolicy}},
+		},
+		"signed links expiring before the cache": {
+			config: storage.CDNConfig{Signer: signer, Expiration: storage.CDNLinkExpiration(10 * time.Minute), Links: storage.CDNLinks{catalog.AssetImage: storage.LinkSigned}},
+		},
+		"signed links expiring before twice the cache": {
+			config: storage.CDNConfig{Signer: signer, Expiration: storage.CDNLinkExpiration(15 * time.Minute), Links: storage.CDNLinks{catalog.AssetImage: storage.LinkSigned}},
+		},
+		"unknown link mode": {
+			config: storage.CDNConfig{Links: storage.CDNLinks{catalog.AssetImage: "private"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.config.Validate(10 * time.Minute)
+
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,395 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/log"
+)
+
+var (
+	ErrInvalidKey       = errors.New("the key of the object is not valid")
+	ErrInvalidSignature = errors.New("the signature of the link is not valid")
+	ErrLinkExpired      = errors.New("the link has expired")
+	ErrObjectNotFound   = errors.New("the object was not found")
+)
+
+// localLinkExpiration is how long the links of the local storage are valid, the same as the S3 presigned URLs.
+const localLinkExpiration = 15 * time.Minute
+
+// LocalStoragePath is the path of the routes serving the objects of the local storage.
+const LocalStoragePath = "/storage"
+
+// LocalStorageDir is the directory where the local storage keeps the objects. The bucket is used when it's empty.
+type LocalStorageDir string
+
+// LocalStorageURL is the URL the app is reachable at, which serves the links of the local storage.
+type LocalStorageURL string
+
+type LocalStorageSecret []byte
+
+type LocalConfig struct {
+	Dir    LocalStorageDir
+	URL    LocalStorageURL
+	Secret LocalStorageSecret
+}
+
+// LocalStorage keeps the objects in a directory, so the app runs without S3. Its links point to the app itself, which
+// checks their HMAC signature and expiration and accepts the same uploads as the presigned URLs of the bucket.
+type LocalStorage struct {
+	LocalConfig
+}
+
+// objectMetadata is stored next to each object, as the filesystem has no place for it.
+type objectMetadata struct {
+	ContentType string `json:"contentType"`
+	Checksum    string `json:"checksum,omitempty"`
+}
+
+func NewLocalStorage(c LocalConfig) *LocalStorage {
+	return &LocalStorage{LocalConfig: c}
+}
+
+// Validate checks the links of the local storage leave enough time to be used once they are cached for linkCacheTTL.
+func (c LocalConfig) Validate(linkCacheTTL time.Duration) error {
+	return validateLinkExpiration("local storage links", localLinkExpiration, linkCacheTTL)
+}
+
+func (s *LocalStorage) GenerateGetPreSignedUrl(_ context.Context, key string, _ catalog.Asset) (string, error) {
+	link, err := s.link("GET", key, url.Values{})
+	if err != nil {
+		return "", fmt.Errorf("(GenerateGetPreSignedUrl) failed generating link for key: %s: %w", key, err)
+	}
+
+	return link, nil
+}
+
+// GeneratePutPreSignedUrl signs the content type, length and checksum of the object in the link, so Upload rejects
+// any other content.
+func (s *LocalStorage) GeneratePutPreSignedUrl(ctx context.Context, key string, object catalog.ObjectInfo) (string, error) {
+	log.Infof(ctx, "generating PUT presigned url for key: %s", key)
+
+	params := url.Values{}
+	params.Set("content-type", object.ContentType)
+	params.Set("content-length", strconv.FormatInt(object.Size, 10))
+	if object.Checksum != "" {
+		params.Set("checksum", strings.ToLower(object.Checksum))
+	}
+
+	link, err := s.link("PUT", key, params)
+	if err != nil {
+		return "", fmt.Errorf("(GeneratePutPreSignedUrl) failed generating link for key: %s: %w", key, err)
+	}
+
+	return link, nil
+}
+
+func (s *LocalStorage) HeadObject(_ context.Context, key string) (catalog.ObjectInfo, error) {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed inspecting object for key: %s: %w", key, err)
+	}
+
+	stat, err := os.Stat(objectPath)
+	if os.IsNotExist(err) {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed finding object for key: %s: %w", key, catalog.ErrUploadNotFound)
+	}
+	if err != nil {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed inspecting object for key: %s: %w", key, err)
+	}
+
+	metadata, err := readMetadata(metadataPath)
+	if err != nil {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed reading metadata for key: %s: %w", key, err)
+	}
+
+	return catalog.ObjectInfo{Size: stat.Size(), ContentType: metadata.ContentType, Checksum: metadata.Checksum}, nil
+}
+
+func (s *LocalStorage) GetObject(_ context.Context, key string) (io.ReadCloser, error) {
+	file, _, err := s.open(key)
+	if err != nil {
+		return nil, fmt.Errorf("(GetObject) failed getting object for key: %s: %w", key, err)
+	}
+
+	return file, nil
+}
+
+func (s *LocalStorage) GetObjectRange(_ context.Context, key string, offset int64) (io.ReadCloser, error) {
+	file, _, err := s.open(key)
+	if err != nil {
+		return nil, fmt.Errorf("(GetObjectRange) failed getting object for key: %s: %w", key, err)
+	}
+
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("(GetObjectRange) failed seeking object for key: %s to %d: %w", key, offset, err)
+	}
+
+	return file, nil
+}
+
+func (s *LocalStorage) PutObject(ctx context.Context, key string, contentType string, body io.Reader) error {
+	log.Infof(ctx, "storing object for key: %s", key)
+
+	if err := s.write(key, objectMetadata{ContentType: contentType}, -1, body); err != nil {
+		return fmt.Errorf("(PutObject) failed putting object for key: %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) ListObjects(_ context.Context, prefix string) ([]string, error) {
+	root := filepath.Join(string(s.Dir), "objects")
+	dir := filepath.Join(root, filepath.FromSlash(path.Dir("/"+prefix)))
+
+	var keys []string
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("(ListObjects) failed listing objects for prefix: %s: %w", prefix, err)
+	}
+
+	return keys, nil
+}
+
+func (s *LocalStorage) DeleteObjects(ctx context.Context, keys []string) error {
+	log.Infof(ctx, "deleting %d objects", len(keys))
+
+	for _, key := range keys {
+		objectPath, metadataPath, err := s.paths(key)
+		if err != nil {
+			return fmt.Errorf("(DeleteObjects) failed deleting object for key: %s: %w", key, err)
+		}
+
+		for _, p := range []string{objectPath, metadataPath} {
+			if err = os.Remove(p); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("(DeleteObjects) failed deleting object for key: %s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Upload stores the body sent to a PUT link. Like S3, it fails when the content type, length or checksum of the
+// upload are not the ones signed in the link.
+func (s *LocalStorage) Upload(ctx context.Context, key string, params url.Values, contentType string, size int64, body io.Reader) error {
+	if err := s.verify("PUT", key, params); err != nil {
+		return fmt.Errorf("(Upload) failed verifying link for key: %s: %w", key, err)
+	}
+
+	if contentType != params.Get("content-type") {
+		return fmt.Errorf("(Upload) content type %s was not signed: %w", contentType, catalog.ErrInvalidUpload)
+	}
+	if strconv.FormatInt(size, 10) != params.Get("content-length") {
+		return fmt.Errorf("(Upload) content length %d was not signed: %w", size, catalog.ErrInvalidUpload)
+	}
+
+	log.Infof(ctx, "storing uploaded object for key: %s", key)
+
+	if err := s.write(key, objectMetadata{ContentType: contentType, Checksum: params.Get("checksum")}, size, body); err != nil {
+		return fmt.Errorf("(Upload) failed storing object for key: %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// Download opens the object of a GET link. The caller must close it.
+func (s *LocalStorage) Download(_ context.Context, key string, params url.Values) (*os.File, catalog.ObjectInfo, error) {
+	if err := s.verify("GET", key, params); err != nil {
+		return nil, catalog.ObjectInfo{}, fmt.Errorf("(Download) failed verifying link for key: %s: %w", key, err)
+	}
+
+	file, metadata, err := s.open(key)
+	if err != nil {
+		return nil, catalog.ObjectInfo{}, fmt.Errorf("(Download) failed opening object for key: %s: %w", key, err)
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, catalog.ObjectInfo{}, fmt.Errorf("(Download) failed inspecting object for key: %s: %w", key, err)
+	}
+
+	return file, catalog.ObjectInfo{Size: stat.Size(), ContentType: metadata.ContentType, Checksum: metadata.Checksum}, nil
+}
+
+// link signs the method, key and parameters of the link along with its expiration.
+func (s *LocalStorage) link(method, key string, params url.Values) (string, error) {
+	if _, _, err := s.paths(key); err != nil {
+		return "", err
+	}
+
+	params.Set("expires", strconv.FormatInt(time.Now().Add(localLinkExpiration).Unix(), 10))
+	params.Set("signature", s.sign(method, key, params))
+
+	return fmt.Sprintf("%s%s/%s?%s", strings.TrimSuffix(string(s.URL), "/"), LocalStoragePath, escapeKey(key), params.Encode()), nil
+}
+
+func (s *LocalStorage) sign(method, key string, params url.Values) string {
+	signed := url.Values{}
+	for name, values := range params {
+		if name != "signature" {
+			signed[name] = values
+		}
+	}
+
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(method + "\n" + key + "\n" + signed.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *LocalStorage) verify(method, key string, params url.Values) error {
+	if !hmac.Equal([]byte(params.Get("signature")), []byte(s.sign(method, key, params))) {
+		return ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(params.Get("expires"), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if time.Now().Unix() > expires {
+		return ErrLinkExpired
+	}
+
+	return nil
+}
+
+// paths returns where the object and its metadata are stored. Keys are relative paths that must stay in the directory.
+func (s *LocalStorage) paths(key string) (string, string, error) {
+	if key == "" || strings.Contains(key, "\\") || path.Clean("/"+key) != "/"+key {
+		return "", "", ErrInvalidKey
+	}
+
+	return filepath.Join(string(s.Dir), "objects", filepath.FromSlash(key)),
+		filepath.Join(string(s.Dir), "metadata", filepath.FromSlash(key)+".json"), nil
+}
+
+func (s *LocalStorage) open(key string) (*os.File, objectMetadata, error) {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return nil, objectMetadata{}, err
+	}
+
+	file, err := os.Open(objectPath)
+	if os.IsNotExist(err) {
+		return nil, objectMetadata{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, objectMetadata{}, err
+	}
+
+	metadata, err := readMetadata(metadataPath)
+	if err != nil {
+		_ = file.Close()
+		return nil, objectMetadata{}, err
+	}
+
+	return file, metadata, nil
+}
+
+// write stores the object in a temporary file that replaces the previous one once it's complete, so readers never see
+// a partial object. When the size is not negative, the content must have that size, and its checksum when it has one.
+func (s *LocalStorage) write(key string, metadata objectMetadata, size int64, body io.Reader) error {
+	objectPath, metadataPath, err := s.paths(key)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range []string{objectPath, metadataPath} {
+		if err = os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(objectPath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	defer temp.Close()
+
+	if size >= 0 {
+		body = io.LimitReader(body, size+1)
+	}
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(temp, hash), body)
+	if err != nil {
+		return err
+	}
+
+	if size >= 0 && written != size {
+		return fmt.Errorf("received %d bytes instead of %d: %w", written, size, catalog.ErrInvalidUpload)
+	}
+	if metadata.Checksum != "" && metadata.Checksum != hex.EncodeToString(hash.Sum(nil)) {
+		return fmt.Errorf("checksum of the content does not match: %w", catalog.ErrInvalidUpload)
+	}
+
+	if err = temp.Close(); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	if err = ioutil.WriteFile(metadataPath, encoded, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), objectPath)
+}
+
+func readMetadata(metadataPath string) (objectMetadata, error) {
+	content, err := ioutil.ReadFile(metadataPath)
+	if err != nil {
+		return objectMetadata{}, err
+	}
+
+	var metadata objectMetadata
+	if err = json.Unmarshal(content, &metadata); err != nil {
+		return objectMetadata{}, err
+	}
+
+	return metadata, nil
+}
+
+// escapeKey escapes each segment of the key to use it as the path of a URL.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,152 @@
+package storage_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStorage(t *testing.T) *storage.LocalStorage {
+	return storage.NewLocalStorage(storage.LocalConfig{
+		Dir:    storage.LocalStorageDir(t.TempDir()),
+		URL:    "http://localhost:8080/",
+		Secret: []byte("secret"),
+	})
+}
+
+// linkParams returns the key and the query of a link of the local storage.
+func linkParams(t *testing.T, link string) (string, url.Values) {
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(parsed.Path, storage.LocalStoragePath+"/"))
+
+	return strings.TrimPrefix(parsed.Path, storage.LocalStoragePath+"/"), parsed.Query()
+}
+
+func TestLocalStorage_UploadAndDownload(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestLocalStorage(t)
+	content := "some content"
+	sum := sha256.Sum256([]byte(content))
+	object := catalog.ObjectInfo{Size: int64(len(content)), ContentType: "application/pdf", Checksum: hex.EncodeToString(sum[:])}
+
+	link, err := s.GeneratePutPreSignedUrl(ctx, "books/some id", object)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(link, "http://localhost:8080/storage/books/some%20id?"))
+
+	key, params := linkParams(t, link)
+	key, err = url.PathUnescape(key)
+	require.NoError(t, err)
+	require.NoError(t, s.Upload(ctx, key, params, object.ContentType, object.Size, strings.NewReader(content)))
+
+	info, err := s.HeadObject(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, object, info)
+
+	link, err = s.GenerateGetPreSignedUrl(ctx, key, catalog.AssetBookFile)
+	require.NoError(t, err)
+
+	_, params = linkParams(t, link)
+	file, info, err := s.Download(ctx, key, params)
+	require.NoError(t, err)
+	defer file.Close()
+
+	downloaded, err := ioutil.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(downloaded))
+	assert.Equal(t, object, info)
+}
+
+func TestLocalStorage_UploadNotMatchingTheLink(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestLocalStorage(t)
+	sum := sha256.Sum256([]byte("expected"))
+	object := catalog.ObjectInfo{Size: 8, ContentType: "application/pdf", Checksum: hex.EncodeToString(sum[:])}
+
+	link, err := s.GeneratePutPreSignedUrl(ctx, "key", object)
+	require.NoError(t, err)
+	_, params := linkParams(t, link)
+
+	err = s.Upload(ctx, "key", params, "image/png", 8, strings.NewReader("expected"))
+	assert.ErrorIs(t, err, catalog.ErrInvalidUpload)
+
+	err = s.Upload(ctx, "key", params, "application/pdf", 9, strings.NewReader("expected!"))
+	assert.ErrorIs(t, err, catalog.ErrInvalidUpload)
+
+	err = s.Upload(ctx, "key", params, "application/pdf", 8, strings.NewReader("tampered"))
+	assert.ErrorIs(t, err, catalog.ErrInvalidUpload)
+
+	err = s.Upload(ctx, "other-key", params, "application/pdf", 8, strings.NewReader("expected"))
+	assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+
+	_, err = s.HeadObject(ctx, "key")
+	assert.ErrorIs(t, err, catalog.ErrUploadNotFound)
+}
+
+func TestLocalStorage_DownloadWithInvalidLink(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestLocalStorage(t)
+	require.NoError(t, s.PutObject(ctx, "key", "image/png", strings.NewReader("image")))
+
+	link, err := s.GenerateGetPreSignedUrl(ctx, "key", catalog.AssetImage)
+	require.NoError(t, err)
+	_, params := linkParams(t, link)
+
+	params.Set("expires", "1")
+	_, _, err = s.Download(ctx, "key", params)
+	assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+
+	_, _, err = s.Download(ctx, "key", url.Values{})
+	assert.ErrorIs(t, err, storage.ErrInvalidSignature)
+}
+
+func TestLocalStorage_InvalidKey(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	for _, key := range []string{"", "../key", "key/../../other", "/key", "key/"} {
+		_, err := s.GenerateGetPreSignedUrl(context.TODO(), key, catalog.AssetImage)
+		assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+}
+
+func TestLocalStorage_ListObjects(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestLocalStorage(t)
+	require.NoError(t, s.PutObject(ctx, "watermarks/id/order-1", "application/pdf", strings.NewReader("pdf")))
+	require.NoError(t, s.PutObject(ctx, "watermarks/id/order-2", "application/pdf", strings.NewReader("pdf")))
+	require.NoError(t, s.PutObject(ctx, "watermarks/other-id/order-1", "application/pdf", strings.NewReader("pdf")))
+
+	keys, err := s.ListObjects(ctx, "watermarks/id/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"watermarks/id/order-1", "watermarks/id/order-2"}, keys)
+
+	keys, err = s.ListObjects(ctx, "watermarks/missing/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestLocalStorage_DeleteObjects(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestLocalStorage(t)
+	require.NoError(t, s.PutObject(ctx, "variants/id/thumbnail", "image/jpeg", strings.NewReader("image")))
+
+	require.NoError(t, s.DeleteObjects(ctx, []string{"variants/id/thumbnail", "missing"}))
+
+	_, err := s.GetObject(ctx, "variants/id/thumbnail")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestLocalConfig_Validate(t *testing.T) {
+	assert.NoError(t, storage.LocalConfig{}.Validate(7*time.Minute))
+	assert.Error(t, storage.LocalConfig{}.Validate(10*time.Minute))
+}
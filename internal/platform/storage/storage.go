@@ -2,14 +2,24 @@ package storage
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/log"
 )
 
+// presignExpiration is how long the presigned URLs of the bucket are valid.
+const presignExpiration = 15 * time.Minute
+
 type Config struct {
 	S3Client      *s3.Client
 	PresignClient *s3.PresignClient
@@ -26,14 +36,28 @@ func NewStorage(c Config) *Storage {
 	return &Storage{Config: c}
 }
 
-func (c *Storage) GenerateGetPreSignedUrl(ctx context.Context, key string) (string, error) {
+// Validate checks the presigned URLs leave enough time to be used once they are cached for linkCacheTTL.
+func (c Config) Validate(linkCacheTTL time.Duration) error {
+	return validateLinkExpiration("presigned links", presignExpiration, linkCacheTTL)
+}
+
+// validateLinkExpiration checks the links expire at least twice as late as linkCacheTTL, the time a generated link is
+// cached, so a link served from the cache just before it's refreshed still leaves its client time to use it.
+func validateLinkExpiration(links string, expiration, linkCacheTTL time.Duration) error {
+	if expiration < 2*linkCacheTTL {
+		return fmt.Errorf("%s expiring after %s cannot be cached for more than half of it, not %s", links, expiration, linkCacheTTL)
+	}
+	return nil
+}
+
+func (c *Storage) GenerateGetPreSignedUrl(ctx context.Context, key string, _ catalog.Asset) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
 	presignResult, err := c.PresignClient.PresignGetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(string(c.Bucket)),
 		Key:    aws.String(key),
-	})
+	}, s3.WithPresignExpires(presignExpiration))
 
 	if err != nil {
 		return "", fmt.Errorf("(GenerateGetPreSignedUrl) failed generating presignedUrl for key: %s: %w", key, err)
@@ -42,20 +66,174 @@ func (c *Storage) GenerateGetPreSignedUrl(ctx context.Context, key string) (stri
 	return presignResult.URL, nil
 }
 
-func (c *Storage) GeneratePutPreSignedUrl(ctx context.Context, key string) (string, error) {
+// GeneratePutPreSignedUrl signs the content type and length of the object, so S3 rejects any other upload. When the
+// object has a checksum, it's signed as well and S3 rejects content with a different one.
+func (c *Storage) GeneratePutPreSignedUrl(ctx context.Context, key string, object catalog.ObjectInfo) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
 	log.Infof(ctx, "generating PUT presigned url for key: %s", key)
 
-	presignResult, err := c.PresignClient.PresignPutObject(ctx, &s3.PutObjectInput{
+	input := &s3.PutObjectInput{
+		Bucket:        aws.String(string(c.Bucket)),
+		Key:           aws.String(key),
+		ContentType:   aws.String(object.ContentType),
+		ContentLength: aws.Int64(object.Size),
+	}
+	if object.Checksum != "" {
+		checksum, err := hex.DecodeString(object.Checksum)
+		if err != nil {
+			return "", fmt.Errorf("(GeneratePutPreSignedUrl) failed decoding checksum for key: %s: %w", key, err)
+		}
+		input.ChecksumSHA256 = aws.String(base64.StdEncoding.EncodeToString(checksum))
+	}
+
+	presignResult, err := c.PresignClient.PresignPutObject(ctx, input, s3.WithPresignExpires(presignExpiration))
+
+	if err != nil {
+		return "", fmt.Errorf("(GeneratePutPreSignedUrl) failed generating presignedUrl for key: %s: %w", key, err)
+	}
+
+	return presignResult.URL, nil
+}
+
+// HeadObject describes the object without downloading it. The checksum is only known when it was sent on upload.
+func (c *Storage) HeadObject(ctx context.Context, key string) (catalog.ObjectInfo, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	output, err := c.S3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket:       aws.String(string(c.Bucket)),
+		Key:          aws.String(key),
+		ChecksumMode: types.ChecksumModeEnabled,
+	})
+
+	var notFound *types.NotFound
+	if errors.As(err, &notFound) {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed finding object for key: %s: %w", key, catalog.ErrUploadNotFound)
+	}
+	if err != nil {
+		return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed inspecting object for key: %s: %w", key, err)
+	}
+
+	info := catalog.ObjectInfo{Size: aws.Int64Value(output.ContentLength), ContentType: aws.StringValue(output.ContentType)}
+	if output.ChecksumSHA256 != nil {
+		checksum, err := base64.StdEncoding.DecodeString(*output.ChecksumSHA256)
+		if err != nil {
+			return catalog.ObjectInfo{}, fmt.Errorf("(HeadObject) failed decoding checksum for key: %s: %w", key, err)
+		}
+		info.Checksum = hex.EncodeToString(checksum)
+	}
+
+	return info, nil
+}
+
+func (c *Storage) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := c.S3Client.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(string(c.Bucket)),
 		Key:    aws.String(key),
 	})
 
 	if err != nil {
-		return "", fmt.Errorf("(GeneratePutPreSignedUrl) failed generating presignedUrl for key: %s: %w", key, err)
+		return nil, fmt.Errorf("(GetObject) failed getting object for key: %s: %w", key, err)
 	}
 
-	return presignResult.URL, nil
+	return output.Body, nil
+}
+
+func (c *Storage) GetObjectRange(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	output, err := c.S3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(string(c.Bucket)),
+		Key:    aws.String(key),
+		Range:  aws.String(fmt.Sprintf("bytes=%d-", offset)),
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("(GetObjectRange) failed getting object for key: %s from %d: %w", key, offset, err)
+	}
+
+	return output.Body, nil
+}
+
+func (c *Storage) PutObject(ctx context.Context, key string, contentType string, body io.Reader) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	log.Infof(ctx, "storing object for key: %s", key)
+
+	_, err := c.S3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(string(c.Bucket)),
+		Key:         aws.String(key),
+		ContentType: aws.String(contentType),
+		Body:        body,
+	})
+
+	if err != nil {
+		return fmt.Errorf("(PutObject) failed putting object for key: %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *Storage) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(c.S3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(string(c.Bucket)),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("(ListObjects) failed listing objects for prefix: %s: %w", prefix, err)
+		}
+
+		for _, object := range page.Contents {
+			keys = append(keys, aws.StringValue(object.Key))
+		}
+	}
+
+	return keys, nil
+}
+
+// deleteObjectsBatchSize is the maximum number of keys S3 deletes in a single request.
+const deleteObjectsBatchSize = 1000
+
+func (c *Storage) DeleteObjects(ctx context.Context, keys []string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	log.Infof(ctx, "deleting %d objects", len(keys))
+
+	for start := 0; start < len(keys); start += deleteObjectsBatchSize {
+		end := start + deleteObjectsBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		objects := make([]types.ObjectIdentifier, 0, end-start)
+		for _, key := range keys[start:end] {
+			objects = append(objects, types.ObjectIdentifier{Key: aws.String(key)})
+		}
+
+		output, err := c.S3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(string(c.Bucket)),
+			Delete: &types.Delete{Objects: objects, Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return fmt.Errorf("(DeleteObjects) failed deleting %d objects: %w", len(objects), err)
+		}
+
+		if len(output.Errors) > 0 {
+			failed := make([]string, 0, len(output.Errors))
+			for _, e := range output.Errors {
+				failed = append(failed, fmt.Sprintf("%s: %s", aws.StringValue(e.Key), aws.StringValue(e.Message)))
+			}
+			return fmt.Errorf("(DeleteObjects) failed deleting %d objects: %s", len(failed), strings.Join(failed, ", "))
+		}
+	}
+
+	return nil
 }
@@ -3,8 +3,12 @@ package storage_test
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/ebookstore/internal/core/catalog"
 	"github.com/ebookstore/internal/platform/config"
 	"github.com/ebookstore/internal/platform/storage"
 	"github.com/ebookstore/test"
@@ -50,10 +54,15 @@ func TestStorageClientTestSuiteRun(t *testing.T) {
 	suite.Run(t, new(StorageClientTestSuite))
 }
 
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, storage.Config{}.Validate(7*time.Minute))
+	assert.Error(t, storage.Config{}.Validate(10*time.Minute))
+}
+
 func (s *StorageClientTestSuite) TestGenerateGetPreSignedUrl() {
 	key := "some-key"
 
-	url, err := s.storage.GenerateGetPreSignedUrl(context.TODO(), key)
+	url, err := s.storage.GenerateGetPreSignedUrl(context.TODO(), key, catalog.AssetBookFile)
 
 	assert.Nil(s.T(), err)
 	assert.NotEmpty(s.T(), url)
@@ -62,8 +71,46 @@ func (s *StorageClientTestSuite) TestGenerateGetPreSignedUrl() {
 func (s *StorageClientTestSuite) TestGeneratePutPreSignedUrl() {
 	key := "some-key"
 
-	url, err := s.storage.GeneratePutPreSignedUrl(context.TODO(), key)
+	object := catalog.ObjectInfo{Size: 1024, ContentType: "image/png", Checksum: strings.Repeat("ab", 32)}
+
+	url, err := s.storage.GeneratePutPreSignedUrl(context.TODO(), key, object)
 
 	assert.Nil(s.T(), err)
 	assert.NotEmpty(s.T(), url)
 }
+
+func (s *StorageClientTestSuite) TestPutAndGetObject() {
+	ctx := context.TODO()
+	key := "sample-key"
+
+	err := s.storage.PutObject(ctx, key, "application/pdf", strings.NewReader("%PDF-1.7 sample"))
+	s.Require().NoError(err)
+
+	body, err := s.storage.GetObject(ctx, key)
+	s.Require().NoError(err)
+	defer body.Close()
+
+	content, err := ioutil.ReadAll(body)
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "%PDF-1.7 sample", string(content))
+}
+
+func (s *StorageClientTestSuite) TestHeadObject() {
+	ctx := context.TODO()
+	key := "head-key"
+
+	err := s.storage.PutObject(ctx, key, "application/pdf", strings.NewReader("%PDF-1.7 sample"))
+	s.Require().NoError(err)
+
+	info, err := s.storage.HeadObject(ctx, key)
+
+	s.Require().NoError(err)
+	assert.Equal(s.T(), int64(15), info.Size)
+	assert.Equal(s.T(), "application/pdf", info.ContentType)
+}
+
+func (s *StorageClientTestSuite) TestHeadObject_NotFound() {
+	_, err := s.storage.HeadObject(context.TODO(), "missing-key")
+
+	assert.ErrorIs(s.T(), err, catalog.ErrUploadNotFound)
+}
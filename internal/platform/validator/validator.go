@@ -3,6 +3,7 @@ package validator
 import (
 	"fmt"
 
+	"github.com/ebookstore/internal/core/catalog"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -11,8 +12,14 @@ type Validator struct {
 }
 
 func New() *Validator {
+	validate := validator.New()
+
+	// The built-in isbn rules do not match the catalog normalization, so a book ISBN is checked with the same parser
+	_ = validate.RegisterValidation("book_isbn", isBookISBN)
+	_ = validate.RegisterValidation("accessibility_feature", isAccessibilityFeature)
+
 	return &Validator{
-		validate: validator.New(),
+		validate: validate,
 	}
 }
 
@@ -23,3 +30,12 @@ func (v *Validator) Validate(i interface{}) error {
 
 	return nil
 }
+
+func isBookISBN(fl validator.FieldLevel) bool {
+	_, err := catalog.NormalizeISBN(fl.Field().String())
+	return err == nil
+}
+
+func isAccessibilityFeature(fl validator.FieldLevel) bool {
+	return catalog.IsAccessibilityFeature(fl.Field().String())
+}
@@ -35,3 +35,52 @@ func TestValidator_Validate(t *testing.T) {
 	}
 
 }
+
+func TestValidator_ValidateBookISBN(t *testing.T) {
+	type book struct {
+		ISBN string `validate:"omitempty,book_isbn"`
+	}
+
+	tests := []struct {
+		Name          string
+		Book          book
+		ExpectedError bool
+	}{
+		{
+			Name:          "Empty ISBN",
+			Book:          book{ISBN: ""},
+			ExpectedError: false,
+		},
+		{
+			Name:          "Valid ISBN-10",
+			Book:          book{ISBN: "0-321-12521-5"},
+			ExpectedError: false,
+		},
+		{
+			Name:          "Valid ISBN-13",
+			Book:          book{ISBN: "978-0-321-12521-7"},
+			ExpectedError: false,
+		},
+		{
+			Name:          "Invalid checksum",
+			Book:          book{ISBN: "978-0-321-12521-8"},
+			ExpectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := New().Validate(tc.Book)
+			assert.Equal(t, tc.ExpectedError, err != nil)
+		})
+	}
+}
+
+func TestValidator_ValidateAccessibilityFeature(t *testing.T) {
+	type book struct {
+		Features []string `validate:"omitempty,dive,accessibility_feature"`
+	}
+
+	assert.NoError(t, New().Validate(book{Features: []string{"alternativeText", "tableOfContents"}}))
+	assert.Error(t, New().Validate(book{Features: []string{"alternativeText", "unknown"}}))
+}
@@ -0,0 +1,56 @@
+package watermarker
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/pdf"
+)
+
+// Watermarker stamps the PDFs with their buyer: a footer on every page, and the same details as invisible text
+// and in the document properties, so a leaked copy can be traced back to its order.
+type Watermarker struct{}
+
+func NewWatermarker() *Watermarker {
+	return &Watermarker{}
+}
+
+// Watermark returns the content stamped with the buyer of the given mark.
+func (w *Watermarker) Watermark(format catalog.BookFormat, r io.Reader, mark catalog.Watermark) ([]byte, error) {
+	if format != catalog.FormatPDF {
+		return nil, fmt.Errorf("(Watermark) unsupported format %s: %w", format, catalog.ErrInvalidWatermarkSource)
+	}
+
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("(Watermark) failed reading content: %w", err)
+	}
+
+	document, err := pdf.Read(content)
+	if err != nil {
+		return nil, fmt.Errorf("(Watermark) failed reading %s content: %v: %w", format, err, catalog.ErrInvalidWatermarkSource)
+	}
+
+	timestamp := mark.Time.UTC().Format(time.RFC3339)
+	document.Stamp(
+		fmt.Sprintf("Licensed to %s <%s> - Order %s - %s", mark.Name, mark.Email, mark.OrderID, mark.Time.UTC().Format("2006-01-02 15:04 MST")),
+		fmt.Sprintf("buyer=%s; email=%s; order=%s; timestamp=%s", mark.Name, mark.Email, mark.OrderID, timestamp),
+	)
+	document.SetInfo(map[pdf.Name]string{
+		"Buyer":       mark.Name,
+		"BuyerEmail":  mark.Email,
+		"OrderID":     mark.OrderID,
+		"WatermarkAt": timestamp,
+	})
+
+	var buf bytes.Buffer
+	if _, err = document.WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("(Watermark) failed writing stamped content: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package watermarker
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ebookstore/internal/core/catalog"
+	"github.com/ebookstore/internal/platform/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMark = catalog.Watermark{
+	Name:    "Jane Doe",
+	Email:   "jane@ebookstore.com",
+	OrderID: "order-1",
+	Time:    time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC),
+}
+
+func testPDF() []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	buf.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	buf.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 612 792] >>\nendobj\n")
+	buf.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>\nendobj\n")
+	content := "BT (Chapter one) Tj ET"
+	fmt.Fprintf(&buf, "4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+
+	return buf.Bytes()
+}
+
+func TestWatermarker_Watermark(t *testing.T) {
+	stamped, err := NewWatermarker().Watermark(catalog.FormatPDF, bytes.NewReader(testPDF()), testMark)
+	require.NoError(t, err)
+
+	document, err := pdf.Read(stamped)
+	require.NoError(t, err)
+	require.Equal(t, 1, document.NumPages())
+
+	contents, ok := document.Resolve(document.Page(0)["Contents"]).(pdf.Array)
+	require.True(t, ok)
+	stamp, ok := document.Resolve(contents[len(contents)-1]).(pdf.Stream)
+	require.True(t, ok)
+	data, err := stamp.Decode()
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), "(Licensed to Jane Doe <jane@ebookstore.com> - Order order-1 - 2026-10-19 09:30 UTC) Tj")
+	assert.Contains(t, string(data), "(buyer=Jane Doe; email=jane@ebookstore.com; order=order-1; timestamp=2026-10-19T09:30:00Z) Tj")
+	assert.Contains(t, string(stamped), "/BuyerEmail (jane@ebookstore.com)")
+	assert.Contains(t, string(stamped), "/OrderID (order-1)")
+	assert.Contains(t, string(stamped), "/WatermarkAt (2026-10-19T09:30:00Z)")
+}
+
+func TestWatermarker_WatermarkInvalidPDF(t *testing.T) {
+	_, err := NewWatermarker().Watermark(catalog.FormatPDF, strings.NewReader("not a pdf"), testMark)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidWatermarkSource)
+}
+
+func TestWatermarker_WatermarkUnsupportedFormat(t *testing.T) {
+	_, err := NewWatermarker().Watermark(catalog.FormatEPUB, strings.NewReader("PK"), testMark)
+
+	assert.ErrorIs(t, err, catalog.ErrInvalidWatermarkSource)
+}
@@ -0,0 +1,15 @@
+CREATE TABLE categories
+(
+    id         VARCHAR(36)  NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    parent_id  VARCHAR(36)  NULL,
+    created_at TIMESTAMP    NOT NULL,
+    updated_at TIMESTAMP    NOT NULL,
+    CONSTRAINT categories_pkey PRIMARY KEY (id),
+    CONSTRAINT categories_slug_unique UNIQUE (slug),
+    CONSTRAINT categories_parent_id_fkey FOREIGN KEY (parent_id)
+        REFERENCES categories (id) ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
@@ -0,0 +1 @@
+DROP TABLE books_categories;
\ No newline at end of file
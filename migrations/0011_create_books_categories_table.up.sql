@@ -0,0 +1,12 @@
+CREATE TABLE books_categories
+(
+    book_id     VARCHAR(36) NOT NULL,
+    category_id VARCHAR(36) NOT NULL,
+    CONSTRAINT books_categories_pkey PRIMARY KEY (book_id, category_id),
+    CONSTRAINT books_categories_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT books_categories_category_id_fkey FOREIGN KEY (category_id)
+        REFERENCES categories (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_books_categories_category_id ON books_categories (category_id);
@@ -0,0 +1,12 @@
+CREATE TABLE authors
+(
+    id         VARCHAR(36)  NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    slug       VARCHAR(100) NOT NULL,
+    bio        TEXT         NOT NULL DEFAULT '',
+    photo_id   VARCHAR(50)  NULL,
+    created_at TIMESTAMP    NOT NULL,
+    updated_at TIMESTAMP    NOT NULL,
+    CONSTRAINT authors_pkey PRIMARY KEY (id),
+    CONSTRAINT authors_slug_unique UNIQUE (slug)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE books_authors
+(
+    book_id   VARCHAR(36) NOT NULL,
+    author_id VARCHAR(36) NOT NULL,
+    role      VARCHAR(20) NOT NULL,
+    position  INTEGER     NOT NULL,
+    CONSTRAINT books_authors_pkey PRIMARY KEY (book_id, author_id, role),
+    CONSTRAINT books_authors_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT books_authors_author_id_fkey FOREIGN KEY (author_id)
+        REFERENCES authors (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_books_authors_author_id ON books_authors (author_id);
@@ -0,0 +1,14 @@
+ALTER TABLE books ADD COLUMN author_name VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE books
+SET author_name = LEFT(names.author_name, 100)
+FROM (
+    SELECT ba.book_id, STRING_AGG(a.name, ', ' ORDER BY ba.position) AS author_name
+    FROM books_authors ba
+             INNER JOIN authors a ON a.id = ba.author_id
+    WHERE ba.role = 'author'
+    GROUP BY ba.book_id
+) AS names
+WHERE names.book_id = books.id;
+
+ALTER TABLE books ALTER COLUMN author_name DROP DEFAULT;
@@ -0,0 +1,22 @@
+-- Every distinct author_name becomes an author identified by its slug. The id is derived from the slug,
+-- so the books can be linked without looking the generated authors up again.
+CREATE TEMPORARY TABLE book_author_names AS
+SELECT id                                                                                 AS book_id,
+       TRIM(author_name)                                                                  AS name,
+       TRIM(BOTH '-' FROM LOWER(REGEXP_REPLACE(author_name, '[^[:alnum:]]+', '-', 'g'))) AS slug
+FROM books;
+
+DELETE FROM book_author_names WHERE slug = '';
+
+INSERT INTO authors (id, name, slug, bio, created_at, updated_at)
+SELECT DISTINCT ON (slug) CAST(CAST(MD5(slug) AS UUID) AS VARCHAR), name, slug, '', NOW(), NOW()
+FROM book_author_names
+ORDER BY slug, name;
+
+INSERT INTO books_authors (book_id, author_id, role, position)
+SELECT book_id, CAST(CAST(MD5(slug) AS UUID) AS VARCHAR), 'author', 0
+FROM book_author_names;
+
+DROP TABLE book_author_names;
+
+ALTER TABLE books DROP COLUMN author_name;
@@ -0,0 +1,11 @@
+DROP INDEX idx_books_language;
+DROP INDEX idx_books_publisher;
+
+ALTER TABLE books
+    DROP CONSTRAINT books_isbn_unique,
+    DROP COLUMN subtitle,
+    DROP COLUMN isbn,
+    DROP COLUMN publisher,
+    DROP COLUMN language,
+    DROP COLUMN page_count,
+    DROP COLUMN edition;
@@ -0,0 +1,11 @@
+ALTER TABLE books
+    ADD COLUMN subtitle   VARCHAR(200) NOT NULL DEFAULT '',
+    ADD COLUMN isbn       VARCHAR(13)  NULL,
+    ADD COLUMN publisher  VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN language   VARCHAR(35)  NOT NULL DEFAULT '',
+    ADD COLUMN page_count INTEGER      NOT NULL DEFAULT 0,
+    ADD COLUMN edition    VARCHAR(50)  NOT NULL DEFAULT '',
+    ADD CONSTRAINT books_isbn_unique UNIQUE (isbn);
+
+CREATE INDEX idx_books_publisher ON books (publisher);
+CREATE INDEX idx_books_language ON books (language);
@@ -0,0 +1 @@
+DROP TABLE books_accessibility_features;
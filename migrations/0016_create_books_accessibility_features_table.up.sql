@@ -0,0 +1,10 @@
+CREATE TABLE books_accessibility_features
+(
+    book_id VARCHAR(36) NOT NULL,
+    feature VARCHAR(50) NOT NULL,
+    CONSTRAINT books_accessibility_features_pkey PRIMARY KEY (book_id, feature),
+    CONSTRAINT books_accessibility_features_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_books_accessibility_features_feature ON books_accessibility_features (feature);
@@ -0,0 +1,21 @@
+CREATE TABLE reviews
+(
+    id         VARCHAR(36)  NOT NULL,
+    book_id    VARCHAR(36)  NOT NULL,
+    user_id    VARCHAR(36)  NOT NULL,
+    rating     SMALLINT     NOT NULL,
+    title      VARCHAR(150) NOT NULL DEFAULT '',
+    body       TEXT         NOT NULL DEFAULT '',
+    status     VARCHAR(20)  NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    updated_at TIMESTAMP    NOT NULL,
+    CONSTRAINT reviews_pkey PRIMARY KEY (id),
+    CONSTRAINT reviews_book_id_user_id_unique UNIQUE (book_id, user_id),
+    CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT reviews_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT reviews_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_reviews_status ON reviews (status);
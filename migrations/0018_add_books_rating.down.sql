@@ -0,0 +1,5 @@
+DROP INDEX idx_books_rating_average;
+
+ALTER TABLE books
+    DROP COLUMN rating_average,
+    DROP COLUMN rating_count;
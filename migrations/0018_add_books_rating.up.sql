@@ -0,0 +1,5 @@
+ALTER TABLE books
+    ADD COLUMN rating_average NUMERIC(3, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN rating_count   INTEGER       NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_books_rating_average ON books (rating_average);
@@ -0,0 +1,17 @@
+CREATE TABLE wishlist_items
+(
+    user_id          VARCHAR(36)  NOT NULL,
+    book_id          VARCHAR(36)  NOT NULL,
+    name             VARCHAR(255) NOT NULL,
+    preview_image_id VARCHAR(36)  NOT NULL DEFAULT '',
+    price            INT          NOT NULL,
+    notified_price   INT          NULL,
+    created_at       TIMESTAMP    NOT NULL,
+    CONSTRAINT wishlist_items_pkey PRIMARY KEY (user_id, book_id),
+    CONSTRAINT wishlist_items_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT wishlist_items_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_wishlist_items_book_id ON wishlist_items (book_id);
@@ -0,0 +1,3 @@
+ALTER TABLE books
+    DROP CONSTRAINT books_external_id_unique,
+    DROP COLUMN external_id;
@@ -0,0 +1,3 @@
+ALTER TABLE books
+    ADD COLUMN external_id VARCHAR(100) NULL,
+    ADD CONSTRAINT books_external_id_unique UNIQUE (external_id);
@@ -0,0 +1,14 @@
+CREATE TABLE app_tokens
+(
+    id         VARCHAR(36)  NOT NULL,
+    user_id    VARCHAR(36)  NOT NULL,
+    name       VARCHAR(100) NOT NULL,
+    token_hash VARCHAR(64)  NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    CONSTRAINT app_tokens_pkey PRIMARY KEY (id),
+    CONSTRAINT app_tokens_token_hash_unique UNIQUE (token_hash),
+    CONSTRAINT app_tokens_user_id_fkey FOREIGN KEY (user_id)
+        REFERENCES users (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_app_tokens_user_id ON app_tokens (user_id);
@@ -0,0 +1,8 @@
+ALTER TABLE books ADD COLUMN content_id VARCHAR(100) NOT NULL DEFAULT '';
+
+UPDATE books
+SET content_id = book_files.content_id
+FROM book_files
+WHERE book_files.book_id = books.id AND book_files.format = 'pdf';
+
+DROP TABLE book_files;
@@ -0,0 +1,22 @@
+CREATE TABLE book_files
+(
+    book_id    VARCHAR(36)  NOT NULL,
+    format     VARCHAR(10)  NOT NULL,
+    content_id VARCHAR(100) NOT NULL,
+    media_type VARCHAR(100) NOT NULL,
+    size       BIGINT       NOT NULL DEFAULT 0,
+    checksum   VARCHAR(64)  NOT NULL DEFAULT '',
+    created_at TIMESTAMP    NOT NULL,
+    updated_at TIMESTAMP    NOT NULL,
+    CONSTRAINT book_files_pkey PRIMARY KEY (book_id, format),
+    CONSTRAINT book_files_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- The books had a single content in PDF. Their size and checksum are unknown until they are uploaded again.
+INSERT INTO book_files (book_id, format, content_id, media_type, created_at, updated_at)
+SELECT id, 'pdf', content_id, 'application/pdf', created_at, updated_at
+FROM books
+WHERE content_id <> '';
+
+ALTER TABLE books DROP COLUMN content_id;
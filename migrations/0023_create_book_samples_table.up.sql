@@ -0,0 +1,14 @@
+CREATE TABLE book_samples
+(
+    book_id    VARCHAR(36)  NOT NULL,
+    format     VARCHAR(10)  NOT NULL,
+    content_id VARCHAR(100) NOT NULL,
+    media_type VARCHAR(100) NOT NULL,
+    size       BIGINT       NOT NULL DEFAULT 0,
+    generated  BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP    NOT NULL,
+    updated_at TIMESTAMP    NOT NULL,
+    CONSTRAINT book_samples_pkey PRIMARY KEY (book_id),
+    CONSTRAINT book_samples_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
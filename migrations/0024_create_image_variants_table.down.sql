@@ -0,0 +1,4 @@
+DROP TABLE image_variants;
+
+ALTER TABLE images
+    DROP COLUMN variants_generated;
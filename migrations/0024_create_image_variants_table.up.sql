@@ -0,0 +1,16 @@
+ALTER TABLE images
+    ADD COLUMN variants_generated BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE image_variants
+(
+    image_id   VARCHAR(100) NOT NULL,
+    name       VARCHAR(20)  NOT NULL,
+    content_id VARCHAR(200) NOT NULL,
+    media_type VARCHAR(100) NOT NULL,
+    width      INTEGER      NOT NULL,
+    height     INTEGER      NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    CONSTRAINT image_variants_pkey PRIMARY KEY (image_id, name),
+    CONSTRAINT image_variants_image_id_fkey FOREIGN KEY (image_id)
+        REFERENCES images (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE images
+    DROP COLUMN position,
+    DROP COLUMN is_main;
@@ -0,0 +1,11 @@
+ALTER TABLE images
+    ADD COLUMN position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN is_main  BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Images were listed in the order the database returned them, which is usually the insertion order
+UPDATE images
+SET position = ordered.position,
+    is_main  = ordered.position = 0
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY book_id ORDER BY ctid) - 1 AS position
+      FROM images) AS ordered
+WHERE images.id = ordered.id;
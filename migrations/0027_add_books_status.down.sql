@@ -0,0 +1,5 @@
+DROP INDEX idx_books_scheduled_publish_at;
+
+ALTER TABLE books
+    DROP COLUMN publish_at,
+    DROP COLUMN status;
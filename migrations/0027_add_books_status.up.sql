@@ -0,0 +1,12 @@
+ALTER TABLE books
+    ADD COLUMN status     VARCHAR(20) NOT NULL DEFAULT 'published',
+    ADD COLUMN publish_at TIMESTAMP;
+
+-- The books created so far were listed right away, new books start as drafts
+UPDATE books
+SET publish_at = created_at;
+
+ALTER TABLE books
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX idx_books_scheduled_publish_at ON books (publish_at) WHERE status = 'scheduled';
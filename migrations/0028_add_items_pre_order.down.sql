@@ -0,0 +1,5 @@
+DROP INDEX idx_items_pending_releases;
+
+ALTER TABLE items
+    DROP COLUMN release_notified,
+    DROP COLUMN pre_order;
@@ -0,0 +1,5 @@
+ALTER TABLE items
+    ADD COLUMN pre_order        BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN release_notified BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_items_pending_releases ON items (id) WHERE pre_order AND NOT release_notified;
@@ -0,0 +1,8 @@
+CREATE TABLE uploads
+(
+    key        VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    CONSTRAINT uploads_pkey PRIMARY KEY (key)
+);
+
+CREATE INDEX idx_uploads_created_at ON uploads (created_at);
@@ -0,0 +1,18 @@
+CREATE TABLE downloads
+(
+    id         VARCHAR(36) NOT NULL,
+    user_id    VARCHAR(36) NOT NULL,
+    order_id   VARCHAR(36) NOT NULL,
+    item_id    VARCHAR(36) NOT NULL,
+    format     VARCHAR(10) NOT NULL,
+    "offset"   BIGINT      NOT NULL,
+    bytes      BIGINT      NOT NULL,
+    size       BIGINT      NOT NULL,
+    completed  BOOLEAN     NOT NULL,
+    created_at TIMESTAMP   NOT NULL,
+    CONSTRAINT downloads_pkey PRIMARY KEY (id),
+    CONSTRAINT downloads_order_id_fkey FOREIGN KEY (order_id)
+        REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_downloads_order_id_item_id ON downloads (order_id, item_id);
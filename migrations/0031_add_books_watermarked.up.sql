@@ -0,0 +1,2 @@
+ALTER TABLE books
+    ADD COLUMN watermarked BOOLEAN NOT NULL DEFAULT FALSE;
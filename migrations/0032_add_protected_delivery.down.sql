@@ -0,0 +1,9 @@
+DROP TABLE licenses;
+DROP TABLE protected_contents;
+
+ALTER TABLE users
+    DROP COLUMN license_key,
+    DROP COLUMN license_hint;
+
+ALTER TABLE books
+    DROP COLUMN protected;
@@ -0,0 +1,36 @@
+ALTER TABLE books
+    ADD COLUMN protected BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE users
+    ADD COLUMN license_key  VARCHAR(64)  NOT NULL DEFAULT '',
+    ADD COLUMN license_hint VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE protected_contents
+(
+    content_id VARCHAR(100) NOT NULL,
+    book_id    VARCHAR(36)  NOT NULL,
+    key        BYTEA        NOT NULL,
+    size       BIGINT       NOT NULL,
+    checksum   VARCHAR(64)  NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    CONSTRAINT protected_contents_pkey PRIMARY KEY (content_id),
+    CONSTRAINT protected_contents_book_id_fkey FOREIGN KEY (book_id)
+        REFERENCES books (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE licenses
+(
+    id         VARCHAR(36) NOT NULL,
+    user_id    VARCHAR(36) NOT NULL,
+    order_id   VARCHAR(36) NOT NULL,
+    book_id    VARCHAR(36) NOT NULL,
+    print      INT,
+    copy       INT,
+    start      TIMESTAMP   NOT NULL,
+    "end"      TIMESTAMP,
+    created_at TIMESTAMP   NOT NULL,
+    CONSTRAINT licenses_pkey PRIMARY KEY (id),
+    CONSTRAINT licenses_order_id_book_id_unique UNIQUE (order_id, book_id),
+    CONSTRAINT licenses_order_id_fkey FOREIGN KEY (order_id)
+        REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE
+);
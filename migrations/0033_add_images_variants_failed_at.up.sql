@@ -0,0 +1,2 @@
+ALTER TABLE images
+    ADD COLUMN variants_failed_at TIMESTAMP NULL;
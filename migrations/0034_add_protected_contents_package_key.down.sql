@@ -0,0 +1,2 @@
+ALTER TABLE protected_contents
+    DROP COLUMN package_key;
@@ -0,0 +1,6 @@
+ALTER TABLE protected_contents
+    ADD COLUMN package_key VARCHAR(150) NOT NULL DEFAULT '';
+
+-- The packages were stored under the content id before each encryption got its own key.
+UPDATE protected_contents
+SET package_key = 'protected/' || content_id;
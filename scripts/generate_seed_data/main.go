@@ -49,6 +49,9 @@ func main() {
 	users := createUsers()
 	log.Printf("Created %d Users", len(users))
 
+	authors := createAuthors()
+	log.Printf("Created %d Authors", len(authors))
+
 	books := createBooks()
 	log.Printf("Created %d Books", len(books))
 
@@ -67,6 +70,10 @@ func cleanDatabase() {
 		log.Fatal(err)
 	}
 
+	if err := db.Delete(&catalog.Author{}, "1 = 1").Error; err != nil {
+		log.Fatal(err)
+	}
+
 	if err := db.Delete(&auth.User{}, "1 = 1").Error; err != nil {
 		log.Fatal(err)
 	}
@@ -125,6 +132,32 @@ func createUsers() []auth.User {
 	return []auth.User{customer1, customer2, admin}
 }
 
+func createAuthors() []catalog.Author {
+	log.Println("Creating authors...")
+
+	author1 := catalog.Author{
+		ID:   "author-id1",
+		Name: "Robert C. Martin",
+		Slug: "robert-c-martin",
+		Bio:  "Software engineer and instructor, best known for promoting software design principles.",
+	}
+	if err := db.Create(&author1).Error; err != nil {
+		log.Fatal(err)
+	}
+
+	author2 := catalog.Author{
+		ID:   "author-id2",
+		Name: "Eric Evans",
+		Slug: "eric-evans",
+		Bio:  "Author of the book that introduced Domain-Driven Design.",
+	}
+	if err := db.Create(&author2).Error; err != nil {
+		log.Fatal(err)
+	}
+
+	return []catalog.Author{author1, author2}
+}
+
 func createBooks() []catalog.Book {
 	log.Println("Creating books...")
 
@@ -147,9 +180,18 @@ func createBooks() []catalog.Book {
 	book1 := catalog.Book{
 		ID:          "book-id1",
 		Title:       "Clean Code",
+		Subtitle:    "A Handbook of Agile Software Craftsmanship",
 		Description: "Craftsman Guide",
-		AuthorName:  "Robert c. Martin",
-		ContentID:   "book1-content",
+		ISBN:        stringPtr("9780132350884"),
+		Publisher:   "Prentice Hall",
+		Language:    "en",
+		PageCount:   464,
+		Authors: []catalog.BookAuthor{
+			{AuthorID: "author-id1", Role: catalog.RoleAuthor},
+		},
+		Files: []catalog.BookFile{
+			{BookID: "book-id1", Format: catalog.FormatPDF, ContentID: "book1-content", MediaType: catalog.FormatPDF.MediaType()},
+		},
 		Images: []catalog.Image{
 			{
 				ID:          "book1-poster",
@@ -158,6 +200,7 @@ func createBooks() []catalog.Book {
 		},
 		Price:       5000,
 		ReleaseDate: time.Time{},
+		Status:      catalog.StatusPublished,
 	}
 	if err = db.Create(&book1).Error; err != nil {
 		log.Fatal(err)
@@ -182,9 +225,18 @@ func createBooks() []catalog.Book {
 	book2 := catalog.Book{
 		ID:          "book-id2",
 		Title:       "Domain Driver Design",
+		Subtitle:    "Tackling Complexity in the Heart of Software",
 		Description: "Tackling Complexity",
-		AuthorName:  "Eric Evans",
-		ContentID:   "book2-content",
+		ISBN:        stringPtr("9780321125217"),
+		Publisher:   "Addison-Wesley",
+		Language:    "en",
+		PageCount:   560,
+		Authors: []catalog.BookAuthor{
+			{AuthorID: "author-id2", Role: catalog.RoleAuthor},
+		},
+		Files: []catalog.BookFile{
+			{BookID: "book-id2", Format: catalog.FormatPDF, ContentID: "book2-content", MediaType: catalog.FormatPDF.MediaType()},
+		},
 		Images: []catalog.Image{
 			{
 				ID:          "book2-poster",
@@ -193,6 +245,7 @@ func createBooks() []catalog.Book {
 		},
 		Price:       3000,
 		ReleaseDate: time.Time{},
+		Status:      catalog.StatusPublished,
 	}
 	if err = db.Create(&book2).Error; err != nil {
 		log.Fatal(err)
@@ -274,6 +327,10 @@ func createOrders() []shop.Order {
 	return []shop.Order{order1, order2, order3}
 }
 
+func stringPtr(s string) *string {
+	return &s
+}
+
 func saveFile(ctx context.Context, key string, contentType string, content io.ReadSeeker) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()